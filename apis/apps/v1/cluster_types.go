@@ -198,6 +198,24 @@ type ClusterSpec struct {
 	//
 	// +optional
 	Backup *ClusterBackup `json:"backup,omitempty"`
+
+	// Specifies the maintenance window of the Cluster.
+	//
+	// Disruptive OpsRequests, such as "VerticalScaling", "Upgrade", "Restart" and "Switchover", targeting this Cluster
+	// will stay in the "Scheduled" phase until the window opens, unless the OpsRequest specifies its own window.
+	//
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Specifies the placement policy of the Cluster across the data-plane Kubernetes clusters,
+	// when KubeBlocks manages multiple Kubernetes clusters.
+	//
+	// The placement is evaluated when the Cluster is created, and re-evaluated when any of the data-plane clusters
+	// it is placed on stays unavailable beyond the failover grace period.
+	// Without the policy, the data-plane clusters are picked randomly.
+	//
+	// +optional
+	Placement *ClusterPlacement `json:"placement,omitempty"`
 }
 
 // ClusterStatus defines the observed state of the Cluster.
@@ -237,6 +255,110 @@ type ClusterStatus struct {
 	//
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Records the failover of the Cluster from the unavailable data-plane Kubernetes clusters,
+	// when KubeBlocks manages multiple Kubernetes clusters.
+	//
+	// +optional
+	Failover *ClusterFailoverStatus `json:"failover,omitempty"`
+}
+
+// ClusterFailoverPhase defines the phase of the failover of a Cluster.
+//
+// +enum
+// +kubebuilder:validation:Enum={Pending,Migrating,Completed}
+type ClusterFailoverPhase string
+
+const (
+	// PendingFailoverPhase indicates that some data-plane clusters are unavailable,
+	// and the failover is waiting for them to recover within the grace period.
+	PendingFailoverPhase ClusterFailoverPhase = "Pending"
+
+	// MigratingFailoverPhase indicates that the placement has been moved to the healthy data-plane clusters,
+	// and the affected instances are being rebuilt.
+	MigratingFailoverPhase ClusterFailoverPhase = "Migrating"
+
+	// CompletedFailoverPhase indicates that all the affected instances have been rebuilt,
+	// and the ones left on the evicted data-plane clusters have been deleted.
+	CompletedFailoverPhase ClusterFailoverPhase = "Completed"
+)
+
+// ClusterFailoverStatus represents the status of the failover of a Cluster from the unavailable data-plane clusters.
+type ClusterFailoverStatus struct {
+	// The current phase of the failover.
+	//
+	// +kubebuilder:validation:Required
+	Phase ClusterFailoverPhase `json:"phase"`
+
+	// The kube contexts of the unavailable data-plane clusters.
+	//
+	// +optional
+	UnavailableContexts []string `json:"unavailableContexts,omitempty"`
+
+	// The time when the data-plane clusters were observed unavailable.
+	//
+	// +optional
+	UnavailableSince *metav1.Time `json:"unavailableSince,omitempty"`
+
+	// Maps the kube contexts of the unavailable data-plane clusters to the ones replacing them.
+	//
+	// +optional
+	Migrations map[string]string `json:"migrations,omitempty"`
+
+	// The kube contexts of the data-plane clusters the instances are evicted from.
+	// The instances left on them are deleted once they become reachable again, before the failover is completed.
+	//
+	// +optional
+	EvictedContexts []string `json:"evictedContexts,omitempty"`
+
+	// The instances to be rebuilt on the healthy data-plane clusters, under the same names.
+	//
+	// +optional
+	Instances []FailoverInstanceStatus `json:"instances,omitempty"`
+
+	// The time when the placement was moved.
+	//
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// The time when all the affected instances were rebuilt.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Provides additional information about the failover.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// FailoverInstanceStatus represents the status of an instance rebuilt in the failover.
+type FailoverInstanceStatus struct {
+	// The name of the instance.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The name of the component the instance belongs to.
+	//
+	// +kubebuilder:validation:Required
+	Component string `json:"component"`
+
+	// The kube context of the unavailable data-plane cluster the instance was placed on.
+	//
+	// +optional
+	Context string `json:"context,omitempty"`
+
+	// Whether the instance has been rebuilt, with the data loaded and the member joined if required.
+	//
+	// +optional
+	Rebuilt bool `json:"rebuilt,omitempty"`
+
+	// Whether the instance left on the evicted data-plane cluster has been deleted,
+	// to keep it from serving along with the rebuilt one when the data-plane cluster recovers.
+	//
+	// +optional
+	Fenced bool `json:"fenced,omitempty"`
 }
 
 // TerminationPolicyType defines termination policy types.
@@ -829,6 +951,91 @@ type ClusterBackup struct {
 	IncrementalCronExpression string `json:"incrementalCronExpression,omitempty"`
 }
 
+// MaintenanceWindow defines a recurring period of time during which disruptive operations are allowed to start.
+type MaintenanceWindow struct {
+	// The cron expression that specifies when the window opens, e.g. "0 2 * * 6" for 02:00 every Saturday.
+	// See https://en.wikipedia.org/wiki/Cron.
+	//
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// Specifies how long the window stays open after it opens, in seconds.
+	//
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:validation:Required
+	DurationSeconds int32 `json:"durationSeconds"`
+
+	// Specifies the IANA time zone name used to interpret the schedule, e.g. "Asia/Shanghai".
+	// Defaults to UTC if not set.
+	//
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ClusterPlacement defines how to place the replicas of a Cluster across the data-plane Kubernetes clusters,
+// each of which is identified by its kube context.
+type ClusterPlacement struct {
+	// Specifies the topology label keys to spread the replicas across, in the order of precedence,
+	// e.g. ["topology.kubernetes.io/region", "topology.kubernetes.io/zone"].
+	//
+	// The topology domains of a data-plane cluster are those of its nodes, e.g. all the zones its nodes are in.
+	// The data-plane clusters are selected to cover as many values of the first key as possible,
+	// then of the second key, and so on.
+	//
+	// +optional
+	SpreadKeys []string `json:"spreadKeys,omitempty"`
+
+	// Specifies the weights of the data-plane clusters, the ones not listed have the weight of 1.
+	//
+	// The data-plane clusters with higher weights are preferred, and take proportionally more replicas
+	// when there are more replicas than the selected data-plane clusters.
+	// The data-plane clusters with the weight of 0 are never selected.
+	//
+	// +optional
+	Weights []PlacementWeight `json:"weights,omitempty"`
+
+	// Specifies whether to prefer the data-plane clusters with more free resources (CPU, then memory),
+	// i.e. the allocatable resources of the nodes not requested by the pods yet.
+	//
+	// +optional
+	CapacityAware bool `json:"capacityAware,omitempty"`
+
+	// Pins the components or shardings to the specified data-plane clusters, regardless of the placement of the Cluster.
+	//
+	// +optional
+	Pins []PlacementPin `json:"pins,omitempty"`
+}
+
+// PlacementWeight defines the weight of a data-plane Kubernetes cluster.
+type PlacementWeight struct {
+	// Specifies the kube context of the data-plane cluster.
+	//
+	// +kubebuilder:validation:Required
+	Context string `json:"context"`
+
+	// Specifies the weight of the data-plane cluster.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Required
+	Weight int32 `json:"weight"`
+}
+
+// PlacementPin pins a component or sharding to the specified data-plane Kubernetes clusters.
+type PlacementPin struct {
+	// Specifies the name of the component or sharding.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the kube contexts of the data-plane clusters, the replicas are distributed across them in order.
+	// The unavailable ones are skipped.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	Contexts []string `json:"contexts"`
+}
+
 // ClusterPhase defines the phase of the Cluster within the .status.phase field.
 //
 // +enum
@@ -1723,13 +1723,15 @@ type ComponentLifecycleActions struct {
 //     to access context information such as details about pods, components, the overall cluster state,
 //     or database connection credentials.
 //     These variables provide a dynamic and context-aware mechanism for script execution.
-//   - HTTPAction: Performs an HTTP request.
-//     HTTPAction is to be implemented in future version.
-//   - GRPCAction: In future version, Actions will support initiating gRPC calls.
+//   - HTTPAction: Performs an HTTP request to an endpoint of the replica.
+//     The path, headers and body can reference the same predefined variables by using `$(VAR_NAME)`.
+//   - GRPCAction: Invokes a unary gRPC method of the replica.
 //     This allows developers to implement Actions using plugins written in programming language like Go,
 //     providing greater flexibility and extensibility.
 //
-// An action is considered successful on returning 0, or HTTP 200 for status HTTP(s) Actions.
+// Only one of the `exec`, `http` and `grpc` can be specified.
+//
+// An action is considered successful on returning 0, HTTP 2xx for HTTP(s) Actions, or OK for gRPC Actions.
 // Any other return value or HTTP status codes indicate failure,
 // and the action may be retried based on the configured retry policy.
 //
@@ -1738,6 +1740,8 @@ type ComponentLifecycleActions struct {
 //     or included in the HTTP response payload for HTTP(s) actions.
 //   - If an action encounters any errors, error messages should be written to stderr,
 //     or detailed in the HTTP response with the appropriate non-200 status code.
+//
+// +kubebuilder:validation:XValidation:rule="[has(self.exec), has(self.http), has(self.grpc)].filter(x, x).size() <= 1",message="only one of the exec, http and grpc can be specified"
 type Action struct {
 	// Defines the command to run.
 	//
@@ -1746,6 +1750,20 @@ type Action struct {
 	// +optional
 	Exec *ExecAction `json:"exec,omitempty"`
 
+	// Defines the HTTP request to perform.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	HTTP *HTTPAction `json:"http,omitempty"`
+
+	// Defines the gRPC method to invoke.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	GRPC *GRPCAction `json:"grpc,omitempty"`
+
 	// Specifies the maximum duration in seconds that the Action is allowed to run.
 	//
 	// If the Action does not complete within this time frame, it will be terminated.
@@ -1863,6 +1881,100 @@ type ExecAction struct {
 	Container string `json:"container,omitempty"`
 }
 
+// HTTPAction describes an Action that performs an HTTP request against the replica.
+type HTTPAction struct {
+	// Specifies the target port for the HTTP request.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Indicates the server's domain name or IP address. Defaults to the localhost of the replica.
+	//
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Designates the protocol used to make the request, such as HTTP or HTTPS.
+	// If not specified, HTTP is used by default.
+	//
+	// +optional
+	Scheme corev1.URIScheme `json:"scheme,omitempty"`
+
+	// Specifies the endpoint to be requested on the HTTP server.
+	// The predefined variables can be referenced by using `$(VAR_NAME)`.
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Represents the type of HTTP request to be made, such as "GET," "POST," "PUT," etc.
+	// If not specified, "GET" is the default method.
+	//
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Allows for the inclusion of custom headers in the request.
+	// The predefined variables can be referenced in the values by using `$(VAR_NAME)`.
+	//
+	// +optional
+	Headers []corev1.HTTPHeader `json:"headers,omitempty"`
+
+	// Specifies the template of the request body.
+	// The predefined variables can be referenced by using `$(VAR_NAME)`.
+	//
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// Specifies the matcher for the response body.
+	// A response with a non-2xx status code is always considered failed.
+	//
+	// +optional
+	Response *ActionOutputMatcher `json:"response,omitempty"`
+}
+
+// GRPCAction describes an Action that invokes a unary gRPC method of the replica.
+//
+// The gRPC server must enable the server reflection service, which is used to resolve the request and response
+// messages of the method.
+type GRPCAction struct {
+	// Specifies the target port of the gRPC server.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Indicates the server's domain name or IP address. Defaults to the localhost of the replica.
+	//
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Specifies the fully-qualified name of the gRPC service, e.g. "grpc.health.v1.Health".
+	//
+	// +kubebuilder:validation:Required
+	Service string `json:"service"`
+
+	// Specifies the name of the method to invoke, e.g. "Check".
+	//
+	// +kubebuilder:validation:Required
+	Method string `json:"method"`
+
+	// Specifies the template of the request message in JSON format.
+	// The predefined variables can be referenced by using `$(VAR_NAME)`.
+	//
+	// +optional
+	Request string `json:"request,omitempty"`
+
+	// Specifies the metadata to be sent with the request.
+	// The predefined variables can be referenced in the values by using `$(VAR_NAME)`.
+	//
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Specifies the matcher for the response message, which is encoded in JSON format.
+	//
+	// +optional
+	Response *ActionOutputMatcher `json:"response,omitempty"`
+}
+
 // TargetPodSelector defines how to select pod(s) to execute an Action.
 // +enum
 // +kubebuilder:validation:Enum={Any,All,Role,Ordinal}
@@ -75,6 +75,21 @@ type RolloutSpec struct {
 	// +optional
 	Components []RolloutComponent `json:"components,omitempty"`
 
+	// Specifies whether the rollout is paused.
+	//
+	// No more instances will be rolled out until the rollout is resumed by setting it to false.
+	//
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Specifies whether to abort the rollout.
+	//
+	// If set to true, the rollout will be stopped and the new instances will be rolled back. It cannot be undone.
+	//
+	// +kubebuilder:validation:XValidation:rule="oldSelf == false || self == true",message="abort cannot be undone"
+	// +optional
+	Abort bool `json:"abort,omitempty"`
+
 	// TODO: auto-reclaim the successful rollouts.
 }
 
@@ -136,6 +151,14 @@ type RolloutComponent struct {
 	// +optional
 	Replicas *intstr.IntOrString `json:"replicas,omitempty"`
 
+	// The index of the last step that has been approved manually.
+	//
+	// The steps that require manual approval, and whose index is less than or equal to it, can go on to the next step.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ApprovedStep *int32 `json:"approvedStep,omitempty"`
+
 	// Additional meta for the instances.
 	//
 	// +optional
@@ -183,6 +206,21 @@ type RolloutStrategyReplace struct {
 	// +optional
 	ScaleDownDelaySeconds *int32 `json:"scaleDownDelaySeconds,omitempty"`
 
+	// Specifies the analysis to run against the new instances before scaling down the old instances.
+	//
+	// If the analysis fails, the rollout will be aborted and the new instances will be rolled back.
+	//
+	// +optional
+	Analysis *RolloutAnalysis `json:"analysis,omitempty"`
+
+	// Specifies the steps to roll out the instances.
+	//
+	// The instances will be rolled out step by step, and all the remaining instances will be rolled out after the last step.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Steps []RolloutStep `json:"steps,omitempty"`
+
 	// TODO: policy to scale-down the old instances and retain the PVCs.
 }
 
@@ -201,6 +239,31 @@ type RolloutStrategyCreate struct {
 	//
 	// +optional
 	Promotion *RolloutPromotion `json:"promotion,omitempty"`
+
+	// Specifies the steps to create the new instances.
+	//
+	// The new instances will be created step by step, and all the remaining instances will be created after the last step.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Steps []RolloutStep `json:"steps,omitempty"`
+}
+
+type RolloutStep struct {
+	// The number or percentage of the new instances to be rolled out when the step is finished.
+	//
+	// The percentage is relative to the replicas of the component before rollout.
+	//
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:Required
+	Replicas intstr.IntOrString `json:"replicas"`
+
+	// Specifies whether to wait for manual approval before going on to the next step.
+	//
+	// The step can be approved by setting the `approvedStep` of the component.
+	//
+	// +optional
+	ManualApproval bool `json:"manualApproval,omitempty"`
 }
 
 type RolloutPromotion struct {
@@ -220,6 +283,13 @@ type RolloutPromotion struct {
 	// +optional
 	Condition *RolloutPromoteCondition `json:"condition,omitempty"`
 
+	// Specifies the analysis to run against the new instances before promoting them.
+	//
+	// If the analysis fails, the rollout will be aborted and the new instances will be rolled back.
+	//
+	// +optional
+	Analysis *RolloutAnalysis `json:"analysis,omitempty"`
+
 	// The delay seconds before scaling down the old instances.
 	//
 	// +kubebuilder:default=30
@@ -245,6 +315,95 @@ type RolloutPromoteCondition struct {
 	// TODO: variables can be used in the conditions.
 }
 
+type RolloutAnalysis struct {
+	// The checks to be evaluated against the new instances.
+	// A measurement succeeds only if all the checks pass.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	Checks []RolloutAnalysisCheck `json:"checks"`
+
+	// The number of seconds between two measurements.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	// +optional
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+
+	// The number of seconds the analysis lasts.
+	// The analysis succeeds if the failed measurements do not exceed the `failureLimit` within the duration.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=60
+	// +optional
+	DurationSeconds *int32 `json:"durationSeconds,omitempty"`
+
+	// The maximum number of failed measurements allowed, the analysis fails once it is exceeded.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	// +optional
+	FailureLimit *int32 `json:"failureLimit,omitempty"`
+}
+
+// RolloutAnalysisCheck defines a check of the analysis, exactly one of action, expression and metric should be specified.
+//
+// +kubebuilder:validation:XValidation:rule="[has(self.action), has(self.expression), has(self.metric)].filter(x, x).size() == 1",message="exactly one of action, expression and metric should be specified"
+type RolloutAnalysisCheck struct {
+	// The name of the check.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern:=`^[a-z]([a-z0-9\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// The action to be executed on each new instance, the check passes if the action exits successfully.
+	//
+	// +optional
+	Action *appsv1.Action `json:"action,omitempty"`
+
+	// The CEL expression to be evaluated, the check passes if the expression returns true.
+	//
+	// The following variables are available in the expression:
+	//
+	// - `component`: the Component object.
+	// - `instanceSet`: the InstanceSet object of the Component.
+	//
+	// For example: `instanceSet.status.availableReplicas == instanceSet.status.replicas`.
+	//
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Expression *string `json:"expression,omitempty"`
+
+	// The metric to be scraped from the exporter endpoint of each new instance,
+	// which is declared in the ComponentDefinition.
+	//
+	// +optional
+	Metric *RolloutAnalysisMetric `json:"metric,omitempty"`
+}
+
+type RolloutAnalysisMetric struct {
+	// The name of the metric.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The labels to select the samples of the metric.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// The CEL expression to be evaluated against the value of each selected sample, which is available as `value`.
+	// The check passes if the expression returns true for all the samples, for example: `value < 0.01`.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=256
+	Condition string `json:"condition"`
+}
+
 type RolloutInstanceMeta struct {
 	//// Meta added to the old instances.
 	////
@@ -268,16 +427,43 @@ type InstanceMeta struct {
 // RolloutState defines the state of the Rollout within the .status.state field.
 //
 // +enum
-// +kubebuilder:validation:Enum={Pending,Rolling,Succeed,Error}
+// +kubebuilder:validation:Enum={Pending,Rolling,Paused,Succeed,Aborted,Error}
 type RolloutState string
 
 const (
 	PendingRolloutState RolloutState = "Pending"
 	RollingRolloutState RolloutState = "Rolling"
+	PausedRolloutState  RolloutState = "Paused"
 	SucceedRolloutState RolloutState = "Succeed"
+	AbortedRolloutState RolloutState = "Aborted"
 	ErrorRolloutState   RolloutState = "Error"
 )
 
+// RolloutStepState defines the state of a rollout step.
+//
+// +enum
+// +kubebuilder:validation:Enum={Pending,Rolling,WaitingForApproval,Succeed}
+type RolloutStepState string
+
+const (
+	PendingRolloutStepState            RolloutStepState = "Pending"
+	RollingRolloutStepState            RolloutStepState = "Rolling"
+	WaitingForApprovalRolloutStepState RolloutStepState = "WaitingForApproval"
+	SucceedRolloutStepState            RolloutStepState = "Succeed"
+)
+
+// RolloutAnalysisPhase defines the phase of the analysis.
+//
+// +enum
+// +kubebuilder:validation:Enum={Running,Succeeded,Failed}
+type RolloutAnalysisPhase string
+
+const (
+	RunningRolloutAnalysisPhase   RolloutAnalysisPhase = "Running"
+	SucceededRolloutAnalysisPhase RolloutAnalysisPhase = "Succeeded"
+	FailedRolloutAnalysisPhase    RolloutAnalysisPhase = "Failed"
+)
+
 type RolloutComponentStatus struct {
 	// The name of the component.
 	//
@@ -328,4 +514,73 @@ type RolloutComponentStatus struct {
 	//
 	// +optional
 	LastScaleDownTimestamp metav1.Time `json:"lastScaleDownTimestamp,omitempty"`
+
+	// The status of the analysis against the new instances.
+	//
+	// +optional
+	Analysis *RolloutAnalysisStatus `json:"analysis,omitempty"`
+
+	// The index of the current step.
+	//
+	// +optional
+	CurrentStep *int32 `json:"currentStep,omitempty"`
+
+	// The status of all the steps.
+	//
+	// +optional
+	Steps []RolloutStepStatus `json:"steps,omitempty"`
+}
+
+type RolloutStepStatus struct {
+	// The number of the new instances to be rolled out when the step is finished.
+	//
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// The state of the step.
+	//
+	// +optional
+	State RolloutStepState `json:"state,omitempty"`
+
+	// The time when the step started.
+	//
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// The time when the step finished.
+	//
+	// +optional
+	CompletionTimestamp metav1.Time `json:"completionTimestamp,omitempty"`
+}
+
+type RolloutAnalysisStatus struct {
+	// The phase of the analysis.
+	//
+	// +optional
+	Phase RolloutAnalysisPhase `json:"phase,omitempty"`
+
+	// The time when the analysis started.
+	//
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// The time of the last measurement.
+	//
+	// +optional
+	LastMeasurementTimestamp metav1.Time `json:"lastMeasurementTimestamp,omitempty"`
+
+	// The number of measurements taken.
+	//
+	// +optional
+	Measurements int32 `json:"measurements,omitempty"`
+
+	// The number of failed measurements.
+	//
+	// +optional
+	FailedMeasurements int32 `json:"failedMeasurements,omitempty"`
+
+	// The message of the last failed measurement.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
 }
@@ -3631,6 +3631,112 @@ func (in *Rollout) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysis) DeepCopyInto(out *RolloutAnalysis) {
+	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]RolloutAnalysisCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DurationSeconds != nil {
+		in, out := &in.DurationSeconds, &out.DurationSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureLimit != nil {
+		in, out := &in.FailureLimit, &out.FailureLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysis.
+func (in *RolloutAnalysis) DeepCopy() *RolloutAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysisCheck) DeepCopyInto(out *RolloutAnalysisCheck) {
+	*out = *in
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(apisappsv1.Action)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expression != nil {
+		in, out := &in.Expression, &out.Expression
+		*out = new(string)
+		**out = **in
+	}
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(RolloutAnalysisMetric)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysisCheck.
+func (in *RolloutAnalysisCheck) DeepCopy() *RolloutAnalysisCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysisCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysisMetric) DeepCopyInto(out *RolloutAnalysisMetric) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysisMetric.
+func (in *RolloutAnalysisMetric) DeepCopy() *RolloutAnalysisMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysisMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysisStatus) DeepCopyInto(out *RolloutAnalysisStatus) {
+	*out = *in
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	in.LastMeasurementTimestamp.DeepCopyInto(&out.LastMeasurementTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysisStatus.
+func (in *RolloutAnalysisStatus) DeepCopy() *RolloutAnalysisStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysisStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutComponent) DeepCopyInto(out *RolloutComponent) {
 	*out = *in
@@ -3650,6 +3756,11 @@ func (in *RolloutComponent) DeepCopyInto(out *RolloutComponent) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.ApprovedStep != nil {
+		in, out := &in.ApprovedStep, &out.ApprovedStep
+		*out = new(int32)
+		**out = **in
+	}
 	if in.InstanceMeta != nil {
 		in, out := &in.InstanceMeta, &out.InstanceMeta
 		*out = new(RolloutInstanceMeta)
@@ -3677,6 +3788,23 @@ func (in *RolloutComponentStatus) DeepCopyInto(out *RolloutComponentStatus) {
 	}
 	in.LastScaleUpTimestamp.DeepCopyInto(&out.LastScaleUpTimestamp)
 	in.LastScaleDownTimestamp.DeepCopyInto(&out.LastScaleDownTimestamp)
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(RolloutAnalysisStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CurrentStep != nil {
+		in, out := &in.CurrentStep, &out.CurrentStep
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]RolloutStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutComponentStatus.
@@ -3784,6 +3912,11 @@ func (in *RolloutPromotion) DeepCopyInto(out *RolloutPromotion) {
 		*out = new(RolloutPromoteCondition)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(RolloutAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ScaleDownDelaySeconds != nil {
 		in, out := &in.ScaleDownDelaySeconds, &out.ScaleDownDelaySeconds
 		*out = new(int32)
@@ -3852,6 +3985,39 @@ func (in *RolloutStatus) DeepCopy() *RolloutStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStep) DeepCopyInto(out *RolloutStep) {
+	*out = *in
+	out.Replicas = in.Replicas
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStep.
+func (in *RolloutStep) DeepCopy() *RolloutStep {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStepStatus) DeepCopyInto(out *RolloutStepStatus) {
+	*out = *in
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStepStatus.
+func (in *RolloutStepStatus) DeepCopy() *RolloutStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
 	*out = *in
@@ -3900,6 +4066,11 @@ func (in *RolloutStrategyCreate) DeepCopyInto(out *RolloutStrategyCreate) {
 		*out = new(RolloutPromotion)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]RolloutStep, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategyCreate.
@@ -3945,6 +4116,16 @@ func (in *RolloutStrategyReplace) DeepCopyInto(out *RolloutStrategyReplace) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(RolloutAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]RolloutStep, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategyReplace.
@@ -210,6 +210,52 @@ type BackupStatus struct {
 	//
 	// +optional
 	Extras []map[string]string `json:"extras,omitempty"`
+
+	// Records the time when the backup was last verified by restoring it into a sandbox.
+	//
+	// +optional
+	LastVerificationTime *metav1.Time `json:"lastVerificationTime,omitempty"`
+
+	// Describes the current state of the backup.
+	// The `Verified` condition records the outcome of the last verification.
+	//
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Records the copies of the backup in the secondary backup repositories.
+	// A copy has the same path and kopia repository path as the backup in the primary backup repository.
+	//
+	// +optional
+	Replicas []BackupReplicaStatus `json:"replicas,omitempty"`
+}
+
+// BackupReplicaStatus records the state of a copy of the backup in a secondary backup repository.
+type BackupReplicaStatus struct {
+	// Specifies the name of the secondary backup repository.
+	//
+	// +kubebuilder:validation:Required
+	BackupRepoName string `json:"backupRepoName"`
+
+	// Represents the current phase of the copy.
+	//
+	// +optional
+	Phase BackupReplicaPhase `json:"phase,omitempty"`
+
+	// Records the time when the latest copy started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time when the latest copy completed.
+	// The backup can be restored from the secondary backup repository once it is set.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Records the reason why the copy failed or is pending.
+	//
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
 }
 
 // BackupTimeRange records the time range of backed up data, for PITR, this is the
@@ -266,6 +312,37 @@ const (
 	BackupPhaseDeleting BackupPhase = "Deleting"
 )
 
+// BackupReplicaPhase describes the lifecycle phase of a copy of the backup.
+// +enum
+// +kubebuilder:validation:Enum={Pending,Copying,Completed,Failed}
+type BackupReplicaPhase string
+
+const (
+	// BackupReplicaPhasePending means the copy is waiting for the secondary backup repository to be available.
+	BackupReplicaPhasePending BackupReplicaPhase = "Pending"
+
+	// BackupReplicaPhaseCopying means the backup data is being copied.
+	BackupReplicaPhaseCopying BackupReplicaPhase = "Copying"
+
+	// BackupReplicaPhaseCompleted means the backup data has been copied, and the copy can be restored.
+	BackupReplicaPhaseCompleted BackupReplicaPhase = "Completed"
+
+	// BackupReplicaPhaseFailed means the copy failed, delete the copy job to retry it.
+	BackupReplicaPhaseFailed BackupReplicaPhase = "Failed"
+)
+
+const (
+	// ConditionTypeVerified is the name of the condition that indicates whether
+	// the backup has been verified restorable.
+	ConditionTypeVerified = "Verified"
+
+	// ReasonVerificationSucceeded indicates that the backup was restored into a sandbox and passed the check.
+	ReasonVerificationSucceeded = "VerificationSucceeded"
+
+	// ReasonVerificationFailed indicates that the backup could not be restored or failed the check.
+	ReasonVerificationFailed = "VerificationFailed"
+)
+
 type ActionStatus struct {
 	// The name of the action.
 	//
@@ -401,6 +478,7 @@ const (
 // +kubebuilder:printcolumn:name="CREATION-TIME",type=string,JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="COMPLETION-TIME",type=string,JSONPath=`.status.completionTimestamp`
 // +kubebuilder:printcolumn:name="EXPIRATION-TIME",type=string,JSONPath=`.status.expiration`
+// +kubebuilder:printcolumn:name="VERIFIED",type=string,JSONPath=`.status.conditions[?(@.type=="Verified")].status`,priority=1
 
 // Backup is the Schema for the backups API.
 type Backup struct {
@@ -84,6 +84,33 @@ type BackupPolicySpec struct {
 	//
 	// +optional
 	RetentionPolicy BackupPolicyRetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// Specifies the secondary backup repositories which the backups of this policy are copied to,
+	// to keep off-site copies for disaster recovery.
+	//
+	// +optional
+	Replication *BackupReplication `json:"replication,omitempty"`
+}
+
+// BackupReplication defines how to copy the backups to the secondary backup repositories.
+type BackupReplication struct {
+	// Specifies the names of the secondary backup repositories.
+	// Each completed backup is copied to all of them, including the kopia repository
+	// the backup is stored in, and the state of each copy is recorded in `backup.status.replicas`.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=set
+	BackupRepoNames []string `json:"backupRepoNames"`
+
+	// Specifies the interval in seconds to copy the new data of the running continuous backups,
+	// e.g. the log segments for PITR, to the secondary backup repositories.
+	//
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:default=600
+	// +optional
+	SyncIntervalSeconds int32 `json:"syncIntervalSeconds,omitempty"`
 }
 
 type BackupTarget struct {
@@ -121,6 +121,60 @@ type BackupRepoStatus struct {
 	//
 	// +optional
 	IsDefault bool `json:"isDefault,omitempty"`
+
+	// Records the time of the latest health check of the backup repository.
+	// The backup repository is checked periodically after the pre-check passes, by writing,
+	// reading and deleting a canary object.
+	//
+	// +optional
+	LastHealthCheckTime *metav1.Time `json:"lastHealthCheckTime,omitempty"`
+
+	// Reports the storage used by the backups in the backup repository.
+	// It's calculated from the total size reported by the backups.
+	//
+	// +optional
+	Usage *BackupRepoUsage `json:"usage,omitempty"`
+}
+
+// BackupRepoUsage reports the storage used by the backups in a backup repository.
+type BackupRepoUsage struct {
+	// Represents the total bytes used by the backups.
+	//
+	// +optional
+	UsedBytes int64 `json:"usedBytes,omitempty"`
+
+	// Represents the number of the backups.
+	//
+	// +optional
+	BackupCount int32 `json:"backupCount,omitempty"`
+
+	// Reports the storage used by the backups of each backup policy.
+	//
+	// +optional
+	BackupPolicies []BackupPolicyUsage `json:"backupPolicies,omitempty"`
+}
+
+// BackupPolicyUsage reports the storage used by the backups of a backup policy.
+type BackupPolicyUsage struct {
+	// Specifies the namespace of the backup policy.
+	//
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// Specifies the name of the backup policy.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Represents the total bytes used by the backups of the backup policy.
+	//
+	// +optional
+	UsedBytes int64 `json:"usedBytes,omitempty"`
+
+	// Represents the number of the backups of the backup policy.
+	//
+	// +optional
+	BackupCount int32 `json:"backupCount,omitempty"`
 }
 
 // +genclient
@@ -133,6 +187,7 @@ type BackupRepoStatus struct {
 // +kubebuilder:printcolumn:name="STORAGEPROVIDER",type="string",JSONPath=".spec.storageProviderRef"
 // +kubebuilder:printcolumn:name="ACCESSMETHOD",type="string",JSONPath=".spec.accessMethod"
 // +kubebuilder:printcolumn:name="DEFAULT",type="boolean",JSONPath=`.status.isDefault`
+// +kubebuilder:printcolumn:name="HEALTHY",type="string",JSONPath=`.status.conditions[?(@.type=="Healthy")].status`
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // BackupRepo is a repository for storing backup data.
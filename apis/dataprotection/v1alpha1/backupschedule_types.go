@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -41,6 +42,64 @@ type BackupScheduleSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinItems=1
 	Schedules []SchedulePolicy `json:"schedules"`
+
+	// Specifies the tiered (grandfather-father-son) retention policy for the backups across all the schedules.
+	//
+	// When specified, the completed full, selective and incremental backups of the schedules are retained by the tiers
+	// instead of the `retentionPeriod`: a backup is retained as long as any tier retains it, otherwise it will be pruned.
+	// The full backups which the continuous backups of the schedules depend on for PITR are always retained,
+	// and so are the parent backups of the retained incremental backups.
+	//
+	// +optional
+	RetentionTiers *RetentionTiers `json:"retentionTiers,omitempty"`
+}
+
+// RetentionTiers defines how many backups to retain in each tier.
+// For each tier, the latest backup of each period (hour, day, week, month or year, in UTC) is retained,
+// up to the number of the most recent periods specified.
+//
+// +kubebuilder:validation:XValidation:rule="self.hourly > 0 || self.daily > 0 || self.weekly > 0 || self.monthly > 0 || self.yearly > 0",message="at least one tier must retain backups."
+type RetentionTiers struct {
+	// Specifies the number of the hourly backups to retain.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	// +optional
+	Hourly int32 `json:"hourly"`
+
+	// Specifies the number of the daily backups to retain.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	// +optional
+	Daily int32 `json:"daily"`
+
+	// Specifies the number of the weekly backups to retain, the week starts on Monday.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	// +optional
+	Weekly int32 `json:"weekly"`
+
+	// Specifies the number of the monthly backups to retain.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	// +optional
+	Monthly int32 `json:"monthly"`
+
+	// Specifies the number of the yearly backups to retain.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	// +optional
+	Yearly int32 `json:"yearly"`
+
+	// Specifies whether to only report the backups to be pruned in `status.prunableBackups` without deleting them.
+	// In dry-run mode, the backups are still deleted according to the `retentionPeriod`.
+	//
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 type SchedulePolicy struct {
@@ -93,6 +152,68 @@ type SchedulePolicy struct {
 	// +kubebuilder:validation:MaxItems=128
 	// +optional
 	Parameters []ParameterPair `json:"parameters,omitempty"`
+
+	// Specifies how to periodically verify that the backups of this schedule can be restored.
+	// The latest completed backup is restored into sandbox volumes and checked by the `check` action,
+	// the outcome is recorded in the `Verified` condition of the backup.
+	//
+	// +optional
+	Verification *BackupVerification `json:"verification,omitempty"`
+}
+
+// BackupVerification defines how to verify the backups of a schedule.
+type BackupVerification struct {
+	// Specifies whether the verification is enabled or not.
+	//
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Specifies the cron expression for the verification. The timezone is in UTC.
+	// see https://en.wikipedia.org/wiki/Cron.
+	//
+	// +kubebuilder:validation:Required
+	CronExpression string `json:"cronExpression"`
+
+	// Specifies the storage class of the sandbox volumes which the backup is restored into.
+	// If not specified, the storage class of the source volumes will be used.
+	//
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Specifies the action to check the restored data.
+	//
+	// +kubebuilder:validation:Required
+	Check BackupVerificationCheck `json:"check"`
+
+	// Specifies the maximum duration in seconds of a verification, including the restore and the check.
+	// The verification is considered failed if it does not complete in time.
+	//
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:default=3600
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// BackupVerificationCheck defines the action to check the restored data.
+// It runs as a job with the sandbox volumes mounted at the same paths as the source volumes of the backup,
+// the check is considered successful if the job completes successfully.
+type BackupVerificationCheck struct {
+	// Specifies the image of the check container.
+	//
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+
+	// Specifies the commands to check the restored data.
+	//
+	// +kubebuilder:validation:Required
+	Command []string `json:"command"`
+
+	// Specifies the environment variables for the check container.
+	//
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 }
 
 // BackupScheduleStatus defines the observed state of BackupSchedule.
@@ -118,6 +239,12 @@ type BackupScheduleStatus struct {
 	//
 	// +optional
 	Schedules map[string]ScheduleStatus `json:"schedules,omitempty"`
+
+	// Lists the backups which are not retained by the `retentionTiers` and will be pruned next,
+	// in the order of the oldest first.
+	//
+	// +optional
+	PrunableBackups []string `json:"prunableBackups,omitempty"`
 }
 
 // BackupSchedulePhase defines the phase of BackupSchedule
@@ -111,6 +111,14 @@ type BackupRef struct {
 
 	// Specifies the source target for restoration, identified by its name.
 	SourceTargetName string `json:"sourceTargetName,omitempty"`
+
+	// Specifies the backup repository to restore the backup from, it must be the primary backup repository
+	// of the backup or one of the secondary backup repositories the backup has been copied to.
+	// If not specified, the primary backup repository is used, and a secondary backup repository
+	// with a completed copy is used instead if the primary one is unavailable.
+	//
+	// +optional
+	BackupRepoName string `json:"backupRepoName,omitempty"`
 }
 
 type RestoreKubeResources struct {
@@ -489,6 +497,11 @@ type RestoreStatus struct {
 	//
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Records the backup repository which the backup is restored from.
+	//
+	// +optional
+	BackupRepoName string `json:"backupRepoName,omitempty"`
 }
 
 // +genclient
@@ -545,6 +545,11 @@ func (in *BackupPolicySpec) DeepCopyInto(out *BackupPolicySpec) {
 		*out = new(EncryptionConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(BackupReplication)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicySpec.
@@ -686,6 +691,21 @@ func (in *BackupPolicyTemplateStatus) DeepCopy() *BackupPolicyTemplateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupPolicyUsage) DeepCopyInto(out *BackupPolicyUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicyUsage.
+func (in *BackupPolicyUsage) DeepCopy() *BackupPolicyUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupPolicyUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupRef) DeepCopyInto(out *BackupRef) {
 	*out = *in
@@ -701,6 +721,49 @@ func (in *BackupRef) DeepCopy() *BackupRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupReplicaStatus) DeepCopyInto(out *BackupReplicaStatus) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReplicaStatus.
+func (in *BackupReplicaStatus) DeepCopy() *BackupReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupReplication) DeepCopyInto(out *BackupReplication) {
+	*out = *in
+	if in.BackupRepoNames != nil {
+		in, out := &in.BackupRepoNames, &out.BackupRepoNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReplication.
+func (in *BackupReplication) DeepCopy() *BackupReplication {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupRepo) DeepCopyInto(out *BackupRepo) {
 	*out = *in
@@ -803,6 +866,15 @@ func (in *BackupRepoStatus) DeepCopyInto(out *BackupRepoStatus) {
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
+	if in.LastHealthCheckTime != nil {
+		in, out := &in.LastHealthCheckTime, &out.LastHealthCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(BackupRepoUsage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepoStatus.
@@ -815,6 +887,26 @@ func (in *BackupRepoStatus) DeepCopy() *BackupRepoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRepoUsage) DeepCopyInto(out *BackupRepoUsage) {
+	*out = *in
+	if in.BackupPolicies != nil {
+		in, out := &in.BackupPolicies, &out.BackupPolicies
+		*out = make([]BackupPolicyUsage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepoUsage.
+func (in *BackupRepoUsage) DeepCopy() *BackupRepoUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRepoUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupSchedule) DeepCopyInto(out *BackupSchedule) {
 	*out = *in
@@ -889,6 +981,11 @@ func (in *BackupScheduleSpec) DeepCopyInto(out *BackupScheduleSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RetentionTiers != nil {
+		in, out := &in.RetentionTiers, &out.RetentionTiers
+		*out = new(RetentionTiers)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupScheduleSpec.
@@ -911,6 +1008,11 @@ func (in *BackupScheduleStatus) DeepCopyInto(out *BackupScheduleStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.PrunableBackups != nil {
+		in, out := &in.PrunableBackups, &out.PrunableBackups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupScheduleStatus.
@@ -1015,6 +1117,24 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 			}
 		}
 	}
+	if in.LastVerificationTime != nil {
+		in, out := &in.LastVerificationTime, &out.LastVerificationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]BackupReplicaStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
@@ -1106,6 +1226,59 @@ func (in *BackupTimeRange) DeepCopy() *BackupTimeRange {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerification) DeepCopyInto(out *BackupVerification) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	in.Check.DeepCopyInto(&out.Check)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerification.
+func (in *BackupVerification) DeepCopy() *BackupVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationCheck) DeepCopyInto(out *BackupVerificationCheck) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationCheck.
+func (in *BackupVerificationCheck) DeepCopy() *BackupVerificationCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BaseJobActionSpec) DeepCopyInto(out *BaseJobActionSpec) {
 	*out = *in
@@ -1847,6 +2020,21 @@ func (in *RestoreVolumeClaimsTemplate) DeepCopy() *RestoreVolumeClaimsTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetentionTiers) DeepCopyInto(out *RetentionTiers) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionTiers.
+func (in *RetentionTiers) DeepCopy() *RetentionTiers {
+	if in == nil {
+		return nil
+	}
+	out := new(RetentionTiers)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuntimeSettings) DeepCopyInto(out *RuntimeSettings) {
 	*out = *in
@@ -1876,6 +2064,11 @@ func (in *SchedulePolicy) DeepCopyInto(out *SchedulePolicy) {
 		*out = make([]ParameterPair, len(*in))
 		copy(*out, *in)
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(BackupVerification)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulePolicy.
@@ -80,6 +80,17 @@ type AddonSpec struct {
 	//
 	// +optional
 	CliPlugins []CliPlugin `json:"cliPlugins,omitempty"`
+
+	// Specifies the add-ons that this add-on depends on.
+	// The add-on will not be installed until all of its dependencies are enabled,
+	// and an add-on can not be disabled while any enabled add-on depends on it.
+	//
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Dependencies []AddonDependency `json:"dependencies,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
 }
 
 // AddonStatus defines the observed state of an add-on.
@@ -100,6 +111,22 @@ type AddonStatus struct {
 	//
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Records the version of the add-on that is currently installed.
+	//
+	// +optional
+	InstalledVersion string `json:"installedVersion,omitempty"`
+
+	// Records the Helm chart location URL of the add-on that is currently installed.
+	//
+	// +optional
+	InstalledChartLocationURL string `json:"installedChartLocationURL,omitempty"`
+
+	// Records the recent upgrades of the add-on, the latest one comes last.
+	// At most 10 records are kept.
+	//
+	// +optional
+	UpgradeHistory []AddonUpgradeRecord `json:"upgradeHistory,omitempty"`
 }
 
 type InstallableSpec struct {
@@ -178,6 +205,46 @@ type HelmTypeInstallSpec struct {
 	// +kubeBuilder:default="/charts"
 	// +optional
 	ChartsPathInImage string `json:"chartsPathInImage,omitempty"`
+
+	// Defines how to pull the Helm chart from an OCI registry. It takes effect only if
+	// the ChartLocationURL starts with "oci://".
+	//
+	// +optional
+	OCIRegistry *HelmOCIRegistry `json:"ociRegistry,omitempty"`
+
+	// Defines how to verify the provenance of the Helm chart before installation.
+	// The chart installation fails if the verification fails.
+	//
+	// +optional
+	Verification *HelmChartVerification `json:"verification,omitempty"`
+}
+
+type HelmOCIRegistry struct {
+	// Specifies the Secret of type `kubernetes.io/dockerconfigjson` holding the credentials
+	// to access the registry. The Secret should be in the same namespace as KubeBlocks.
+	//
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// Specifies the digest of the chart artifact, e.g. "sha256:4f2f1b0c...", to pin the chart
+	// to a specific artifact instead of a mutable tag.
+	//
+	// +kubebuilder:validation:Pattern:=`^sha256:[a-f0-9]{64}$`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// Specifies whether to access the registry over plain HTTP, e.g. a local registry without TLS.
+	//
+	// +optional
+	PlainHTTP bool `json:"plainHTTP,omitempty"`
+}
+
+type HelmChartVerification struct {
+	// Selects the key of a Secret holding the public keyring used to verify the provenance of the chart.
+	// The Secret should be in the same namespace as KubeBlocks.
+	//
+	// +kubebuilder:validation:Required
+	KeyringSecretRef DataObjectKeySelector `json:"keyringSecretRef"`
 }
 
 type HelmInstallOptions map[string]string
@@ -302,6 +369,19 @@ type ResourceMappingItem struct {
 	Memory *ResourceReqLimItem `json:"memory,omitempty"`
 }
 
+type AddonDependency struct {
+	// Specifies the name of the add-on depended on.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the version constraint of the add-on depended on, e.g. ">=1.0.0, <2.0.0".
+	// Any version is accepted if not specified.
+	//
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
 type CliPlugin struct {
 	// Specifies the name of the plugin.
 	//
@@ -733,6 +813,22 @@ func (r *HelmTypeInstallSpec) BuildMergedValues(installSpec *AddonInstallSpec) H
 	return installValues
 }
 
+// IsOCIChart checks whether the Helm chart is pulled from an OCI registry.
+func (r *HelmTypeInstallSpec) IsOCIChart() bool {
+	return r != nil && strings.HasPrefix(r.ChartLocationURL, "oci://")
+}
+
+// GetChartLocation returns the location of the Helm chart, the OCI chart is pinned to the digest if specified.
+func (r *HelmTypeInstallSpec) GetChartLocation() string {
+	if r == nil {
+		return ""
+	}
+	if r.IsOCIChart() && r.OCIRegistry != nil && r.OCIRegistry.Digest != "" {
+		return fmt.Sprintf("%s@%s", strings.TrimSuffix(r.ChartLocationURL, "/"), r.OCIRegistry.Digest)
+	}
+	return r.ChartLocationURL
+}
+
 // BuildContainerArgs derives helm container args.
 func (r *HelmTypeInstallSpec) BuildContainerArgs(helmContainer *corev1.Container, installValues HelmInstallValues) error {
 	// Add extra helm installation option flags
@@ -788,3 +884,49 @@ func NewAddonInstallSpecItem() AddonInstallSpecItem {
 		},
 	}
 }
+
+type AddonUpgradeRecord struct {
+	// Specifies the version upgraded from.
+	//
+	// +optional
+	FromVersion string `json:"fromVersion,omitempty"`
+
+	// Specifies the version upgraded to.
+	//
+	// +optional
+	ToVersion string `json:"toVersion,omitempty"`
+
+	// Specifies the Helm chart location URL upgraded from.
+	//
+	// +optional
+	FromChartLocationURL string `json:"fromChartLocationURL,omitempty"`
+
+	// Specifies the Helm chart location URL upgraded to.
+	//
+	// +optional
+	ToChartLocationURL string `json:"toChartLocationURL,omitempty"`
+
+	// Specifies the revision of the Helm release to roll back to if the upgrade fails.
+	//
+	// +optional
+	FromRevision int `json:"fromRevision,omitempty"`
+
+	// Defines the phase of the upgrade. It can take one of the following values:
+	// `Prechecking`, `PrecheckFailed`, `Upgrading`, `Succeeded`, `RollingBack`, `RolledBack`, `RollbackFailed`.
+	//
+	// +kubebuilder:validation:Enum={Prechecking,PrecheckFailed,Upgrading,Succeeded,RollingBack,RolledBack,RollbackFailed}
+	Phase AddonUpgradePhase `json:"phase"`
+
+	// Provides a human-readable message of the upgrade, e.g. the reasons why the precheck fails.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Records the time when the upgrade started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Records the time when the upgrade completed.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -444,3 +445,26 @@ func TestAddonInstallHasSetValues(t *testing.T) {
 	}
 	g.Expect(installSpec.HasSetValues()).Should(BeTrue())
 }
+
+func TestHelmInstallSpecGetChartLocation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var helmSpec *HelmTypeInstallSpec
+	g.Expect(helmSpec.IsOCIChart()).Should(BeFalse())
+	g.Expect(helmSpec.GetChartLocation()).Should(BeEmpty())
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	helmSpec = &HelmTypeInstallSpec{
+		ChartLocationURL: "https://charts.example.com/mysql-1.0.0.tgz",
+		OCIRegistry:      &HelmOCIRegistry{Digest: digest},
+	}
+	g.Expect(helmSpec.IsOCIChart()).Should(BeFalse())
+	g.Expect(helmSpec.GetChartLocation()).Should(Equal(helmSpec.ChartLocationURL))
+
+	helmSpec.ChartLocationURL = "oci://registry.local:5000/charts/mysql"
+	g.Expect(helmSpec.IsOCIChart()).Should(BeTrue())
+	g.Expect(helmSpec.GetChartLocation()).Should(Equal("oci://registry.local:5000/charts/mysql@" + digest))
+
+	helmSpec.OCIRegistry.Digest = ""
+	g.Expect(helmSpec.GetChartLocation()).Should(Equal(helmSpec.ChartLocationURL))
+}
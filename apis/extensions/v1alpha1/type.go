@@ -58,6 +58,20 @@ const (
 	AddonDisabling AddonPhase = "Disabling"
 )
 
+// AddonUpgradePhase defines the phases of an add-on upgrade.
+// +enum
+type AddonUpgradePhase string
+
+const (
+	AddonUpgradePrechecking    AddonUpgradePhase = "Prechecking"
+	AddonUpgradePrecheckFailed AddonUpgradePhase = "PrecheckFailed"
+	AddonUpgradeUpgrading      AddonUpgradePhase = "Upgrading"
+	AddonUpgradeSucceeded      AddonUpgradePhase = "Succeeded"
+	AddonUpgradeRollingBack    AddonUpgradePhase = "RollingBack"
+	AddonUpgradeRolledBack     AddonUpgradePhase = "RolledBack"
+	AddonUpgradeRollbackFailed AddonUpgradePhase = "RollbackFailed"
+)
+
 // AddonSelectorKey are selector requirement key types.
 // +enum
 // +kubebuilder:validation:Enum={KubeGitVersion,KubeVersion,KubeProvider}
@@ -75,6 +89,8 @@ const (
 	ConditionTypeChecked     = "InstallableChecked"
 	ConditionTypeSucceed     = "Succeed"
 	ConditionTypeFailed      = "Failed"
+	ConditionTypeDependency  = "DependencyChecked"
+	ConditionTypeUpgraded    = "Upgraded"
 )
 
 // SetKubeServerVersion provides "_KUBE_SERVER_INFO" viper settings helper function.
@@ -79,6 +79,21 @@ func (in *AddonDefaultInstallSpecItem) DeepCopy() *AddonDefaultInstallSpecItem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonDependency) DeepCopyInto(out *AddonDependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonDependency.
+func (in *AddonDependency) DeepCopy() *AddonDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonInstallExtraItem) DeepCopyInto(out *AddonInstallExtraItem) {
 	*out = *in
@@ -206,6 +221,11 @@ func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
 		*out = make([]CliPlugin, len(*in))
 		copy(*out, *in)
 	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]AddonDependency, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSpec.
@@ -228,6 +248,13 @@ func (in *AddonStatus) DeepCopyInto(out *AddonStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UpgradeHistory != nil {
+		in, out := &in.UpgradeHistory, &out.UpgradeHistory
+		*out = make([]AddonUpgradeRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonStatus.
@@ -240,6 +267,26 @@ func (in *AddonStatus) DeepCopy() *AddonStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonUpgradeRecord) DeepCopyInto(out *AddonUpgradeRecord) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonUpgradeRecord.
+func (in *AddonUpgradeRecord) DeepCopy() *AddonUpgradeRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonUpgradeRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CliPlugin) DeepCopyInto(out *CliPlugin) {
 	*out = *in
@@ -291,6 +338,21 @@ func (in HelmInstallOptions) DeepCopy() HelmInstallOptions {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmChartVerification) DeepCopyInto(out *HelmChartVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmChartVerification.
+func (in *HelmChartVerification) DeepCopy() *HelmChartVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmChartVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HelmInstallValues) DeepCopyInto(out *HelmInstallValues) {
 	*out = *in
@@ -346,6 +408,26 @@ func (in *HelmJSONValueMapType) DeepCopy() *HelmJSONValueMapType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmOCIRegistry) DeepCopyInto(out *HelmOCIRegistry) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmOCIRegistry.
+func (in *HelmOCIRegistry) DeepCopy() *HelmOCIRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmOCIRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HelmTypeInstallSpec) DeepCopyInto(out *HelmTypeInstallSpec) {
 	*out = *in
@@ -358,6 +440,16 @@ func (in *HelmTypeInstallSpec) DeepCopyInto(out *HelmTypeInstallSpec) {
 	}
 	in.InstallValues.DeepCopyInto(&out.InstallValues)
 	in.ValuesMapping.DeepCopyInto(&out.ValuesMapping)
+	if in.OCIRegistry != nil {
+		in, out := &in.OCIRegistry, &out.OCIRegistry
+		*out = new(HelmOCIRegistry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(HelmChartVerification)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmTypeInstallSpec.
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,7 +30,9 @@ import (
 const (
 	// condition types
 	ConditionTypeCancelled          = "Cancelled"
+	ConditionTypeRolledBack         = "RolledBack"
 	ConditionTypeWaitForProgressing = "WaitForProgressing"
+	ConditionTypeScheduled          = "Scheduled"
 	ConditionTypeValidated          = "Validated"
 	ConditionTypeSucceed            = "Succeed"
 	ConditionTypeFailed             = "Failed"
@@ -47,6 +50,7 @@ const (
 	ConditionTypeBackup             = "Backup"
 	ConditionTypeInstanceRebuilding = "InstancesRebuilding"
 	ConditionTypeCustomOperation    = "CustomOperation"
+	ConditionTypePipeline           = "Pipeline"
 
 	// condition and event reasons
 	ReasonClusterPhaseMismatch  = "ClusterPhaseMismatch"
@@ -58,6 +62,11 @@ const (
 	ReasonOpsCancelFailed       = "CancelFailed"
 	ReasonOpsCancelSucceed      = "CancelSucceed"
 	ReasonOpsCancelByController = "CancelByController"
+	ReasonOpsRollingBack        = "RollingBack"
+	ReasonOpsRollbackFailed     = "RollbackFailed"
+	ReasonOpsRollbackSucceed    = "RollbackSucceed"
+	ReasonMaintenanceWindowWait = "WaitForMaintenanceWindow"
+	ReasonMaintenanceWindowOpen = "MaintenanceWindowOpened"
 )
 
 func (r *OpsRequest) SetStatusCondition(condition metav1.Condition) {
@@ -76,6 +85,29 @@ func NewWaitForProcessingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewScheduledCondition the opsRequest waits for the next maintenance window to start.
+func NewScheduledCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeScheduled,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonMaintenanceWindowWait,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`OpsRequest "%s" is scheduled to start in the maintenance window at %s`,
+			ops.Name, ops.Status.ScheduledStartTimestamp.UTC().Format(time.RFC3339)),
+	}
+}
+
+// NewMaintenanceWindowOpenedCondition the maintenance window is open and the opsRequest can be started.
+func NewMaintenanceWindowOpenedCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeScheduled,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonMaintenanceWindowOpen,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf(`The maintenance window is open, start to process OpsRequest "%s"`, ops.Name),
+	}
+}
+
 // NewCancelingCondition the controller is canceling the OpsRequest
 func NewCancelingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -103,6 +135,47 @@ func NewCancelFailedCondition(ops *OpsRequest, err error) *metav1.Condition {
 	}
 }
 
+// NewRollingBackCondition the controller is rolling back the failed OpsRequest.
+func NewRollingBackCondition(ops *OpsRequest, err error) *metav1.Condition {
+	msg := fmt.Sprintf(`Start to roll back the failed OpsRequest "%s" in Cluster: "%s"`, ops.Name, ops.Spec.GetClusterName())
+	if err != nil {
+		msg = fmt.Sprintf("%s, failure: %s", msg, err.Error())
+	}
+	return &metav1.Condition{
+		Type:               ConditionTypeRolledBack,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonOpsRollingBack,
+		LastTransitionTime: metav1.Now(),
+		Message:            msg,
+	}
+}
+
+// NewRollbackFailedCondition creates a condition for rolling back failed.
+func NewRollbackFailedCondition(ops *OpsRequest, err error) *metav1.Condition {
+	msg := fmt.Sprintf(`Failed to roll back OpsRequest "%s"`, ops.Name)
+	if err != nil {
+		msg = err.Error()
+	}
+	return &metav1.Condition{
+		Type:               ConditionTypeRolledBack,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonOpsRollbackFailed,
+		LastTransitionTime: metav1.Now(),
+		Message:            msg,
+	}
+}
+
+// NewRollbackSucceedCondition creates a condition for rolling back successfully.
+func NewRollbackSucceedCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeRolledBack,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonOpsRollbackSucceed,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf(`Roll back OpsRequest "%s" successfully`, ops.Name),
+	}
+}
+
 // NewAbortedCondition creates a condition for aborted phase.
 func NewAbortedCondition(message string) *metav1.Condition {
 	return &metav1.Condition{
@@ -185,6 +258,17 @@ func NewRestartingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewPipelineCondition creates a condition that the operation starts to run the pipeline.
+func NewPipelineCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypePipeline,
+		Status:             metav1.ConditionTrue,
+		Reason:             "PipelineStarted",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to run the pipeline in Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
+
 // NewInstancesRebuildingCondition creates a condition that the operation starts to rebuild the instances.
 func NewInstancesRebuildingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
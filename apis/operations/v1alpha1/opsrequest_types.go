@@ -29,6 +29,7 @@ import (
 // OpsRequestSpec defines the desired state of OpsRequest
 //
 // +kubebuilder:validation:XValidation:rule="has(self.cancel) && self.cancel ? (self.type in ['VerticalScaling', 'HorizontalScaling']) : true",message="forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling']"
+// +kubebuilder:validation:XValidation:rule="has(self.rollbackOnFailure) && self.rollbackOnFailure ? (self.type in ['VerticalScaling', 'Upgrade']) : true",message="forbidden to rollback the opsRequest which type not in ['VerticalScaling','Upgrade']"
 type OpsRequestSpec struct {
 	// Specifies the name of the Cluster resource that this operation is targeting.
 	//
@@ -36,7 +37,7 @@ type OpsRequestSpec struct {
 	ClusterName string `json:"clusterName,omitempty"`
 
 	// Indicates whether the current operation should be canceled and terminated gracefully if it's in the
-	// "Pending", "Creating", or "Running" state.
+	// "Pending", "Scheduled", "Creating", or "Running" state.
 	//
 	// This field applies only to "VerticalScaling" and "HorizontalScaling" opsRequests.
 	//
@@ -66,7 +67,7 @@ type OpsRequestSpec struct {
 
 	// Specifies the type of this operation. Supported types include "Start", "Stop", "Restart", "Switchover",
 	// "VerticalScaling", "HorizontalScaling", "VolumeExpansion", "Reconfiguring", "Upgrade", "Backup", "Restore",
-	// "Expose", "RebuildInstance", "Custom".
+	// "Expose", "RebuildInstance", "Custom", "Pipeline".
 	//
 	// Note: This field is immutable once set.
 	//
@@ -101,6 +102,32 @@ type OpsRequestSpec struct {
 	// +kubebuilder:Minimum=0
 	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 
+	// Indicates whether to restore the previous configuration of the components automatically if the operation fails,
+	// including exceeding the `timeoutSeconds`.
+	//
+	// This field applies only to "VerticalScaling" and "Upgrade" opsRequests.
+	// The configuration recorded in `status.lastConfiguration`, such as resources, instance templates,
+	// componentDef and serviceVersion, will be applied to the components again,
+	// and the opsRequest ends in the "RolledBack" phase if the rollback succeeds.
+	//
+	// Note: Once set, the `rollbackOnFailure` field is immutable and cannot be updated.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rollbackOnFailure"
+	// +optional
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+
+	// Specifies the maintenance window in which the OpsRequest is allowed to start.
+	// It takes precedence over the maintenance window of the Cluster.
+	//
+	// This field applies only to disruptive opsRequests, such as "VerticalScaling", "Upgrade", "Restart" and "Switchover".
+	// The OpsRequest stays in the "Scheduled" phase until the window opens, and if the window closes before it starts,
+	// it is deferred to the next window.
+	// It is ignored if `force` is true.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.maintenanceWindow"
+	// +optional
+	MaintenanceWindow *appsv1.MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
 	// Exactly one of its members must be set.
 	SpecificOpsRequest `json:",inline"`
 }
@@ -230,6 +257,13 @@ type SpecificOpsRequest struct {
 	//
 	// +optional
 	CustomOps *CustomOps `json:"custom,omitempty"`
+
+	// Specifies a pipeline of operations, such as backup, then upgrade, then reconfigure, then restart.
+	// Each step of the pipeline is carried out by a separate OpsRequest.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.pipeline"
+	// +optional
+	Pipeline *Pipeline `json:"pipeline,omitempty"`
 }
 
 // ComponentOps specifies the Component to be operated on.
@@ -565,6 +599,153 @@ type CustomOps struct {
 	CustomOpsComponents []CustomOpsComponent `json:"components"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 }
 
+// Pipeline defines the steps of a "Pipeline" OpsRequest.
+type Pipeline struct {
+	// Lists the steps of the pipeline.
+	//
+	// If none of the steps specifies `dependsOn`, the steps are executed one by one in the order of the list.
+	// Otherwise, a step starts once all the steps it depends on have completed, and the steps without
+	// `dependsOn` start immediately.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	Steps []PipelineStep `json:"steps" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// PipelineStep defines a step of the pipeline.
+type PipelineStep struct {
+	// Specifies the name of the step, which must be unique within the pipeline.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Specifies the names of the steps that must be completed before this step starts.
+	//
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Specifies what to do if the step fails. Supported policies include:
+	//
+	// - `Abort`: No more steps will be started, and the pipeline fails once the running steps have completed.
+	// - `Continue`: The failure is ignored, and the steps that depend on this step will be started.
+	// - `Rollback`: No more steps will be started, and the steps that have succeeded are rolled back
+	//   in the reverse order of their completion. Only "VerticalScaling" and "Upgrade" steps can be rolled back.
+	//
+	// +kubebuilder:default=Abort
+	// +optional
+	FailurePolicy PipelineStepFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// Specifies the maximum duration (in seconds) that the step is allowed to run.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// Specifies the type of the step. Supported types include "Upgrade", "VerticalScaling", "HorizontalScaling",
+	// "VolumeExpansion", "Restart", "Reconfiguring", "Switchover", "Start", "Stop", "Backup".
+	//
+	// +kubebuilder:validation:Required
+	Type OpsType `json:"type"`
+
+	// Exactly one of its members must be set, and it must match the type of the step.
+	PipelineStepOps `json:",inline"`
+}
+
+// PipelineStepOps specifies the operation of a pipeline step.
+type PipelineStepOps struct {
+	// Specifies the desired new version of the Cluster.
+	//
+	// +optional
+	Upgrade *Upgrade `json:"upgrade,omitempty"`
+
+	// Lists HorizontalScaling objects, each specifying scaling requirements for a Component,
+	// including desired replica changes, configurations for new instances, modifications for existing instances,
+	// and take offline/online the specified instances.
+	//
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	HorizontalScalingList []HorizontalScaling `json:"horizontalScaling,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Lists VolumeExpansion objects, each specifying a component and its corresponding volumeClaimTemplates
+	// that requires storage expansion.
+	//
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	VolumeExpansionList []VolumeExpansion `json:"volumeExpansion,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Lists Components to be started. If empty, all components will be started.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=1024
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	StartList []ComponentOps `json:"start,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Lists Components to be stopped. If empty, all components will be stopped.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=1024
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	StopList []ComponentOps `json:"stop,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Lists Components to be restarted.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=1024
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	RestartList []ComponentOps `json:"restart,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Lists Switchover objects, each specifying a Component to perform the switchover operation.
+	//
+	// +optional
+	SwitchoverList []Switchover `json:"switchover,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Lists VerticalScaling objects, each specifying a component and its desired compute resources for vertical scaling.
+	//
+	// +kubebuilder:validation:MaxItems=1024
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	VerticalScalingList []VerticalScaling `json:"verticalScaling,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Lists Reconfigure objects, each specifying a Component and its configuration updates.
+	//
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	Reconfigures []Reconfigure `json:"reconfigures,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Specifies the parameters to back up a Cluster.
+	//
+	// +optional
+	Backup *Backup `json:"backup,omitempty"`
+}
+
 type CustomOpsComponent struct {
 	// Specifies the name of the Component.
 	ComponentOps `json:",inline"`
@@ -918,7 +1099,7 @@ type OpsRequestStatus struct {
 	ClusterGeneration int64 `json:"clusterGeneration,omitempty"`
 
 	// Represents the phase of the OpsRequest.
-	// Possible values include "Pending", "Creating", "Running", "Cancelling", "Cancelled", "Failed", "Succeed".
+	// Possible values include "Pending", "Scheduled", "Creating", "Running", "Cancelling", "Cancelled", "RollingBack", "RolledBack", "Failed", "Succeed".
 	Phase OpsPhase `json:"phase,omitempty"`
 
 	// Represents the progress of the OpsRequest.
@@ -937,6 +1118,10 @@ type OpsRequestStatus struct {
 	// A collection of additional key-value pairs that provide supplementary information for the OpsRequest.
 	Extras []map[string]string `json:"extras,omitempty"`
 
+	// Records the next eligible start time of the OpsRequest when it is waiting for the maintenance window to open.
+	// +optional
+	ScheduledStartTimestamp metav1.Time `json:"scheduledStartTimestamp,omitempty"`
+
 	// Records the time when the OpsRequest started processing.
 	// +optional
 	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
@@ -949,10 +1134,18 @@ type OpsRequestStatus struct {
 	// +optional
 	CancelTimestamp metav1.Time `json:"cancelTimestamp,omitempty"`
 
+	// Records the time when the OpsRequest started to roll back after failure.
+	// +optional
+	RollbackTimestamp metav1.Time `json:"rollbackTimestamp,omitempty"`
+
+	// Describes the progress of each step of a "Pipeline" OpsRequest.
+	// +optional
+	PipelineProgressDetails []ProgressStatusDetail `json:"pipelineProgressDetails,omitempty"`
+
 	// Describes the detailed status of the OpsRequest.
 	// Possible condition types include "Cancelled", "WaitForProgressing", "Validated", "Succeed", "Failed", "Restarting",
 	// "VerticalScaling", "HorizontalScaling", "VolumeExpanding", "Reconfigure", "Switchover", "Stopping", "Starting",
-	// "VersionUpgrading", "Exposing", "Backup", "InstancesRebuilding", "CustomOperation".
+	// "VersionUpgrading", "Exposing", "Backup", "InstancesRebuilding", "CustomOperation", "Pipeline".
 	// +optional
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -1199,3 +1392,27 @@ func (s *Switchover) GetComponentName() string {
 	}
 	return s.ComponentName
 }
+
+// GetStepDependencies returns the names of the steps that each step depends on.
+// if none of the steps specifies dependsOn, each step depends on its previous step.
+func (p *Pipeline) GetStepDependencies() map[string][]string {
+	dependencies := make(map[string][]string, len(p.Steps))
+	sequential := true
+	for _, step := range p.Steps {
+		if len(step.DependsOn) > 0 {
+			sequential = false
+			break
+		}
+	}
+	for i, step := range p.Steps {
+		switch {
+		case !sequential:
+			dependencies[step.Name] = step.DependsOn
+		case i > 0:
+			dependencies[step.Name] = []string{p.Steps[i-1].Name}
+		default:
+			dependencies[step.Name] = nil
+		}
+	}
+	return dependencies
+}
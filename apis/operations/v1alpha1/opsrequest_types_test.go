@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -52,3 +53,59 @@ func TestSetStatusAndMessage(t *testing.T) {
 		t.Error("set progressDetail status and message failed")
 	}
 }
+
+func TestGetStepDependencies(t *testing.T) {
+	pipeline := &Pipeline{
+		Steps: []PipelineStep{{Name: "backup"}, {Name: "upgrade"}, {Name: "restart"}},
+	}
+	dependencies := pipeline.GetStepDependencies()
+	if len(dependencies["backup"]) != 0 || dependencies["upgrade"][0] != "backup" || dependencies["restart"][0] != "upgrade" {
+		t.Errorf("expected the steps to be executed in order, got %v", dependencies)
+	}
+
+	pipeline.Steps[2].DependsOn = []string{"backup"}
+	dependencies = pipeline.GetStepDependencies()
+	if len(dependencies["backup"]) != 0 || len(dependencies["upgrade"]) != 0 || dependencies["restart"][0] != "backup" {
+		t.Errorf("expected the steps to be executed as a DAG, got %v", dependencies)
+	}
+}
+
+func TestValidatePipeline(t *testing.T) {
+	newOps := func(steps ...PipelineStep) *OpsRequest {
+		ops := &OpsRequest{}
+		ops.Name = "pipeline-ops"
+		ops.Spec.Type = PipelineType
+		ops.Spec.Pipeline = &Pipeline{Steps: steps}
+		return ops
+	}
+	backupOps := PipelineStepOps{Backup: &Backup{}}
+	restartOps := PipelineStepOps{RestartList: []ComponentOps{{ComponentName: "mysql"}}}
+	testCases := []struct {
+		name    string
+		ops     *OpsRequest
+		wantErr bool
+	}{
+		{"empty", newOps(), true},
+		{"sequential", newOps(PipelineStep{Name: "a", Type: BackupType, PipelineStepOps: backupOps},
+			PipelineStep{Name: "b", Type: RestartType, PipelineStepOps: restartOps}), false},
+		{"duplicate", newOps(PipelineStep{Name: "a", Type: BackupType, PipelineStepOps: backupOps},
+			PipelineStep{Name: "a", Type: RestartType, PipelineStepOps: restartOps}), true},
+		{"unsupported type", newOps(PipelineStep{Name: "a", Type: PipelineType}), true},
+		{"missing operation", newOps(PipelineStep{Name: "a", Type: RestartType}), true},
+		{"mismatched operation", newOps(PipelineStep{Name: "a", Type: RestartType, PipelineStepOps: backupOps}), true},
+		{"extra operation", newOps(PipelineStep{Name: "a", Type: RestartType, PipelineStepOps: PipelineStepOps{
+			Backup:      &Backup{},
+			RestartList: []ComponentOps{{ComponentName: "mysql"}},
+		}}), true},
+		{"non-existent dependency", newOps(PipelineStep{Name: "a", Type: BackupType, PipelineStepOps: backupOps, DependsOn: []string{"c"}}), true},
+		{"cycle", newOps(PipelineStep{Name: "a", Type: BackupType, PipelineStepOps: backupOps, DependsOn: []string{"b"}},
+			PipelineStep{Name: "b", Type: RestartType, PipelineStepOps: restartOps, DependsOn: []string{"a"}}), true},
+		{"max name length", newOps(PipelineStep{Name: strings.Repeat("a", 41), Type: BackupType, PipelineStepOps: backupOps}), false},
+		{"name too long", newOps(PipelineStep{Name: strings.Repeat("a", 42), Type: BackupType, PipelineStepOps: backupOps}), true},
+	}
+	for _, tc := range testCases {
+		if err := tc.ops.validatePipeline(); (err != nil) != tc.wantErr {
+			t.Errorf("%s: expected error: %v, got: %v", tc.name, tc.wantErr, err)
+		}
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -50,7 +51,7 @@ var (
 // IsComplete checks if opsRequest has been completed.
 func (r *OpsRequest) IsComplete(phases ...OpsPhase) bool {
 	completedPhase := func(phase OpsPhase) bool {
-		return slices.Contains([]OpsPhase{OpsCancelledPhase, OpsSucceedPhase, OpsAbortedPhase, OpsFailedPhase}, phase)
+		return slices.Contains([]OpsPhase{OpsCancelledPhase, OpsRolledBackPhase, OpsSucceedPhase, OpsAbortedPhase, OpsFailedPhase}, phase)
 	}
 	if len(phases) == 0 {
 		return completedPhase(r.Status.Phase)
@@ -145,10 +146,116 @@ func (r *OpsRequest) ValidateOps(ctx context.Context,
 		return r.validateExpose(ctx, cluster)
 	case RebuildInstanceType:
 		return r.validateRebuildInstance(cluster)
+	case PipelineType:
+		return r.validatePipeline()
 	}
 	return nil
 }
 
+// validatePipeline validates the steps of the pipeline,
+// the operation of each step will be validated by the OpsRequest of the step.
+func (r *OpsRequest) validatePipeline() error {
+	pipeline := r.Spec.Pipeline
+	if pipeline == nil || len(pipeline.Steps) == 0 {
+		return notEmptyError("spec.pipeline.steps")
+	}
+	stepTypes := []OpsType{UpgradeType, VerticalScalingType, HorizontalScalingType, VolumeExpansionType, RestartType,
+		ReconfiguringType, SwitchoverType, StartType, StopType, BackupType}
+	stepNames := sets.New[string]()
+	for _, step := range pipeline.Steps {
+		if stepNames.Has(step.Name) {
+			return fmt.Errorf(`duplicate step "%s" in spec.pipeline.steps`, step.Name)
+		}
+		if !slices.Contains(stepTypes, step.Type) {
+			return fmt.Errorf(`the type "%s" of step "%s" is not supported in a pipeline`, step.Type, step.Name)
+		}
+		if opsTypes := step.specifiedOpsTypes(); len(opsTypes) != 1 || opsTypes[0] != step.Type {
+			return fmt.Errorf(`step "%s" must specify exactly one operation and it must match the type "%s", but got %v`,
+				step.Name, step.Type, opsTypes)
+		}
+		// the step is carried out by the OpsRequest "<pipeline>-<step>" and rolled back by "<pipeline>-<step>-rollback",
+		// and both the names are used as label values which must be no more than 63 characters.
+		if l := len(r.Name) + len(step.Name) + len("--rollback"); l > validation.LabelValueMaxLength {
+			return fmt.Errorf(`the name of the pipeline "%s" and step "%s" are too long, the total length must be no more than %d characters`,
+				r.Name, step.Name, validation.LabelValueMaxLength-len("--rollback"))
+		}
+		stepNames.Insert(step.Name)
+	}
+	dependencies := pipeline.GetStepDependencies()
+	for stepName, dependsOn := range dependencies {
+		for _, dep := range dependsOn {
+			if !stepNames.Has(dep) {
+				return fmt.Errorf(`step "%s" depends on a non-existent step "%s"`, stepName, dep)
+			}
+		}
+	}
+	// detect cycles by depth-first search.
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := map[string]int{}
+	var visit func(stepName string) error
+	visit = func(stepName string) error {
+		switch states[stepName] {
+		case visiting:
+			return fmt.Errorf(`circular dependency detected at step "%s" in spec.pipeline.steps`, stepName)
+		case visited:
+			return nil
+		}
+		states[stepName] = visiting
+		for _, dep := range dependencies[stepName] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		states[stepName] = visited
+		return nil
+	}
+	for _, step := range pipeline.Steps {
+		if err := visit(step.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// specifiedOpsTypes returns the types of the operations specified in the step.
+func (s PipelineStepOps) specifiedOpsTypes() []OpsType {
+	var opsTypes []OpsType
+	if s.Upgrade != nil {
+		opsTypes = append(opsTypes, UpgradeType)
+	}
+	if len(s.VerticalScalingList) > 0 {
+		opsTypes = append(opsTypes, VerticalScalingType)
+	}
+	if len(s.HorizontalScalingList) > 0 {
+		opsTypes = append(opsTypes, HorizontalScalingType)
+	}
+	if len(s.VolumeExpansionList) > 0 {
+		opsTypes = append(opsTypes, VolumeExpansionType)
+	}
+	if len(s.RestartList) > 0 {
+		opsTypes = append(opsTypes, RestartType)
+	}
+	if len(s.Reconfigures) > 0 {
+		opsTypes = append(opsTypes, ReconfiguringType)
+	}
+	if len(s.SwitchoverList) > 0 {
+		opsTypes = append(opsTypes, SwitchoverType)
+	}
+	if len(s.StartList) > 0 {
+		opsTypes = append(opsTypes, StartType)
+	}
+	if len(s.StopList) > 0 {
+		opsTypes = append(opsTypes, StopType)
+	}
+	if s.Backup != nil {
+		opsTypes = append(opsTypes, BackupType)
+	}
+	return opsTypes
+}
+
 // validateExpose validates expose api when spec.type is Expose
 func (r *OpsRequest) validateExpose(_ context.Context, cluster *appsv1.Cluster) error {
 	exposeList := r.Spec.ExposeList
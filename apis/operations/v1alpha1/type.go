@@ -42,18 +42,21 @@ const (
 
 // OpsPhase defines opsRequest phase.
 // +enum
-// +kubebuilder:validation:Enum={Pending,Creating,Running,Cancelling,Cancelled,Aborted,Failed,Succeed}
+// +kubebuilder:validation:Enum={Pending,Scheduled,Creating,Running,Cancelling,Cancelled,RollingBack,RolledBack,Aborted,Failed,Succeed}
 type OpsPhase string
 
 const (
-	OpsPendingPhase    OpsPhase = "Pending"
-	OpsCreatingPhase   OpsPhase = "Creating"
-	OpsRunningPhase    OpsPhase = "Running"
-	OpsCancellingPhase OpsPhase = "Cancelling"
-	OpsSucceedPhase    OpsPhase = "Succeed"
-	OpsCancelledPhase  OpsPhase = "Cancelled"
-	OpsFailedPhase     OpsPhase = "Failed"
-	OpsAbortedPhase    OpsPhase = "Aborted"
+	OpsPendingPhase     OpsPhase = "Pending"
+	OpsScheduledPhase   OpsPhase = "Scheduled"
+	OpsCreatingPhase    OpsPhase = "Creating"
+	OpsRunningPhase     OpsPhase = "Running"
+	OpsCancellingPhase  OpsPhase = "Cancelling"
+	OpsRollingBackPhase OpsPhase = "RollingBack"
+	OpsSucceedPhase     OpsPhase = "Succeed"
+	OpsCancelledPhase   OpsPhase = "Cancelled"
+	OpsRolledBackPhase  OpsPhase = "RolledBack"
+	OpsFailedPhase      OpsPhase = "Failed"
+	OpsAbortedPhase     OpsPhase = "Aborted"
 )
 
 // Phase represents the current status of the ClusterDefinition CR.
@@ -72,7 +75,7 @@ const (
 
 // OpsType defines operation types.
 // +enum
-// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,Custom}
+// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,Custom,Pipeline}
 type OpsType string
 
 const (
@@ -90,6 +93,18 @@ const (
 	RestoreType           OpsType = "Restore"
 	RebuildInstanceType   OpsType = "RebuildInstance" // RebuildInstance rebuilding an instance is very useful when a node is offline or an instance is unrecoverable.
 	CustomType            OpsType = "Custom"          // use opsDefinition
+	PipelineType          OpsType = "Pipeline"        // PipelineType runs multiple operations in order or as a DAG.
+)
+
+// PipelineStepFailurePolicy defines what to do when a step of the pipeline fails.
+// +enum
+// +kubebuilder:validation:Enum={Abort,Continue,Rollback}
+type PipelineStepFailurePolicy string
+
+const (
+	AbortOnStepFailure    PipelineStepFailurePolicy = "Abort"
+	ContinueOnStepFailure PipelineStepFailurePolicy = "Continue"
+	RollbackOnStepFailure PipelineStepFailurePolicy = "Rollback"
 )
 
 // ProgressStatus defines the status of the opsRequest progress.
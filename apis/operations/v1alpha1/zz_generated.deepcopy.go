@@ -754,6 +754,11 @@ func (in *OpsRequestSpec) DeepCopyInto(out *OpsRequestSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(appsv1.MaintenanceWindow)
+		**out = **in
+	}
 	in.SpecificOpsRequest.DeepCopyInto(&out.SpecificOpsRequest)
 }
 
@@ -791,9 +796,18 @@ func (in *OpsRequestStatus) DeepCopyInto(out *OpsRequestStatus) {
 			}
 		}
 	}
+	in.ScheduledStartTimestamp.DeepCopyInto(&out.ScheduledStartTimestamp)
 	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
 	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
 	in.CancelTimestamp.DeepCopyInto(&out.CancelTimestamp)
+	in.RollbackTimestamp.DeepCopyInto(&out.RollbackTimestamp)
+	if in.PipelineProgressDetails != nil {
+		in, out := &in.PipelineProgressDetails, &out.PipelineProgressDetails
+		*out = make([]ProgressStatusDetail, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -1022,6 +1036,127 @@ func (in *ParametersSchema) DeepCopy() *ParametersSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pipeline) DeepCopyInto(out *Pipeline) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]PipelineStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pipeline.
+func (in *Pipeline) DeepCopy() *Pipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(Pipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineStep) DeepCopyInto(out *PipelineStep) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	in.PipelineStepOps.DeepCopyInto(&out.PipelineStepOps)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineStep.
+func (in *PipelineStep) DeepCopy() *PipelineStep {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineStepOps) DeepCopyInto(out *PipelineStepOps) {
+	*out = *in
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(Upgrade)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HorizontalScalingList != nil {
+		in, out := &in.HorizontalScalingList, &out.HorizontalScalingList
+		*out = make([]HorizontalScaling, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeExpansionList != nil {
+		in, out := &in.VolumeExpansionList, &out.VolumeExpansionList
+		*out = make([]VolumeExpansion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartList != nil {
+		in, out := &in.StartList, &out.StartList
+		*out = make([]ComponentOps, len(*in))
+		copy(*out, *in)
+	}
+	if in.StopList != nil {
+		in, out := &in.StopList, &out.StopList
+		*out = make([]ComponentOps, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestartList != nil {
+		in, out := &in.RestartList, &out.RestartList
+		*out = make([]ComponentOps, len(*in))
+		copy(*out, *in)
+	}
+	if in.SwitchoverList != nil {
+		in, out := &in.SwitchoverList, &out.SwitchoverList
+		*out = make([]Switchover, len(*in))
+		copy(*out, *in)
+	}
+	if in.VerticalScalingList != nil {
+		in, out := &in.VerticalScalingList, &out.VerticalScalingList
+		*out = make([]VerticalScaling, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Reconfigures != nil {
+		in, out := &in.Reconfigures, &out.Reconfigures
+		*out = make([]Reconfigure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(Backup)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineStepOps.
+func (in *PipelineStepOps) DeepCopy() *PipelineStepOps {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineStepOps)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodInfoExtractor) DeepCopyInto(out *PodInfoExtractor) {
 	*out = *in
@@ -1411,6 +1546,11 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 		*out = new(CustomOps)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Pipeline != nil {
+		in, out := &in.Pipeline, &out.Pipeline
+		*out = new(Pipeline)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecificOpsRequest.
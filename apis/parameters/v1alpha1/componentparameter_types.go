@@ -148,6 +148,24 @@ type ComponentParameterSpec struct {
 	// +listType=map
 	// +listMapKey=name
 	ConfigItemDetails []ConfigTemplateItemDetail `json:"configItemDetails,omitempty"`
+
+	// Specifies the revision to roll back the configuration to.
+	//
+	// When set, the controller restores the parameters and the rendered configuration of the given revision,
+	// and re-applies them through the reconfigure policies as a new revision.
+	// The revision must be one of the revisions recorded in `status.revisionHistory`.
+	// The field is cleared once the rollback has been accepted.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RollbackToRevision *int64 `json:"rollbackToRevision,omitempty"`
+
+	// Specifies the number of revisions to keep in the change history.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
 }
 
 type ReconcileDetail struct {
@@ -225,6 +243,86 @@ type ConfigTemplateItemDetailStatus struct {
 	//
 	// +optional
 	ReconcileDetail *ReconcileDetail `json:"reconcileDetail,omitempty"`
+
+	// Provides the result of the most recent configuration drift detection. This field is optional.
+	//
+	// +optional
+	DriftStatus *ConfigDriftStatus `json:"driftStatus,omitempty"`
+}
+
+// ConfigDriftStatus represents the result of a configuration drift detection.
+type ConfigDriftStatus struct {
+	// Represents the time of the most recent detection.
+	//
+	// +kubebuilder:validation:Required
+	LastDetectTime metav1.Time `json:"lastDetectTime"`
+
+	// Represents the revision of the configuration item the detection was performed against.
+	//
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Represents the revision of the configuration item the drift was last remediated against.
+	// The drift is not remediated again within the same revision, to avoid looping on the drift that can not be remediated.
+	//
+	// +optional
+	RemediatedRevision string `json:"remediatedRevision,omitempty"`
+
+	// Lists the instances whose configuration differs from the rendered one.
+	//
+	// +optional
+	Instances []InstanceDrift `json:"instances,omitempty"`
+}
+
+// InstanceDrift describes the configuration drift of a single instance.
+type InstanceDrift struct {
+	// Represents the name of the pod.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Lists the parameters whose effective value differs from the rendered one.
+	//
+	// +optional
+	Parameters []ParameterDrift `json:"parameters,omitempty"`
+
+	// Provides the error message if the configuration of the instance cannot be read.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Represents the time when the reconfigure policy was re-triggered for this instance.
+	//
+	// +optional
+	RemediateTime *metav1.Time `json:"remediateTime,omitempty"`
+}
+
+// ParameterDrift describes a parameter whose effective value differs from the rendered one.
+type ParameterDrift struct {
+	// Represents the name of the configuration file the parameter belongs to.
+	//
+	// +kubebuilder:validation:Required
+	FileName string `json:"fileName"`
+
+	// Represents the name of the parameter.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Indicates where the drifted value was observed.
+	//
+	// +kubebuilder:validation:Required
+	Source DriftSource `json:"source"`
+
+	// Represents the rendered value of the parameter. Absent if the parameter is not rendered.
+	//
+	// +optional
+	Expected *string `json:"expected,omitempty"`
+
+	// Represents the effective value of the parameter. Absent if the parameter is not found in the instance.
+	//
+	// +optional
+	Actual *string `json:"actual,omitempty"`
 }
 
 // ComponentParameterStatus defines the observed state of ComponentConfiguration
@@ -264,4 +362,105 @@ type ComponentParameterStatus struct {
 	// +listType=map
 	// +listMapKey=name
 	ConfigurationItemStatus []ConfigTemplateItemDetailStatus `json:"configurationStatus"`
+
+	// Records the history of the configuration changes, ordered by revision.
+	// The number of records is limited by `spec.revisionHistoryLimit`.
+	//
+	// +optional
+	RevisionHistory []ParameterRevision `json:"revisionHistory,omitempty"`
+}
+
+// ParameterRevision records a change of the configuration.
+type ParameterRevision struct {
+	// Represents the revision of the change, which is the generation of the ComponentParameter.
+	//
+	// +kubebuilder:validation:Required
+	Revision int64 `json:"revision"`
+
+	// Represents the time when the change was observed.
+	//
+	// +kubebuilder:validation:Required
+	CreationTime metav1.Time `json:"creationTime"`
+
+	// Represents the time when the change was completed, either succeeded or failed.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Represents the object that requested the change, e.g. `OpsRequest/mysql-reconfigure-xxxx`.
+	// Empty if the ComponentParameter was updated directly.
+	//
+	// +optional
+	RequestedBy string `json:"requestedBy,omitempty"`
+
+	// Represents the revision rolled back to, if the change is a rollback.
+	//
+	// +optional
+	RollbackToRevision *int64 `json:"rollbackToRevision,omitempty"`
+
+	// Lists the parameters changed in this revision.
+	//
+	// +optional
+	Changes []ParameterChange `json:"changes,omitempty"`
+
+	// Lists the reconfigure policy and the outcome of each configuration template.
+	//
+	// +optional
+	Items []ParameterRevisionItem `json:"items,omitempty"`
+
+	// Indicates the outcome of the change.
+	//
+	// +optional
+	Phase ParameterPhase `json:"phase,omitempty"`
+}
+
+// ParameterChange describes the change of a single parameter.
+type ParameterChange struct {
+	// Represents the name of the configuration template.
+	//
+	// +kubebuilder:validation:Required
+	TemplateName string `json:"templateName"`
+
+	// Represents the name of the configuration file.
+	//
+	// +kubebuilder:validation:Required
+	FileName string `json:"fileName"`
+
+	// Represents the name of the parameter.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Represents the value before the change. Absent if the parameter was not set.
+	//
+	// +optional
+	OldValue *string `json:"oldValue,omitempty"`
+
+	// Represents the value after the change. Absent if the parameter was removed.
+	//
+	// +optional
+	NewValue *string `json:"newValue,omitempty"`
+}
+
+// ParameterRevisionItem records how a configuration template was reconfigured in a revision.
+type ParameterRevisionItem struct {
+	// Represents the name of the configuration template.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Represents the reconfigure policy applied, e.g. "syncReload", "rolling" or "restart".
+	//
+	// +optional
+	Policy string `json:"policy,omitempty"`
+
+	// Indicates the outcome of the reconfiguration.
+	//
+	// +optional
+	Phase ParameterPhase `json:"phase,omitempty"`
+
+	// Provides the error message if the reconfiguration failed.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
 }
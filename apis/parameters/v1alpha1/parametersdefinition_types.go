@@ -20,6 +20,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 )
 
 // +genclient
@@ -158,6 +160,164 @@ type ParametersDefinitionSpec struct {
 	// +listType=set
 	// +optional
 	ImmutableParameters []string `json:"immutableParameters,omitempty"`
+
+	// Specifies the constraints spanning several parameters of the configuration file.
+	//
+	// Each constraint is a CEL or CUE expression that can reference the parameters of the configuration file,
+	// as well as the resources and replicas of the component,
+	// e.g. `innodb_buffer_pool_size` must be less than 80% of the memory limit of the container.
+	//
+	// The constraints are evaluated against the updated configuration before it is applied,
+	// both by the Parameter controller and the pre-check of the Reconfigure OpsRequest.
+	// The update is rejected if any of the constraints is violated.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Constraints []ParameterConstraint `json:"constraints,omitempty"`
+
+	// Specifies the parameters whose values are derived from the resources of the component,
+	// such as buffer sizes, worker threads and connection limits.
+	//
+	// The values are recomputed when the component is vertically scaled by an OpsRequest,
+	// and the changed ones are applied through the reconfigure policies,
+	// so that a restart is only required if any of the changed parameters is static.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	DerivedParameters []DerivedParameter `json:"derivedParameters,omitempty"`
+
+	// Specifies the policy to detect the drift between the rendered configuration and
+	// the configuration actually loaded by the running instances.
+	//
+	// When set, the controller periodically reads the configuration file inside each pod, and optionally
+	// queries the live values from the engine, then reports the differences in the status of ComponentParameter.
+	//
+	// +optional
+	DriftDetection *DriftDetectionPolicy `json:"driftDetection,omitempty"`
+}
+
+// ParameterConstraint defines a relational constraint among the parameters of a configuration file.
+//
+// The following variables are available in the expression:
+//
+//   - `parameters`: the parameters of the configuration file, keyed by the parameter name.
+//     Values that can be parsed as numbers are converted to int or float, the others are kept as strings.
+//   - `component.replicas`: the number of replicas of the component.
+//   - `component.resources.limits` and `component.resources.requests`: the `cpu` in millicores and the `memory` in bytes,
+//     absent if not specified.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.cel) != has(self.cue)",message="exactly one of cel and cue must be specified"
+type ParameterConstraint struct {
+	// Specifies the unique name of the constraint.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies a CEL expression which evaluates to a bool, true means the constraint is satisfied.
+	//
+	// In addition to the standard functions, `parseSize` converts a size such as "1G" or "512Mi" to bytes,
+	// the single letter units K, M, G and T are treated as powers of 1024 as most databases do.
+	//
+	// For example:
+	//
+	// ```
+	// !has(component.resources.limits.memory) ||
+	//   parseSize(parameters.innodb_buffer_pool_size) * 10 < component.resources.limits.memory * 8
+	// ```
+	//
+	// Referencing a parameter that is not set is an error, use `has(parameters.xxx)` to guard optional parameters.
+	//
+	// +optional
+	CEL string `json:"cel,omitempty"`
+
+	// Specifies a CUE expression which is unified with the `parameters` and the `component`,
+	// the constraint is violated if the unification fails.
+	//
+	// For example:
+	//
+	// ```
+	// parameters: max_connections?: <=(component.replicas*1000)
+	// ```
+	//
+	// +optional
+	CUE string `json:"cue,omitempty"`
+
+	// Provides the message reported when the constraint is violated.
+	// If not specified, the error of the evaluation is reported.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DerivedParameter defines a parameter whose value is derived from the resources of the component.
+type DerivedParameter struct {
+	// Specifies the name of the parameter.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies a CEL expression to compute the value of the parameter.
+	//
+	// The expression can reference the same variables and functions as the ParameterConstraint,
+	// where `parameters` are the current parameters of the configuration file.
+	// The result is converted to a string, for example:
+	//
+	// ```
+	// string(component.resources.limits.memory * 3 / 4 / 1048576) + "M"
+	// ```
+	//
+	// +kubebuilder:validation:Required
+	Expression string `json:"expression"`
+}
+
+// DriftDetectionPolicy defines how to detect and remediate the configuration drift of the running instances.
+type DriftDetectionPolicy struct {
+	// Specifies the interval in seconds between two consecutive detections.
+	//
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:default=300
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// Specifies the action to query the live values of the parameters from the running engine,
+	// e.g. `SHOW GLOBAL VARIABLES` for MySQL.
+	//
+	// If not set, only the configuration file inside the pod is compared.
+	//
+	// +optional
+	RuntimeQuery *RuntimeQueryAction `json:"runtimeQuery,omitempty"`
+
+	// Specifies whether to re-trigger the reconfigure policy for the instances whose configuration has drifted.
+	// The drift is remediated at most once per revision of the configuration.
+	//
+	// +optional
+	AutoRemediate *bool `json:"autoRemediate,omitempty"`
+
+	// Lists the parameters whose values are redacted in the drift status.
+	// The parameters whose names contain "password", "secret", "token" or "credential" are always redacted.
+	//
+	// +optional
+	SensitiveParameters []string `json:"sensitiveParameters,omitempty"`
+}
+
+// RuntimeQueryAction defines a command to query the live values of the parameters from the running engine.
+type RuntimeQueryAction struct {
+	// Specifies the command to be executed.
+	//
+	// The command is expected to print one parameter per line to stdout, formatted as `name=value`.
+	// Values are compared with the rendered ones by the types defined in the parameters schema,
+	// e.g. `ON` equals `1` for a boolean, and `128M` equals `134217728` for an integer.
+	//
+	// +kubebuilder:validation:Required
+	Command []string `json:"command"`
+
+	// Specifies the name of the container in which the command is executed.
+	// Defaults to the container that mounts the configuration file.
+	//
+	// +optional
+	Container string `json:"container,omitempty"`
 }
 
 type ParameterDeletedPolicy struct {
@@ -225,6 +385,11 @@ type ReloadAction struct {
 	// +optional
 	AutoTrigger *AutoTrigger `json:"autoTrigger,omitempty"`
 
+	// Allows to reload the process by calling the HTTP or gRPC endpoint exposed by the engine.
+	//
+	// +optional
+	HTTPTrigger *HTTPTrigger `json:"httpTrigger,omitempty"`
+
 	// Used to match labels on the pod to determine whether a dynamic reload should be performed.
 	//
 	// In some scenarios, only specific pods (e.g., primary replicas) need to undergo a dynamic reload.
@@ -510,6 +675,106 @@ type AutoTrigger struct {
 	ProcessName string `json:"processName,omitempty"`
 }
 
+// HTTPTrigger reloads the process by calling the HTTP or gRPC endpoint exposed by the engine.
+//
+// It is intended for the engines that reload the configuration through an admin API instead of a Unix signal,
+// e.g. the `/-/reload` endpoint of Prometheus.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.http) != has(self.grpc)",message="exactly one of http and grpc must be specified"
+type HTTPTrigger struct {
+	// Defines the HTTP request to perform.
+	//
+	// The updated parameters can be referenced in the path and the values of headers by using `$(PARAM_NAME)`.
+	//
+	// +optional
+	HTTP *appsv1.HTTPAction `json:"http,omitempty"`
+
+	// Defines the gRPC method to invoke.
+	//
+	// The gRPC server must enable the server reflection service.
+	//
+	// +optional
+	GRPC *appsv1.GRPCAction `json:"grpc,omitempty"`
+
+	// Specifies a Go template to render the HTTP request body, or the gRPC request message in JSON format.
+	// The template accesses key-value pairs of updated parameters via the '$' variable.
+	// If specified, it takes precedence over the body of `http` and the request of `grpc`.
+	//
+	// Example template:
+	//
+	// ```yaml
+	// requestTemplate: |-
+	//   {
+	//   {{- range $i, $k := keys $ | sortAlpha }}
+	//   {{- if $i }},{{ end }}
+	//     {{ quote $k }}: {{ quote (get $ $k) }}
+	//   {{- end }}
+	//   }
+	// ```
+	//
+	// +optional
+	RequestTemplate string `json:"requestTemplate,omitempty"`
+
+	// Specifies the Secret that provides the credentials to access the endpoint.
+	//
+	// +optional
+	AuthSecretRef *ReloadAuthSecretRef `json:"authSecretRef,omitempty"`
+
+	// Specifies the HTTP status codes that indicate a successful reload.
+	// If not specified, any 2xx status code is considered successful.
+	//
+	// +optional
+	ExpectedStatusCodes []int32 `json:"expectedStatusCodes,omitempty"`
+
+	// Specifies the maximum duration in seconds of a single request.
+	//
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Defines the strategy to retry the request after a failure.
+	//
+	// +optional
+	RetryPolicy *appsv1.RetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Determines whether parameter updates should be synchronized with the "config-manager".
+	//
+	// - If set to 'True', the controller calls the endpoint through the "config-manager" synchronously.
+	// - If set to 'False', the "config-manager" watches the configuration files and calls the endpoint on changes.
+	//
+	// +optional
+	Sync *bool `json:"sync,omitempty"`
+}
+
+// ReloadAuthType defines how the credentials are sent to the reload endpoint.
+//
+// +enum
+// +kubebuilder:validation:Enum={Basic,Bearer}
+type ReloadAuthType string
+
+const (
+	BasicReloadAuth  ReloadAuthType = "Basic"
+	BearerReloadAuth ReloadAuthType = "Bearer"
+)
+
+// ReloadAuthSecretRef references a Secret in the namespace of the Cluster that holds the credentials.
+type ReloadAuthSecretRef struct {
+	// Specifies the name of the Secret.
+	//
+	// - For `Basic` authentication, the Secret must contain the keys `username` and `password`.
+	// - For `Bearer` authentication, the Secret must contain the key `token`.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the authentication scheme, defaults to `Bearer`.
+	//
+	// For gRPC, the credentials are sent as the `authorization` metadata.
+	//
+	// +kubebuilder:default=Bearer
+	// +optional
+	Type ReloadAuthType `json:"type,omitempty"`
+}
+
 // FileFormatConfig specifies the format of the configuration file and any associated parameters
 // that are specific to the chosen format.
 type FileFormatConfig struct {
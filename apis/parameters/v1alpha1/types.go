@@ -149,6 +149,16 @@ const (
 	CFinishedPhase       ParameterPhase = "Finished"
 )
 
+// DriftSource defines where a configuration drift is observed.
+// +enum
+// +kubebuilder:validation:Enum={File,Runtime}
+type DriftSource string
+
+const (
+	FileDriftSource    DriftSource = "File"
+	RuntimeDriftSource DriftSource = "Runtime"
+)
+
 type ParametersInFile struct {
 	// Holds the configuration keys and values. This field is a workaround for issues found in kubebuilder and code-generator.
 	// Refer to https://github.com/kubernetes-sigs/kubebuilder/issues/528 and https://github.com/kubernetes/code-generator/issues/50 for more details.
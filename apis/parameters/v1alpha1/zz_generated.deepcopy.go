@@ -140,6 +140,16 @@ func (in *ComponentParameterSpec) DeepCopyInto(out *ComponentParameterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RollbackToRevision != nil {
+		in, out := &in.RollbackToRevision, &out.RollbackToRevision
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentParameterSpec.
@@ -169,6 +179,13 @@ func (in *ComponentParameterStatus) DeepCopyInto(out *ComponentParameterStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RevisionHistory != nil {
+		in, out := &in.RevisionHistory, &out.RevisionHistory
+		*out = make([]ParameterRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentParameterStatus.
@@ -271,6 +288,29 @@ func (in *ComponentReconfiguringStatus) DeepCopy() *ComponentReconfiguringStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigDriftStatus) DeepCopyInto(out *ConfigDriftStatus) {
+	*out = *in
+	in.LastDetectTime.DeepCopyInto(&out.LastDetectTime)
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]InstanceDrift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigDriftStatus.
+func (in *ConfigDriftStatus) DeepCopy() *ConfigDriftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigDriftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigTemplateExtension) DeepCopyInto(out *ConfigTemplateExtension) {
 	*out = *in
@@ -347,6 +387,11 @@ func (in *ConfigTemplateItemDetailStatus) DeepCopyInto(out *ConfigTemplateItemDe
 		*out = new(ReconcileDetail)
 		**out = **in
 	}
+	if in.DriftStatus != nil {
+		in, out := &in.DriftStatus, &out.DriftStatus
+		*out = new(ConfigDriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigTemplateItemDetailStatus.
@@ -359,6 +404,21 @@ func (in *ConfigTemplateItemDetailStatus) DeepCopy() *ConfigTemplateItemDetailSt
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DerivedParameter) DeepCopyInto(out *DerivedParameter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DerivedParameter.
+func (in *DerivedParameter) DeepCopy() *DerivedParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(DerivedParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DownwardAPIChangeTriggeredAction) DeepCopyInto(out *DownwardAPIChangeTriggeredAction) {
 	*out = *in
@@ -391,6 +451,36 @@ func (in *DownwardAPIChangeTriggeredAction) DeepCopy() *DownwardAPIChangeTrigger
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetectionPolicy) DeepCopyInto(out *DriftDetectionPolicy) {
+	*out = *in
+	if in.RuntimeQuery != nil {
+		in, out := &in.RuntimeQuery, &out.RuntimeQuery
+		*out = new(RuntimeQueryAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoRemediate != nil {
+		in, out := &in.AutoRemediate, &out.AutoRemediate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SensitiveParameters != nil {
+		in, out := &in.SensitiveParameters, &out.SensitiveParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetectionPolicy.
+func (in *DriftDetectionPolicy) DeepCopy() *DriftDetectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileFormatConfig) DeepCopyInto(out *FileFormatConfig) {
 	*out = *in
@@ -427,6 +517,51 @@ func (in *FormatterAction) DeepCopy() *FormatterAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPTrigger) DeepCopyInto(out *HTTPTrigger) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(v1.HTTPAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(v1.GRPCAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuthSecretRef != nil {
+		in, out := &in.AuthSecretRef, &out.AuthSecretRef
+		*out = new(ReloadAuthSecretRef)
+		**out = **in
+	}
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(v1.RetryPolicy)
+		**out = **in
+	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPTrigger.
+func (in *HTTPTrigger) DeepCopy() *HTTPTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IniConfig) DeepCopyInto(out *IniConfig) {
 	*out = *in
@@ -442,6 +577,32 @@ func (in *IniConfig) DeepCopy() *IniConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceDrift) DeepCopyInto(out *InstanceDrift) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ParameterDrift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemediateTime != nil {
+		in, out := &in.RemediateTime, &out.RemediateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceDrift.
+func (in *InstanceDrift) DeepCopy() *InstanceDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParamConfigRenderer) DeepCopyInto(out *ParamConfigRenderer) {
 	*out = *in
@@ -570,6 +731,46 @@ func (in *Parameter) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterChange) DeepCopyInto(out *ParameterChange) {
+	*out = *in
+	if in.OldValue != nil {
+		in, out := &in.OldValue, &out.OldValue
+		*out = new(string)
+		**out = **in
+	}
+	if in.NewValue != nil {
+		in, out := &in.NewValue, &out.NewValue
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterChange.
+func (in *ParameterChange) DeepCopy() *ParameterChange {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterConstraint) DeepCopyInto(out *ParameterConstraint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterConstraint.
+func (in *ParameterConstraint) DeepCopy() *ParameterConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParameterDeletedPolicy) DeepCopyInto(out *ParameterDeletedPolicy) {
 	*out = *in
@@ -590,6 +791,31 @@ func (in *ParameterDeletedPolicy) DeepCopy() *ParameterDeletedPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterDrift) DeepCopyInto(out *ParameterDrift) {
+	*out = *in
+	if in.Expected != nil {
+		in, out := &in.Expected, &out.Expected
+		*out = new(string)
+		**out = **in
+	}
+	if in.Actual != nil {
+		in, out := &in.Actual, &out.Actual
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterDrift.
+func (in *ParameterDrift) DeepCopy() *ParameterDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParameterList) DeepCopyInto(out *ParameterList) {
 	*out = *in
@@ -622,6 +848,58 @@ func (in *ParameterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterRevision) DeepCopyInto(out *ParameterRevision) {
+	*out = *in
+	in.CreationTime.DeepCopyInto(&out.CreationTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RollbackToRevision != nil {
+		in, out := &in.RollbackToRevision, &out.RollbackToRevision
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]ParameterChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ParameterRevisionItem, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterRevision.
+func (in *ParameterRevision) DeepCopy() *ParameterRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterRevisionItem) DeepCopyInto(out *ParameterRevisionItem) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterRevisionItem.
+func (in *ParameterRevisionItem) DeepCopy() *ParameterRevisionItem {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterRevisionItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParameterSpec) DeepCopyInto(out *ParameterSpec) {
 	*out = *in
@@ -775,6 +1053,21 @@ func (in *ParametersDefinitionSpec) DeepCopyInto(out *ParametersDefinitionSpec)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Constraints != nil {
+		in, out := &in.Constraints, &out.Constraints
+		*out = make([]ParameterConstraint, len(*in))
+		copy(*out, *in)
+	}
+	if in.DerivedParameters != nil {
+		in, out := &in.DerivedParameters, &out.DerivedParameters
+		*out = make([]DerivedParameter, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(DriftDetectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParametersDefinitionSpec.
@@ -960,6 +1253,11 @@ func (in *ReloadAction) DeepCopyInto(out *ReloadAction) {
 		*out = new(AutoTrigger)
 		**out = **in
 	}
+	if in.HTTPTrigger != nil {
+		in, out := &in.HTTPTrigger, &out.HTTPTrigger
+		*out = new(HTTPTrigger)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TargetPodSelector != nil {
 		in, out := &in.TargetPodSelector, &out.TargetPodSelector
 		*out = new(metav1.LabelSelector)
@@ -977,6 +1275,41 @@ func (in *ReloadAction) DeepCopy() *ReloadAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReloadAuthSecretRef) DeepCopyInto(out *ReloadAuthSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReloadAuthSecretRef.
+func (in *ReloadAuthSecretRef) DeepCopy() *ReloadAuthSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ReloadAuthSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeQueryAction) DeepCopyInto(out *RuntimeQueryAction) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeQueryAction.
+func (in *RuntimeQueryAction) DeepCopy() *RuntimeQueryAction {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeQueryAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScriptConfig) DeepCopyInto(out *ScriptConfig) {
 	*out = *in
@@ -92,13 +92,25 @@ type ObjectReference struct {
 	Name string `json:"name,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="has(self.desiredSpec) != has(self.opsRequestSpec)",message="exactly one of desiredSpec and opsRequestSpec should be specified"
 type DryRun struct {
 	// DesiredSpec specifies the desired spec of the TargetObject.
 	// The desired spec will be merged into the current spec by a strategic merge patch way to build the final spec,
 	// and the reconciliation plan will be calculated by comparing the current spec to the final spec.
 	// DesiredSpec should be a valid YAML string.
 	//
-	DesiredSpec string `json:"desiredSpec"`
+	// +optional
+	DesiredSpec string `json:"desiredSpec,omitempty"`
+
+	// OpsRequestSpec specifies the spec of an OpsRequest to be applied to the TargetObject.
+	// The final spec is built by the handler of the OpsRequest type, the same way as the OpsRequest controller does,
+	// and the reconciliation plan will be calculated by comparing the current spec to the final spec.
+	// Supported types: VerticalScaling, HorizontalScaling, Upgrade, Reconfiguring, VolumeExpansion, Restart, Start and Stop.
+	// The clusterName in the spec is ignored, the TargetObject is always used.
+	// OpsRequestSpec should be a valid YAML string.
+	//
+	// +optional
+	OpsRequestSpec string `json:"opsRequestSpec,omitempty"`
 }
 
 // StateEvaluationExpression defines an object state evaluation expression.
@@ -138,7 +150,7 @@ type DryRunResult struct {
 	// +optional
 	Message string `json:"message,omitempty"`
 
-	// DesiredSpecRevision specifies the revision of the DesiredSpec.
+	// DesiredSpecRevision specifies the revision of the DesiredSpec or the OpsRequestSpec.
 	//
 	DesiredSpecRevision string `json:"desiredSpecRevision"`
 
@@ -41,7 +41,10 @@ import (
 )
 
 const (
-	defaultMaxConcurrency = 32
+	defaultMaxConcurrency   = 32
+	defaultAuditLogDir      = "/tmp/kbagent/audit"
+	defaultAuditLogRecords  = 1024
+	defaultTransferSpoolDir = "/tmp/kbagent/transfer"
 )
 
 var serverConfig server.Config
@@ -57,6 +60,10 @@ func init() {
 	pflag.IntVar(&serverConfig.Concurrency, "max-concurrency", defaultMaxConcurrency,
 		fmt.Sprintf("The maximum number of concurrent connections the Server may serve, use the default value %d if <=0.", defaultMaxConcurrency))
 	pflag.BoolVar(&serverConfig.Logging, "api-logging", true, "Enable api logging for kb-agent request.")
+	pflag.StringVar(&serverConfig.AuditLogDir, "audit-log-dir", defaultAuditLogDir, "The directory to store the audit log of actions, disable the audit log if it is empty.")
+	pflag.IntVar(&serverConfig.AuditLogRecords, "audit-log-records", defaultAuditLogRecords, "The maximum number of action records kept in the audit log.")
+	pflag.StringVar(&serverConfig.TransferSpoolDir, "transfer-spool-dir", defaultTransferSpoolDir, "The directory to spool the data dumps of the transfers, which should be on a volume large enough to hold them.")
+	pflag.Int64Var(&serverConfig.TransferSpoolMaxBytes, "transfer-spool-max-bytes", 0, "The maximum bytes of the spool of a transfer, it is only bounded by the free space of the volume if <=0.")
 }
 
 func main() {
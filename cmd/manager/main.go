@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -156,6 +157,9 @@ func init() {
 	viper.SetDefault(constant.FeatureGateInPlacePodVerticalScaling, false)
 	viper.SetDefault(constant.I18nResourcesName, "kubeblocks-i18n-resources")
 	viper.SetDefault(constant.APIVersionSupported, "")
+	viper.SetDefault(constant.CfgKeyMultiClusterFailoverGracePeriodSeconds, 300)
+	viper.SetDefault(constant.CfgKeyTraceStoreRetention, "168h")
+	viper.SetDefault(constant.CfgKeyTraceStoreMaxChanges, 10000)
 }
 
 type flagName string
@@ -551,10 +555,16 @@ func main() {
 	}
 
 	if viper.GetBool(traceFlagKey.viperName()) {
+		cycleExporter, err := tracecontrollers.NewCycleExporterFromConfig(context.Background())
+		if err != nil {
+			setupLog.Error(err, "unable to create trace exporter")
+			os.Exit(1)
+		}
 		traceReconciler := &tracecontrollers.ReconciliationTraceReconciler{
-			Client:   mgr.GetClient(),
-			Scheme:   mgr.GetScheme(),
-			Recorder: mgr.GetEventRecorderFor("reconciliation-trace-controller"),
+			Client:        mgr.GetClient(),
+			Scheme:        mgr.GetScheme(),
+			Recorder:      mgr.GetEventRecorderFor("reconciliation-trace-controller"),
+			CycleExporter: cycleExporter,
 		}
 		if err := traceReconciler.SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ReconciliationTrace")
@@ -564,6 +574,12 @@ func main() {
 			setupLog.Error(err, "unable to add trace informer manager", "controller", "InformerManager")
 			os.Exit(1)
 		}
+		if traceReconciler.CycleExporter != nil {
+			if err := mgr.Add(traceReconciler.CycleExporter); err != nil {
+				setupLog.Error(err, "unable to add trace exporter", "controller", "CycleExporter")
+				os.Exit(1)
+			}
+		}
 	}
 
 	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
@@ -623,9 +639,10 @@ func main() {
 			os.Exit(1)
 		}
 		if err = (&parameterscontrollers.ComponentParameterReconciler{
-			Client:   client,
-			Scheme:   mgr.GetScheme(),
-			Recorder: mgr.GetEventRecorderFor("component-parameter-controller"),
+			Client:     client,
+			Scheme:     mgr.GetScheme(),
+			Recorder:   mgr.GetEventRecorderFor("component-parameter-controller"),
+			RestConfig: mgr.GetConfig(),
 		}).SetupWithManager(mgr, multiClusterMgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ComponentParameter")
 			os.Exit(1)
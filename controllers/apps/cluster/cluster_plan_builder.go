@@ -65,6 +65,9 @@ type clusterTransformContext struct {
 
 	// TODO: remove this, annotations to be added to components for sharding, mapping with @allComps.
 	annotations map[string]map[string]string
+
+	// placements of the components and shardings pinned to specific data-plane k8s clusters
+	placements map[string]string
 }
 
 // clusterPlanBuilder a graph.PlanBuilder implementation for Cluster reconciliation
@@ -1065,12 +1065,34 @@ func buildComponentWrapper(transCtx *clusterTransformContext,
 	if err != nil {
 		return nil, err
 	}
+	buildComponentPlacement(transCtx, spec, comp)
 	if err = buildComponentSidecars(transCtx, comp, running); err != nil {
 		return nil, err
 	}
 	return comp, nil
 }
 
+// buildComponentPlacement overrides the placement inherited from the cluster if the component or sharding is pinned,
+// and requests to rebuild the instances migrated from the unavailable data-plane k8s clusters.
+func buildComponentPlacement(transCtx *clusterTransformContext, spec *appsv1.ClusterComponentSpec, comp *appsv1.Component) {
+	annotate := func(key, value string) {
+		if comp.Annotations == nil {
+			comp.Annotations = make(map[string]string)
+		}
+		comp.Annotations[key] = value
+	}
+	name := shardingCompNName(comp)
+	if len(name) == 0 {
+		name = spec.Name
+	}
+	if placement, ok := transCtx.placements[name]; ok {
+		annotate(constant.KBAppMultiClusterPlacementKey, placement)
+	}
+	if request := failoverRebuildRequest(transCtx.Cluster.Status.Failover, spec.Name); len(request) > 0 {
+		annotate(constant.MultiClusterRebuildReplicasKey, request)
+	}
+}
+
 func buildComponentSidecars(transCtx *clusterTransformContext, proto, running *appsv1.Component) error {
 	// component definitions used by all components and shardings of the cluster
 	compDefs := func() sets.Set[string] {
@@ -20,15 +20,21 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package cluster
 
 import (
+	"cmp"
+	"fmt"
 	"math/rand"
 	"slices"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 // clusterPlacementTransformer handles replicas placement.
@@ -48,21 +54,42 @@ func (t *clusterPlacementTransformer) Transform(ctx graph.TransformContext, dag
 		return nil // do nothing
 	}
 
-	if t.assigned(transCtx) {
-		transCtx.Context = appsutil.IntoContext(transCtx.Context, appsutil.Placement(transCtx.OrigCluster))
-		return nil
+	var delayedErr error
+	cluster := transCtx.Cluster
+	if !t.assigned(transCtx) {
+		p, err := t.assign(transCtx)
+		if err != nil {
+			return err
+		}
+		if cluster.Annotations == nil {
+			cluster.Annotations = make(map[string]string)
+		}
+		cluster.Annotations[constant.KBAppMultiClusterPlacementKey] = strings.Join(p, ",")
+	} else if err := t.failover(transCtx); err != nil {
+		if !intctrlutil.IsDelayedRequeueError(err) {
+			return err
+		}
+		delayedErr = err
 	}
 
-	p := t.assign(transCtx)
+	placements, err := t.pin(transCtx)
+	if err != nil {
+		return err
+	}
+	transCtx.placements = placements
 
-	cluster := transCtx.Cluster
-	if cluster.Annotations == nil {
-		cluster.Annotations = make(map[string]string)
+	// the objects of the cluster should be placed on the data-plane k8s clusters of all the components
+	placement := appsutil.Placement(cluster)
+	if len(placements) > 0 {
+		contexts := sets.New(strings.Split(placement, ",")...)
+		for _, p := range placements {
+			contexts.Insert(strings.Split(p, ",")...)
+		}
+		placement = strings.Join(sets.List(contexts.Delete("")), ",")
 	}
-	cluster.Annotations[constant.KBAppMultiClusterPlacementKey] = strings.Join(p, ",")
-	transCtx.Context = appsutil.IntoContext(transCtx.Context, appsutil.Placement(cluster))
+	transCtx.Context = appsutil.IntoContext(transCtx.Context, placement)
 
-	return nil
+	return delayedErr
 }
 
 func (t *clusterPlacementTransformer) assigned(transCtx *clusterTransformContext) bool {
@@ -75,7 +102,28 @@ func (t *clusterPlacementTransformer) assigned(transCtx *clusterTransformContext
 	return ok && len(strings.TrimSpace(p)) > 0
 }
 
-func (t *clusterPlacementTransformer) assign(transCtx *clusterTransformContext) []string {
+func (t *clusterPlacementTransformer) assign(transCtx *clusterTransformContext) ([]string, error) {
+	policy := transCtx.Cluster.Spec.Placement
+	if policy == nil {
+		return t.assignRandomly(transCtx), nil
+	}
+
+	infos, err := t.multiClusterMgr.DescribeContexts(transCtx.Context)
+	if err != nil {
+		return nil, err
+	}
+	var current []string
+	if p := appsutil.Placement(transCtx.OrigCluster); len(p) > 0 {
+		current = strings.Split(p, ",")
+	}
+	placement := placeByPolicy(policy, infos, t.maxReplicas(transCtx), current)
+	if len(placement) == 0 {
+		return nil, fmt.Errorf("no available data-plane k8s cluster satisfies the placement policy")
+	}
+	return placement, nil
+}
+
+func (t *clusterPlacementTransformer) assignRandomly(transCtx *clusterTransformContext) []string {
 	replicas := t.maxReplicas(transCtx)
 	contexts := t.multiClusterMgr.GetContexts()
 	if replicas >= len(contexts) {
@@ -98,3 +146,148 @@ func (t *clusterPlacementTransformer) maxReplicas(transCtx *clusterTransformCont
 	})
 	return replicas
 }
+
+// pin returns the placements of the pinned components and shardings, the unavailable data-plane k8s clusters are skipped
+// unless the failover is still waiting for them to recover.
+func (t *clusterPlacementTransformer) pin(transCtx *clusterTransformContext) (map[string]string, error) {
+	policy := transCtx.Cluster.Spec.Placement
+	if policy == nil || len(policy.Pins) == 0 {
+		return nil, nil
+	}
+	pending := failoverPending(transCtx.Cluster)
+	placements := make(map[string]string)
+	for _, pin := range policy.Pins {
+		contexts := slices.DeleteFunc(slices.Clone(pin.Contexts), func(context string) bool {
+			return !pending && !t.multiClusterMgr.IsContextAvailable(context)
+		})
+		if len(contexts) == 0 {
+			return nil, fmt.Errorf("none of the data-plane k8s clusters pinned for %s is available", pin.Name)
+		}
+		placements[pin.Name] = strings.Join(contexts, ",")
+	}
+	return placements, nil
+}
+
+// placeByPolicy selects the data-plane k8s clusters for the replicas according to the placement policy.
+// The clusters in the current placement are preferred to keep the placement as stable as possible.
+func placeByPolicy(policy *appsv1.ClusterPlacement, infos []multicluster.ContextInfo, replicas int, current []string) []string {
+	weights := make(map[string]int32)
+	for _, w := range policy.Weights {
+		weights[w.Context] = w.Weight
+	}
+	weightOf := func(context string) int32 {
+		if w, ok := weights[context]; ok {
+			return w
+		}
+		return 1
+	}
+
+	preferred := sets.New(current...)
+	candidates := slices.DeleteFunc(slices.Clone(infos), func(info multicluster.ContextInfo) bool {
+		return !info.Available || weightOf(info.Name) == 0
+	})
+	slices.SortFunc(candidates, func(a, b multicluster.ContextInfo) int {
+		if pa, pb := preferred.Has(a.Name), preferred.Has(b.Name); pa != pb {
+			if pa {
+				return -1
+			}
+			return 1
+		}
+		if c := cmp.Compare(weightOf(b.Name), weightOf(a.Name)); c != 0 {
+			return c
+		}
+		if policy.CapacityAware {
+			for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				qa, qb := a.Free(name), b.Free(name)
+				if c := qb.Cmp(qa); c != 0 {
+					return c
+				}
+			}
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	// select the clusters one by one, the one sharing the fewest topology domains with the selected ones first
+	selected := make([]multicluster.ContextInfo, 0)
+	used := make([]bool, len(candidates))
+	for len(selected) < min(replicas, len(candidates)) {
+		best, bestScore := -1, []int(nil)
+		for i, candidate := range candidates {
+			if used[i] {
+				continue
+			}
+			score := spreadScore(policy.SpreadKeys, selected, candidate)
+			if best < 0 || slices.Compare(score, bestScore) < 0 {
+				best, bestScore = i, score
+			}
+		}
+		used[best] = true
+		selected = append(selected, candidates[best])
+	}
+
+	placement := make([]string, 0, len(selected))
+	for _, info := range selected {
+		placement = append(placement, info.Name)
+	}
+	if replicas > len(placement) {
+		return weightedSequence(placement, weightOf, replicas)
+	}
+	return placement
+}
+
+// spreadScore returns, for each of the topology keys, the number of the selected clusters sharing any topology domain
+// with the candidate, up to that key. The topology domains of a cluster are those of its nodes.
+func spreadScore(keys []string, selected []multicluster.ContextInfo, candidate multicluster.ContextInfo) []int {
+	score := make([]int, len(keys))
+	for _, s := range selected {
+		for i := range keys {
+			if topologyDomains(keys[:i+1], s).Intersection(topologyDomains(keys[:i+1], candidate)).Len() == 0 {
+				break
+			}
+			score[i]++
+		}
+	}
+	return score
+}
+
+// topologyDomains returns the topology domains of the nodes of the cluster, identified by the values of the keys.
+func topologyDomains(keys []string, info multicluster.ContextInfo) sets.Set[string] {
+	domains := sets.New[string]()
+	for _, labels := range info.NodeLabels {
+		values := make([]string, 0, len(keys))
+		for _, key := range keys {
+			values = append(values, labels[key])
+		}
+		domains.Insert(strings.Join(values, "/"))
+	}
+	return domains
+}
+
+// weightedSequence repeats the clusters in proportion to their weights, interleaved by the smooth weighted round-robin,
+// since the replicas are assigned to the placement in the order of their ordinals. The sequence is as long as the replicas,
+// and the sequence of fewer replicas is always a prefix of the longer one, to keep the placement stable on scaling.
+func weightedSequence(contexts []string, weightOf func(string) int32, replicas int) []string {
+	total := int32(0)
+	weights := make([]int32, len(contexts))
+	for i, context := range contexts {
+		weights[i] = weightOf(context)
+		total += weights[i]
+	}
+	if total == 0 {
+		return contexts
+	}
+	sequence := make([]string, 0, replicas)
+	current := make([]int32, len(contexts))
+	for len(sequence) < replicas {
+		best := 0
+		for i := range contexts {
+			current[i] += weights[i]
+			if current[i] > current[best] {
+				best = i
+			}
+		}
+		current[best] -= total
+		sequence = append(sequence, contexts[best])
+	}
+	return sequence
+}
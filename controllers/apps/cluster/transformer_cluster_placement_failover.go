@@ -0,0 +1,356 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	failoverCheckInterval = 10 * time.Second
+)
+
+// failover moves the replicas placed on the data-plane k8s clusters which stay unavailable beyond the grace period
+// to the healthy ones, and tracks the rebuilding of the affected instances.
+func (t *clusterPlacementTransformer) failover(transCtx *clusterTransformContext) error {
+	gracePeriod := viper.GetInt(constant.CfgKeyMultiClusterFailoverGracePeriodSeconds)
+	if gracePeriod < 0 {
+		return nil // failover is disabled
+	}
+
+	cluster := transCtx.Cluster
+	status := cluster.Status.Failover
+	if status != nil && status.Phase == appsv1.MigratingFailoverPhase {
+		return t.checkRebuilt(transCtx)
+	}
+
+	unavailable := t.unavailableContexts(transCtx)
+	if len(unavailable) == 0 {
+		if status != nil && status.Phase == appsv1.PendingFailoverPhase {
+			cluster.Status.Failover = nil // recovered within the grace period
+		}
+		return nil
+	}
+	if status != nil && status.Phase == appsv1.CompletedFailoverPhase &&
+		sets.New(status.UnavailableContexts...).HasAll(unavailable...) {
+		return nil // the replicas have been migrated from them already
+	}
+
+	if status == nil || status.Phase != appsv1.PendingFailoverPhase || !slices.Equal(status.UnavailableContexts, unavailable) {
+		status = &appsv1.ClusterFailoverStatus{
+			Phase:               appsv1.PendingFailoverPhase,
+			UnavailableContexts: unavailable,
+			UnavailableSince:    &metav1.Time{Time: time.Now()},
+			Message:             "waiting for the unavailable data-plane k8s clusters to recover within the grace period",
+		}
+		cluster.Status.Failover = status
+	}
+	if remaining := time.Until(status.UnavailableSince.Add(time.Duration(gracePeriod) * time.Second)); remaining > 0 {
+		return intctrlutil.NewDelayedRequeueError(remaining, "wait for the unavailable data-plane k8s clusters to recover")
+	}
+	return t.migrate(transCtx, status)
+}
+
+// unavailableContexts returns the unavailable data-plane k8s clusters the cluster and its pinned components are placed on.
+func (t *clusterPlacementTransformer) unavailableContexts(transCtx *clusterTransformContext) []string {
+	contexts := sets.New(strings.Split(appsutil.Placement(transCtx.Cluster), ",")...)
+	if policy := transCtx.Cluster.Spec.Placement; policy != nil {
+		for _, pin := range policy.Pins {
+			contexts.Insert(pin.Contexts...)
+		}
+	}
+	unavailable := make([]string, 0)
+	for _, context := range sets.List(contexts.Delete("")) {
+		if !t.multiClusterMgr.IsContextAvailable(context) {
+			unavailable = append(unavailable, context)
+		}
+	}
+	return unavailable
+}
+
+func (t *clusterPlacementTransformer) migrate(transCtx *clusterTransformContext, status *appsv1.ClusterFailoverStatus) error {
+	cluster := transCtx.Cluster
+	placement := strings.Split(appsutil.Placement(cluster), ",")
+	migrations, err := t.replacements(transCtx, placement, sets.New(status.UnavailableContexts...))
+	if err != nil {
+		return err
+	}
+	instances, err := t.affectedInstances(transCtx, sets.New(status.UnavailableContexts...))
+	if err != nil {
+		return err
+	}
+
+	if len(migrations) > 0 {
+		for i, context := range placement {
+			if replacement, ok := migrations[context]; ok {
+				placement[i] = replacement
+			}
+		}
+		cluster.Annotations[constant.KBAppMultiClusterPlacementKey] = strings.Join(placement, ",")
+	}
+
+	status.Phase = appsv1.MigratingFailoverPhase
+	status.Migrations = migrations
+	status.EvictedContexts = evictedContexts(instances)
+	status.Instances = instances
+	status.StartTime = &metav1.Time{Time: time.Now()}
+	status.Message = ""
+	return t.checkRebuilt(transCtx)
+}
+
+// replacements selects the healthy data-plane k8s clusters to replace the unavailable ones in the placement.
+// The clusters not in the placement yet are preferred, otherwise the replicas will be co-located with the healthy ones.
+func (t *clusterPlacementTransformer) replacements(transCtx *clusterTransformContext,
+	placement []string, unavailable sets.Set[string]) (map[string]string, error) {
+	dead := make([]string, 0)
+	healthy := sets.New[string]()
+	for _, context := range placement {
+		if unavailable.Has(context) {
+			if !slices.Contains(dead, context) {
+				dead = append(dead, context)
+			}
+		} else {
+			healthy.Insert(context)
+		}
+	}
+	if len(dead) == 0 {
+		return nil, nil
+	}
+
+	infos, err := t.multiClusterMgr.DescribeContexts(transCtx.Context)
+	if err != nil {
+		return nil, err
+	}
+	infos = slices.DeleteFunc(slices.Clone(infos), func(info multicluster.ContextInfo) bool {
+		return !info.Available || unavailable.Has(info.Name)
+	})
+
+	var candidates []string
+	if policy := transCtx.Cluster.Spec.Placement; policy != nil {
+		// place the whole replicas again with the healthy ones preferred, to spread the replacements with them
+		candidates = placeByPolicy(policy, infos, len(healthy)+len(dead), sets.List(healthy))
+	} else {
+		for _, info := range infos {
+			candidates = append(candidates, info.Name)
+		}
+		slices.Sort(candidates)
+	}
+	selected := make([]string, 0)
+	for _, context := range candidates {
+		if len(selected) < len(dead) && !healthy.Has(context) && !slices.Contains(selected, context) {
+			selected = append(selected, context)
+		}
+	}
+	if len(selected) == 0 {
+		selected = sets.List(healthy)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no available data-plane k8s cluster to migrate the replicas to")
+	}
+
+	migrations := make(map[string]string)
+	for i, context := range dead {
+		migrations[context] = selected[i%len(selected)]
+	}
+	return migrations, nil
+}
+
+// affectedInstances returns the instances placed on the unavailable data-plane k8s clusters.
+func (t *clusterPlacementTransformer) affectedInstances(transCtx *clusterTransformContext,
+	unavailable sets.Set[string]) ([]appsv1.FailoverInstanceStatus, error) {
+	var (
+		instances = make([]appsv1.FailoverInstanceStatus, 0)
+		err       error
+	)
+	transCtx.traverse(func(spec *appsv1.ClusterComponentSpec) {
+		if err != nil {
+			return
+		}
+		its, err1 := t.workload(transCtx, spec.Name)
+		if err1 != nil || its == nil {
+			err = err1
+			return
+		}
+		contexts := strings.Split(appsutil.Placement(its), ",")
+		names, err1 := component.GeneratePodNamesByITS(its)
+		if err1 != nil {
+			err = err1
+			return
+		}
+		for _, name := range names {
+			ordinal, err2 := strconv.Atoi(name[strings.LastIndex(name, "-")+1:])
+			if err2 != nil {
+				continue
+			}
+			if context := contexts[ordinal%len(contexts)]; unavailable.Has(context) {
+				instances = append(instances, appsv1.FailoverInstanceStatus{
+					Name:      name,
+					Component: spec.Name,
+					Context:   context,
+				})
+			}
+		}
+	})
+	return instances, err
+}
+
+func evictedContexts(instances []appsv1.FailoverInstanceStatus) []string {
+	contexts := sets.New[string]()
+	for _, instance := range instances {
+		contexts.Insert(instance.Context)
+	}
+	return sets.List(contexts)
+}
+
+// checkRebuilt updates the rebuilding progress of the affected instances, fences the ones left on the evicted
+// data-plane k8s clusters, and completes the failover if all of them are rebuilt and fenced.
+func (t *clusterPlacementTransformer) checkRebuilt(transCtx *clusterTransformContext) error {
+	status := transCtx.Cluster.Status.Failover
+	for i, instance := range status.Instances {
+		if !instance.Rebuilt {
+			its, err := t.workload(transCtx, instance.Component)
+			if err != nil {
+				return err
+			}
+			rebuilt, err := component.IsReplicaRebuilt(its, failoverRebuildRequest(status, instance.Component), instance.Name)
+			if err != nil {
+				return err
+			}
+			status.Instances[i].Rebuilt = rebuilt
+		}
+		if !instance.Fenced {
+			fenced, err := t.fence(transCtx, instance)
+			if err != nil {
+				return err
+			}
+			status.Instances[i].Fenced = fenced
+		}
+	}
+
+	if slices.ContainsFunc(status.Instances, func(instance appsv1.FailoverInstanceStatus) bool {
+		return !instance.Rebuilt
+	}) {
+		return intctrlutil.NewDelayedRequeueError(failoverCheckInterval, "wait for the instances to be rebuilt")
+	}
+	if slices.ContainsFunc(status.Instances, func(instance appsv1.FailoverInstanceStatus) bool {
+		return !instance.Fenced
+	}) {
+		status.Message = "waiting for the evicted data-plane k8s clusters to be reachable to delete the instances left on them"
+		return intctrlutil.NewDelayedRequeueError(failoverCheckInterval, "wait for the instances left on the evicted data-plane k8s clusters to be deleted")
+	}
+	status.Phase = appsv1.CompletedFailoverPhase
+	status.Message = ""
+	status.CompletionTime = &metav1.Time{Time: time.Now()}
+	return nil
+}
+
+// fence deletes the pods and PVCs of the instance left on the evicted data-plane k8s cluster once it is reachable again,
+// to keep the stale instance from serving along with the rebuilt one. It reports whether all of them are gone.
+func (t *clusterPlacementTransformer) fence(transCtx *clusterTransformContext, instance appsv1.FailoverInstanceStatus) (bool, error) {
+	if !t.multiClusterMgr.IsContextAvailable(instance.Context) {
+		return false, nil
+	}
+	cli, _ := t.multiClusterMgr.GetContextClient(instance.Context)
+	if cli == nil {
+		return false, fmt.Errorf("the client of the evicted data-plane k8s cluster %s is not found", instance.Context)
+	}
+	labels := client.MatchingLabels{
+		constant.AppInstanceLabelKey:  transCtx.Cluster.Name,
+		constant.KBAppPodNameLabelKey: instance.Name,
+	}
+	// the PVCs are kept by the protection finalizer until the pod is gone
+	for _, kind := range []struct {
+		obj  client.Object
+		list client.ObjectList
+	}{
+		{&corev1.Pod{}, &corev1.PodList{}},
+		{&corev1.PersistentVolumeClaim{}, &corev1.PersistentVolumeClaimList{}},
+	} {
+		if err := cli.List(transCtx.Context, kind.list, client.InNamespace(transCtx.Cluster.Namespace), labels); err != nil {
+			return false, err
+		}
+		if meta.LenList(kind.list) == 0 {
+			continue
+		}
+		if err := cli.DeleteAllOf(transCtx.Context, kind.obj, client.InNamespace(transCtx.Cluster.Namespace), labels); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+func (t *clusterPlacementTransformer) workload(transCtx *clusterTransformContext, compName string) (*workloads.InstanceSet, error) {
+	its := &workloads.InstanceSet{}
+	key := types.NamespacedName{
+		Namespace: transCtx.Cluster.Namespace,
+		Name:      constant.GenerateWorkloadNamePattern(transCtx.Cluster.Name, compName),
+	}
+	if err := transCtx.Client.Get(transCtx.Context, key, its); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return its, nil
+}
+
+// failoverPending checks whether the failover is waiting for the unavailable data-plane k8s clusters to recover.
+func failoverPending(cluster *appsv1.Cluster) bool {
+	return cluster.Status.Failover != nil && cluster.Status.Failover.Phase == appsv1.PendingFailoverPhase
+}
+
+// failoverRebuildRequest builds the request to rebuild the instances of the component affected by the failover.
+func failoverRebuildRequest(status *appsv1.ClusterFailoverStatus, compName string) string {
+	if status == nil || status.Phase != appsv1.MigratingFailoverPhase || status.StartTime == nil {
+		return ""
+	}
+	replicas := make([]string, 0)
+	for _, instance := range status.Instances {
+		if instance.Component == compName {
+			replicas = append(replicas, instance.Name)
+		}
+	}
+	if len(replicas) == 0 {
+		return ""
+	}
+	return component.BuildRebuildReplicasRequest(strconv.FormatInt(status.StartTime.Unix(), 10), replicas)
+}
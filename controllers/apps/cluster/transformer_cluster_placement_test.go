@@ -0,0 +1,400 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cluster
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+type mockMultiClusterManager struct {
+	infos   []multicluster.ContextInfo
+	clients map[string]client.Client
+}
+
+var _ multicluster.Manager = &mockMultiClusterManager{}
+
+func (m *mockMultiClusterManager) GetClient() client.Client {
+	return nil
+}
+
+func (m *mockMultiClusterManager) GetContexts() []string {
+	contexts := make([]string, 0)
+	for _, info := range m.infos {
+		contexts = append(contexts, info.Name)
+	}
+	return contexts
+}
+
+func (m *mockMultiClusterManager) IsContextAvailable(context string) bool {
+	for _, info := range m.infos {
+		if info.Name == context {
+			return info.Available
+		}
+	}
+	return false
+}
+
+func (m *mockMultiClusterManager) DescribeContexts(context.Context) ([]multicluster.ContextInfo, error) {
+	return m.infos, nil
+}
+
+func (m *mockMultiClusterManager) GetContextClient(context string) (client.Client, *rest.Config) {
+	return m.clients[context], nil
+}
+
+func (m *mockMultiClusterManager) Bind(ctrl.Manager) error {
+	return nil
+}
+
+func (m *mockMultiClusterManager) Own(*builder.Builder, client.Object, client.Object) multicluster.Manager {
+	return m
+}
+
+func (m *mockMultiClusterManager) Watch(*builder.Builder, client.Object, handler.EventHandler) multicluster.Manager {
+	return m
+}
+
+var _ = Describe("cluster placement transformer test", func() {
+	newContextInfo := func(name, region, zone, cpu string) multicluster.ContextInfo {
+		return multicluster.ContextInfo{
+			Name:      name,
+			Available: true,
+			NodeLabels: []map[string]string{
+				{
+					corev1.LabelTopologyRegion: region,
+					corev1.LabelTopologyZone:   zone,
+				},
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse(cpu),
+			},
+		}
+	}
+
+	var infos []multicluster.ContextInfo
+
+	BeforeEach(func() {
+		infos = []multicluster.ContextInfo{
+			newContextInfo("a1", "region-a", "zone-a1", "8"),
+			newContextInfo("a2", "region-a", "zone-a2", "32"),
+			newContextInfo("b1", "region-b", "zone-b1", "16"),
+			newContextInfo("c1", "region-c", "zone-c1", "4"),
+		}
+	})
+
+	Context("place by policy", func() {
+		It("spread across regions", func() {
+			policy := &appsv1.ClusterPlacement{
+				SpreadKeys: []string{corev1.LabelTopologyRegion, corev1.LabelTopologyZone},
+			}
+			Expect(placeByPolicy(policy, infos, 3, nil)).Should(Equal([]string{"a1", "b1", "c1"}))
+			Expect(placeByPolicy(policy, infos, 4, nil)).Should(Equal([]string{"a1", "b1", "c1", "a2"}))
+		})
+
+		It("capacity aware", func() {
+			policy := &appsv1.ClusterPlacement{
+				SpreadKeys:    []string{corev1.LabelTopologyRegion},
+				CapacityAware: true,
+			}
+			Expect(placeByPolicy(policy, infos, 2, nil)).Should(Equal([]string{"a2", "b1"}))
+			policy.SpreadKeys = nil
+			Expect(placeByPolicy(policy, infos, 2, nil)).Should(Equal([]string{"a2", "b1"}))
+			Expect(placeByPolicy(policy, infos, 3, nil)).Should(Equal([]string{"a2", "b1", "a1"}))
+
+			By("rank by the free resources rather than the allocatable ones")
+			infos[1].Requested = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("28")}
+			Expect(placeByPolicy(policy, infos, 2, nil)).Should(Equal([]string{"b1", "a1"}))
+		})
+
+		It("spread across the clusters spanning multiple regions", func() {
+			policy := &appsv1.ClusterPlacement{
+				SpreadKeys: []string{corev1.LabelTopologyRegion},
+			}
+			// the nodes of d1 are in both region-a and region-b
+			d1 := newContextInfo("d1", "region-a", "zone-a3", "8")
+			d1.NodeLabels = append(d1.NodeLabels, map[string]string{
+				corev1.LabelTopologyRegion: "region-b",
+				corev1.LabelTopologyZone:   "zone-b2",
+			})
+			infos = append(infos, d1)
+			Expect(placeByPolicy(policy, infos, 3, nil)).Should(Equal([]string{"a1", "b1", "c1"}))
+			Expect(placeByPolicy(policy, infos, 4, nil)).Should(Equal([]string{"a1", "b1", "c1", "a2"}))
+		})
+
+		It("weighted distribution", func() {
+			policy := &appsv1.ClusterPlacement{
+				Weights: []appsv1.PlacementWeight{
+					{Context: "a1", Weight: 0},
+					{Context: "b1", Weight: 4},
+					{Context: "c1", Weight: 2},
+				},
+			}
+			Expect(placeByPolicy(policy, infos, 2, nil)).Should(Equal([]string{"b1", "c1"}))
+			// a2 has the weight of 1, takes 1/7 of the replicas
+			Expect(placeByPolicy(policy, infos, 5, nil)).Should(Equal([]string{"b1", "c1", "b1", "a2", "b1"}))
+			Expect(placeByPolicy(policy, infos, 7, nil)).Should(Equal([]string{"b1", "c1", "b1", "a2", "b1", "c1", "b1"}))
+
+			By("the sequence is as long as the replicas even with the large weights")
+			policy.Weights = []appsv1.PlacementWeight{
+				{Context: "a1", Weight: 100},
+				{Context: "a2", Weight: 99},
+				{Context: "b1", Weight: 98},
+				{Context: "c1", Weight: 97},
+			}
+			Expect(placeByPolicy(policy, infos, 5, nil)).Should(Equal([]string{"a1", "a2", "b1", "c1", "a1"}))
+		})
+
+		It("keep the current placement", func() {
+			policy := &appsv1.ClusterPlacement{
+				SpreadKeys: []string{corev1.LabelTopologyRegion},
+			}
+			infos[2].Available = false
+			Expect(placeByPolicy(policy, infos, 2, []string{"b1", "c1"})).Should(Equal([]string{"c1", "a1"}))
+		})
+	})
+
+	Context("transform", func() {
+		var cli client.Client
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(workloads.AddToScheme(scheme)).Should(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).Build()
+			viper.Set(constant.CfgKeyMultiClusterFailoverGracePeriodSeconds, 0)
+		})
+
+		AfterEach(func() {
+			viper.Set(constant.CfgKeyMultiClusterFailoverGracePeriodSeconds, nil)
+		})
+
+		newTransCtx := func(cluster *appsv1.Cluster) *clusterTransformContext {
+			return &clusterTransformContext{
+				Context:     ctx,
+				Client:      cli,
+				Cluster:     cluster,
+				OrigCluster: cluster.DeepCopy(),
+				components:  []*appsv1.ClusterComponentSpec{{Name: "mysql", Replicas: 2}, {Name: "proxy", Replicas: 1}},
+			}
+		}
+
+		It("re-evaluates the placement when a data-plane cluster is unavailable", func() {
+			cluster := &appsv1.Cluster{
+				Spec: appsv1.ClusterSpec{
+					Placement: &appsv1.ClusterPlacement{
+						SpreadKeys: []string{corev1.LabelTopologyRegion},
+						Pins:       []appsv1.PlacementPin{{Name: "proxy", Contexts: []string{"b1", "c1"}}},
+					},
+				},
+			}
+			transformer := &clusterPlacementTransformer{multiClusterMgr: &mockMultiClusterManager{infos: infos}}
+
+			By("assign the placement")
+			transCtx := newTransCtx(cluster)
+			Expect(transformer.Transform(transCtx, graph.NewDAG())).Should(Succeed())
+			Expect(cluster.Annotations[constant.KBAppMultiClusterPlacementKey]).Should(Equal("a1,b1"))
+			Expect(transCtx.placements).Should(HaveKeyWithValue("proxy", "b1,c1"))
+			placement, err := multicluster.FromContext(transCtx.Context)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(strings.Split(placement, ",")).Should(ConsistOf("a1", "b1", "c1"))
+
+			By("keep the placement")
+			transCtx = newTransCtx(cluster)
+			Expect(transformer.Transform(transCtx, graph.NewDAG())).Should(Succeed())
+			Expect(cluster.Annotations[constant.KBAppMultiClusterPlacementKey]).Should(Equal("a1,b1"))
+
+			By("mark b1 as unavailable")
+			infos[2].Available = false
+			transCtx = newTransCtx(cluster)
+			Expect(transformer.Transform(transCtx, graph.NewDAG())).Should(Succeed())
+			Expect(cluster.Annotations[constant.KBAppMultiClusterPlacementKey]).Should(Equal("a1,c1"))
+			Expect(transCtx.placements).Should(HaveKeyWithValue("proxy", "c1"))
+
+			By("all the pinned data-plane clusters are unavailable")
+			infos[3].Available = false
+			transCtx = newTransCtx(cluster)
+			Expect(transformer.Transform(transCtx, graph.NewDAG())).ShouldNot(Succeed())
+		})
+
+		It("migrates the replicas after the grace period", func() {
+			viper.Set(constant.CfgKeyMultiClusterFailoverGracePeriodSeconds, 300)
+			cluster := &appsv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "test",
+					Annotations: map[string]string{constant.KBAppMultiClusterPlacementKey: "a1,b1"},
+				},
+			}
+			its := &workloads.InstanceSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "test-mysql",
+					Annotations: map[string]string{constant.KBAppMultiClusterPlacementKey: "a1,b1"},
+				},
+				Spec: workloads.InstanceSetSpec{
+					Replicas: ptr.To[int32](2),
+				},
+			}
+			Expect(cli.Create(ctx, its)).Should(Succeed())
+			labels := map[string]string{constant.AppInstanceLabelKey: "test", constant.KBAppPodNameLabelKey: "test-mysql-1"}
+			b1Cli := fake.NewClientBuilder().WithObjects(
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-mysql-1", Labels: labels}},
+				&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "data-test-mysql-1", Labels: labels}},
+			).Build()
+			transformer := &clusterPlacementTransformer{
+				multiClusterMgr: &mockMultiClusterManager{infos: infos, clients: map[string]client.Client{"b1": b1Cli}},
+			}
+
+			By("wait for the unavailable data-plane cluster to recover")
+			infos[2].Available = false
+			err := transformer.Transform(newTransCtx(cluster), graph.NewDAG())
+			Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+			Expect(cluster.Status.Failover).ShouldNot(BeNil())
+			Expect(cluster.Status.Failover.Phase).Should(Equal(appsv1.PendingFailoverPhase))
+			Expect(cluster.Status.Failover.UnavailableContexts).Should(Equal([]string{"b1"}))
+			Expect(cluster.Annotations[constant.KBAppMultiClusterPlacementKey]).Should(Equal("a1,b1"))
+
+			By("recovered within the grace period")
+			infos[2].Available = true
+			Expect(transformer.Transform(newTransCtx(cluster), graph.NewDAG())).Should(Succeed())
+			Expect(cluster.Status.Failover).Should(BeNil())
+
+			By("migrate the replicas after the grace period")
+			infos[2].Available = false
+			Expect(intctrlutil.IsDelayedRequeueError(transformer.Transform(newTransCtx(cluster), graph.NewDAG()))).Should(BeTrue())
+			cluster.Status.Failover.UnavailableSince = &metav1.Time{Time: time.Now().Add(-301 * time.Second)}
+			err = transformer.Transform(newTransCtx(cluster), graph.NewDAG())
+			Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+			Expect(cluster.Status.Failover.Phase).Should(Equal(appsv1.MigratingFailoverPhase))
+			Expect(cluster.Status.Failover.Migrations).Should(HaveKeyWithValue("b1", "a2"))
+			Expect(cluster.Status.Failover.EvictedContexts).Should(Equal([]string{"b1"}))
+			Expect(cluster.Status.Failover.Instances).Should(Equal([]appsv1.FailoverInstanceStatus{
+				{Name: "test-mysql-1", Component: "mysql", Context: "b1"},
+			}))
+			Expect(cluster.Annotations[constant.KBAppMultiClusterPlacementKey]).Should(Equal("a1,a2"))
+
+			By("request to rebuild the instances")
+			comp := &appsv1.Component{}
+			buildComponentPlacement(newTransCtx(cluster), &appsv1.ClusterComponentSpec{Name: "mysql"}, comp)
+			request := comp.Annotations[constant.MultiClusterRebuildReplicasKey]
+			Expect(request).Should(HaveSuffix(":test-mysql-1"))
+
+			By("keep migrating after the instances are rebuilt while the evicted data-plane cluster is unreachable")
+			Expect(component.RebuildReplicasStatus(nil, its, request, false, false)).Should(Succeed())
+			Expect(component.UpdateReplicasStatusFunc(its, func(status *component.ReplicasStatus) error {
+				for i := range status.Status {
+					status.Status[i].Provisioned = true
+				}
+				return nil
+			})).Should(Succeed())
+			Expect(cli.Update(ctx, its)).Should(Succeed())
+			Expect(intctrlutil.IsDelayedRequeueError(transformer.Transform(newTransCtx(cluster), graph.NewDAG()))).Should(BeTrue())
+			Expect(cluster.Status.Failover.Phase).Should(Equal(appsv1.MigratingFailoverPhase))
+			Expect(cluster.Status.Failover.Instances[0].Rebuilt).Should(BeTrue())
+			Expect(cluster.Status.Failover.Instances[0].Fenced).Should(BeFalse())
+			Expect(b1Cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-mysql-1"}, &corev1.Pod{})).Should(Succeed())
+
+			By("delete the instance left on the evicted data-plane cluster after it recovers")
+			infos[2].Available = true
+			pods, pvcs := &corev1.PodList{}, &corev1.PersistentVolumeClaimList{}
+			Expect(intctrlutil.IsDelayedRequeueError(transformer.Transform(newTransCtx(cluster), graph.NewDAG()))).Should(BeTrue())
+			Expect(b1Cli.List(ctx, pods)).Should(Succeed())
+			Expect(pods.Items).Should(BeEmpty())
+			Expect(intctrlutil.IsDelayedRequeueError(transformer.Transform(newTransCtx(cluster), graph.NewDAG()))).Should(BeTrue())
+			Expect(b1Cli.List(ctx, pvcs)).Should(Succeed())
+			Expect(pvcs.Items).Should(BeEmpty())
+
+			By("complete the failover after the left instance is deleted")
+			Expect(transformer.Transform(newTransCtx(cluster), graph.NewDAG())).Should(Succeed())
+			Expect(cluster.Status.Failover.Phase).Should(Equal(appsv1.CompletedFailoverPhase))
+			Expect(cluster.Status.Failover.Instances[0].Fenced).Should(BeTrue())
+
+			By("keep the completed failover and the placement")
+			completed := cluster.Status.Failover.DeepCopy()
+			Expect(transformer.Transform(newTransCtx(cluster), graph.NewDAG())).Should(Succeed())
+			Expect(cluster.Status.Failover).Should(Equal(completed))
+			Expect(cluster.Annotations[constant.KBAppMultiClusterPlacementKey]).Should(Equal("a1,a2"))
+		})
+
+		It("does not fail over the pinned data-plane clusters again", func() {
+			cluster := &appsv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "test",
+					Annotations: map[string]string{constant.KBAppMultiClusterPlacementKey: "a1,a2"},
+				},
+				Spec: appsv1.ClusterSpec{
+					Placement: &appsv1.ClusterPlacement{
+						Pins: []appsv1.PlacementPin{{Name: "proxy", Contexts: []string{"b1", "c1"}}},
+					},
+				},
+			}
+			transformer := &clusterPlacementTransformer{multiClusterMgr: &mockMultiClusterManager{infos: infos}}
+
+			By("fail over the unavailable pinned data-plane cluster")
+			infos[2].Available = false
+			transCtx := newTransCtx(cluster)
+			Expect(transformer.Transform(transCtx, graph.NewDAG())).Should(Succeed())
+			Expect(cluster.Status.Failover.Phase).Should(Equal(appsv1.CompletedFailoverPhase))
+			Expect(cluster.Status.Failover.UnavailableContexts).Should(Equal([]string{"b1"}))
+			Expect(transCtx.placements).Should(HaveKeyWithValue("proxy", "c1"))
+
+			By("reconcile again after the failover is completed")
+			completed := cluster.Status.Failover.DeepCopy()
+			viper.Set(constant.CfgKeyMultiClusterFailoverGracePeriodSeconds, 300)
+			transCtx = newTransCtx(cluster)
+			Expect(transformer.Transform(transCtx, graph.NewDAG())).Should(Succeed())
+			Expect(cluster.Status.Failover).Should(Equal(completed))
+			Expect(transCtx.placements).Should(HaveKeyWithValue("proxy", "c1"))
+
+			By("fail over the newly unavailable data-plane cluster")
+			infos[0].Available = false
+			err := transformer.Transform(newTransCtx(cluster), graph.NewDAG())
+			Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+			Expect(cluster.Status.Failover.Phase).Should(Equal(appsv1.PendingFailoverPhase))
+			Expect(cluster.Status.Failover.UnavailableContexts).Should(Equal([]string{"a1", "b1"}))
+		})
+	})
+})
@@ -121,9 +121,24 @@ func (t *componentWorkloadTransformer) reconcileWorkload(ctx context.Context, cl
 		return err
 	}
 
+	if err := t.rebuildReplicas(synthesizedComp, comp, runningITS, protoITS); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// rebuildReplicas rebuilds the replicas requested, which are moved from the unavailable data-plane k8s clusters.
+func (t *componentWorkloadTransformer) rebuildReplicas(synthesizedComp *component.SynthesizedComponent,
+	comp *appsv1.Component, runningITS, protoITS *workloads.InstanceSet) error {
+	request := comp.Annotations[constant.MultiClusterRebuildReplicasKey]
+	if len(request) == 0 {
+		return nil
+	}
+	hasMemberJoinDefined, hasDataActionDefined := hasMemberJoinNDataActionDefined(synthesizedComp.LifecycleActions)
+	return component.RebuildReplicasStatus(runningITS, protoITS, request, hasMemberJoinDefined, hasDataActionDefined)
+}
+
 func (t *componentWorkloadTransformer) buildInstanceSetPlacementAnnotation(comp *appsv1.Component, its *workloads.InstanceSet) {
 	p := appsutil.Placement(comp)
 	if len(p) > 0 {
@@ -447,6 +462,15 @@ func hasMemberJoinNDataActionDefined(lifecycleActions *appsv1.ComponentLifecycle
 		return false, false
 	}
 	hasActionDefined := func(actions []*appsv1.Action) bool {
+		for _, action := range actions {
+			if action == nil || (action.Exec == nil && action.HTTP == nil && action.GRPC == nil) {
+				return false
+			}
+		}
+		return true
+	}
+	// the data actions are streamed through the stdin/stdout of the command, only exec action is supported
+	hasExecActionDefined := func(actions []*appsv1.Action) bool {
 		for _, action := range actions {
 			if action == nil || action.Exec == nil {
 				return false
@@ -455,5 +479,5 @@ func hasMemberJoinNDataActionDefined(lifecycleActions *appsv1.ComponentLifecycle
 		return true
 	}
 	return hasActionDefined([]*appsv1.Action{lifecycleActions.MemberJoin}),
-		hasActionDefined([]*appsv1.Action{lifecycleActions.DataDump, lifecycleActions.DataLoad})
+		hasExecActionDefined([]*appsv1.Action{lifecycleActions.DataDump, lifecycleActions.DataLoad})
 }
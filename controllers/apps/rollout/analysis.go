@@ -0,0 +1,351 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	celtypes "github.com/google/cel-go/common/types"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	defaultAnalysisIntervalSeconds = 10
+	defaultAnalysisDurationSeconds = 60
+	analysisScrapeTimeout          = 5 * time.Second
+)
+
+// analyze runs the analysis against the new instances of the component, and returns the phase of the analysis.
+// A requeue error will be returned if the analysis is still running.
+func analyze(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout,
+	comp appsv1alpha1.RolloutComponent, analysis *appsv1alpha1.RolloutAnalysis, tplName, compDef string) (appsv1alpha1.RolloutAnalysisPhase, error) {
+	status := rolloutComponentStatus(rollout, comp.Name)
+	if status == nil {
+		return "", controllerutil.NewDelayedRequeueError(time.Second, fmt.Sprintf("the status of component %s is not ready", comp.Name))
+	}
+	if status.Analysis == nil {
+		status.Analysis = &appsv1alpha1.RolloutAnalysisStatus{
+			Phase:          appsv1alpha1.RunningRolloutAnalysisPhase,
+			StartTimestamp: metav1.Now(),
+		}
+	}
+	result := status.Analysis
+	if result.Phase == appsv1alpha1.SucceededRolloutAnalysisPhase || result.Phase == appsv1alpha1.FailedRolloutAnalysisPhase {
+		return result.Phase, nil
+	}
+
+	interval := time.Duration(ptr.Deref(analysis.IntervalSeconds, defaultAnalysisIntervalSeconds)) * time.Second
+	if !result.LastMeasurementTimestamp.IsZero() {
+		if diff := time.Until(result.LastMeasurementTimestamp.Add(interval)); diff > 0 {
+			return result.Phase, controllerutil.NewDelayedRequeueError(diff, fmt.Sprintf("wait for the next measurement of component %s", comp.Name))
+		}
+	}
+
+	result.Measurements++
+	result.LastMeasurementTimestamp = metav1.Now()
+	if err := measure(transCtx, comp, analysis, tplName, compDef); err != nil {
+		result.FailedMeasurements++
+		result.Message = err.Error()
+		transCtx.Logger.Info("the measurement of analysis failed", "component", comp.Name, "error", err.Error())
+	}
+
+	switch {
+	case result.FailedMeasurements > ptr.Deref(analysis.FailureLimit, 0):
+		result.Phase = appsv1alpha1.FailedRolloutAnalysisPhase
+	case !time.Now().Before(result.StartTimestamp.Add(time.Duration(ptr.Deref(analysis.DurationSeconds, defaultAnalysisDurationSeconds)) * time.Second)):
+		result.Phase = appsv1alpha1.SucceededRolloutAnalysisPhase
+	default:
+		return result.Phase, controllerutil.NewDelayedRequeueError(interval, fmt.Sprintf("the analysis of component %s is running", comp.Name))
+	}
+	return result.Phase, nil
+}
+
+// measure evaluates all the checks once, it returns an error if any of them does not pass.
+func measure(transCtx *rolloutTransformContext, comp appsv1alpha1.RolloutComponent,
+	analysis *appsv1alpha1.RolloutAnalysis, tplName, compDef string) error {
+	pods, err := newInstances(transCtx, comp.Name, tplName)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return fmt.Errorf("there is no new instance to analyze")
+	}
+	for _, check := range analysis.Checks {
+		switch {
+		case check.Action != nil:
+			err = checkAction(transCtx, comp.Name, check, pods)
+		case check.Expression != nil:
+			err = checkExpression(transCtx, comp.Name, *check.Expression)
+		case check.Metric != nil:
+			err = checkMetric(transCtx, check.Metric, compDef, pods)
+		}
+		if err != nil {
+			return fmt.Errorf("check %s failed: %s", check.Name, err.Error())
+		}
+	}
+	return nil
+}
+
+func newInstances(transCtx *rolloutTransformContext, compName, tplName string) ([]*corev1.Pod, error) {
+	labels := constant.GetCompLabels(transCtx.Cluster.Name, compName)
+	labels[constant.KBAppInstanceTemplateLabelKey] = tplName
+	podList := &corev1.PodList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(transCtx.Cluster.Namespace),
+		client.MatchingLabels(labels),
+	}
+	if err := transCtx.Client.List(transCtx.Context, podList, listOpts...); err != nil {
+		return nil, err
+	}
+	pods := make([]*corev1.Pod, 0)
+	for i, pod := range podList.Items {
+		if pod.DeletionTimestamp == nil {
+			pods = append(pods, &podList.Items[i])
+		}
+	}
+	return pods, nil
+}
+
+// checkAction calls the action on each new instance, the check passes if all the calls succeed.
+func checkAction(transCtx *rolloutTransformContext, compName string, check appsv1alpha1.RolloutAnalysisCheck, pods []*corev1.Pod) error {
+	for _, pod := range pods {
+		lfa, err := lifecycle.New(transCtx.Cluster.Namespace, transCtx.Cluster.Name, compName, nil, nil, pod)
+		if err != nil {
+			return err
+		}
+		if err = lfa.UserDefined(transCtx.Context, transCtx.Client, nil, analysisActionName(check.Name), check.Action, nil); err != nil {
+			return fmt.Errorf("instance %s: %s", pod.Name, err.Error())
+		}
+	}
+	return nil
+}
+
+func analysisActionName(checkName string) string {
+	return fmt.Sprintf("rollout-analysis-%s", checkName)
+}
+
+// checkExpression evaluates the CEL expression against the Component and InstanceSet objects.
+func checkExpression(transCtx *rolloutTransformContext, compName, expression string) error {
+	comp, ok := transCtx.Components[compName]
+	if !ok || comp == nil {
+		return fmt.Errorf("the component object is not found")
+	}
+	its := &workloads.InstanceSet{}
+	itsKey := types.NamespacedName{
+		Namespace: transCtx.Cluster.Namespace,
+		Name:      constant.GenerateWorkloadNamePattern(transCtx.Cluster.Name, compName),
+	}
+	if err := transCtx.Client.Get(transCtx.Context, itsKey, its); err != nil {
+		return err
+	}
+	passed, err := evalAnalysisExpression(expression, map[string]client.Object{"component": comp, "instanceSet": its})
+	if err != nil {
+		return err
+	}
+	if !passed {
+		return fmt.Errorf("the expression evaluates to false")
+	}
+	return nil
+}
+
+func evalAnalysisExpression(expression string, objects map[string]client.Object) (bool, error) {
+	var (
+		vars  = make([]cel.EnvOption, 0)
+		input = make(map[string]any)
+	)
+	for name, obj := range objects {
+		unstructuredMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return false, fmt.Errorf("failed to convert %s to unstructured: %w", name, err)
+		}
+		value, err := structpb.NewStruct(unstructuredMap)
+		if err != nil {
+			return false, fmt.Errorf("failed to create structpb value: %w", err)
+		}
+		vars = append(vars, cel.Variable(name, cel.MapType(cel.StringType, cel.DynType)))
+		input[name] = celtypes.NewDynamicMap(celtypes.DefaultTypeAdapter, value.AsMap())
+	}
+	return evalCELBool(expression, vars, input)
+}
+
+// checkMetric scrapes the metric from the exporter endpoint of each new instance,
+// and evaluates the condition against the value of each selected sample.
+func checkMetric(transCtx *rolloutTransformContext, metric *appsv1alpha1.RolloutAnalysisMetric, compDefName string, pods []*corev1.Pod) error {
+	compDef := &appsv1.ComponentDefinition{}
+	if err := transCtx.Client.Get(transCtx.Context, types.NamespacedName{Name: compDefName}, compDef); err != nil {
+		return err
+	}
+	exporter := compDef.Spec.Exporter
+	if exporter == nil {
+		return fmt.Errorf("there is no exporter declared in the component definition %s", compDefName)
+	}
+	for _, pod := range pods {
+		values, err := scrapeMetric(transCtx.Context, pod, exporter, metric)
+		if err != nil {
+			return fmt.Errorf("instance %s: %s", pod.Name, err.Error())
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("instance %s: no sample of metric %s matches", pod.Name, metric.Name)
+		}
+		for _, v := range values {
+			passed, err := evalCELBool(metric.Condition, []cel.EnvOption{cel.Variable("value", cel.DoubleType)}, map[string]any{"value": v})
+			if err != nil {
+				return err
+			}
+			if !passed {
+				return fmt.Errorf("instance %s: the value %v of metric %s does not satisfy the condition", pod.Name, v, metric.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func scrapeMetric(ctx context.Context, pod *corev1.Pod, exporter *appsv1.Exporter, metric *appsv1alpha1.RolloutAnalysisMetric) ([]float64, error) {
+	if len(pod.Status.PodIP) == 0 {
+		return nil, fmt.Errorf("the pod IP is not assigned")
+	}
+	var container *corev1.Container
+	for i, c := range pod.Spec.Containers {
+		if c.Name == exporter.ContainerName {
+			container = &pod.Spec.Containers[i]
+			break
+		}
+	}
+	port := common.FromContainerPort(common.Exporter{Exporter: *exporter}, container)
+	if len(port) == 0 {
+		return nil, fmt.Errorf("the scrape port of the exporter is not found")
+	}
+	url := fmt.Sprintf("%s://%s%s", strings.ToLower(common.FromScheme(*exporter)), net.JoinHostPort(pod.Status.PodIP, port), common.FromScrapePath(*exporter))
+
+	ctx, cancel := context.WithTimeout(ctx, analysisScrapeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to scrape metrics from %s: %s", url, rsp.Status)
+	}
+
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+	family, ok := families[metric.Name]
+	if !ok {
+		return nil, nil
+	}
+	values := make([]float64, 0)
+	for _, m := range family.GetMetric() {
+		if !matchMetricLabels(m, metric.Labels) {
+			continue
+		}
+		switch family.GetType() {
+		case dto.MetricType_GAUGE:
+			values = append(values, m.GetGauge().GetValue())
+		case dto.MetricType_COUNTER:
+			values = append(values, m.GetCounter().GetValue())
+		case dto.MetricType_UNTYPED:
+			values = append(values, m.GetUntyped().GetValue())
+		default:
+			return nil, fmt.Errorf("the type %s of metric %s is not supported", family.GetType().String(), metric.Name)
+		}
+	}
+	return values, nil
+}
+
+func matchMetricLabels(m *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, pair := range m.GetLabel() {
+		if v, ok := labels[pair.GetName()]; ok {
+			if v != pair.GetValue() {
+				return false
+			}
+			matched++
+		}
+	}
+	return matched == len(labels)
+}
+
+func evalCELBool(expression string, vars []cel.EnvOption, input map[string]any) (bool, error) {
+	env, err := cel.NewEnv(vars...)
+	if err != nil {
+		return false, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return false, fmt.Errorf("failed to compile expression: %w", issues.Err())
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return false, fmt.Errorf("failed to create CEL program: %w", err)
+	}
+	out, _, err := prg.Eval(input)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression: %w", err)
+	}
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression did not return a boolean")
+	}
+	return result, nil
+}
+
+func rolloutComponentStatus(rollout *appsv1alpha1.Rollout, compName string) *appsv1alpha1.RolloutComponentStatus {
+	for i, status := range rollout.Status.Components {
+		if status.Name == compName {
+			return &rollout.Status.Components[i]
+		}
+	}
+	return nil
+}
+
+func isAnalysisFailed(rollout *appsv1alpha1.Rollout, compName string) bool {
+	status := rolloutComponentStatus(rollout, compName)
+	return status != nil && status.Analysis != nil && status.Analysis.Phase == appsv1alpha1.FailedRolloutAnalysisPhase
+}
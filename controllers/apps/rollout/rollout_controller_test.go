@@ -29,6 +29,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -306,6 +308,38 @@ var _ = Describe("rollout controller", func() {
 				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.SucceedRolloutState))
 			})).Should(Succeed())
 		})
+		It("abort", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(defaultInplaceStrategy).
+					SetReplicas(replicas)
+			})
+
+			mockClusterNCompRunning()
+
+			By("checking the cluster spec been updated")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(serviceVersion2))
+			})).Should(Succeed())
+
+			By("aborting the rollout")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Abort = true
+			})()).Should(Succeed())
+
+			By("checking the cluster spec been reverted")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(serviceVersion1))
+				g.Expect(cluster.Spec.ComponentSpecs[0].ComponentDef).Should(Equal(compDefName))
+			})).Should(Succeed())
+
+			mockClusterNCompRunning()
+
+			By("checking the rollout state as aborted")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.AbortedRolloutState))
+			})).Should(Succeed())
+		})
 	})
 
 	Context("replace", func() {
@@ -502,25 +536,491 @@ var _ = Describe("rollout controller", func() {
 				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.SucceedRolloutState))
 			})).Should(Succeed())
 		})
+
+		It("analysis failed", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(appsv1alpha1.RolloutStrategy{
+						Replace: &appsv1alpha1.RolloutStrategyReplace{
+							Analysis: &appsv1alpha1.RolloutAnalysis{
+								Checks: []appsv1alpha1.RolloutAnalysisCheck{
+									{
+										Name:       "always-false",
+										Expression: ptr.To("false"),
+									},
+								},
+							},
+						},
+					}).
+					SetReplicas(replicas)
+			})
+
+			By("creating pods for the component")
+			mockCreatePods([]int32{0, 1, 2}, "")
+
+			mockClusterNCompRunning() // to up
+
+			By("checking the cluster spec after roll up")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas + 1))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(int32(1)))
+			})).Should(Succeed())
+
+			By("creating the new pod")
+			newPods := mockCreatePods([]int32{10}, string(rolloutObj.UID[:8]))
+
+			mockClusterNCompRunning() // to analyze
+
+			By("checking the analysis failed")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.Components).Should(HaveLen(1))
+				g.Expect(rollout.Status.Components[0].Analysis).ShouldNot(BeNil())
+				g.Expect(rollout.Status.Components[0].Analysis.Phase).Should(Equal(appsv1alpha1.FailedRolloutAnalysisPhase))
+				g.Expect(rollout.Status.Components[0].ScaleDownInstances).Should(BeEmpty())
+			})).Should(Succeed())
+
+			By("checking the new instances are rolled back")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(spec.ServiceVersion).Should(Equal(serviceVersion1))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(int32(0)))
+				g.Expect(spec.OfflineInstances).Should(BeEmpty())
+			})).Should(Succeed())
+
+			By("deleting the new pod")
+			Expect(testCtx.Cli.Delete(testCtx.Ctx, newPods[0])).Should(Succeed())
+
+			mockClusterNCompRunning()
+
+			By("checking the rollout state as aborted")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.AbortedRolloutState))
+			})).Should(Succeed())
+		})
+
+		It("pause & resume", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(defaultReplaceStrategy).
+					SetReplicas(replicas).
+					SetPaused(true)
+			})
+
+			mockClusterNCompRunning()
+
+			By("checking the rollout state as paused")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.PausedRolloutState))
+			})).Should(Succeed())
+
+			By("checking the cluster spec not changed")
+			Consistently(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(spec.Instances).Should(BeEmpty())
+			})).Should(Succeed())
+
+			By("resuming the rollout")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Paused = false
+			})()).Should(Succeed())
+
+			By("checking the cluster spec after roll up")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas + 1))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(int32(1)))
+			})).Should(Succeed())
+		})
+
+		It("abort", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(defaultReplaceStrategy).
+					SetReplicas(replicas)
+			})
+
+			By("creating pods for the component")
+			mockCreatePods([]int32{0, 1, 2}, "")
+
+			mockClusterNCompRunning() // to up
+
+			By("creating the new pod")
+			newPods := mockCreatePods([]int32{10}, string(rolloutObj.UID[:8]))
+
+			mockClusterNCompRunning() // to down
+
+			By("checking the cluster spec after scale down")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(spec.OfflineInstances).Should(HaveLen(1))
+			})).Should(Succeed())
+
+			By("aborting the rollout")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Abort = true
+			})()).Should(Succeed())
+
+			By("checking the new instances are rolled back")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(int32(0)))
+				g.Expect(spec.OfflineInstances).Should(BeEmpty())
+			})).Should(Succeed())
+
+			By("deleting the new pod")
+			Expect(testCtx.Cli.Delete(testCtx.Ctx, newPods[0])).Should(Succeed())
+
+			mockClusterNCompRunning()
+
+			By("checking the rollout state as aborted")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.AbortedRolloutState))
+			})).Should(Succeed())
+		})
+
+		It("steps with manual approval", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(appsv1alpha1.RolloutStrategy{
+						Replace: &appsv1alpha1.RolloutStrategyReplace{
+							Steps: []appsv1alpha1.RolloutStep{
+								{
+									Replicas:       intstr.FromString("30%"),
+									ManualApproval: true,
+								},
+							},
+						},
+					}).
+					SetReplicas(replicas)
+			})
+
+			By("creating pods for the component")
+			mockCreatePods([]int32{0, 1, 2}, "")
+
+			mockClusterNCompRunning() // to up
+
+			By("creating the new pod")
+			mockCreatePods([]int32{10}, string(rolloutObj.UID[:8]))
+
+			mockClusterNCompRunning() // to down
+
+			By("checking the step is waiting for approval")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.Components).Should(HaveLen(1))
+				status := rollout.Status.Components[0]
+				g.Expect(status.CurrentStep).ShouldNot(BeNil())
+				g.Expect(*status.CurrentStep).Should(Equal(int32(0)))
+				g.Expect(status.Steps).Should(HaveLen(1))
+				g.Expect(status.Steps[0].Replicas).Should(Equal(int32(1)))
+				g.Expect(status.Steps[0].State).Should(Equal(appsv1alpha1.WaitingForApprovalRolloutStepState))
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.PausedRolloutState))
+			})).Should(Succeed())
+
+			By("checking the cluster spec after the step")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(int32(1)))
+			})).Should(Succeed())
+
+			By("approving the step")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Components[0].ApprovedStep = ptr.To[int32](0)
+			})()).Should(Succeed())
+
+			mockClusterNCompRunning() // to up
+
+			By("checking the step succeed and the next instance is rolling")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.Components[0].Steps[0].State).Should(Equal(appsv1alpha1.SucceedRolloutStepState))
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas + 1))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(int32(2)))
+			})).Should(Succeed())
+		})
 	})
 
-	// Context("create", func() {
-	//	It("auto promotion", func() {
-	//	})
-	//
-	//	It("partly", func() {
-	//	})
-	//
-	//	It("partly - done", func() {
-	//	})
-	//
-	//	It("promote condition", func() {
-	//	})
-	//
-	//	It("scale down", func() {
-	//	})
-	//
-	//	It("tear down", func() {
-	//	})
-	// })
+	Context("create", func() {
+		createStrategy := func(expression string) appsv1alpha1.RolloutStrategy {
+			return appsv1alpha1.RolloutStrategy{
+				Create: &appsv1alpha1.RolloutStrategyCreate{
+					Canary: ptr.To(true),
+					Promotion: &appsv1alpha1.RolloutPromotion{
+						Auto:                  ptr.To(true),
+						DelaySeconds:          ptr.To[int32](0),
+						ScaleDownDelaySeconds: ptr.To[int32](0),
+						Analysis: &appsv1alpha1.RolloutAnalysis{
+							Checks: []appsv1alpha1.RolloutAnalysisCheck{
+								{
+									Name:       "check",
+									Expression: ptr.To(expression),
+								},
+							},
+							DurationSeconds: ptr.To[int32](0),
+						},
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			createClusterNCompObj()
+		})
+
+		It("analysis failed", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(createStrategy("false")).
+					SetReplicas(replicas)
+			})
+
+			By("creating pods for the component")
+			mockCreatePods([]int32{0, 1, 2}, "")
+
+			mockClusterNCompRunning() // to create
+
+			By("checking the new instances are created as canary")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas * 2))
+				g.Expect(spec.ServiceVersion).Should(Equal(serviceVersion1))
+				g.Expect(spec.Instances).Should(HaveLen(1))
+				g.Expect(spec.Instances[0].ServiceVersion).Should(Equal(serviceVersion2))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(replicas))
+				g.Expect(ptr.Deref(spec.Instances[0].Canary, false)).Should(BeTrue())
+			})).Should(Succeed())
+
+			By("creating the new pods")
+			newPods := mockCreatePods([]int32{10, 11, 12}, string(rolloutObj.UID[:8]))
+
+			mockClusterNCompRunning() // to analyze
+
+			By("checking the analysis failed")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.Components).Should(HaveLen(1))
+				g.Expect(rollout.Status.Components[0].Analysis).ShouldNot(BeNil())
+				g.Expect(rollout.Status.Components[0].Analysis.Phase).Should(Equal(appsv1alpha1.FailedRolloutAnalysisPhase))
+				g.Expect(rollout.Status.Components[0].ScaleDownInstances).Should(BeEmpty())
+			})).Should(Succeed())
+
+			By("checking the new instances are not promoted but rolled back")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(spec.ServiceVersion).Should(Equal(serviceVersion1))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(int32(0)))
+				g.Expect(ptr.Deref(spec.Instances[0].Canary, false)).Should(BeTrue())
+				g.Expect(spec.OfflineInstances).Should(BeEmpty())
+			})).Should(Succeed())
+
+			By("deleting the new pods")
+			for _, pod := range newPods {
+				Expect(testCtx.Cli.Delete(testCtx.Ctx, pod)).Should(Succeed())
+			}
+
+			mockClusterNCompRunning()
+
+			By("checking the rollout state as aborted")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.AbortedRolloutState))
+			})).Should(Succeed())
+		})
+
+		It("analysis succeed", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(createStrategy("true")).
+					SetReplicas(replicas)
+			})
+
+			By("creating pods for the component")
+			pods := mockCreatePods([]int32{0, 1, 2}, "")
+
+			mockClusterNCompRunning() // to create
+
+			By("checking the new instances are created as canary")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas * 2))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(replicas))
+				g.Expect(ptr.Deref(spec.Instances[0].Canary, false)).Should(BeTrue())
+			})).Should(Succeed())
+
+			By("creating the new pods")
+			mockCreatePods([]int32{10, 11, 12}, string(rolloutObj.UID[:8]))
+
+			mockClusterNCompRunning() // to analyze and promote
+
+			By("checking the analysis succeed")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.Components).Should(HaveLen(1))
+				g.Expect(rollout.Status.Components[0].Analysis).ShouldNot(BeNil())
+				g.Expect(rollout.Status.Components[0].Analysis.Phase).Should(Equal(appsv1alpha1.SucceededRolloutAnalysisPhase))
+			})).Should(Succeed())
+
+			By("checking the new instances are promoted")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas * 2))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(replicas))
+				g.Expect(ptr.Deref(spec.Instances[0].Canary, false)).Should(BeFalse())
+			})).Should(Succeed())
+
+			mockClusterNCompRunning() // to down
+
+			By("checking the old instance is scaled down")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas*2 - 1))
+				g.Expect(spec.OfflineInstances).Should(HaveLen(1))
+				g.Expect(spec.OfflineInstances[0]).Should(Equal(pods[0].Name))
+			})).Should(Succeed())
+		})
+
+		It("steps with manual approval", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(appsv1alpha1.RolloutStrategy{
+						Create: &appsv1alpha1.RolloutStrategyCreate{
+							Steps: []appsv1alpha1.RolloutStep{
+								{
+									Replicas:       intstr.FromString("30%"),
+									ManualApproval: true,
+								},
+							},
+						},
+					}).
+					SetReplicas(replicas)
+			})
+
+			By("creating pods for the component")
+			mockCreatePods([]int32{0, 1, 2}, "")
+
+			mockClusterNCompRunning() // to create
+
+			By("checking the cluster spec after the step")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas + 1))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(int32(1)))
+			})).Should(Succeed())
+
+			By("creating the new pod")
+			mockCreatePods([]int32{10}, string(rolloutObj.UID[:8]))
+
+			mockClusterNCompRunning()
+
+			By("checking the step is waiting for approval")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.Components).Should(HaveLen(1))
+				status := rollout.Status.Components[0]
+				g.Expect(status.Steps).Should(HaveLen(1))
+				g.Expect(status.Steps[0].Replicas).Should(Equal(int32(1)))
+				g.Expect(status.Steps[0].State).Should(Equal(appsv1alpha1.WaitingForApprovalRolloutStepState))
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.PausedRolloutState))
+			})).Should(Succeed())
+
+			By("approving the step")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Components[0].ApprovedStep = ptr.To[int32](0)
+			})()).Should(Succeed())
+
+			mockClusterNCompRunning() // to create
+
+			By("checking the step succeed and the remaining instances are created")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.Components[0].Steps[0].State).Should(Equal(appsv1alpha1.SucceedRolloutStepState))
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas * 2))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(replicas))
+			})).Should(Succeed())
+		})
+
+		It("pause & resume", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(appsv1alpha1.RolloutStrategy{
+						Create: &appsv1alpha1.RolloutStrategyCreate{},
+					}).
+					SetReplicas(replicas).
+					SetPaused(true)
+			})
+
+			mockClusterNCompRunning()
+
+			By("checking the rollout state as paused")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.PausedRolloutState))
+			})).Should(Succeed())
+
+			By("checking the cluster spec not changed")
+			Consistently(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(spec.Instances).Should(BeEmpty())
+			})).Should(Succeed())
+
+			By("resuming the rollout")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Paused = false
+			})()).Should(Succeed())
+
+			By("checking the new instances are created")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas * 2))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(replicas))
+			})).Should(Succeed())
+		})
+
+		It("abort", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(appsv1alpha1.RolloutStrategy{
+						Create: &appsv1alpha1.RolloutStrategyCreate{},
+					}).
+					SetReplicas(replicas)
+			})
+
+			By("creating pods for the component")
+			mockCreatePods([]int32{0, 1, 2}, "")
+
+			mockClusterNCompRunning() // to create
+
+			By("checking the new instances are created")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas * 2))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(replicas))
+			})).Should(Succeed())
+
+			By("aborting the rollout")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Abort = true
+			})()).Should(Succeed())
+
+			By("checking the new instances are rolled back")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(int32(0)))
+			})).Should(Succeed())
+
+			mockClusterNCompRunning()
+
+			By("checking the rollout state as aborted")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.AbortedRolloutState))
+			})).Should(Succeed())
+		})
+	})
 })
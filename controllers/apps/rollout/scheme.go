@@ -24,6 +24,7 @@ import (
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 )
 
@@ -31,4 +32,5 @@ func init() {
 	model.AddScheme(clientgoscheme.AddToScheme)
 	model.AddScheme(appsv1alpha1.AddToScheme)
 	model.AddScheme(appsv1.AddToScheme)
+	model.AddScheme(workloads.AddToScheme)
 }
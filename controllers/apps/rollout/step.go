@@ -0,0 +1,97 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// rolloutStep tracks the steps of the component, and returns the target replicas of the new instances for now.
+// It returns true if the rollout should be held until the current step is approved manually.
+func rolloutStep(rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent, steps []appsv1alpha1.RolloutStep,
+	replicas, targetReplicas, rolledOutReplicas int32) (int32, bool, error) {
+	if len(steps) == 0 {
+		return targetReplicas, false, nil
+	}
+	status := rolloutComponentStatus(rollout, comp.Name)
+	if status == nil {
+		return 0, false, controllerutil.NewDelayedRequeueError(time.Second, fmt.Sprintf("the status of component %s is not ready", comp.Name))
+	}
+
+	if len(status.Steps) != len(steps) {
+		status.Steps = make([]appsv1alpha1.RolloutStepStatus, len(steps))
+		for i, step := range steps {
+			stepReplicas, err := intstr.GetScaledValueFromIntOrPercent(&step.Replicas, int(replicas), true)
+			if err != nil {
+				return 0, false, errors.Wrapf(err, "failed to get scaled value for replicas of step %d, component %s", i, comp.Name)
+			}
+			status.Steps[i] = appsv1alpha1.RolloutStepStatus{
+				Replicas: min(int32(stepReplicas), targetReplicas),
+				State:    appsv1alpha1.PendingRolloutStepState,
+			}
+		}
+		status.CurrentStep = ptr.To[int32](0)
+	}
+
+	for i := ptr.Deref(status.CurrentStep, 0); i < int32(len(steps)); i++ {
+		status.CurrentStep = ptr.To(i)
+		stepStatus := &status.Steps[i]
+		if stepStatus.State == appsv1alpha1.SucceedRolloutStepState {
+			continue
+		}
+		if stepStatus.StartTimestamp.IsZero() {
+			stepStatus.StartTimestamp = metav1.Now()
+		}
+		if rolledOutReplicas < stepStatus.Replicas {
+			stepStatus.State = appsv1alpha1.RollingRolloutStepState
+			return stepStatus.Replicas, false, nil
+		}
+		if steps[i].ManualApproval && ptr.Deref(comp.ApprovedStep, -1) < i {
+			stepStatus.State = appsv1alpha1.WaitingForApprovalRolloutStepState
+			return stepStatus.Replicas, true, nil
+		}
+		stepStatus.State = appsv1alpha1.SucceedRolloutStepState
+		stepStatus.CompletionTimestamp = metav1.Now()
+	}
+	return targetReplicas, false, nil
+}
+
+func isStepWaitingForApproval(rollout *appsv1alpha1.Rollout, compName string) bool {
+	status := rolloutComponentStatus(rollout, compName)
+	if status == nil || status.CurrentStep == nil || int(*status.CurrentStep) >= len(status.Steps) {
+		return false
+	}
+	return status.Steps[*status.CurrentStep].State == appsv1alpha1.WaitingForApprovalRolloutStepState
+}
+
+// isComponentAborting checks whether the rollout of the component should be aborted,
+// either it is aborted manually or the analysis failed.
+func isComponentAborting(rollout *appsv1alpha1.Rollout, compName string) bool {
+	return rollout.Spec.Abort || isAnalysisFailed(rollout, compName)
+}
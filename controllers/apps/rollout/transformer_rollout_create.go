@@ -21,6 +21,8 @@ package rollout
 
 import (
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -39,7 +41,7 @@ var _ graph.Transformer = &rolloutCreateTransformer{}
 
 func (t *rolloutCreateTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) {
 		return nil
 	}
 	return t.rollout(transCtx)
@@ -68,52 +70,38 @@ func (t *rolloutCreateTransformer) components(transCtx *rolloutTransformContext)
 func (t *rolloutCreateTransformer) component(transCtx *rolloutTransformContext,
 	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) error {
 	spec := transCtx.ClusterComps[comp.Name]
-	replicas, targetReplicas, err := t.replicas(rollout, comp, spec)
+	replicas, targetReplicas, err := createReplicas(rollout, comp, spec)
 	if err != nil {
 		return err
 	}
 
-	if (replicas + targetReplicas) > spec.Replicas {
-		return t.rolling(transCtx, comp, spec, replicas, targetReplicas)
+	if isComponentAborting(rollout, comp.Name) {
+		return t.abort(transCtx, rollout, comp, spec, replicas)
 	}
-
-	return t.promote(transCtx, comp, spec, replicas, targetReplicas)
-}
-
-func (t *rolloutCreateTransformer) replicas(rollout *appsv1alpha1.Rollout,
-	comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec) (int32, int32, error) {
-	// the original replicas
-	replicas := spec.Replicas
-	for _, status := range rollout.Status.Components {
-		if status.Name == comp.Name {
-			replicas = status.Replicas
-			break
-		}
+	if rollout.Spec.Paused {
+		return nil
 	}
 
-	// the target replicas
-	target, err := func() (int32, error) {
-		if comp.Replicas != nil {
-			replicas, err := intstr.GetScaledValueFromIntOrPercent(comp.Replicas, int(replicas), false)
-			if err != nil {
-				return 0, errors.Wrapf(err, "failed to get scaled value for replicas of component %s", comp.Name)
-			}
-			return int32(replicas), nil
-		}
-		return 0, nil
-	}()
-	if err != nil {
-		return 0, 0, err
+	newReplicas := int32(0)
+	if tpl := createInstanceTemplate(rollout, spec); tpl != nil {
+		newReplicas = ptr.Deref(tpl.Replicas, 0)
+	}
+	stepReplicas, hold, err := rolloutStep(rollout, comp, comp.Strategy.Create.Steps, replicas, targetReplicas, newReplicas)
+	if err != nil || hold {
+		return err
 	}
-	if target < 0 {
-		return 0, 0, errors.Errorf("invalid target replicas %d for component %s", target, comp.Name)
+	if newReplicas < stepReplicas {
+		return t.rolling(transCtx, comp, spec, stepReplicas)
+	}
+	if stepReplicas < targetReplicas {
+		return nil
 	}
 
-	return replicas, target, nil
+	return t.promote(transCtx, rollout, comp, spec, replicas)
 }
 
 func (t *rolloutCreateTransformer) rolling(transCtx *rolloutTransformContext,
-	comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec, replicas, targetReplicas int32) error {
+	comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec, targetReplicas int32) error {
 	if !checkClusterNCompRunning(transCtx, comp.Name) {
 		return controllerutil.NewDelayedRequeueError(componentNotReadyRequeueDuration, fmt.Sprintf("the component %s is not ready", comp.Name))
 	}
@@ -122,7 +110,7 @@ func (t *rolloutCreateTransformer) rolling(transCtx *rolloutTransformContext,
 	if err != nil {
 		return err
 	}
-	spec.Replicas += targetReplicas
+	spec.Replicas += targetReplicas - *tpl.Replicas
 	tpl.Replicas = ptr.To(targetReplicas)
 
 	return nil
@@ -130,17 +118,14 @@ func (t *rolloutCreateTransformer) rolling(transCtx *rolloutTransformContext,
 
 func (t *rolloutCreateTransformer) instanceTemplate(transCtx *rolloutTransformContext,
 	comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec) (*appsv1.InstanceTemplate, error) {
-	name := string(transCtx.Rollout.UID[:8])
-	for i, tpl := range spec.Instances {
-		if tpl.Name == name {
-			return &spec.Instances[i], nil
-		}
+	if tpl := createInstanceTemplate(transCtx.Rollout, spec); tpl != nil {
+		return tpl, nil
 	}
 	if len(spec.Instances) > 0 && !spec.FlatInstanceOrdinal {
 		return nil, fmt.Errorf("not support the create strategy with the flatInstanceOrdinal is false")
 	}
 	tpl := appsv1.InstanceTemplate{
-		Name:     name,
+		Name:     string(transCtx.Rollout.UID[:8]),
 		Canary:   comp.Strategy.Create.Canary,
 		Replicas: ptr.To[int32](0),
 	}
@@ -150,6 +135,17 @@ func (t *rolloutCreateTransformer) instanceTemplate(transCtx *rolloutTransformCo
 	if comp.CompDef != nil {
 		tpl.CompDef = *comp.CompDef
 	}
+	if comp.Strategy.Create.SchedulingPolicy != nil {
+		policy := comp.Strategy.Create.SchedulingPolicy
+		tpl.SchedulingPolicy = &appsv1.SchedulingPolicy{
+			SchedulerName:             policy.SchedulerName,
+			NodeSelector:              policy.NodeSelector,
+			NodeName:                  policy.NodeName,
+			Affinity:                  policy.Affinity,
+			Tolerations:               policy.Tolerations,
+			TopologySpreadConstraints: policy.TopologySpreadConstraints,
+		}
+	}
 	if comp.InstanceMeta != nil && comp.InstanceMeta.Canary != nil {
 		tpl.Labels = comp.InstanceMeta.Canary.Labels
 		tpl.Annotations = comp.InstanceMeta.Canary.Annotations
@@ -159,13 +155,162 @@ func (t *rolloutCreateTransformer) instanceTemplate(transCtx *rolloutTransformCo
 	return &spec.Instances[len(spec.Instances)-1], nil
 }
 
-func (t *rolloutCreateTransformer) promote(transCtx *rolloutTransformContext,
-	comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec, replicas, targetReplicas int32) error {
-	if comp.Strategy.Create.Promotion == nil || !ptr.Deref(comp.Strategy.Create.Promotion.Auto, false) {
+// promote promotes the new instances after all of them have been created, and then scales down the old instances.
+func (t *rolloutCreateTransformer) promote(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout,
+	comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec, replicas int32) error {
+	promotion := comp.Strategy.Create.Promotion
+	if promotion == nil {
+		return nil
+	}
+
+	tpl, err := t.instanceTemplate(transCtx, comp, spec)
+	if err != nil {
+		return err
+	}
+
+	if promotion.Analysis != nil {
+		status := rolloutComponentStatus(rollout, comp.Name)
+		if status == nil || status.Analysis == nil {
+			// start the analysis after the new instances are ready
+			if !checkClusterNCompRunning(transCtx, comp.Name) {
+				return controllerutil.NewDelayedRequeueError(componentNotReadyRequeueDuration, fmt.Sprintf("the component %s is not ready", comp.Name))
+			}
+		}
+		compDef := tpl.CompDef
+		if len(compDef) == 0 {
+			compDef = spec.ComponentDef
+		}
+		phase, err := analyze(transCtx, rollout, comp, promotion.Analysis, tpl.Name, compDef)
+		if err != nil {
+			return err
+		}
+		if phase == appsv1alpha1.FailedRolloutAnalysisPhase {
+			return t.abort(transCtx, rollout, comp, spec, replicas)
+		}
+	}
+
+	if !ptr.Deref(promotion.Auto, false) {
+		return nil
+	}
+
+	if ptr.Deref(tpl.Canary, false) {
+		if err = t.checkDelaySeconds(rollout, comp, promotion.DelaySeconds); err != nil {
+			return err
+		}
+		tpl.Canary = nil
+		return nil
+	}
+	return t.down(transCtx, rollout, comp, spec, tpl, replicas)
+}
+
+// down scales down the old instances one by one after the new instances are promoted.
+func (t *rolloutCreateTransformer) down(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout,
+	comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec, tpl *appsv1.InstanceTemplate, replicas int32) error {
+	if spec.Replicas <= replicas {
+		return nil
+	}
+	promotion := comp.Strategy.Create.Promotion
+	if err := t.checkDelaySeconds(rollout, comp, ptr.To(ptr.Deref(promotion.DelaySeconds, 0)+ptr.Deref(promotion.ScaleDownDelaySeconds, 0))); err != nil {
+		return err
+	}
+	if !checkClusterNCompRunning(transCtx, comp.Name) {
+		return controllerutil.NewDelayedRequeueError(componentNotReadyRequeueDuration, fmt.Sprintf("the component %s is not ready", comp.Name))
+	}
+
+	instance, instTpl, err := pickInstanceToScaleDown(transCtx, spec, tpl)
+	if err != nil {
+		return err
+	}
+
+	spec.Replicas -= 1
+	if instTpl != nil {
+		if instTpl.Replicas == nil || *instTpl.Replicas == 0 {
+			return fmt.Errorf("the instance template %s still has running instances, but its replicas is already 0", instTpl.Name)
+		}
+		instTpl.Replicas = ptr.To(*instTpl.Replicas - 1)
+	}
+	if len(instance) > 0 {
+		spec.OfflineInstances = append(spec.OfflineInstances, instance)
+		if status := rolloutComponentStatus(rollout, comp.Name); status != nil {
+			status.ScaleDownInstances = append(status.ScaleDownInstances, instance)
+		}
+	}
+	return nil
+}
+
+// checkDelaySeconds checks whether the delay seconds have elapsed since the last new instance was created.
+func (t *rolloutCreateTransformer) checkDelaySeconds(rollout *appsv1alpha1.Rollout,
+	comp appsv1alpha1.RolloutComponent, delaySeconds *int32) error {
+	if delaySeconds == nil || *delaySeconds == 0 {
+		return nil
+	}
+	if *delaySeconds < 0 {
+		return controllerutil.NewDelayedRequeueError(infiniteDelayRequeueDuration, "infinite delay")
+	}
+	status := rolloutComponentStatus(rollout, comp.Name)
+	if status == nil || status.LastScaleUpTimestamp.IsZero() {
 		return nil
 	}
+	diff := time.Until(status.LastScaleUpTimestamp.Add(time.Duration(*delaySeconds) * time.Second))
+	if diff > 0 {
+		return controllerutil.NewDelayedRequeueError(diff, fmt.Sprintf("delay to promote for %s seconds", diff.String()))
+	}
+	return nil
+}
+
+// abort rolls back the new instances, and brings back the old instances that have been scaled down.
+func (t *rolloutCreateTransformer) abort(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout,
+	comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec, replicas int32) error {
+	if tpl := createInstanceTemplate(transCtx.Rollout, spec); tpl != nil {
+		tpl.Replicas = ptr.To[int32](0)
+	}
+	spec.Replicas = replicas
+	if status := rolloutComponentStatus(rollout, comp.Name); status != nil {
+		spec.OfflineInstances = slices.DeleteFunc(spec.OfflineInstances, func(instance string) bool {
+			return slices.Contains(status.ScaleDownInstances, instance)
+		})
+	}
+	return nil
+}
+
+func createReplicas(rollout *appsv1alpha1.Rollout,
+	comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec) (int32, int32, error) {
+	// the original replicas
+	replicas := spec.Replicas
+	for _, status := range rollout.Status.Components {
+		if status.Name == comp.Name {
+			replicas = status.Replicas
+			break
+		}
+	}
+
+	// the target replicas
+	target, err := func() (int32, error) {
+		if comp.Replicas != nil {
+			replicas, err := intstr.GetScaledValueFromIntOrPercent(comp.Replicas, int(replicas), false)
+			if err != nil {
+				return 0, errors.Wrapf(err, "failed to get scaled value for replicas of component %s", comp.Name)
+			}
+			return int32(replicas), nil
+		}
+		return 0, nil
+	}()
+	if err != nil {
+		return 0, 0, err
+	}
+	if target < 0 || target > replicas {
+		return 0, 0, errors.Errorf("the target replicas %d is out-of-range, component %s, replicas: %d", target, comp.Name, replicas)
+	}
 
-	// TODO: promote
+	return replicas, target, nil
+}
 
+func createInstanceTemplate(rollout *appsv1alpha1.Rollout, spec *appsv1.ClusterComponentSpec) *appsv1.InstanceTemplate {
+	name := string(rollout.UID[:8])
+	for i, tpl := range spec.Instances {
+		if tpl.Name == name {
+			return &spec.Instances[i]
+		}
+	}
 	return nil
 }
@@ -38,7 +38,7 @@ var _ graph.Transformer = &rolloutInplaceTransformer{}
 
 func (t *rolloutInplaceTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) {
 		return nil
 	}
 	return t.rollout(transCtx)
@@ -79,11 +79,18 @@ func (t *rolloutInplaceTransformer) component(transCtx *rolloutTransformContext,
 	}
 
 	serviceVersion, compDef := serviceVersionNCompDef(transCtx.Rollout, comp, spec)
+	if transCtx.Rollout.Spec.Abort {
+		return t.abort(spec, serviceVersion, compDef)
+	}
 	if serviceVersion != spec.ServiceVersion || compDef != spec.ComponentDef {
 		return nil
 	}
 	// TODO: how about the target service version and component definition are same with the original ones?
 
+	if transCtx.Rollout.Spec.Paused {
+		return nil
+	}
+
 	if !checkClusterNCompRunning(transCtx, comp.Name) {
 		return controllerutil.NewDelayedRequeueError(componentNotReadyRequeueDuration, fmt.Sprintf("the component %s is not ready", comp.Name))
 	}
@@ -97,6 +104,13 @@ func (t *rolloutInplaceTransformer) component(transCtx *rolloutTransformContext,
 	return nil
 }
 
+// abort reverts the service version and component definition of the component to the original ones.
+func (t *rolloutInplaceTransformer) abort(spec *appsv1.ClusterComponentSpec, serviceVersion, compDef string) error {
+	spec.ServiceVersion = serviceVersion
+	spec.ComponentDef = compDef
+	return nil
+}
+
 // serviceVersionNCompDef obtains the original service version and component definition.
 func serviceVersionNCompDef(rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec) (string, string) {
 	serviceVer, compDef := spec.ServiceVersion, spec.ComponentDef
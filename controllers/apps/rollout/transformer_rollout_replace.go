@@ -46,7 +46,7 @@ var _ graph.Transformer = &rolloutReplaceTransformer{}
 
 func (t *rolloutReplaceTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) {
 		return nil
 	}
 	return t.rollout(transCtx)
@@ -93,10 +93,31 @@ func (t *rolloutReplaceTransformer) rolling(transCtx *rolloutTransformContext,
 	if err != nil {
 		return err
 	}
-	if *tpl.Replicas == replicas && spec.Replicas == replicas {
+	if isComponentAborting(rollout, comp.Name) {
+		return t.abort(rollout, comp, spec, tpl, replicas, exist)
+	}
+	if rollout.Spec.Paused {
+		return nil
+	}
+
+	target, hold, err := rolloutStep(rollout, comp, comp.Strategy.Replace.Steps, replicas, replicas, *tpl.Replicas-(spec.Replicas-replicas))
+	if err != nil || hold {
+		return err
+	}
+	if *tpl.Replicas == target && spec.Replicas == replicas {
 		return nil
 	}
 
+	if exist && spec.Replicas != replicas && comp.Strategy.Replace.Analysis != nil {
+		// analyze the new instances before scaling down the old instances
+		if err = t.analyze(transCtx, rollout, comp, spec, tpl); err != nil {
+			return err
+		}
+		if isAnalysisFailed(rollout, comp.Name) {
+			return t.abort(rollout, comp, spec, tpl, replicas, exist)
+		}
+	}
+
 	if !checkClusterNCompRunning(transCtx, comp.Name) {
 		return controllerutil.NewDelayedRequeueError(componentNotReadyRequeueDuration, fmt.Sprintf("the component %s is not ready", comp.Name))
 	}
@@ -115,6 +136,38 @@ func (t *rolloutReplaceTransformer) rolling(transCtx *rolloutTransformContext,
 	}
 }
 
+func (t *rolloutReplaceTransformer) analyze(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec, tpl *appsv1.InstanceTemplate) error {
+	status := rolloutComponentStatus(rollout, comp.Name)
+	if status == nil || status.Analysis == nil {
+		// start the analysis after the new instances are ready
+		if !checkClusterNCompRunning(transCtx, comp.Name) {
+			return controllerutil.NewDelayedRequeueError(componentNotReadyRequeueDuration, fmt.Sprintf("the component %s is not ready", comp.Name))
+		}
+	}
+	compDef := tpl.CompDef
+	if len(compDef) == 0 {
+		compDef = spec.ComponentDef
+	}
+	_, err := analyze(transCtx, rollout, comp, comp.Strategy.Replace.Analysis, tpl.Name, compDef)
+	return err
+}
+
+// abort rolls back the new instances, and brings back the old instances that have been scaled down.
+func (t *rolloutReplaceTransformer) abort(rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent,
+	spec *appsv1.ClusterComponentSpec, tpl *appsv1.InstanceTemplate, replicas int32, exist bool) error {
+	if exist {
+		tpl.Replicas = ptr.To[int32](0)
+	}
+	spec.Replicas = replicas
+	if status := rolloutComponentStatus(rollout, comp.Name); status != nil {
+		spec.OfflineInstances = slices.DeleteFunc(spec.OfflineInstances, func(instance string) bool {
+			return slices.Contains(status.ScaleDownInstances, instance)
+		})
+	}
+	return nil
+}
+
 func (t *rolloutReplaceTransformer) up(transCtx *rolloutTransformContext,
 	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec, tpl *appsv1.InstanceTemplate) error {
 	if err := t.checkDelaySeconds(rollout, comp, *tpl.Replicas, false); err != nil {
@@ -131,7 +184,7 @@ func (t *rolloutReplaceTransformer) down(transCtx *rolloutTransformContext,
 		return err
 	}
 
-	instance, instTpl, err := t.pickInstanceToScaleDown(transCtx, spec, tpl)
+	instance, instTpl, err := pickInstanceToScaleDown(transCtx, spec, tpl)
 	if err != nil {
 		return err
 	}
@@ -206,7 +259,8 @@ func (t *rolloutReplaceTransformer) checkDelaySeconds(rollout *appsv1alpha1.Roll
 	return nil
 }
 
-func (t *rolloutReplaceTransformer) pickInstanceToScaleDown(transCtx *rolloutTransformContext,
+// pickInstanceToScaleDown picks an old instance to scale down, it returns the instance template it belongs to as well.
+func pickInstanceToScaleDown(transCtx *rolloutTransformContext,
 	spec *appsv1.ClusterComponentSpec, tpl *appsv1.InstanceTemplate) (string, *appsv1.InstanceTemplate, error) {
 	matchingLabels := constant.GetCompLabels(transCtx.Cluster.Name, spec.Name)
 	matchingLabels[constant.KBAppReleasePhaseKey] = constant.ReleasePhaseStable
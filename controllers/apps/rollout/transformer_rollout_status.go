@@ -22,6 +22,7 @@ package rollout
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -38,7 +39,7 @@ var _ graph.Transformer = &rolloutStatusTransformer{}
 
 func (t *rolloutStatusTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) {
 		return nil
 	}
 
@@ -49,6 +50,10 @@ func (t *rolloutStatusTransformer) Transform(ctx graph.TransformContext, dag *gr
 	}
 	// TODO: sharding
 
+	if rollout.Spec.Paused && (state == appsv1alpha1.PendingRolloutState || state == appsv1alpha1.RollingRolloutState) {
+		state = appsv1alpha1.PausedRolloutState
+	}
+
 	rollout.Status.ObservedGeneration = rollout.Generation
 	rollout.Status.State = state
 
@@ -70,6 +75,8 @@ func (t *rolloutStatusTransformer) components(transCtx *rolloutTransformContext,
 	var (
 		hasError   = false
 		hasRolling = false
+		hasAborted = false
+		hasPaused  = false
 		hasSucceed = false
 		hasPending = false
 		allSucceed = true
@@ -84,6 +91,12 @@ func (t *rolloutStatusTransformer) components(transCtx *rolloutTransformContext,
 			allSucceed = false
 		case appsv1alpha1.SucceedRolloutState:
 			hasSucceed = true
+		case appsv1alpha1.AbortedRolloutState:
+			hasAborted = true
+			allSucceed = false
+		case appsv1alpha1.PausedRolloutState:
+			hasPaused = true
+			allSucceed = false
 		case appsv1alpha1.PendingRolloutState:
 			hasPending = true
 			allSucceed = false
@@ -96,6 +109,10 @@ func (t *rolloutStatusTransformer) components(transCtx *rolloutTransformContext,
 		return appsv1alpha1.ErrorRolloutState, nil
 	case hasRolling:
 		return appsv1alpha1.RollingRolloutState, nil
+	case hasAborted:
+		return appsv1alpha1.AbortedRolloutState, nil
+	case hasPaused:
+		return appsv1alpha1.PausedRolloutState, nil
 	case allSucceed:
 		return appsv1alpha1.SucceedRolloutState, nil
 	case hasSucceed:
@@ -125,6 +142,12 @@ func (t *rolloutStatusTransformer) inplace(transCtx *rolloutTransformContext,
 	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) (appsv1alpha1.RolloutState, error) {
 	spec := t.compSpec(transCtx, comp.Name)
 	serviceVersion, compDef := serviceVersionNCompDef(rollout, comp, spec)
+	if rollout.Spec.Abort {
+		if serviceVersion == spec.ServiceVersion && compDef == spec.ComponentDef && checkClusterNCompRunning(transCtx, comp.Name) {
+			return appsv1alpha1.AbortedRolloutState, nil
+		}
+		return appsv1alpha1.RollingRolloutState, nil // rolling back
+	}
 	if serviceVersion == spec.ServiceVersion && compDef == spec.ComponentDef {
 		return appsv1alpha1.PendingRolloutState, nil
 	}
@@ -145,6 +168,9 @@ func (t *rolloutStatusTransformer) replace(transCtx *rolloutTransformContext,
 		}
 	}
 	if rollingTpl == nil {
+		if isComponentAborting(rollout, comp.Name) {
+			return appsv1alpha1.AbortedRolloutState, nil
+		}
 		return appsv1alpha1.PendingRolloutState, nil
 	}
 
@@ -184,6 +210,15 @@ func (t *rolloutStatusTransformer) replace(transCtx *rolloutTransformContext,
 		}
 	}
 
+	if isComponentAborting(rollout, comp.Name) {
+		if checkClusterNCompRunning(transCtx, comp.Name) && *rollingTpl.Replicas == 0 && allPodCnt == spec.Replicas {
+			return appsv1alpha1.AbortedRolloutState, nil
+		}
+		return appsv1alpha1.RollingRolloutState, nil // rolling back
+	}
+	if isStepWaitingForApproval(rollout, comp.Name) {
+		return appsv1alpha1.PausedRolloutState, nil
+	}
 	if !checkClusterNCompRunning(transCtx, comp.Name) || spec.Replicas != *rollingTpl.Replicas {
 		return appsv1alpha1.RollingRolloutState, nil
 	}
@@ -195,8 +230,78 @@ func (t *rolloutStatusTransformer) replace(transCtx *rolloutTransformContext,
 
 func (t *rolloutStatusTransformer) create(transCtx *rolloutTransformContext,
 	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) (appsv1alpha1.RolloutState, error) {
-	// TODO: impl
-	return "", createStrategyNotSupportedError
+	spec := t.compSpec(transCtx, comp.Name)
+	newTpl := createInstanceTemplate(rollout, spec)
+	if newTpl == nil {
+		if isComponentAborting(rollout, comp.Name) {
+			return appsv1alpha1.AbortedRolloutState, nil
+		}
+		return appsv1alpha1.PendingRolloutState, nil
+	}
+	replicas, targetReplicas, err := createReplicas(rollout, comp, spec)
+	if err != nil {
+		return "", err
+	}
+
+	pods := &corev1.PodList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(rollout.Namespace),
+		client.MatchingLabels(constant.GetCompLabels(rollout.Spec.ClusterName, comp.Name)),
+	}
+	if err := transCtx.Client.List(transCtx.Context, pods, listOpts...); err != nil {
+		return "", err
+	}
+
+	allPodCnt := int32(len(pods.Items))
+	newPodCnt := int32(generics.CountFunc(pods.Items, func(pod corev1.Pod) bool {
+		if pod.Labels != nil {
+			return pod.Labels[constant.KBAppInstanceTemplateLabelKey] == newTpl.Name
+		}
+		return false
+	}))
+	status := rolloutComponentStatus(rollout, comp.Name)
+	if status != nil && checkClusterNCompRunning(transCtx, comp.Name) {
+		rolledOutReplicas := newPodCnt - (allPodCnt - status.Replicas)
+		if status.NewReplicas < newPodCnt {
+			status.LastScaleUpTimestamp = metav1.Now()
+		}
+		if status.RolledOutReplicas < rolledOutReplicas {
+			status.LastScaleDownTimestamp = metav1.Now()
+		}
+		status.NewReplicas = newPodCnt
+		status.RolledOutReplicas = max(rolledOutReplicas, 0)
+		status.CanaryReplicas = 0
+		if ptr.Deref(newTpl.Canary, false) {
+			status.CanaryReplicas = newPodCnt
+		}
+	}
+
+	if isComponentAborting(rollout, comp.Name) {
+		if checkClusterNCompRunning(transCtx, comp.Name) && *newTpl.Replicas == 0 && allPodCnt == spec.Replicas {
+			return appsv1alpha1.AbortedRolloutState, nil
+		}
+		return appsv1alpha1.RollingRolloutState, nil // rolling back
+	}
+	if isStepWaitingForApproval(rollout, comp.Name) {
+		return appsv1alpha1.PausedRolloutState, nil
+	}
+	if !checkClusterNCompRunning(transCtx, comp.Name) || *newTpl.Replicas != targetReplicas {
+		return appsv1alpha1.RollingRolloutState, nil
+	}
+	promotion := comp.Strategy.Create.Promotion
+	if promotion == nil {
+		return appsv1alpha1.SucceedRolloutState, nil // the new instances are created, and no promotion is required
+	}
+	if promotion.Analysis != nil && (status == nil || status.Analysis == nil || status.Analysis.Phase != appsv1alpha1.SucceededRolloutAnalysisPhase) {
+		return appsv1alpha1.RollingRolloutState, nil // analyzing
+	}
+	if !ptr.Deref(promotion.Auto, false) {
+		return appsv1alpha1.PausedRolloutState, nil // wait for promotion
+	}
+	if ptr.Deref(newTpl.Canary, false) || spec.Replicas != replicas || allPodCnt != spec.Replicas {
+		return appsv1alpha1.RollingRolloutState, nil // promoting or scaling down
+	}
+	return appsv1alpha1.SucceedRolloutState, nil
 }
 
 func (t *rolloutStatusTransformer) compSpec(transCtx *rolloutTransformContext, compName string) *appsv1.ClusterComponentSpec {
@@ -213,3 +318,12 @@ func (t *rolloutStatusTransformer) compSpec(transCtx *rolloutTransformContext, c
 func isRolloutSucceed(rollout *appsv1alpha1.Rollout) bool {
 	return rollout.Status.State == appsv1alpha1.SucceedRolloutState
 }
+
+func isRolloutAborted(rollout *appsv1alpha1.Rollout) bool {
+	return rollout.Status.State == appsv1alpha1.AbortedRolloutState
+}
+
+// isRolloutTerminated checks whether the rollout has been finished, either succeed or aborted.
+func isRolloutTerminated(rollout *appsv1alpha1.Rollout) bool {
+	return isRolloutSucceed(rollout) || isRolloutAborted(rollout)
+}
@@ -22,6 +22,8 @@ package rollout
 import (
 	"slices"
 
+	"k8s.io/utils/ptr"
+
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
@@ -33,7 +35,7 @@ var _ graph.Transformer = &rolloutTearDownTransformer{}
 
 func (t *rolloutTearDownTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) {
 		return nil
 	}
 	return t.tearDown(transCtx)
@@ -76,6 +78,9 @@ func (t *rolloutTearDownTransformer) inplace(transCtx *rolloutTransformContext,
 
 func (t *rolloutTearDownTransformer) replace(transCtx *rolloutTransformContext,
 	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) error {
+	if isComponentAborting(rollout, comp.Name) {
+		return nil // the rollout is aborted, keep the original spec
+	}
 	spec := transCtx.ClusterComps[comp.Name]
 	replicas, _, err := replaceReplicas(rollout, comp, spec)
 	if err != nil {
@@ -102,6 +107,33 @@ func (t *rolloutTearDownTransformer) replace(transCtx *rolloutTransformContext,
 
 func (t *rolloutTearDownTransformer) create(transCtx *rolloutTransformContext,
 	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) error {
-	// TODO: impl
-	return createStrategyNotSupportedError
+	if isComponentAborting(rollout, comp.Name) {
+		return nil // the rollout is aborted, keep the original spec
+	}
+	promotion := comp.Strategy.Create.Promotion
+	if promotion == nil || !ptr.Deref(promotion.Auto, false) {
+		return nil // the new instances are not promoted, keep them as they are
+	}
+	spec := transCtx.ClusterComps[comp.Name]
+	replicas, targetReplicas, err := createReplicas(rollout, comp, spec)
+	if err != nil {
+		return err
+	}
+	tpl := createInstanceTemplate(rollout, spec)
+	if tpl == nil || targetReplicas != replicas {
+		return nil // partially rolled out, the new instances are kept in the instance template
+	}
+	if !ptr.Deref(tpl.Canary, false) && *tpl.Replicas == replicas && spec.Replicas == replicas && checkClusterNCompRunning(transCtx, comp.Name) {
+		spec.ServiceVersion = tpl.ServiceVersion
+		spec.ComponentDef = tpl.CompDef
+		spec.OfflineInstances = slices.DeleteFunc(spec.OfflineInstances, func(instance string) bool {
+			for _, status := range rollout.Status.Components {
+				if status.Name == comp.Name {
+					return slices.Contains(status.ScaleDownInstances, instance)
+				}
+			}
+			return false
+		})
+	}
+	return nil
 }
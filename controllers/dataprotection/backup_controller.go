@@ -641,10 +641,18 @@ func (r *BackupReconciler) handleRunningPhase(
 		if err != nil {
 			return r.updateStatusIfFailed(reqCtx, backup, request.Backup, fmt.Errorf("failed to set expiration time, %v", err))
 		}
+		// copy the new data of the continuous backup to the secondary backup repos
+		requeueAfter, err := r.replicateBackup(reqCtx, request.Backup, request.BackupPolicy)
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to replicate the backup")
+		}
 		// update status
 		if err = r.Client.Status().Patch(reqCtx.Ctx, request.Backup, client.MergeFrom(backup)); err != nil {
 			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 		}
+		if requeueAfter > 0 {
+			return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "")
+		}
 		return intctrlutil.Reconciled()
 	}
 	if existFailedAction {
@@ -755,7 +763,7 @@ func (r *BackupReconciler) checkIsCompletedDuringRunning(reqCtx intctrlutil.Requ
 }
 
 // handleCompletedPhase handles the backup object in completed phase.
-// It will delete the reference workloads.
+// It will delete the reference workloads, and copy the backup to the secondary backup repos.
 func (r *BackupReconciler) handleCompletedPhase(
 	reqCtx intctrlutil.RequestCtx,
 	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
@@ -763,9 +771,53 @@ func (r *BackupReconciler) handleCompletedPhase(
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
 
+	original := backup.DeepCopy()
+	requeueAfter, err := r.replicateBackup(reqCtx, backup, nil)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to replicate the backup")
+	}
+	if !reflect.DeepEqual(original.Status.Replicas, backup.Status.Replicas) {
+		if err = r.Client.Status().Patch(reqCtx.Ctx, backup, client.MergeFrom(original)); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	if requeueAfter > 0 {
+		return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "")
+	}
 	return intctrlutil.Reconciled()
 }
 
+// replicateBackup copies the backup to the secondary backup repos if the replication is
+// specified in the backup policy, the state of the copies is updated in the backup status
+// and should be patched by the caller.
+func (r *BackupReconciler) replicateBackup(reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup,
+	backupPolicy *dpv1alpha1.BackupPolicy) (time.Duration, error) {
+	if backupPolicy == nil {
+		backupPolicy = &dpv1alpha1.BackupPolicy{}
+		if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: backup.Namespace,
+			Name: backup.Spec.BackupPolicyName}, backupPolicy); err != nil {
+			// the backup policy may be deleted, just ignore it
+			return 0, client.IgnoreNotFound(err)
+		}
+	}
+	if backupPolicy.Spec.Replication == nil {
+		return 0, nil
+	}
+	// TODO: update the mcMgr param
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get worker service account: %w", err)
+	}
+	replicator := &dpbackup.Replicator{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		WorkerServiceAccount: saName,
+	}
+	return replicator.Replicate(backup, backupPolicy.Spec.Replication)
+}
+
 func (r *BackupReconciler) updateStatusIfFailed(
 	reqCtx intctrlutil.RequestCtx,
 	original *dpv1alpha1.Backup,
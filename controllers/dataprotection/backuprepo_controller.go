@@ -43,10 +43,12 @@ import (
 	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
@@ -77,6 +79,9 @@ const (
 	defaultCheckInterval   = 1 * time.Minute
 
 	preCheckContainerName = "pre-check"
+
+	// max length of metav1.Condition.Message is 32K
+	conditionMessageLimit = 32 * 1024
 )
 
 var (
@@ -124,6 +129,10 @@ func (r *reconcileContext) preCheckResourceName() string {
 	return cutName(fmt.Sprintf("pre-check-%s-%s", r.repo.UID[:8], r.repo.Name))
 }
 
+func (r *reconcileContext) healthCheckResourceName() string {
+	return cutName(fmt.Sprintf("health-check-%s-%s", r.repo.UID[:8], r.repo.Name))
+}
+
 // BackupRepoReconciler reconciles a BackupRepo object
 type BackupRepoReconciler struct {
 	client.Client
@@ -147,6 +156,9 @@ type BackupRepoReconciler struct {
 // watch or update Backups
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;update;patch
 
+// watch BackupPolicies
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuppolicies,verbs=get;list;watch
+
 // watch or update Restores
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=restores,verbs=get;list;watch;update;patch
 
@@ -254,6 +266,13 @@ func (r *BackupRepoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
+	// calculate the storage used by the associated backups
+	if err = r.updateUsage(reconCtx); err != nil {
+		_ = r.updateStatus(reqCtx, repo)
+		return checkedRequeueWithError(err, reqCtx.Log,
+			"failed to calculate the usage of the repo")
+	}
+
 	// update status phase to ready if all conditions are met
 	if err = r.updateStatus(reqCtx, repo); err != nil {
 		return checkedRequeueWithError(err, reqCtx.Log,
@@ -287,6 +306,21 @@ func (r *BackupRepoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			return checkedRequeueWithError(err, reqCtx.Log,
 				"check associated restores failed")
 		}
+
+		// check backup policies replicating backups to the repo, to create PVC in their namespaces
+		if err = r.prepareForReplicatingBackupPolicies(reconCtx); err != nil {
+			return checkedRequeueWithError(err, reqCtx.Log,
+				"check replicating backup policies failed")
+		}
+
+		// probe the repo periodically, because the storage may become unavailable
+		// after the pre-check, e.g. the credential is expired.
+		requeueAfter, err := r.checkRepoHealth(reconCtx)
+		if err != nil {
+			return checkedRequeueWithError(err, reqCtx.Log,
+				"failed to check the health of the repo")
+		}
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
 
 	return ctrl.Result{}, nil
@@ -637,6 +671,11 @@ func (r *BackupRepoReconciler) preCheckRepo(reconCtx *reconcileContext) (err err
 			return err
 		}
 
+		// the health status is out of date as well
+		if err = r.resetHealthStatus(reconCtx); err != nil {
+			return err
+		}
+
 		err = updateAnnotations(reconCtx.Ctx, r.Client, reconCtx.repo, map[string]string{
 			dataProtectionBackupRepoDigestAnnotationKey:     reconCtx.getDigest(),
 			dataProtectionNeedUpdateToolConfigAnnotationKey: trueVal,
@@ -669,9 +708,9 @@ func (r *BackupRepoReconciler) preCheckRepo(reconCtx *reconcileContext) (err err
 	var pvc *corev1.PersistentVolumeClaim
 	switch {
 	case reconCtx.repo.AccessByMount():
-		job, pvc, err = r.runPreCheckJobForMounting(reconCtx, namespace, saName)
+		job, pvc, err = r.runCheckJobForMounting(reconCtx, reconCtx.preCheckResourceName(), namespace, saName)
 	case reconCtx.repo.AccessByTool():
-		job, err = r.runPreCheckJobForTool(reconCtx, namespace, saName)
+		job, err = r.runCheckJobForTool(reconCtx, reconCtx.preCheckResourceName(), namespace, saName)
 	default:
 		err = fmt.Errorf("unknown access method: %s", reconCtx.repo.Spec.AccessMethod)
 	}
@@ -711,10 +750,8 @@ func (r *BackupRepoReconciler) preCheckRepo(reconCtx *reconcileContext) (err err
 		message = "Pre-check job failed, information collected for diagnosis.\n\n"
 		message += fmt.Sprintf("Job failure message: %s\n\n", failureReason)
 		message += info
-		// max length of metav1.Condition.Message is 32K
-		const messageLimit = 32 * 1024
-		if len(message) > messageLimit {
-			message = message[:messageLimit]
+		if len(message) > conditionMessageLimit {
+			message = message[:conditionMessageLimit]
 		}
 	} else {
 		status = metav1.ConditionTrue
@@ -723,13 +760,121 @@ func (r *BackupRepoReconciler) preCheckRepo(reconCtx *reconcileContext) (err err
 	return nil
 }
 
+func (r *BackupRepoReconciler) checkRepoHealth(reconCtx *reconcileContext) (requeueAfter time.Duration, err error) {
+	name := reconCtx.healthCheckResourceName()
+	interval := time.Duration(viper.GetInt(dptypes.CfgKeyBackupRepoHealthCheckIntervalSeconds)) * time.Second
+	if interval <= 0 {
+		// the health check is disabled
+		return 0, r.removeCheckResources(reconCtx, name)
+	}
+	if reconCtx.repo.Status.LastHealthCheckTime == nil {
+		// the pre-check has just passed, take it as the first health check
+		return interval, r.updateHealthStatus(reconCtx, metav1.ConditionTrue, ReasonHealthCheckPassed, "")
+	}
+
+	namespace := viper.GetString(constant.CfgKeyCtrlrMgrNS)
+	job := &batchv1.Job{}
+	err = r.Client.Get(reconCtx.Ctx, client.ObjectKey{Name: name, Namespace: namespace}, job,
+		multicluster.InControlContext())
+	if err != nil && !apierrors.IsNotFound(err) {
+		return 0, err
+	}
+	if apierrors.IsNotFound(err) {
+		// wait for the next round
+		next := reconCtx.repo.Status.LastHealthCheckTime.Add(interval)
+		if wait := next.Sub(wallClock.Now()); wait > 0 {
+			return wait, nil
+		}
+	}
+
+	saName, err := EnsureWorkerServiceAccount(reconCtx.RequestCtx, r.Client, namespace, r.MultiClusterMgr)
+	if err != nil {
+		return 0, err
+	}
+	var pvc *corev1.PersistentVolumeClaim
+	switch {
+	case reconCtx.repo.AccessByMount():
+		job, pvc, err = r.runCheckJobForMounting(reconCtx, name, namespace, saName)
+	case reconCtx.repo.AccessByTool():
+		job, err = r.runCheckJobForTool(reconCtx, name, namespace, saName)
+	default:
+		err = fmt.Errorf("unknown access method: %s", reconCtx.repo.Spec.AccessMethod)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	finished, jobStatus, failureReason := utils.IsJobFinished(job)
+	if !finished {
+		if wallClock.Since(job.CreationTimestamp.Time) <= defaultPreCheckTimeout {
+			return defaultCheckInterval, nil
+		}
+		jobStatus = batchv1.JobFailed
+		failureReason = "timeout"
+	}
+
+	status := metav1.ConditionTrue
+	reason := ReasonHealthCheckPassed
+	message := ""
+	if jobStatus == batchv1.JobFailed {
+		status = metav1.ConditionFalse
+		reason = ReasonHealthCheckFailed
+		info, err := r.collectPreCheckFailureMessage(reconCtx, job, pvc)
+		if err != nil {
+			return 0, fmt.Errorf("failed to collectPreCheckFailureMessage, err: %w", err)
+		}
+		message = "Health check job failed, information collected for diagnosis.\n\n"
+		message += fmt.Sprintf("Job failure message: %s\n\n", failureReason)
+		message += info
+		if len(message) > conditionMessageLimit {
+			message = message[:conditionMessageLimit]
+		}
+	}
+	if err = r.updateHealthStatus(reconCtx, status, reason, message); err != nil {
+		return 0, err
+	}
+	if status == metav1.ConditionFalse {
+		reconCtx.Recorder.Event(reconCtx.repo, corev1.EventTypeWarning, reason,
+			fmt.Sprintf("health check of the backup repo failed: %s", failureReason))
+	}
+	return interval, r.removeCheckResources(reconCtx, name)
+}
+
+func (r *BackupRepoReconciler) updateHealthStatus(reconCtx *reconcileContext,
+	status metav1.ConditionStatus, reason string, message string) error {
+	repo := reconCtx.repo
+	patch := client.MergeFrom(repo.DeepCopy())
+	setCondition(repo, ConditionTypeHealthy, status, reason, message)
+	repo.Status.LastHealthCheckTime = &metav1.Time{Time: wallClock.Now()}
+	return r.Client.Status().Patch(reconCtx.Ctx, repo, patch, multicluster.InControlContext())
+}
+
+func (r *BackupRepoReconciler) resetHealthStatus(reconCtx *reconcileContext) error {
+	repo := reconCtx.repo
+	if repo.Status.LastHealthCheckTime == nil &&
+		meta.FindStatusCondition(repo.Status.Conditions, ConditionTypeHealthy) == nil {
+		return nil
+	}
+	patch := client.MergeFrom(repo.DeepCopy())
+	meta.RemoveStatusCondition(&repo.Status.Conditions, ConditionTypeHealthy)
+	repo.Status.LastHealthCheckTime = nil
+	if err := r.Client.Status().Patch(reconCtx.Ctx, repo, patch, multicluster.InControlContext()); err != nil {
+		return err
+	}
+	return r.removeCheckResources(reconCtx, reconCtx.healthCheckResourceName())
+}
+
 func (r *BackupRepoReconciler) removePreCheckResources(reconCtx *reconcileContext) error {
+	return r.removeCheckResources(reconCtx, reconCtx.preCheckResourceName())
+}
+
+// removeCheckResources removes the job, PVC and tool config secret created by a pre-check or health check.
+func (r *BackupRepoReconciler) removeCheckResources(reconCtx *reconcileContext, name string) error {
 	objects := []client.Object{
 		&batchv1.Job{},
 		&corev1.PersistentVolumeClaim{},
 		&corev1.Secret{},
 	}
-	name := reconCtx.preCheckResourceName()
 	namespace := viper.GetString(constant.CfgKeyCtrlrMgrNS)
 	objKey := client.ObjectKey{Name: name, Namespace: namespace}
 	for _, obj := range objects {
@@ -745,9 +890,9 @@ func (r *BackupRepoReconciler) removePreCheckResources(reconCtx *reconcileContex
 	return nil
 }
 
-func (r *BackupRepoReconciler) runPreCheckJobForMounting(reconCtx *reconcileContext, namespace string, saName string) (job *batchv1.Job, pvc *corev1.PersistentVolumeClaim, err error) {
+func (r *BackupRepoReconciler) runCheckJobForMounting(reconCtx *reconcileContext, name, namespace, saName string) (job *batchv1.Job, pvc *corev1.PersistentVolumeClaim, err error) {
 	// create PVC
-	pvcName := reconCtx.preCheckResourceName()
+	pvcName := name
 	pvc, err = r.createRepoPVC(reconCtx, pvcName, namespace, map[string]string{
 		dataProtectionBackupRepoDigestAnnotationKey: reconCtx.getDigest(),
 	}, multicluster.InControlContext())
@@ -756,7 +901,7 @@ func (r *BackupRepoReconciler) runPreCheckJobForMounting(reconCtx *reconcileCont
 	}
 	// run pre-check job
 	job = &batchv1.Job{}
-	job.Name = reconCtx.preCheckResourceName()
+	job.Name = name
 	job.Namespace = namespace
 	_, err = createObjectIfNotExist(reconCtx.Ctx, r.Client, job, func() error {
 		runAsUser := int64(0)
@@ -814,7 +959,7 @@ func (r *BackupRepoReconciler) runPreCheckJobForMounting(reconCtx *reconcileCont
 	// these resources were created for the old generation of the backupRepo,
 	// so remove them and then retry.
 	if !reconCtx.hasSameDigest(pvc) || !reconCtx.hasSameDigest(job) {
-		err = r.removePreCheckResources(reconCtx)
+		err = r.removeCheckResources(reconCtx, name)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -823,9 +968,9 @@ func (r *BackupRepoReconciler) runPreCheckJobForMounting(reconCtx *reconcileCont
 	return job, pvc, nil
 }
 
-func (r *BackupRepoReconciler) runPreCheckJobForTool(reconCtx *reconcileContext, namespace string, saName string) (job *batchv1.Job, err error) {
+func (r *BackupRepoReconciler) runCheckJobForTool(reconCtx *reconcileContext, name, namespace, saName string) (job *batchv1.Job, err error) {
 	// create tool config
-	secretName := reconCtx.preCheckResourceName()
+	secretName := name
 	secret, err := r.createToolConfigSecret(reconCtx, secretName, namespace, map[string]string{
 		dataProtectionBackupRepoDigestAnnotationKey: reconCtx.getDigest(),
 	}, multicluster.InControlContext())
@@ -835,7 +980,7 @@ func (r *BackupRepoReconciler) runPreCheckJobForTool(reconCtx *reconcileContext,
 	precheckFilePath := filepath.Join("/", reconCtx.repo.Spec.PathPrefix, "precheck.txt")
 	// run pre-check job
 	job = &batchv1.Job{}
-	job.Name = reconCtx.preCheckResourceName()
+	job.Name = name
 	job.Namespace = namespace
 	_, err = createObjectIfNotExist(reconCtx.Ctx, r.Client, job, func() error {
 		runAsUser := int64(0)
@@ -888,7 +1033,7 @@ datasafed rm %s`, precheckFilePath, precheckFilePath, precheckFilePath),
 	// these resources were created for the old generation of the backupRepo,
 	// so remove them and then retry.
 	if !reconCtx.hasSameDigest(secret) || !reconCtx.hasSameDigest(job) {
-		err = r.removePreCheckResources(reconCtx)
+		err = r.removeCheckResources(reconCtx, name)
 		if err != nil {
 			return nil, err
 		}
@@ -1033,6 +1178,50 @@ func (r *BackupRepoReconciler) listAssociatedBackups(
 	return filtered, err
 }
 
+func (r *BackupRepoReconciler) updateUsage(reconCtx *reconcileContext) error {
+	backups, err := r.listAssociatedBackups(reconCtx.Ctx, reconCtx.repo, nil)
+	if err != nil {
+		return err
+	}
+	reconCtx.repo.Status.Usage = calculateBackupRepoUsage(backups)
+	return nil
+}
+
+// calculateBackupRepoUsage sums up the total size of the backups, and groups them by the backup policy.
+func calculateBackupRepoUsage(backups []*dpv1alpha1.Backup) *dpv1alpha1.BackupRepoUsage {
+	usage := &dpv1alpha1.BackupRepoUsage{}
+	policies := map[types.NamespacedName]*dpv1alpha1.BackupPolicyUsage{}
+	for _, backup := range backups {
+		var size int64
+		if backup.Status.TotalSize != "" {
+			if quantity, err := resource.ParseQuantity(backup.Status.TotalSize); err == nil {
+				size = quantity.Value()
+			}
+		}
+		key := types.NamespacedName{Namespace: backup.Namespace, Name: backup.Spec.BackupPolicyName}
+		policy, ok := policies[key]
+		if !ok {
+			policy = &dpv1alpha1.BackupPolicyUsage{Namespace: key.Namespace, Name: key.Name}
+			policies[key] = policy
+		}
+		policy.UsedBytes += size
+		policy.BackupCount++
+		usage.UsedBytes += size
+		usage.BackupCount++
+	}
+	for _, policy := range policies {
+		usage.BackupPolicies = append(usage.BackupPolicies, *policy)
+	}
+	sort.Slice(usage.BackupPolicies, func(i, j int) bool {
+		a, b := usage.BackupPolicies[i], usage.BackupPolicies[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return usage
+}
+
 func (r *BackupRepoReconciler) prepareForAssociatedBackups(reconCtx *reconcileContext) error {
 	backups, err := r.listAssociatedBackups(reconCtx.Ctx, reconCtx.repo, map[string]string{
 		dataProtectionWaitRepoPreparationKey: trueVal,
@@ -1131,6 +1320,43 @@ func (r *BackupRepoReconciler) prepareForAssociatedRestores(reconCtx *reconcileC
 	return retErr
 }
 
+// listReplicatingBackupPolicies lists the backup policies which copy their backups to the repo.
+func (r *BackupRepoReconciler) listReplicatingBackupPolicies(
+	ctx context.Context, repo *dpv1alpha1.BackupRepo) ([]*dpv1alpha1.BackupPolicy, error) {
+	policyList := &dpv1alpha1.BackupPolicyList{}
+	if err := r.Client.List(ctx, policyList, multicluster.InControlContext()); err != nil {
+		return nil, err
+	}
+	var filtered []*dpv1alpha1.BackupPolicy
+	for idx := range policyList.Items {
+		policy := &policyList.Items[idx]
+		if policy.Spec.Replication != nil && slices.Contains(policy.Spec.Replication.BackupRepoNames, repo.Name) {
+			filtered = append(filtered, policy)
+		}
+	}
+	return filtered, nil
+}
+
+func (r *BackupRepoReconciler) prepareForReplicatingBackupPolicies(reconCtx *reconcileContext) error {
+	policies, err := r.listReplicatingBackupPolicies(reconCtx.Ctx, reconCtx.repo)
+	if err != nil {
+		return err
+	}
+	// the copy jobs run in the namespaces of the backups
+	namespaces := sets.New[string]()
+	for _, policy := range policies {
+		namespaces.Insert(policy.Namespace)
+	}
+	// return any error to reconcile the repo
+	var retErr error
+	for _, namespace := range sets.List(namespaces) {
+		if err := r.prepareBackupRepoInNamespace(reconCtx, namespace); err != nil && retErr == nil {
+			retErr = err
+		}
+	}
+	return retErr
+}
+
 func (r *BackupRepoReconciler) createRepoPVC(reconCtx *reconcileContext,
 	name, namespace string, extraAnnos map[string]string, mcOpt *multicluster.ClientOption) (*corev1.PersistentVolumeClaim, error) {
 
@@ -1471,8 +1697,10 @@ func (r *BackupRepoReconciler) mapBackupToRepo(ctx context.Context, obj client.O
 	// we should reconcile the BackupRepo when:
 	//   1. the Backup needs to use the BackupRepo, but it's not ready for the namespace.
 	//   2. the Backup is being deleted, because it may block the deletion of the BackupRepo.
+	//   3. the Backup is completed, because the usage of the BackupRepo changes.
 	shouldReconcileRepo := backup.Labels[dataProtectionWaitRepoPreparationKey] == trueVal ||
-		!backup.DeletionTimestamp.IsZero()
+		!backup.DeletionTimestamp.IsZero() ||
+		backup.Status.Phase == dpv1alpha1.BackupPhaseCompleted
 	if shouldReconcileRepo {
 		return []ctrl.Request{{
 			NamespacedName: client.ObjectKey{Name: repoName},
@@ -1502,6 +1730,21 @@ func (r *BackupRepoReconciler) mapRestoreToRepo(ctx context.Context, obj client.
 	return nil
 }
 
+func (r *BackupRepoReconciler) mapBackupPolicyToRepos(ctx context.Context, obj client.Object) []ctrl.Request {
+	policy := obj.(*dpv1alpha1.BackupPolicy)
+	if policy.Spec.Replication == nil {
+		return nil
+	}
+	// the repos need to be prepared in the namespace of the policy
+	var requests []ctrl.Request
+	for _, repoName := range policy.Spec.Replication.BackupRepoNames {
+		requests = append(requests, ctrl.Request{
+			NamespacedName: client.ObjectKey{Name: repoName},
+		})
+	}
+	return requests
+}
+
 func (r *BackupRepoReconciler) mapProviderToRepos(ctx context.Context, obj client.Object) []ctrl.Request {
 	return r.providerRefMapper.mapToRequests(obj)
 }
@@ -1539,6 +1782,7 @@ func (r *BackupRepoReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&dpv1alpha1.StorageProvider{}, handler.EnqueueRequestsFromMapFunc(r.mapProviderToRepos)).
 		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.mapBackupToRepo)).
 		Watches(&dpv1alpha1.Restore{}, handler.EnqueueRequestsFromMapFunc(r.mapRestoreToRepo)).
+		Watches(&dpv1alpha1.BackupPolicy{}, handler.EnqueueRequestsFromMapFunc(r.mapBackupPolicyToRepos)).
 		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.mapSecretToRepos)).
 		Owns(&storagev1.StorageClass{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
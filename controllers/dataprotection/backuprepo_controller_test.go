@@ -41,6 +41,8 @@ import (
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
@@ -321,6 +323,11 @@ parameters:
 			return reconCtx.preCheckResourceName()
 		}
 
+		healthCheckResourceName := func(repo *dpv1alpha1.BackupRepo) string {
+			reconCtx := reconcileContext{repo: repo}
+			return reconCtx.healthCheckResourceName()
+		}
+
 		completePreCheckJob := func(repo *dpv1alpha1.BackupRepo) {
 			jobName := preCheckResourceName(repo)
 			namespace := viper.GetString(constant.CfgKeyCtrlrMgrNS)
@@ -757,6 +764,68 @@ parameters:
 			})).Should(Succeed())
 		})
 
+		It("should check the health of the repo periodically", func() {
+			viper.Set(dptypes.CfgKeyBackupRepoHealthCheckIntervalSeconds, 60)
+			fakeClock := testing.NewFakeClock(time.Now())
+			original := wallClock
+			wallClock = fakeClock
+			defer func() {
+				wallClock = original
+				viper.Set(dptypes.CfgKeyBackupRepoHealthCheckIntervalSeconds, 0)
+			}()
+
+			By("checking the pre-check is taken as the first health check")
+			Eventually(testapps.CheckObj(&testCtx, repoKey, func(g Gomega, repo *dpv1alpha1.BackupRepo) {
+				g.Expect(repo.Status.Phase).Should(Equal(dpv1alpha1.BackupRepoReady))
+				g.Expect(meta.IsStatusConditionTrue(repo.Status.Conditions, ConditionTypeHealthy)).Should(BeTrue())
+				g.Expect(repo.Status.LastHealthCheckTime).ShouldNot(BeNil())
+			})).Should(Succeed())
+
+			By("triggering the next health check")
+			fakeClock.Step(2 * time.Minute)
+			Eventually(testapps.GetAndChangeObj(&testCtx, repoKey, func(repo *dpv1alpha1.BackupRepo) {
+				if repo.Annotations == nil {
+					repo.Annotations = make(map[string]string)
+				}
+				repo.Annotations["touch"] = "whatever"
+			})).Should(Succeed())
+			jobKey := types.NamespacedName{
+				Name:      healthCheckResourceName(repo),
+				Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS),
+			}
+			Eventually(testapps.CheckObjExists(&testCtx, jobKey, &batchv1.Job{}, true)).Should(Succeed())
+
+			By("failing the health check job")
+			Eventually(testapps.GetAndChangeObjStatus(&testCtx, jobKey, func(job *batchv1.Job) {
+				job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+					Type:    batchv1.JobFailed,
+					Status:  corev1.ConditionTrue,
+					Reason:  "Failed",
+					Message: "credential expired",
+				})
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, repoKey, func(g Gomega, repo *dpv1alpha1.BackupRepo) {
+				cond := meta.FindStatusCondition(repo.Status.Conditions, ConditionTypeHealthy)
+				g.Expect(cond).ToNot(BeNil())
+				g.Expect(cond.Status).Should(BeEquivalentTo(metav1.ConditionFalse))
+				g.Expect(cond.Reason).Should(BeEquivalentTo(ReasonHealthCheckFailed))
+				g.Expect(cond.Message).Should(ContainSubstring("credential expired"))
+			})).Should(Succeed())
+
+			By("checking new backups are held until the repo recovers")
+			backup := &dpv1alpha1.Backup{}
+			backup.Labels = map[string]string{dataProtectionBackupRepoKey: repoKey.Name}
+			request := &dpbackup.Request{
+				Backup:       backup,
+				RequestCtx:   intctrlutil.RequestCtx{Ctx: testCtx.Ctx},
+				Client:       testCtx.Cli,
+				BackupPolicy: &dpv1alpha1.BackupPolicy{},
+			}
+			err := HandleBackupRepo(request)
+			Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeRequeue)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("credential expired"))
+		})
+
 		createBackupAndCheckPVC := func(namespace string) (backup *dpv1alpha1.Backup, pvcName string) {
 			By("making sure the repo is ready")
 			Eventually(testapps.CheckObj(&testCtx, repoKey, func(g Gomega, repo *dpv1alpha1.BackupRepo) {
@@ -1338,4 +1407,38 @@ new-item=new-value
 			Eventually(testapps.CheckObjExists(&testCtx, pvcKey, &corev1.PersistentVolumeClaim{}, true)).Should(Succeed())
 		})
 	})
+
+	Context("usage of the repo", func() {
+		It("should sum up the total size of the backups by backup policy", func() {
+			newBackup := func(namespace, policy, totalSize string) *dpv1alpha1.Backup {
+				backup := &dpv1alpha1.Backup{}
+				backup.Namespace = namespace
+				backup.Spec.BackupPolicyName = policy
+				backup.Status.TotalSize = totalSize
+				return backup
+			}
+			usage := calculateBackupRepoUsage([]*dpv1alpha1.Backup{
+				newBackup(namespace2, "policy-a", "1Ki"),
+				newBackup("default", "policy-b", "2Ki"),
+				newBackup("default", "policy-a", "1Mi"),
+				newBackup("default", "policy-a", "1024"),
+				// running or invalid backups are counted without size
+				newBackup("default", "policy-a", ""),
+				newBackup("default", "policy-b", "invalid"),
+			})
+			Expect(usage.UsedBytes).Should(BeEquivalentTo(1024 + 2048 + 1024*1024 + 1024))
+			Expect(usage.BackupCount).Should(BeEquivalentTo(6))
+			Expect(usage.BackupPolicies).Should(Equal([]dpv1alpha1.BackupPolicyUsage{
+				{Namespace: "default", Name: "policy-a", UsedBytes: 1024*1024 + 1024, BackupCount: 3},
+				{Namespace: "default", Name: "policy-b", UsedBytes: 2048, BackupCount: 2},
+				{Namespace: namespace2, Name: "policy-a", UsedBytes: 1024, BackupCount: 1},
+			}))
+
+			By("checking the usage of an empty repo")
+			usage = calculateBackupRepoUsage(nil)
+			Expect(usage.UsedBytes).Should(BeZero())
+			Expect(usage.BackupCount).Should(BeZero())
+			Expect(usage.BackupPolicies).Should(BeEmpty())
+		})
+	})
 })
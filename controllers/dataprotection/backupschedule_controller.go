@@ -30,9 +30,11 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
@@ -57,6 +59,10 @@ type BackupScheduleReconciler struct {
 // +kubebuilder:rbac:groups=batch,resources=cronjobs/status,verbs=get
 // +kubebuilder:rbac:groups=batch,resources=cronjobs/finalizers,verbs=update;patch
 
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=restores,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;delete
+
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the backupschedule closer to the desired state.
 func (r *BackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -91,7 +97,15 @@ func (r *BackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return r.patchStatusFailed(reqCtx, backupSchedule, "HandleBackupScheduleFailed", err)
 	}
 
-	return r.patchStatusAvailable(reqCtx, original, backupSchedule)
+	retention, err := dpbackup.EvaluateBackupScheduleRetention(reqCtx.Ctx, r.Client, backupSchedule)
+	if err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+
+	if res, err := r.patchStatusAvailable(reqCtx, original, backupSchedule, retention.Prunable); err != nil {
+		return res, err
+	}
+	return r.handleVerification(reqCtx, backupSchedule)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -106,9 +120,18 @@ func (r *BackupScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		b.Owns(&batchv1beta1.CronJob{})
 	}
 	b.Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.parseBackup))
+	b.Watches(&dpv1alpha1.Restore{}, handler.EnqueueRequestsFromMapFunc(r.parseVerificationObject),
+		builder.WithPredicates(predicate.NewPredicateFuncs(isVerificationObject)))
+	b.Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(r.parseVerificationObject),
+		builder.WithPredicates(predicate.NewPredicateFuncs(isVerificationObject)))
 	return b.Complete(r)
 }
 
+func isVerificationObject(object client.Object) bool {
+	_, ok := object.GetLabels()[dptypes.VerificationLabelKey]
+	return ok
+}
+
 func (r *BackupScheduleReconciler) deleteExternalResources(
 	reqCtx intctrlutil.RequestCtx,
 	backupSchedule *dpv1alpha1.BackupSchedule) error {
@@ -136,7 +159,7 @@ func (r *BackupScheduleReconciler) deleteExternalResources(
 
 // patchStatusAvailable patches backup policy status phase to available.
 func (r *BackupScheduleReconciler) patchStatusAvailable(reqCtx intctrlutil.RequestCtx,
-	origin, backupSchedule *dpv1alpha1.BackupSchedule) (ctrl.Result, error) {
+	origin, backupSchedule *dpv1alpha1.BackupSchedule, prunableBackups []string) (ctrl.Result, error) {
 	if !reflect.DeepEqual(origin.Spec, backupSchedule.Spec) {
 		if err := r.Client.Update(reqCtx.Ctx, backupSchedule); err != nil {
 			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
@@ -144,11 +167,13 @@ func (r *BackupScheduleReconciler) patchStatusAvailable(reqCtx intctrlutil.Reque
 	}
 	// update status phase
 	if backupSchedule.Status.Phase != dpv1alpha1.BackupSchedulePhaseAvailable ||
-		backupSchedule.Status.ObservedGeneration != backupSchedule.Generation {
+		backupSchedule.Status.ObservedGeneration != backupSchedule.Generation ||
+		!reflect.DeepEqual(backupSchedule.Status.PrunableBackups, prunableBackups) {
 		patch := client.MergeFrom(backupSchedule.DeepCopy())
 		backupSchedule.Status.ObservedGeneration = backupSchedule.Generation
 		backupSchedule.Status.Phase = dpv1alpha1.BackupSchedulePhaseAvailable
 		backupSchedule.Status.FailureReason = ""
+		backupSchedule.Status.PrunableBackups = prunableBackups
 		if err := r.Client.Status().Patch(reqCtx.Ctx, backupSchedule, patch); err != nil {
 			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 		}
@@ -203,6 +228,24 @@ func (r *BackupScheduleReconciler) handleSchedule(
 	return scheduler.Schedule()
 }
 
+// handleVerification handles the backups requested to verify.
+func (r *BackupScheduleReconciler) handleVerification(
+	reqCtx intctrlutil.RequestCtx,
+	backupSchedule *dpv1alpha1.BackupSchedule) (ctrl.Result, error) {
+	verifier := dpbackup.Verifier{
+		RequestCtx:     reqCtx,
+		Client:         r.Client,
+		BackupSchedule: backupSchedule,
+	}
+	if err := verifier.Verify(); err != nil {
+		if requeueErr, ok := err.(intctrlutil.RequeueError); ok {
+			return intctrlutil.RequeueAfter(requeueErr.RequeueAfter(), reqCtx.Log, requeueErr.Reason())
+		}
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
 func (r *BackupScheduleReconciler) patchScheduleMetadata(
 	reqCtx intctrlutil.RequestCtx,
 	backupSchedule *dpv1alpha1.BackupSchedule) error {
@@ -219,9 +262,17 @@ func (r *BackupScheduleReconciler) patchScheduleMetadata(
 
 func (r *BackupScheduleReconciler) parseBackup(ctx context.Context, object client.Object) []reconcile.Request {
 	backup := object.(*dpv1alpha1.Backup)
-	backupScheduleName := dptypes.BackupScheduleLabelKey
-	if backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) &&
-		backupScheduleName != "" {
+	backupScheduleName := backup.Labels[dptypes.BackupScheduleLabelKey]
+	if _, ok := backup.Annotations[dptypes.VerificationAnnotationKey]; ok {
+		return r.parseVerificationObject(ctx, object)
+	}
+	// the continuous backups and the backups which may be pruned by the retention tiers.
+	switch backup.Labels[dptypes.BackupTypeLabelKey] {
+	case string(dpv1alpha1.BackupTypeContinuous), string(dpv1alpha1.BackupTypeFull), string(dpv1alpha1.BackupTypeSelective):
+	default:
+		return []reconcile.Request{}
+	}
+	if backupScheduleName != "" {
 		return []reconcile.Request{
 			{
 				NamespacedName: types.NamespacedName{
@@ -233,3 +284,19 @@ func (r *BackupScheduleReconciler) parseBackup(ctx context.Context, object clien
 	}
 	return []reconcile.Request{}
 }
+
+// parseVerificationObject enqueues the backup schedule of the objects created for the backup verification.
+func (r *BackupScheduleReconciler) parseVerificationObject(_ context.Context, object client.Object) []reconcile.Request {
+	backupScheduleName := object.GetLabels()[dptypes.BackupScheduleLabelKey]
+	if backupScheduleName == "" {
+		return []reconcile.Request{}
+	}
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: object.GetNamespace(),
+				Name:      backupScheduleName,
+			},
+		},
+	}
+}
@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
@@ -55,6 +56,7 @@ var _ = Describe("Backup Schedule Controller", func() {
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupPolicySignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupScheduleSignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.RestoreSignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupRepoSignature, true, ml)
 
 		// wait all backup to be deleted, otherwise the controller maybe create
@@ -155,6 +157,101 @@ var _ = Describe("Backup Schedule Controller", func() {
 			})
 		})
 
+		Context("verifies the backups of a backup schedule", func() {
+			const verificationCron = "0 3 * * *"
+			var (
+				clusterInfo    *testdp.BackupClusterInfo
+				backupSchedule *dpv1alpha1.BackupSchedule
+			)
+
+			BeforeEach(func() {
+				clusterInfo = testdp.NewFakeCluster(&testCtx)
+
+				By("creating a backupSchedule with verification")
+				backupSchedule = testdp.NewFakeBackupSchedule(&testCtx, func(schedule *dpv1alpha1.BackupSchedule) {
+					for i := range schedule.Spec.Schedules {
+						if schedule.Spec.Schedules[i].BackupMethod == testdp.BackupMethodName {
+							schedule.Spec.Schedules[i].Enabled = boolptr.True()
+							schedule.Spec.Schedules[i].Verification = &dpv1alpha1.BackupVerification{
+								Enabled:        boolptr.True(),
+								CronExpression: verificationCron,
+								Check: dpv1alpha1.BackupVerificationCheck{
+									Image:   testapps.ApeCloudMySQLImage,
+									Command: []string{"sh", "-c", "ls /"},
+								},
+							}
+						}
+					}
+				})
+			})
+
+			It("should create the cronjob to request the verification", func() {
+				cronJobKey := getCronjobKey(backupSchedule, testdp.BackupMethodName, testdp.BackupMethodName+"-verify")
+				Eventually(testapps.CheckObj(&testCtx, cronJobKey, func(g Gomega, fetched *batchv1.CronJob) {
+					_, cronExpr := dpbackup.BuildCronJobSchedule(verificationCron)
+					g.Expect(fetched.Spec.Schedule).Should(Equal(cronExpr))
+					g.Expect(fetched.Labels[dptypes.VerificationLabelKey]).Should(Equal(testdp.BackupMethodName))
+					g.Expect(fetched.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Args[0]).
+						Should(ContainSubstring(dptypes.VerificationAnnotationKey))
+				})).Should(Succeed())
+
+				By("disabling the verification, the cronjob should be deleted")
+				Eventually(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(backupSchedule), func(schedule *dpv1alpha1.BackupSchedule) {
+					for i := range schedule.Spec.Schedules {
+						if schedule.Spec.Schedules[i].Verification != nil {
+							schedule.Spec.Schedules[i].Verification.Enabled = boolptr.False()
+						}
+					}
+				})).Should(Succeed())
+				Eventually(testapps.CheckObjExists(&testCtx, cronJobKey, &batchv1.CronJob{}, false)).Should(Succeed())
+			})
+
+			It("should restore the backup into the sandbox and record the outcome", func() {
+				By("creating a completed backup and requesting the verification")
+				backup := testdp.NewFakeBackup(&testCtx, func(backup *dpv1alpha1.Backup) {
+					if backup.Labels == nil {
+						backup.Labels = map[string]string{}
+					}
+					if backup.Annotations == nil {
+						backup.Annotations = map[string]string{}
+					}
+					backup.Labels[dptypes.BackupScheduleLabelKey] = backupSchedule.Name
+					backup.Annotations[dptypes.VerificationAnnotationKey] = testdp.BackupMethodName
+				})
+				backupKey := client.ObjectKeyFromObject(backup)
+				Eventually(testapps.GetAndChangeObjStatus(&testCtx, backupKey, func(fetched *dpv1alpha1.Backup) {
+					fetched.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+					testdp.MockBackupStatusMethod(fetched, testdp.BackupMethodName, testdp.DataVolumeName, actionSet.Name)
+					testdp.MockBackupStatusTarget(fetched, dpv1alpha1.PodSelectionStrategyAny)
+					fetched.Status.Target.SelectedTargetPods = []string{clusterInfo.TargetPod.Name}
+				})).Should(Succeed())
+
+				By("checking the restore into the sandbox")
+				Eventually(testapps.List(&testCtx, generics.RestoreSignature, client.InNamespace(testCtx.DefaultNamespace),
+					client.HasLabels{dptypes.VerificationLabelKey})).Should(HaveLen(1))
+				Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					restore := &dpv1alpha1.Restore{}
+					g.Expect(testCtx.Cli.Get(testCtx.Ctx, client.ObjectKey{Namespace: fetched.Namespace,
+						Name: dpbackup.GenerateVerificationName(fetched)}, restore)).Should(Succeed())
+					g.Expect(restore.Spec.PrepareDataConfig.RestoreVolumeClaims).Should(HaveLen(1))
+					g.Expect(restore.Spec.PrepareDataConfig.RestoreVolumeClaims[0].VolumeSource).Should(Equal(testdp.DataVolumeName))
+				})).Should(Succeed())
+				testdp.MockRestoreCompleted(&testCtx, client.MatchingLabels{dptypes.BackupNameLabelKey: backup.Name})
+
+				By("checking the check job and mocking it completed")
+				jobKey := client.ObjectKey{Namespace: backup.Namespace, Name: dpbackup.GenerateVerificationName(backup)}
+				Eventually(testapps.CheckObjExists(&testCtx, jobKey, &batchv1.Job{}, true)).Should(Succeed())
+				testdp.PatchK8sJobStatus(&testCtx, jobKey, batchv1.JobComplete)
+
+				By("checking the outcome of the verification")
+				Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Annotations).ShouldNot(HaveKey(dptypes.VerificationAnnotationKey))
+					g.Expect(fetched.Status.LastVerificationTime).ShouldNot(BeNil())
+					g.Expect(meta.IsStatusConditionTrue(fetched.Status.Conditions, dpv1alpha1.ConditionTypeVerified)).Should(BeTrue())
+				})).Should(Succeed())
+			})
+		})
+
 		Context("creates a backup schedule with empty schedule", func() {
 			It("should fail when create a backupSchedule without nil schedule policy", func() {
 				backupScheduleObj := testdp.NewBackupScheduleFactory(testCtx.DefaultNamespace, testdp.BackupScheduleName).
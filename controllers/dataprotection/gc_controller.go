@@ -37,6 +37,7 @@ import (
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -102,6 +103,29 @@ func (r *GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 		"phase", backup.Status.Phase, "expiration", backup.Status.Expiration)
 	reqCtx.Log = reqCtx.Log.WithValues("expiration", backup.Status.Expiration)
 
+	if managed, prunable, err := r.checkRetentionTiers(reqCtx, backup); err != nil {
+		reqCtx.Log.Error(err, "failed to evaluate retention tiers")
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	} else if managed {
+		if !prunable {
+			reqCtx.Log.V(1).Info("backup is retained by the retention tiers, skipping")
+			return intctrlutil.Reconciled()
+		}
+		if deletable, err := r.isBackupDeletable(reqCtx, backup); err != nil {
+			reqCtx.Log.Error(err, "failed to check backup deletability")
+			return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+		} else if !deletable {
+			return intctrlutil.Reconciled()
+		}
+		reqCtx.Log.Info("backup is not retained by the retention tiers, delete it", "backup", req.String())
+		if err = intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, backup); err != nil {
+			reqCtx.Log.Error(err, "failed to delete backup")
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "PruneBackupFailed", err.Error())
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+
 	now := r.clock.Now()
 	if backup.Status.Expiration == nil || backup.Status.Expiration.After(now) {
 		reqCtx.Log.V(1).Info("backup is not expired yet, skipping")
@@ -133,6 +157,30 @@ func getGCFrequency() time.Duration {
 	return dptypes.DefaultGCFrequencySeconds
 }
 
+// checkRetentionTiers checks whether the backup is managed by the retention tiers of its backup schedule,
+// and whether it should be pruned. The backup is not managed if the retention tiers are in dry-run mode.
+func (r *GCReconciler) checkRetentionTiers(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (bool, bool, error) {
+	scheduleName := backup.Labels[dptypes.BackupScheduleLabelKey]
+	if len(scheduleName) == 0 {
+		return false, false, nil
+	}
+	backupSchedule := &dpv1alpha1.BackupSchedule{}
+	exists, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, r.Client,
+		client.ObjectKey{Name: scheduleName, Namespace: backup.Namespace}, backupSchedule)
+	if err != nil || !exists {
+		return false, false, err
+	}
+	tiers := backupSchedule.Spec.RetentionTiers
+	if tiers == nil || tiers.DryRun {
+		return false, false, nil
+	}
+	result, err := dpbackup.EvaluateBackupScheduleRetention(reqCtx.Ctx, r.Client, backupSchedule)
+	if err != nil {
+		return false, false, err
+	}
+	return result.IsManaged(backup.Name), result.IsPrunable(backup.Name), nil
+}
+
 // isBackupDeletable returns true if the backup can be deleted.
 func (r *GCReconciler) isBackupDeletable(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (bool, error) {
 	if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
@@ -51,6 +51,7 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 		testapps.ClearResources(&testCtx, generics.ClusterSignature, inNS, ml)
 		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml)
 		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupScheduleSignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupPolicySignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupRepoSignature, true, ml)
@@ -118,6 +119,32 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 				Create(&testCtx).GetObject()
 		}
 
+		createScheduledBackup := func(name, methodName, parentName string, completionTime time.Time) client.ObjectKey {
+			backup := testdp.NewBackupFactory(testCtx.DefaultNamespace, name).
+				WithRandomName().
+				AddLabelsInMap(map[string]string{
+					dptypes.BackupScheduleLabelKey: testdp.BackupScheduleName,
+					dptypes.BackupMethodLabelKey:   methodName,
+				}).
+				SetBackupPolicyName(testdp.BackupPolicyName).
+				SetBackupMethod(methodName).
+				Create(&testCtx).GetObject()
+			key := client.ObjectKeyFromObject(backup)
+			testdp.PatchK8sJobStatus(&testCtx, getJobKey(backup), batchv1.JobComplete)
+			checkBackupCompleted(key)
+
+			By("mock backup not to expire")
+			backup.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+			backup.Status.Expiration = &metav1.Time{Time: fakeClock.Now().Add(time.Hour * 24)}
+			backup.Status.StartTimestamp = &metav1.Time{Time: completionTime.Add(-time.Minute)}
+			backup.Status.CompletionTimestamp = &metav1.Time{Time: completionTime}
+			if len(parentName) != 0 {
+				backup.Status.ParentBackupName = parentName
+			}
+			testdp.PatchBackupStatus(&testCtx, key, backup.Status)
+			return key
+		}
+
 		BeforeEach(func() {
 			By("creating an actionSet")
 			actionSet := testdp.NewFakeActionSet(&testCtx, nil)
@@ -266,5 +293,38 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 			Eventually(testapps.CheckObjExists(&testCtx, olderKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
 			Eventually(testapps.CheckObjExists(&testCtx, incrementalKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
 		})
+
+		It("prune backups by retention tiers", func() {
+			By("creating a backup schedule with retention tiers")
+			_ = testdp.NewFakeBackupSchedule(&testCtx, func(schedule *dpv1alpha1.BackupSchedule) {
+				schedule.Spec.RetentionTiers = &dpv1alpha1.RetentionTiers{Daily: 1}
+			})
+
+			today := fakeClock.Now().UTC().Truncate(24 * time.Hour)
+			olderKey := createScheduledBackup("older-daily-backup", testdp.BackupMethodName, "", today.Add(time.Hour))
+			latestKey := createScheduledBackup("latest-daily-backup", testdp.BackupMethodName, "", today.Add(2*time.Hour))
+
+			By("the older backup of the same day should be pruned though it is not expired")
+			Eventually(testapps.CheckObjExists(&testCtx, olderKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, latestKey, &dpv1alpha1.Backup{}, true)).Should(Succeed())
+		})
+
+		It("retain the parent of the incremental backup retained by the retention tiers", func() {
+			By("creating a backup schedule with retention tiers")
+			_ = testdp.NewFakeBackupSchedule(&testCtx, func(schedule *dpv1alpha1.BackupSchedule) {
+				schedule.Spec.RetentionTiers = &dpv1alpha1.RetentionTiers{Daily: 1}
+			})
+			_ = testdp.NewFakeIncActionSet(&testCtx)
+
+			today := fakeClock.Now().UTC().Truncate(24 * time.Hour)
+			parentKey := createScheduledBackup("parent-full-backup", testdp.BackupMethodName, "", today.Add(time.Hour))
+			incrementalKey := createScheduledBackup("incremental-backup", testdp.IncBackupMethodName, parentKey.Name, today.Add(3*time.Hour))
+			fullKey := createScheduledBackup("full-backup", testdp.BackupMethodName, "", today.Add(2*time.Hour))
+
+			By("the incremental backup is the latest of the day, its parent should be retained")
+			Eventually(testapps.CheckObjExists(&testCtx, fullKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
+			Consistently(testapps.CheckObjExists(&testCtx, parentKey, &dpv1alpha1.Backup{}, true)).Should(Succeed())
+			Consistently(testapps.CheckObjExists(&testCtx, incrementalKey, &dpv1alpha1.Backup{}, true)).Should(Succeed())
+		})
 	})
 })
@@ -170,14 +170,11 @@ func CheckBackupRepoForRestore(reqCtx intctrlutil.RequestCtx, cli client.Client,
 	}
 
 	restoreNamespace := restore.Namespace
-	repoName := backup.Status.BackupRepoName
-	repo := &dpv1alpha1.BackupRepo{}
-	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: repoName}, repo); err != nil {
-		if apierrors.IsNotFound(err) {
-			return "", intctrlutil.NewFatalError(fmt.Sprintf("backup repo %s not found", repoName))
-		}
-		return repoName, err
+	repo, err := selectBackupRepoForRestore(reqCtx, cli, restore, backup)
+	if err != nil {
+		return "", err
 	}
+	repoName := repo.Name
 	if repo.Status.Phase != dpv1alpha1.BackupRepoReady {
 		return repoName, dperrors.NewBackupRepoIsNotReady(repo.Name)
 	}
@@ -214,6 +211,65 @@ func CheckBackupRepoForRestore(reqCtx intctrlutil.RequestCtx, cli client.Client,
 	return repoName, nil
 }
 
+// selectBackupRepoForRestore selects the backup repo to restore the backup from. If it is not specified
+// in the restore, the primary backup repo of the backup is used, and a secondary backup repo which the
+// backup has been copied to is used instead if the primary one is unavailable.
+func selectBackupRepoForRestore(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	restore *dpv1alpha1.Restore,
+	backup *dpv1alpha1.Backup) (*dpv1alpha1.BackupRepo, error) {
+	getRepo := func(name string) (*dpv1alpha1.BackupRepo, error) {
+		repo := &dpv1alpha1.BackupRepo{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: name}, repo); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return repo, nil
+	}
+
+	if repoName := restore.Spec.Backup.BackupRepoName; repoName != "" {
+		if repoName != backup.Status.BackupRepoName && !utils.IsBackupReplicaRestorable(backup, repoName) {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf("backup %s has not been copied to backup repo %s", backup.Name, repoName))
+		}
+		repo, err := getRepo(repoName)
+		if err != nil {
+			return nil, err
+		}
+		if repo == nil {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf("backup repo %s not found", repoName))
+		}
+		return repo, nil
+	}
+
+	primary, err := getRepo(backup.Status.BackupRepoName)
+	if err != nil {
+		return nil, err
+	}
+	if primary != nil && utils.IsBackupRepoAvailable(primary) {
+		return primary, nil
+	}
+	for _, replica := range backup.Status.Replicas {
+		if replica.CompletionTimestamp == nil {
+			continue
+		}
+		repo, err := getRepo(replica.BackupRepoName)
+		if err != nil {
+			return nil, err
+		}
+		if repo != nil && utils.IsBackupRepoAvailable(repo) {
+			reqCtx.Log.Info("the primary backup repo is unavailable, restore from the secondary backup repo",
+				"primary", backup.Status.BackupRepoName, "secondary", repo.Name)
+			return repo, nil
+		}
+	}
+	if primary == nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("backup repo %s not found", backup.Status.BackupRepoName))
+	}
+	return primary, nil
+}
+
 func (r *RestoreReconciler) newAction(reqCtx intctrlutil.RequestCtx, restore *dpv1alpha1.Restore) (ctrl.Result, error) {
 	oldRestore := restore.DeepCopy()
 	patch := client.MergeFrom(oldRestore)
@@ -246,6 +302,7 @@ func (r *RestoreReconciler) newAction(reqCtx intctrlutil.RequestCtx, restore *dp
 		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
 	default:
 		dprestore.SetRestoreCheckBackupRepoCondition(restore, dprestore.ReasonCheckBackupRepoSuccessfully, "")
+		restore.Status.BackupRepoName = repoName
 	}
 	if !reflect.DeepEqual(restore.ObjectMeta, oldRestore.ObjectMeta) {
 		if err := r.Client.Patch(reqCtx.Ctx, restore, patch); err != nil {
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 )
 
 const (
@@ -50,6 +52,7 @@ const (
 	ConditionTypePVCTemplateChecked    = "PVCTemplateChecked"
 	ConditionTypeDerivedObjectsDeleted = "DerivedObjectsDeleted"
 	ConditionTypePreCheckPassed        = "PreCheckPassed"
+	ConditionTypeHealthy               = dptypes.BackupRepoConditionTypeHealthy
 
 	// condition reasons
 	ReasonStorageProviderReady      = "StorageProviderReady"
@@ -68,6 +71,8 @@ const (
 	ReasonDerivedObjectsDeleted     = "DerivedObjectsDeleted"
 	ReasonPreCheckPassed            = "PreCheckPassed"
 	ReasonPreCheckFailed            = "PreCheckFailed"
+	ReasonHealthCheckPassed         = "HealthCheckPassed"
+	ReasonHealthCheckFailed         = "HealthCheckFailed"
 	ReasonDigestChanged             = "DigestChanged"
 	ReasonUnknownError              = "UnknownError"
 	ReasonSkipped                   = "Skipped"
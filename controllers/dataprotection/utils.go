@@ -33,6 +33,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -95,6 +96,14 @@ func HandleBackupRepo(request *dpbackup.Request) error {
 		return dperrors.NewBackupRepoIsNotReady(repo.Name)
 	}
 
+	// hold new backups until the repo passes the health check again, the requeue error keeps them from failing
+	if request.Backup.Status.Phase == "" || request.Backup.Status.Phase == dpv1alpha1.BackupPhaseNew {
+		cond := meta.FindStatusCondition(repo.Status.Conditions, ConditionTypeHealthy)
+		if cond != nil && cond.Status == metav1.ConditionFalse {
+			return intctrlutil.NewErrorf(intctrlutil.ErrorTypeRequeue, "requeue to wait for the backup repository %s to be healthy: %s", repo.Name, cond.Message)
+		}
+	}
+
 	switch {
 	case repo.AccessByMount():
 		pvcName := repo.Status.BackupPVCName
@@ -64,6 +64,9 @@ func init() {
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;delete;deletecollection
 // +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get;list
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters;components;componentdefinitions,verbs=get;list
 
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
 
@@ -119,6 +122,14 @@ func (r *AddonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrlerihandler.NewTypeHandler(&enabledWithDefaultValuesStage{stageCtx: buildStageCtx(next...)})
 	}
 
+	dependencyCheckStageBuilder := func(next ...ctrlerihandler.Handler) ctrlerihandler.Handler {
+		return ctrlerihandler.NewTypeHandler(&dependencyCheckStage{stageCtx: buildStageCtx(next...)})
+	}
+
+	upgradeCheckStageBuilder := func(next ...ctrlerihandler.Handler) ctrlerihandler.Handler {
+		return ctrlerihandler.NewTypeHandler(&upgradeCheckStage{stageCtx: buildStageCtx(next...)})
+	}
+
 	progressingStageBuilder := func(next ...ctrlerihandler.Handler) ctrlerihandler.Handler {
 		return ctrlerihandler.NewTypeHandler(&progressingHandler{stageCtx: buildStageCtx(next...)})
 	}
@@ -134,6 +145,8 @@ func (r *AddonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		installableCheckStageBuilder,
 		autoInstallCheckStageBuilder,
 		enabledAutoValuesStageBuilder,
+		dependencyCheckStageBuilder,
+		upgradeCheckStageBuilder,
 		progressingStageBuilder,
 		terminalStateStageBuilder,
 	).Handler("")
@@ -156,6 +169,7 @@ func (r *AddonReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return intctrlutil.NewControllerManagedBy(mgr).
 		For(&extensionsv1alpha1.Addon{}).
 		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(r.findAddonJobs)).
+		Watches(&extensionsv1alpha1.Addon{}, handler.EnqueueRequestsFromMapFunc(r.findRelatedAddons)).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: viper.GetInt(maxConcurrentReconcilesKey),
 		}).
@@ -180,6 +194,32 @@ func (r *AddonReconciler) findAddonJobs(ctx context.Context, job client.Object)
 	}
 }
 
+// findRelatedAddons finds the addons the changed addon depends on and the addons depending on it,
+// which may be blocked by the changed addon.
+func (r *AddonReconciler) findRelatedAddons(ctx context.Context, obj client.Object) []reconcile.Request {
+	addon, ok := obj.(*extensionsv1alpha1.Addon)
+	if !ok {
+		return []reconcile.Request{}
+	}
+	var requests []reconcile.Request
+	for _, dep := range addon.Spec.Dependencies {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: dep.Name}})
+	}
+	addonList := &extensionsv1alpha1.AddonList{}
+	if err := r.List(ctx, addonList); err != nil {
+		return requests
+	}
+	for _, item := range addonList.Items {
+		for _, dep := range item.Spec.Dependencies {
+			if dep.Name == addon.Name {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name}})
+				break
+			}
+		}
+	}
+	return requests
+}
+
 func (r *AddonReconciler) cleanupJobPods(reqCtx intctrlutil.RequestCtx) error {
 	if err := r.DeleteAllOf(reqCtx.Ctx, &corev1.Pod{},
 		client.InNamespace(viper.GetString(constant.CfgKeyCtrlrMgrNS)),
@@ -197,25 +237,9 @@ func (r *AddonReconciler) deleteExternalResources(reqCtx intctrlutil.RequestCtx,
 	if addon.Annotations != nil && addon.Annotations[NoDeleteJobs] == trueVal {
 		return nil, nil
 	}
-	deleteJobIfExist := func(jobName string) error {
-		key := client.ObjectKey{
-			Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS),
-			Name:      jobName,
-		}
-		job := &batchv1.Job{}
-		if err := r.Get(reqCtx.Ctx, key, job); err != nil {
-			return client.IgnoreNotFound(err)
-		}
-		if !job.DeletionTimestamp.IsZero() {
-			return nil
-		}
-		if err := r.Delete(reqCtx.Ctx, job); err != nil {
-			return client.IgnoreNotFound(err)
-		}
-		return nil
-	}
-	for _, j := range []string{getInstallJobName(addon), getUninstallJobName(addon)} {
-		if err := deleteJobIfExist(j); err != nil {
+	for _, j := range []string{getInstallJobName(addon), getUninstallJobName(addon),
+		getPrecheckJobName(addon), getRollbackJobName(addon)} {
+		if err := r.deleteJobIfExist(reqCtx.Ctx, j); err != nil {
 			return nil, err
 		}
 	}
@@ -225,6 +249,24 @@ func (r *AddonReconciler) deleteExternalResources(reqCtx intctrlutil.RequestCtx,
 	return nil, nil
 }
 
+func (r *AddonReconciler) deleteJobIfExist(ctx context.Context, jobName string) error {
+	key := client.ObjectKey{
+		Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS),
+		Name:      jobName,
+	}
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, key, job); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !job.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if err := r.Delete(ctx, job); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return nil
+}
+
 // following provide r.Recorder wrapper for safe operation if r.Recorder is not provided
 
 func (r *AddonReconciler) Event(object k8sruntime.Object, eventtype, reason, message string) {
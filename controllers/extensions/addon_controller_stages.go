@@ -59,6 +59,10 @@ const (
 	operandValueKey = "operand"
 	trueVal         = "true"
 	localChartsPath = "/charts"
+
+	registryConfigPath = "/vol/registry"
+	registryConfigFile = "config.json"
+	keyringPath        = "/vol/keyring"
 )
 
 func init() {
@@ -141,6 +145,10 @@ type enabledWithDefaultValuesStage struct {
 	stageCtx
 }
 
+type dependencyCheckStage struct {
+	stageCtx
+}
+
 type progressingHandler struct {
 	stageCtx
 	enablingStage  enablingStage
@@ -190,6 +198,10 @@ func (r *fetchNDeletionCheckStage) Handle(ctx context.Context) {
 			r.updateResultNErr(res, err)
 			return
 		}
+		if res, err := validateDependents(ctx, &r.stageCtx, addon); res != nil || err != nil {
+			r.updateResultNErr(res, err)
+			return
+		}
 	}
 	res, err := intctrlutil.HandleCRDeletion(*r.reqCtx, r.reconciler, addon, addonFinalizerName, func() (*ctrl.Result, error) {
 		r.deletionStage.Handle(ctx)
@@ -209,6 +221,16 @@ func (r *genIDProceedCheckStage) Handle(ctx context.Context) {
 		switch addon.Status.Phase {
 		case extensionsv1alpha1.AddonEnabled, extensionsv1alpha1.AddonDisabled:
 			if addon.Generation == addon.Status.ObservedGeneration {
+				// backfill the installed version for the addons enabled before it's recorded
+				if addon.Status.Phase == extensionsv1alpha1.AddonEnabled && addon.Spec.Helm != nil &&
+					addon.Status.InstalledVersion == "" && addon.Status.InstalledChartLocationURL == "" {
+					patch := client.MergeFrom(addon.DeepCopy())
+					setAddonInstalled(addon)
+					if err := r.reconciler.Status().Patch(ctx, addon, patch); err != nil {
+						r.setRequeueWithErr(err, "")
+						return
+					}
+				}
 				res, err := r.reconciler.deleteExternalResources(*r.reqCtx, addon)
 				if res != nil || err != nil {
 					r.updateResultNErr(res, err)
@@ -393,6 +415,40 @@ func (r *enabledWithDefaultValuesStage) Handle(ctx context.Context) {
 	r.next.Handle(ctx)
 }
 
+func (r *dependencyCheckStage) Handle(ctx context.Context) {
+	r.process(func(addon *extensionsv1alpha1.Addon) {
+		r.reqCtx.Log.V(1).Info("dependencyCheckStage", "phase", addon.Status.Phase)
+		if len(addon.Spec.Dependencies) == 0 || !addon.Spec.InstallSpec.GetEnabled() {
+			return
+		}
+		// only check before the installation starts
+		switch addon.Status.Phase {
+		case extensionsv1alpha1.AddonEnabling, extensionsv1alpha1.AddonEnabled:
+			return
+		}
+		addons, err := listAddons(ctx, r.reconciler.Client)
+		if err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		reason, message := checkDependencies(addons, addon)
+		if reason == DependencySatisfied {
+			if err = setAddonDependencyCondition(ctx, &r.stageCtx, addon, metav1.ConditionTrue, reason, message); err != nil {
+				r.setRequeueWithErr(err, "")
+			}
+			return
+		}
+		if err = setAddonDependencyCondition(ctx, &r.stageCtx, addon, metav1.ConditionFalse, reason, message); err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		r.reconciler.Event(addon, corev1.EventTypeWarning, reason, message)
+		// the addon will be enqueued again once any of its dependencies changes
+		r.setReconciled()
+	})
+	r.next.Handle(ctx)
+}
+
 func (r *progressingHandler) Handle(ctx context.Context) {
 	r.enablingStage.stageCtx = r.stageCtx
 	r.disablingStage.stageCtx = r.stageCtx
@@ -528,9 +584,111 @@ func setInitContainer(addon *extensionsv1alpha1.Addon, helmJobPodSpec *corev1.Po
 	helmJobPodSpec.InitContainers = append(helmJobPodSpec.InitContainers, copyChartsContainer)
 }
 
+// setChartOptions sets the options to pull the charts from the OCI registry and to verify the charts.
+func setChartOptions(addon *extensionsv1alpha1.Addon, helmJobPodSpec *corev1.PodSpec) {
+	helmSpec := addon.Spec.Helm
+	container := &helmJobPodSpec.Containers[0]
+	if helmSpec.IsOCIChart() && helmSpec.OCIRegistry != nil {
+		if ref := helmSpec.OCIRegistry.CredentialsSecretRef; ref != nil && ref.Name != "" {
+			helmJobPodSpec.Volumes = append(helmJobPodSpec.Volumes, corev1.Volume{
+				Name: "registry-config",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: ref.Name,
+						Items: []corev1.KeyToPath{
+							{
+								Key:  corev1.DockerConfigJsonKey,
+								Path: registryConfigFile,
+							},
+						},
+					},
+				},
+			})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      "registry-config",
+				ReadOnly:  true,
+				MountPath: registryConfigPath,
+			})
+			container.Args = append(container.Args, "--registry-config",
+				fmt.Sprintf("%s/%s", registryConfigPath, registryConfigFile))
+		}
+		if helmSpec.OCIRegistry.PlainHTTP {
+			container.Args = append(container.Args, "--plain-http")
+		}
+	}
+
+	if helmSpec.Verification != nil {
+		ref := helmSpec.Verification.KeyringSecretRef
+		helmJobPodSpec.Volumes = append(helmJobPodSpec.Volumes, corev1.Volume{
+			Name: "keyring",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: ref.Name,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  ref.Key,
+							Path: ref.Key,
+						},
+					},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "keyring",
+			ReadOnly:  true,
+			MountPath: keyringPath,
+		})
+		container.Args = append(container.Args, "--verify", "--keyring", fmt.Sprintf("%s/%s", keyringPath, ref.Key))
+	}
+}
+
+// attachHelmChartOptions checks the Secrets referenced to pull and verify the charts, and sets the options to
+// the Helm job. It returns false if the job can't be built and the stage result has been set.
+func attachHelmChartOptions(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon, helmJob *batchv1.Job) bool {
+	helmSpec := addon.Spec.Helm
+	var refs []extensionsv1alpha1.DataObjectKeySelector
+	if helmSpec.IsOCIChart() && helmSpec.OCIRegistry != nil && helmSpec.OCIRegistry.CredentialsSecretRef != nil {
+		refs = append(refs, extensionsv1alpha1.DataObjectKeySelector{
+			Name: helmSpec.OCIRegistry.CredentialsSecretRef.Name,
+			Key:  corev1.DockerConfigJsonKey,
+		})
+	}
+	if helmSpec.Verification != nil {
+		refs = append(refs, helmSpec.Verification.KeyringSecretRef)
+	}
+	for _, ref := range refs {
+		secret := &corev1.Secret{}
+		key := client.ObjectKey{
+			Name:      ref.Name,
+			Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS)}
+		if err := stageCtx.reconciler.Get(ctx, key, secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				stageCtx.setRequeueWithErr(err, "")
+				return false
+			}
+			stageCtx.setRequeueAfter(time.Second, fmt.Sprintf("Secret %s not found", ref.Name))
+			setAddonErrorConditions(ctx, stageCtx, addon, false, true, AddonRefObjError,
+				fmt.Sprintf("Secret object %v not found", key))
+			return false
+		}
+		if !findDataKey(secret.Data, ref) {
+			setAddonErrorConditions(ctx, stageCtx, addon, true, true, AddonRefObjError,
+				fmt.Sprintf("Attach Secret %v volume source failed, key %s not found", key, ref.Key))
+			stageCtx.setReconciled()
+			return false
+		}
+	}
+	setChartOptions(addon, &helmJob.Spec.Template.Spec)
+	return true
+}
+
 func (r *helmTypeInstallStage) Handle(ctx context.Context) {
 	r.process(func(addon *extensionsv1alpha1.Addon) {
 		r.reqCtx.Log.V(1).Info("helmTypeInstallStage", "phase", addon.Status.Phase)
+		if record := currentUpgrade(addon); record != nil && record.Phase == extensionsv1alpha1.AddonUpgradeRollingBack {
+			rollbackUpgrade(ctx, &r.stageCtx, addon)
+			return
+		}
 		mgrNS := viper.GetString(constant.CfgKeyCtrlrMgrNS)
 
 		key := client.ObjectKey{
@@ -555,6 +713,11 @@ func (r *helmTypeInstallStage) Handle(ctx context.Context) {
 			// 0, and len(job.status.conditions) > 0, and need to handle failed
 			// info. from conditions.
 			if helmInstallJob.Status.Failed > 0 {
+				// roll back the failed upgrade to keep the installed version running
+				if currentUpgrade(addon) != nil {
+					rollbackUpgrade(ctx, &r.stageCtx, addon)
+					return
+				}
 				// job failed set terminal state phase
 				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, InstallationFailed,
 					fmt.Sprintf("Installation failed, do inspect error from jobs.batch %s", key.String()))
@@ -589,7 +752,6 @@ func (r *helmTypeInstallStage) Handle(ctx context.Context) {
 
 		helmInstallJob.ObjectMeta.Name = key.Name
 		helmInstallJob.ObjectMeta.Namespace = key.Namespace
-		helmJobPodSpec := &helmInstallJob.Spec.Template.Spec
 		helmContainer := &helmInstallJob.Spec.Template.Spec.Containers[0]
 		helmContainer.Args = append([]string{
 			"upgrade",
@@ -601,102 +763,118 @@ func (r *helmTypeInstallStage) Handle(ctx context.Context) {
 			"--create-namespace",
 		}, viper.GetStringSlice(addonHelmInstallOptKey)...)
 
-		installValues := addon.Spec.Helm.BuildMergedValues(addon.Spec.InstallSpec)
-		if err = addon.Spec.Helm.BuildContainerArgs(helmContainer, installValues); err != nil {
+		if !attachHelmChartOptions(ctx, &r.stageCtx, addon, helmInstallJob) {
+			return
+		}
+		if !attachHelmJobValues(ctx, &r.stageCtx, addon, helmInstallJob) {
+			return
+		}
+
+		if err := r.reconciler.Create(ctx, helmInstallJob); err != nil {
 			r.setRequeueWithErr(err, "")
 			return
 		}
+		r.setRequeueAfter(time.Second, "")
+	})
+	r.next.Handle(ctx)
+}
 
-		// set values from file
-		for _, cmRef := range installValues.ConfigMapRefs {
-			cm := &corev1.ConfigMap{}
-			key := client.ObjectKey{
-				Name:      cmRef.Name,
-				Namespace: mgrNS}
-			if err := r.reconciler.Get(ctx, key, cm); err != nil {
-				if !apierrors.IsNotFound(err) {
-					r.setRequeueWithErr(err, "")
-					return
-				}
-				r.setRequeueAfter(time.Second, fmt.Sprintf("ConfigMap %s not found", cmRef.Name))
-				setAddonErrorConditions(ctx, &r.stageCtx, addon, false, true, AddonRefObjError,
-					fmt.Sprintf("ConfigMap object %v not found", key))
-				return
-			}
-			if !findDataKey(cm.Data, cmRef) {
-				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, AddonRefObjError,
-					fmt.Sprintf("Attach ConfigMap %v volume source failed, key %s not found", key, cmRef.Key))
-				r.setReconciled()
-				return
+// attachHelmJobValues sets the install values of the addon to the Helm job, including the values from
+// the referenced ConfigMaps and Secrets, and the local charts if any. It returns false if the job can't be
+// built and the stage result has been set.
+func attachHelmJobValues(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon, helmJob *batchv1.Job) bool {
+	mgrNS := viper.GetString(constant.CfgKeyCtrlrMgrNS)
+	helmJobPodSpec := &helmJob.Spec.Template.Spec
+	helmContainer := &helmJob.Spec.Template.Spec.Containers[0]
+	installValues := addon.Spec.Helm.BuildMergedValues(addon.Spec.InstallSpec)
+	if err := addon.Spec.Helm.BuildContainerArgs(helmContainer, installValues); err != nil {
+		stageCtx.setRequeueWithErr(err, "")
+		return false
+	}
+
+	// set values from file
+	for _, cmRef := range installValues.ConfigMapRefs {
+		cm := &corev1.ConfigMap{}
+		key := client.ObjectKey{
+			Name:      cmRef.Name,
+			Namespace: mgrNS}
+		if err := stageCtx.reconciler.Get(ctx, key, cm); err != nil {
+			if !apierrors.IsNotFound(err) {
+				stageCtx.setRequeueWithErr(err, "")
+				return false
 			}
-			attachVolumeMount(helmJobPodSpec, cmRef, cm.Name, "cm",
-				func() corev1.VolumeSource {
-					return corev1.VolumeSource{
-						ConfigMap: &corev1.ConfigMapVolumeSource{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: cm.Name,
-							},
-							Items: []corev1.KeyToPath{
-								{
-									Key:  cmRef.Key,
-									Path: cmRef.Key,
-								},
+			stageCtx.setRequeueAfter(time.Second, fmt.Sprintf("ConfigMap %s not found", cmRef.Name))
+			setAddonErrorConditions(ctx, stageCtx, addon, false, true, AddonRefObjError,
+				fmt.Sprintf("ConfigMap object %v not found", key))
+			return false
+		}
+		if !findDataKey(cm.Data, cmRef) {
+			setAddonErrorConditions(ctx, stageCtx, addon, true, true, AddonRefObjError,
+				fmt.Sprintf("Attach ConfigMap %v volume source failed, key %s not found", key, cmRef.Key))
+			stageCtx.setReconciled()
+			return false
+		}
+		attachVolumeMount(helmJobPodSpec, cmRef, cm.Name, "cm",
+			func() corev1.VolumeSource {
+				return corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: cm.Name,
+						},
+						Items: []corev1.KeyToPath{
+							{
+								Key:  cmRef.Key,
+								Path: cmRef.Key,
 							},
 						},
-					}
-				})
-		}
-
-		for _, secretRef := range installValues.SecretRefs {
-			secret := &corev1.Secret{}
-			key := client.ObjectKey{
-				Name:      secretRef.Name,
-				Namespace: mgrNS}
-			if err := r.reconciler.Get(ctx, key, secret); err != nil {
-				if !apierrors.IsNotFound(err) {
-					r.setRequeueWithErr(err, "")
-					return
+					},
 				}
-				r.setRequeueAfter(time.Second, fmt.Sprintf("Secret %s not found", secret.Name))
-				setAddonErrorConditions(ctx, &r.stageCtx, addon, false, true, AddonRefObjError,
-					fmt.Sprintf("Secret object %v not found", key))
-				return
-			}
-			if !findDataKey(secret.Data, secretRef) {
-				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, AddonRefObjError,
-					fmt.Sprintf("Attach Secret %v volume source failed, key %s not found", key, secretRef.Key))
-				r.setReconciled()
-				return
+			})
+	}
+
+	for _, secretRef := range installValues.SecretRefs {
+		secret := &corev1.Secret{}
+		key := client.ObjectKey{
+			Name:      secretRef.Name,
+			Namespace: mgrNS}
+		if err := stageCtx.reconciler.Get(ctx, key, secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				stageCtx.setRequeueWithErr(err, "")
+				return false
 			}
-			attachVolumeMount(helmJobPodSpec, secretRef, secret.Name, "secret",
-				func() corev1.VolumeSource {
-					return corev1.VolumeSource{
-						Secret: &corev1.SecretVolumeSource{
-							SecretName: secret.Name,
-							Items: []corev1.KeyToPath{
-								{
-									Key:  secretRef.Key,
-									Path: secretRef.Key,
-								},
+			stageCtx.setRequeueAfter(time.Second, fmt.Sprintf("Secret %s not found", secret.Name))
+			setAddonErrorConditions(ctx, stageCtx, addon, false, true, AddonRefObjError,
+				fmt.Sprintf("Secret object %v not found", key))
+			return false
+		}
+		if !findDataKey(secret.Data, secretRef) {
+			setAddonErrorConditions(ctx, stageCtx, addon, true, true, AddonRefObjError,
+				fmt.Sprintf("Attach Secret %v volume source failed, key %s not found", key, secretRef.Key))
+			stageCtx.setReconciled()
+			return false
+		}
+		attachVolumeMount(helmJobPodSpec, secretRef, secret.Name, "secret",
+			func() corev1.VolumeSource {
+				return corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: secret.Name,
+						Items: []corev1.KeyToPath{
+							{
+								Key:  secretRef.Key,
+								Path: secretRef.Key,
 							},
 						},
-					}
-				})
-		}
-
-		// if chartLocationURL starts with 'file://', it means the charts is from local file system
-		// we will copy the charts from charts image to shared volume. Addon container will use the
-		// charts from shared volume to install the addon.
-		setSharedVolume(addon, helmJobPodSpec)
-		setInitContainer(addon, helmJobPodSpec)
+					},
+				}
+			})
+	}
 
-		if err := r.reconciler.Create(ctx, helmInstallJob); err != nil {
-			r.setRequeueWithErr(err, "")
-			return
-		}
-		r.setRequeueAfter(time.Second, "")
-	})
-	r.next.Handle(ctx)
+	// if chartLocationURL starts with 'file://', it means the charts is from local file system
+	// we will copy the charts from charts image to shared volume. Addon container will use the
+	// charts from shared volume to install the addon.
+	setSharedVolume(addon, helmJobPodSpec)
+	setInitContainer(addon, helmJobPodSpec)
+	return true
 }
 
 func (r *helmTypeUninstallStage) Handle(ctx context.Context) {
@@ -840,9 +1018,12 @@ func (r *disablingStage) Handle(ctx context.Context) {
 func (r *terminalStateStage) Handle(ctx context.Context) {
 	r.process(func(addon *extensionsv1alpha1.Addon) {
 		r.reqCtx.Log.V(1).Info("terminalStateStage", "phase", addon.Status.Phase)
-		patchPhaseNCondition := func(phase extensionsv1alpha1.AddonPhase, reason string) {
+		patchPhaseNCondition := func(phase extensionsv1alpha1.AddonPhase, reason string, mutators ...func(*extensionsv1alpha1.Addon)) {
 			r.reqCtx.Log.V(1).Info("patching status", "phase", phase)
 			patch := client.MergeFrom(addon.DeepCopy())
+			for _, mutate := range mutators {
+				mutate(addon)
+			}
 			addon.Status.Phase = phase
 			addon.Status.ObservedGeneration = addon.Generation
 
@@ -866,10 +1047,13 @@ func (r *terminalStateStage) Handle(ctx context.Context) {
 		// transit to enabled or disable phase
 		switch addon.Status.Phase {
 		case "", extensionsv1alpha1.AddonDisabling:
-			patchPhaseNCondition(extensionsv1alpha1.AddonDisabled, AddonDisabled)
+			patchPhaseNCondition(extensionsv1alpha1.AddonDisabled, AddonDisabled, func(addon *extensionsv1alpha1.Addon) {
+				addon.Status.InstalledVersion = ""
+				addon.Status.InstalledChartLocationURL = ""
+			})
 			return
 		case extensionsv1alpha1.AddonEnabling:
-			patchPhaseNCondition(extensionsv1alpha1.AddonEnabled, AddonEnabled)
+			patchPhaseNCondition(extensionsv1alpha1.AddonEnabled, AddonEnabled, completeUpgrade, setAddonInstalled)
 			return
 		}
 	})
@@ -940,7 +1124,7 @@ func createHelmJobProto(addon *extensionsv1alpha1.Addon) (*batchv1.Job, error) {
 			},
 			{
 				Name:  "CHART",
-				Value: addon.Spec.Helm.ChartLocationURL,
+				Value: addon.Spec.Helm.GetChartLocation(),
 			},
 		},
 		VolumeMounts: []corev1.VolumeMount{},
@@ -1256,6 +1440,10 @@ func checkAddonSpec(addon *extensionsv1alpha1.Addon) error {
 		if addon.Spec.Helm == nil {
 			return fmt.Errorf("invalid Helm configuration: either 'Helm' is not specified")
 		}
+		if addon.Spec.Helm.OCIRegistry != nil && !addon.Spec.Helm.IsOCIChart() {
+			return fmt.Errorf("invalid Helm configuration: 'ociRegistry' is specified but the chart location %s is not an OCI reference",
+				addon.Spec.Helm.ChartLocationURL)
+		}
 	}
 	return nil
 }
@@ -1279,3 +1467,153 @@ func setAddonProviderAndVersion(ctx context.Context, stageCtx *stageCtx, addon *
 		addon.Labels[AddonVersion] = addon.Spec.Version
 	}
 }
+
+func listAddons(ctx context.Context, cli client.Client) (map[string]*extensionsv1alpha1.Addon, error) {
+	addonList := &extensionsv1alpha1.AddonList{}
+	if err := cli.List(ctx, addonList); err != nil {
+		return nil, err
+	}
+	addons := make(map[string]*extensionsv1alpha1.Addon, len(addonList.Items))
+	for i := range addonList.Items {
+		addons[addonList.Items[i].Name] = &addonList.Items[i]
+	}
+	return addons, nil
+}
+
+// checkDependencies checks whether the dependencies of the addon are all enabled,
+// and returns the reason and message describing what is blocking the installation.
+func checkDependencies(addons map[string]*extensionsv1alpha1.Addon, addon *extensionsv1alpha1.Addon) (string, string) {
+	if cycle := findDependencyCycle(addons, addon.Name); len(cycle) > 0 {
+		return DependencyCycle, fmt.Sprintf("circular dependency detected: %s", strings.Join(cycle, " -> "))
+	}
+	var notFound, unmatched, notEnabled []string
+	for _, dep := range addon.Spec.Dependencies {
+		depAddon, ok := addons[dep.Name]
+		if !ok {
+			notFound = append(notFound, dep.Name)
+			continue
+		}
+		if len(dep.Version) > 0 {
+			if ok, err := validateVersion(dep.Version, depAddon.Spec.Version); err != nil || !ok {
+				unmatched = append(unmatched, fmt.Sprintf("%s (version %q does not satisfy %q)", dep.Name, depAddon.Spec.Version, dep.Version))
+				continue
+			}
+		}
+		if depAddon.Status.Phase != extensionsv1alpha1.AddonEnabled {
+			phase := depAddon.Status.Phase
+			if phase == "" {
+				phase = extensionsv1alpha1.AddonDisabled
+			}
+			notEnabled = append(notEnabled, fmt.Sprintf("%s (%s)", dep.Name, phase))
+		}
+	}
+	switch {
+	case len(notFound) > 0:
+		return DependencyNotFound, fmt.Sprintf("dependencies not found: %s", strings.Join(notFound, ", "))
+	case len(unmatched) > 0:
+		return DependencyVersionUnmatched, fmt.Sprintf("dependencies version unmatched: %s", strings.Join(unmatched, ", "))
+	case len(notEnabled) > 0:
+		return DependencyNotEnabled, fmt.Sprintf("waiting for dependencies to be enabled: %s", strings.Join(notEnabled, ", "))
+	}
+	return DependencySatisfied, "all dependencies are enabled"
+}
+
+// findDependencyCycle returns the dependency path which starts from and ends at the named addon, if there is one.
+func findDependencyCycle(addons map[string]*extensionsv1alpha1.Addon, name string) []string {
+	var (
+		path    []string
+		visited = map[string]bool{}
+		visit   func(string) bool
+	)
+	visit = func(current string) bool {
+		path = append(path, current)
+		if addon, ok := addons[current]; ok {
+			for _, dep := range addon.Spec.Dependencies {
+				if dep.Name == name {
+					path = append(path, name)
+					return true
+				}
+				if visited[dep.Name] {
+					continue
+				}
+				visited[dep.Name] = true
+				if visit(dep.Name) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	if visit(name) {
+		return path
+	}
+	return nil
+}
+
+// findDependents returns the names of the installed addons which depend on the named addon.
+func findDependents(addons map[string]*extensionsv1alpha1.Addon, name string) []string {
+	var dependents []string
+	for _, addon := range addons {
+		switch addon.Status.Phase {
+		case extensionsv1alpha1.AddonEnabled, extensionsv1alpha1.AddonEnabling:
+		default:
+			continue
+		}
+		if slices.ContainsFunc(addon.Spec.Dependencies, func(dep extensionsv1alpha1.AddonDependency) bool {
+			return dep.Name == name
+		}) {
+			dependents = append(dependents, addon.Name)
+		}
+	}
+	slices.Sort(dependents)
+	return dependents
+}
+
+// validateDependents refuses to disable or delete the addon while any installed addon depends on it.
+func validateDependents(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon) (*ctrl.Result, error) {
+	switch addon.Status.Phase {
+	case "", extensionsv1alpha1.AddonDisabled:
+		return nil, nil
+	}
+	addons, err := listAddons(ctx, stageCtx.reconciler.Client)
+	if err != nil {
+		return nil, err
+	}
+	dependents := findDependents(addons, addon.Name)
+	if len(dependents) == 0 {
+		cond := meta.FindStatusCondition(addon.Status.Conditions, extensionsv1alpha1.ConditionTypeDependency)
+		if cond == nil || cond.Reason != RequiredByOthers {
+			return nil, nil
+		}
+		patch := client.MergeFrom(addon.DeepCopy())
+		meta.RemoveStatusCondition(&addon.Status.Conditions, extensionsv1alpha1.ConditionTypeDependency)
+		return nil, stageCtx.reconciler.Status().Patch(ctx, addon, patch)
+	}
+	message := fmt.Sprintf("required by the installed addons: %s", strings.Join(dependents, ", "))
+	if err = setAddonDependencyCondition(ctx, stageCtx, addon, metav1.ConditionFalse, RequiredByOthers, message); err != nil {
+		return nil, err
+	}
+	stageCtx.reconciler.Event(addon, corev1.EventTypeWarning, RequiredByOthers, message)
+	// the addon will be enqueued again once any of its dependents changes
+	return intctrlutil.ResultToP(intctrlutil.Reconciled())
+}
+
+func setAddonDependencyCondition(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon,
+	status metav1.ConditionStatus, reason, message string) error {
+	cond := meta.FindStatusCondition(addon.Status.Conditions, extensionsv1alpha1.ConditionTypeDependency)
+	if cond != nil && cond.Status == status && cond.Reason == reason && cond.Message == message &&
+		cond.ObservedGeneration == addon.Generation {
+		return nil
+	}
+	patch := client.MergeFrom(addon.DeepCopy())
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               extensionsv1alpha1.ConditionTypeDependency,
+		Status:             status,
+		ObservedGeneration: addon.Generation,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+	return stageCtx.reconciler.Status().Patch(ctx, addon, patch)
+}
@@ -22,6 +22,7 @@ package extensions
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -1052,3 +1053,265 @@ var _ = Describe("Addon controller", func() {
 		})
 	})
 })
+
+var _ = Describe("Addon dependencies", func() {
+	newAddon := func(name, version string, phase extensionsv1alpha1.AddonPhase, deps ...extensionsv1alpha1.AddonDependency) *extensionsv1alpha1.Addon {
+		return &extensionsv1alpha1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: extensionsv1alpha1.AddonSpec{
+				Version:      version,
+				Dependencies: deps,
+			},
+			Status: extensionsv1alpha1.AddonStatus{Phase: phase},
+		}
+	}
+	toMap := func(addons ...*extensionsv1alpha1.Addon) map[string]*extensionsv1alpha1.Addon {
+		m := map[string]*extensionsv1alpha1.Addon{}
+		for _, addon := range addons {
+			m[addon.Name] = addon
+		}
+		return m
+	}
+
+	It("should block the installation until the dependencies are enabled", func() {
+		exporter := newAddon("exporter", "1.2.0", extensionsv1alpha1.AddonEnabling)
+		csi := newAddon("csi", "0.9.0", "")
+		db := newAddon("db", "1.0.0", "",
+			extensionsv1alpha1.AddonDependency{Name: "exporter", Version: ">=1.0.0"},
+			extensionsv1alpha1.AddonDependency{Name: "csi"})
+		addons := toMap(exporter, csi, db)
+
+		reason, message := checkDependencies(addons, db)
+		Expect(reason).Should(Equal(DependencyNotEnabled))
+		Expect(message).Should(ContainSubstring("exporter (Enabling)"))
+		Expect(message).Should(ContainSubstring("csi (Disabled)"))
+
+		exporter.Status.Phase = extensionsv1alpha1.AddonEnabled
+		csi.Status.Phase = extensionsv1alpha1.AddonEnabled
+		reason, _ = checkDependencies(addons, db)
+		Expect(reason).Should(Equal(DependencySatisfied))
+
+		db.Spec.Dependencies[0].Version = ">=2.0.0"
+		reason, message = checkDependencies(addons, db)
+		Expect(reason).Should(Equal(DependencyVersionUnmatched))
+		Expect(message).Should(ContainSubstring("exporter"))
+
+		db.Spec.Dependencies = append(db.Spec.Dependencies, extensionsv1alpha1.AddonDependency{Name: "missing"})
+		reason, message = checkDependencies(addons, db)
+		Expect(reason).Should(Equal(DependencyNotFound))
+		Expect(message).Should(ContainSubstring("missing"))
+	})
+
+	It("should detect the dependency cycles", func() {
+		a := newAddon("a", "", "", extensionsv1alpha1.AddonDependency{Name: "b"})
+		b := newAddon("b", "", "", extensionsv1alpha1.AddonDependency{Name: "c"})
+		c := newAddon("c", "", "", extensionsv1alpha1.AddonDependency{Name: "a"})
+		d := newAddon("d", "", "", extensionsv1alpha1.AddonDependency{Name: "a"})
+		addons := toMap(a, b, c, d)
+
+		Expect(findDependencyCycle(addons, "a")).Should(Equal([]string{"a", "b", "c", "a"}))
+		Expect(findDependencyCycle(addons, "d")).Should(BeNil())
+		reason, message := checkDependencies(addons, b)
+		Expect(reason).Should(Equal(DependencyCycle))
+		Expect(message).Should(ContainSubstring("b -> c -> a -> b"))
+	})
+
+	It("should find the installed dependents", func() {
+		csi := newAddon("csi", "", extensionsv1alpha1.AddonEnabled)
+		db1 := newAddon("db1", "", extensionsv1alpha1.AddonEnabled, extensionsv1alpha1.AddonDependency{Name: "csi"})
+		db2 := newAddon("db2", "", extensionsv1alpha1.AddonEnabling, extensionsv1alpha1.AddonDependency{Name: "csi"})
+		db3 := newAddon("db3", "", extensionsv1alpha1.AddonDisabled, extensionsv1alpha1.AddonDependency{Name: "csi"})
+		Expect(findDependents(toMap(csi, db1, db2, db3), "csi")).Should(Equal([]string{"db1", "db2"}))
+	})
+})
+
+var _ = Describe("Addon upgrade", func() {
+	const manifests = `walk.go:75: found symbolic link in path
+---
+# Source: mysql/templates/cmpd.yaml
+apiVersion: apps.kubeblocks.io/v1
+kind: ComponentDefinition
+metadata:
+  name: mysql-8.0-1.1.0
+spec:
+  serviceVersion: 8.0.35
+---
+# Source: mysql/templates/cmpv.yaml
+apiVersion: apps.kubeblocks.io/v1
+kind: ComponentVersion
+metadata:
+  name: mysql
+spec:
+  compatibilityRules:
+  - compDefs:
+    - ^mysql-8.0-
+    releases:
+    - 8.0.33
+    - 8.0.35
+  releases:
+  - name: 8.0.33
+    serviceVersion: 8.0.33
+  - name: 8.0.35
+    serviceVersion: 8.0.35
+---
+# Source: mysql/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: mysql-config
+`
+
+	newComp := func(cluster, name, compDef, serviceVersion string) kbappsv1.Component {
+		return kbappsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      fmt.Sprintf("%s-%s", cluster, name),
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:    cluster,
+					constant.KBAppComponentLabelKey: name,
+				},
+			},
+			Spec: kbappsv1.ComponentSpec{
+				CompDef:        compDef,
+				ServiceVersion: serviceVersion,
+			},
+		}
+	}
+
+	It("should parse the ComponentDefinitions and ComponentVersions from the rendered manifests", func() {
+		compDefs, compVersions, err := parseAddonManifests(manifests)
+		Expect(err).Should(Succeed())
+		Expect(compDefs).Should(HaveLen(1))
+		Expect(compDefs[0].Name).Should(Equal("mysql-8.0-1.1.0"))
+		Expect(compVersions).Should(HaveLen(1))
+		Expect(compVersions[0].Spec.Releases).Should(HaveLen(2))
+	})
+
+	It("should check the compatibility of the components with the new version", func() {
+		compDefs, compVersions, err := parseAddonManifests(manifests)
+		Expect(err).Should(Succeed())
+
+		cluster := &kbappsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "c1"},
+			Spec: kbappsv1.ClusterSpec{
+				ComponentSpecs: []kbappsv1.ClusterComponentSpec{{Name: "mysql", ComponentDef: "^mysql-8.0-"}},
+			},
+		}
+		clusters := map[types.NamespacedName]*kbappsv1.Cluster{
+			{Namespace: cluster.Namespace, Name: cluster.Name}: cluster,
+		}
+
+		By("the compDef regex in the cluster matches the new compDef")
+		comps := []kbappsv1.Component{newComp("c1", "mysql", "mysql-8.0-1.0.0", "8.0.33")}
+		Expect(checkUpgradeCompatibility(comps, clusters, compDefs, compVersions)).Should(BeEmpty())
+
+		By("the compDef name without the cluster doesn't match the new compDef")
+		comps = append(comps, newComp("c2", "mysql", "mysql-8.0-1.0.0", "8.0.33"))
+		issues := checkUpgradeCompatibility(comps, clusters, compDefs, compVersions)
+		Expect(issues).Should(HaveLen(1))
+		Expect(issues[0]).Should(ContainSubstring("c2-mysql: no ComponentDefinition matches"))
+
+		By("the service version is removed in the new version")
+		comps = []kbappsv1.Component{newComp("c1", "mysql", "mysql-8.0-1.0.0", "8.0.30")}
+		issues = checkUpgradeCompatibility(comps, clusters, compDefs, compVersions)
+		Expect(issues).Should(HaveLen(1))
+		Expect(issues[0]).Should(ContainSubstring("service version 8.0.30 is not supported"))
+	})
+
+	It("should track the upgrade history", func() {
+		addon := &extensionsv1alpha1.Addon{
+			Spec: extensionsv1alpha1.AddonSpec{
+				Version: "1.0.0",
+				Type:    extensionsv1alpha1.HelmType,
+				Helm:    &extensionsv1alpha1.HelmTypeInstallSpec{ChartLocationURL: "file:///mysql-1.0.0.tgz"},
+			},
+			Status: extensionsv1alpha1.AddonStatus{Phase: extensionsv1alpha1.AddonEnabled},
+		}
+		By("the addon installed before the version is recorded")
+		Expect(isAddonUpgrade(addon)).Should(BeFalse())
+		setAddonInstalled(addon)
+		Expect(isAddonUpgrade(addon)).Should(BeFalse())
+
+		By("upgrade to the new version")
+		addon.Spec.Version = "1.1.0"
+		addon.Spec.Helm.ChartLocationURL = "file:///mysql-1.1.0.tgz"
+		Expect(isAddonUpgrade(addon)).Should(BeTrue())
+		Expect(currentUpgrade(addon)).Should(BeNil())
+		addon.Status.UpgradeHistory = appendUpgradeRecord(addon.Status.UpgradeHistory, extensionsv1alpha1.AddonUpgradeRecord{
+			FromVersion:        "1.0.0",
+			ToVersion:          "1.1.0",
+			ToChartLocationURL: "file:///mysql-1.1.0.tgz",
+			Phase:              extensionsv1alpha1.AddonUpgradeUpgrading,
+		})
+		Expect(currentUpgrade(addon)).ShouldNot(BeNil())
+		completeUpgrade(addon)
+		setAddonInstalled(addon)
+		Expect(addon.Status.UpgradeHistory[0].Phase).Should(Equal(extensionsv1alpha1.AddonUpgradeSucceeded))
+		Expect(addon.Status.UpgradeHistory[0].CompletionTime).ShouldNot(BeNil())
+		Expect(currentUpgrade(addon)).Should(BeNil())
+		Expect(isAddonUpgrade(addon)).Should(BeFalse())
+
+		By("the history is limited")
+		for i := 0; i < maxAddonUpgradeHistory+2; i++ {
+			addon.Status.UpgradeHistory = appendUpgradeRecord(addon.Status.UpgradeHistory, extensionsv1alpha1.AddonUpgradeRecord{
+				ToVersion: fmt.Sprintf("1.1.%d", i),
+				Phase:     extensionsv1alpha1.AddonUpgradePrecheckFailed,
+			})
+		}
+		Expect(addon.Status.UpgradeHistory).Should(HaveLen(maxAddonUpgradeHistory))
+		Expect(addon.Status.UpgradeHistory[maxAddonUpgradeHistory-1].ToVersion).Should(Equal(fmt.Sprintf("1.1.%d", maxAddonUpgradeHistory+1)))
+	})
+})
+
+var _ = Describe("Addon charts from OCI registry", func() {
+	It("should set the options to pull and verify the charts", func() {
+		addon := &extensionsv1alpha1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Name: "mysql"},
+			Spec: extensionsv1alpha1.AddonSpec{
+				Type: extensionsv1alpha1.HelmType,
+				Helm: &extensionsv1alpha1.HelmTypeInstallSpec{
+					ChartLocationURL: "oci://registry.local:5000/charts/mysql",
+					OCIRegistry: &extensionsv1alpha1.HelmOCIRegistry{
+						CredentialsSecretRef: &corev1.LocalObjectReference{Name: "registry-credentials"},
+						Digest:               "sha256:" + strings.Repeat("0", 64),
+						PlainHTTP:            true,
+					},
+					Verification: &extensionsv1alpha1.HelmChartVerification{
+						KeyringSecretRef: extensionsv1alpha1.DataObjectKeySelector{Name: "chart-keyring", Key: "pubring.gpg"},
+					},
+				},
+			},
+		}
+		Expect(checkAddonSpec(addon)).Should(Succeed())
+
+		job, err := createHelmJobProto(addon)
+		Expect(err).Should(Succeed())
+		Expect(job.Spec.Template.Spec.Containers[0].Env).Should(ContainElement(corev1.EnvVar{
+			Name:  "CHART",
+			Value: "oci://registry.local:5000/charts/mysql@sha256:" + strings.Repeat("0", 64),
+		}))
+
+		setChartOptions(addon, &job.Spec.Template.Spec)
+		podSpec := job.Spec.Template.Spec
+		Expect(podSpec.Volumes).Should(HaveLen(2))
+		Expect(podSpec.Volumes[0].Secret.SecretName).Should(Equal("registry-credentials"))
+		Expect(podSpec.Volumes[0].Secret.Items[0].Key).Should(Equal(corev1.DockerConfigJsonKey))
+		Expect(podSpec.Volumes[1].Secret.SecretName).Should(Equal("chart-keyring"))
+		Expect(podSpec.Containers[0].VolumeMounts).Should(HaveLen(2))
+		Expect(podSpec.Containers[0].Args).Should(Equal([]string{
+			"--registry-config", "/vol/registry/config.json",
+			"--plain-http",
+			"--verify", "--keyring", "/vol/keyring/pubring.gpg",
+		}))
+
+		By("the OCI registry settings are ignored for non-OCI charts")
+		addon.Spec.Helm.ChartLocationURL = "https://charts.example.com/mysql-1.0.0.tgz"
+		Expect(checkAddonSpec(addon)).ShouldNot(Succeed())
+		addon.Spec.Helm.Verification = nil
+		job, err = createHelmJobProto(addon)
+		Expect(err).Should(Succeed())
+		setChartOptions(addon, &job.Spec.Template.Spec)
+		Expect(job.Spec.Template.Spec.Volumes).Should(BeEmpty())
+		Expect(job.Spec.Template.Spec.Containers[0].Args).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,595 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// maxAddonUpgradeHistory is the max number of the upgrade records kept in the addon status.
+const maxAddonUpgradeHistory = 10
+
+var manifestSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// upgradeCheckStage prechecks the upgrade of an enabled addon before the Helm upgrade runs, the Clusters
+// provisioned by the addon are checked against the ComponentDefinitions and ComponentVersions of the new version.
+type upgradeCheckStage struct {
+	stageCtx
+}
+
+func (r *upgradeCheckStage) Handle(ctx context.Context) {
+	r.process(func(addon *extensionsv1alpha1.Addon) {
+		r.reqCtx.Log.V(1).Info("upgradeCheckStage", "phase", addon.Status.Phase)
+		if addon.Status.Phase != extensionsv1alpha1.AddonEnabled || !addon.Spec.InstallSpec.GetEnabled() || !isAddonUpgrade(addon) {
+			return
+		}
+		record, err := r.startUpgrade(ctx, addon)
+		if err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		if record.Phase == extensionsv1alpha1.AddonUpgradeUpgrading {
+			return
+		}
+		issues, done := r.precheck(ctx, addon)
+		if !done {
+			return
+		}
+		if len(issues) > 0 {
+			r.failPrecheck(ctx, addon, strings.Join(issues, "; "))
+			return
+		}
+		revision, err := getDeployedRevision(ctx, r.reconciler.Client, addon)
+		if err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		patch := client.MergeFrom(addon.DeepCopy())
+		record = currentUpgrade(addon)
+		record.Phase = extensionsv1alpha1.AddonUpgradeUpgrading
+		record.FromRevision = revision
+		if err = r.reconciler.Status().Patch(ctx, addon, patch); err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		r.reconciler.Event(addon, corev1.EventTypeNormal, UpgradePrecheckPassed,
+			fmt.Sprintf("Precheck passed, upgrading to version %s", addon.Spec.Version))
+		// move on to the progressing stage to run the Helm upgrade
+	})
+	r.next.Handle(ctx)
+}
+
+// startUpgrade returns the record of the ongoing upgrade, a new record is added if the upgrade is just started.
+func (r *upgradeCheckStage) startUpgrade(ctx context.Context, addon *extensionsv1alpha1.Addon) (*extensionsv1alpha1.AddonUpgradeRecord, error) {
+	if record := currentUpgrade(addon); record != nil {
+		return record, nil
+	}
+	// the precheck job may be left by a former upgrade to another version
+	if err := r.reconciler.deleteJobIfExist(ctx, getPrecheckJobName(addon)); err != nil {
+		return nil, err
+	}
+	patch := client.MergeFrom(addon.DeepCopy())
+	addon.Status.UpgradeHistory = appendUpgradeRecord(addon.Status.UpgradeHistory, extensionsv1alpha1.AddonUpgradeRecord{
+		FromVersion:          addon.Status.InstalledVersion,
+		ToVersion:            addon.Spec.Version,
+		FromChartLocationURL: addon.Status.InstalledChartLocationURL,
+		ToChartLocationURL:   addon.Spec.Helm.GetChartLocation(),
+		Phase:                extensionsv1alpha1.AddonUpgradePrechecking,
+		StartTime:            metav1.Now(),
+	})
+	if err := r.reconciler.Status().Patch(ctx, addon, patch); err != nil {
+		return nil, err
+	}
+	r.reconciler.Event(addon, corev1.EventTypeNormal, AddonUpgrading,
+		fmt.Sprintf("Start to upgrade from version %s to %s", addon.Status.InstalledVersion, addon.Spec.Version))
+	return currentUpgrade(addon), nil
+}
+
+// precheck renders the charts of the new version by a Helm template job, and checks the rendered manifests.
+// It returns false if the precheck is still in progress.
+func (r *upgradeCheckStage) precheck(ctx context.Context, addon *extensionsv1alpha1.Addon) ([]string, bool) {
+	key := client.ObjectKey{
+		Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS),
+		Name:      getPrecheckJobName(addon),
+	}
+	precheckJob := &batchv1.Job{}
+	if err := r.reconciler.Get(ctx, key, precheckJob); client.IgnoreNotFound(err) != nil {
+		r.setRequeueWithErr(err, "")
+		return nil, false
+	} else if err == nil {
+		switch {
+		case !precheckJob.GetDeletionTimestamp().IsZero():
+			r.setRequeueAfter(time.Second, fmt.Sprintf("waiting for the stale Helm precheck job %s to be deleted", key.Name))
+		case precheckJob.Status.Succeeded > 0:
+			manifests, err := getJobPodLogs(ctx, &r.stageCtx, addon, key.Name)
+			if err != nil {
+				r.setRequeueWithErr(err, "")
+				return nil, false
+			}
+			issues, err := r.checkCompatibility(ctx, addon, manifests)
+			if err != nil {
+				r.setRequeueWithErr(err, "")
+				return nil, false
+			}
+			return issues, true
+		case precheckJob.Status.Active > 0:
+			r.setRequeueAfter(time.Second, fmt.Sprintf("running Helm precheck job %s", key.Name))
+		case precheckJob.Status.Failed > 0:
+			return []string{fmt.Sprintf("failed to render the charts of version %s, do inspect error from jobs.batch %s",
+				addon.Spec.Version, key.String())}, true
+		default:
+			r.setRequeueAfter(time.Second, "")
+		}
+		return nil, false
+	}
+
+	precheckJob, err := createHelmJobProto(addon)
+	if err != nil {
+		r.setRequeueWithErr(err, "")
+		return nil, false
+	}
+	chartsPath, err := buildLocalChartsPath(addon)
+	if err != nil {
+		r.setRequeueWithErr(err, "")
+		return nil, false
+	}
+	precheckJob.ObjectMeta.Name = key.Name
+	precheckJob.ObjectMeta.Namespace = key.Namespace
+	helmContainer := &precheckJob.Spec.Template.Spec.Containers[0]
+	helmContainer.Args = []string{
+		"template",
+		"$(RELEASE_NAME)",
+		chartsPath,
+		"--namespace",
+		"$(RELEASE_NS)",
+	}
+	if !attachHelmChartOptions(ctx, &r.stageCtx, addon, precheckJob) {
+		return nil, false
+	}
+	if !attachHelmJobValues(ctx, &r.stageCtx, addon, precheckJob) {
+		return nil, false
+	}
+	if err = r.reconciler.Create(ctx, precheckJob); err != nil {
+		r.setRequeueWithErr(err, "")
+		return nil, false
+	}
+	r.setRequeueAfter(time.Second, "")
+	return nil, false
+}
+
+// checkCompatibility checks the Components provisioned by the ComponentDefinitions of the addon against the
+// rendered manifests of the new version.
+func (r *upgradeCheckStage) checkCompatibility(ctx context.Context, addon *extensionsv1alpha1.Addon, manifests string) ([]string, error) {
+	compDefs, compVersions, err := parseAddonManifests(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	compDefList := &appsv1.ComponentDefinitionList{}
+	if err = r.reconciler.List(ctx, compDefList); err != nil {
+		return nil, err
+	}
+	owned := sets.New[string]()
+	for _, compDef := range compDefList.Items {
+		if compDef.Annotations[helmReleaseNameAnnotationKey] == getHelmReleaseName(addon) {
+			owned.Insert(compDef.Name)
+		}
+	}
+	if owned.Len() == 0 {
+		return nil, nil
+	}
+
+	compList := &appsv1.ComponentList{}
+	if err = r.reconciler.List(ctx, compList); err != nil {
+		return nil, err
+	}
+	comps := slices.DeleteFunc(compList.Items, func(comp appsv1.Component) bool {
+		return !owned.Has(comp.Spec.CompDef)
+	})
+	clusters := make(map[types.NamespacedName]*appsv1.Cluster)
+	for _, comp := range comps {
+		key := types.NamespacedName{Namespace: comp.Namespace, Name: comp.Labels[constant.AppInstanceLabelKey]}
+		if _, ok := clusters[key]; ok || key.Name == "" {
+			continue
+		}
+		cluster := &appsv1.Cluster{}
+		if err = r.reconciler.Get(ctx, key, cluster); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		clusters[key] = cluster
+	}
+	return checkUpgradeCompatibility(comps, clusters, compDefs, compVersions), nil
+}
+
+func (r *upgradeCheckStage) failPrecheck(ctx context.Context, addon *extensionsv1alpha1.Addon, message string) {
+	patch := client.MergeFrom(addon.DeepCopy())
+	record := currentUpgrade(addon)
+	record.Phase = extensionsv1alpha1.AddonUpgradePrecheckFailed
+	record.Message = message
+	record.CompletionTime = &metav1.Time{Time: time.Now()}
+	// the addon keeps running the installed version, and waits for the spec to be changed
+	addon.Status.ObservedGeneration = addon.Generation
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               extensionsv1alpha1.ConditionTypeUpgraded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: addon.Generation,
+		Reason:             UpgradePrecheckFailed,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+	if err := r.reconciler.Status().Patch(ctx, addon, patch); err != nil {
+		r.setRequeueWithErr(err, "")
+		return
+	}
+	r.reconciler.Event(addon, corev1.EventTypeWarning, UpgradePrecheckFailed, message)
+	r.setReconciled()
+}
+
+// rollbackUpgrade rolls back the Helm release of the addon to the revision before the failed upgrade.
+func rollbackUpgrade(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon) {
+	installJobKey := client.ObjectKey{
+		Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS),
+		Name:      getInstallJobName(addon),
+	}
+	if record := currentUpgrade(addon); record.Phase != extensionsv1alpha1.AddonUpgradeRollingBack {
+		patch := client.MergeFrom(addon.DeepCopy())
+		record.Phase = extensionsv1alpha1.AddonUpgradeRollingBack
+		record.Message = fmt.Sprintf("Upgrade failed, do inspect error from jobs.batch %s", installJobKey.String())
+		if err := stageCtx.reconciler.Status().Patch(ctx, addon, patch); err != nil {
+			stageCtx.setRequeueWithErr(err, "")
+			return
+		}
+		stageCtx.reconciler.Event(addon, corev1.EventTypeWarning, UpgradeFailed,
+			fmt.Sprintf("Upgrade to version %s failed, rolling back to version %s", record.ToVersion, record.FromVersion))
+	}
+
+	key := client.ObjectKey{
+		Namespace: installJobKey.Namespace,
+		Name:      getRollbackJobName(addon),
+	}
+	rollbackJob := &batchv1.Job{}
+	if err := stageCtx.reconciler.Get(ctx, key, rollbackJob); client.IgnoreNotFound(err) != nil {
+		stageCtx.setRequeueWithErr(err, "")
+		return
+	} else if err == nil {
+		switch {
+		case rollbackJob.Status.Succeeded > 0:
+			patch := client.MergeFrom(addon.DeepCopy())
+			record := currentUpgrade(addon)
+			record.Phase = extensionsv1alpha1.AddonUpgradeRolledBack
+			record.CompletionTime = &metav1.Time{Time: time.Now()}
+			// the installed version keeps running, and waits for the spec to be changed
+			addon.Status.Phase = extensionsv1alpha1.AddonEnabled
+			addon.Status.ObservedGeneration = addon.Generation
+			meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+				Type:               extensionsv1alpha1.ConditionTypeUpgraded,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: addon.Generation,
+				Reason:             UpgradeRolledBack,
+				Message:            record.Message,
+				LastTransitionTime: metav1.Now(),
+			})
+			if err = stageCtx.reconciler.Status().Patch(ctx, addon, patch); err != nil {
+				stageCtx.setRequeueWithErr(err, "")
+				return
+			}
+			stageCtx.reconciler.Event(addon, corev1.EventTypeWarning, UpgradeRolledBack,
+				fmt.Sprintf("Upgrade to version %s has been rolled back to version %s", record.ToVersion, record.FromVersion))
+			stageCtx.setReconciled()
+		case rollbackJob.Status.Active > 0:
+			stageCtx.setRequeueAfter(time.Second, fmt.Sprintf("running Helm rollback job %s", key.Name))
+		case rollbackJob.Status.Failed > 0:
+			message := fmt.Sprintf("Rollback failed, do inspect error from jobs.batch %s and %s", installJobKey.String(), key.String())
+			patch := client.MergeFrom(addon.DeepCopy())
+			record := currentUpgrade(addon)
+			record.Phase = extensionsv1alpha1.AddonUpgradeRollbackFailed
+			record.Message = message
+			record.CompletionTime = &metav1.Time{Time: time.Now()}
+			if err = stageCtx.reconciler.Status().Patch(ctx, addon, patch); err != nil {
+				stageCtx.setRequeueWithErr(err, "")
+				return
+			}
+			setAddonErrorConditions(ctx, stageCtx, addon, true, true, UpgradeRollbackFailed, message)
+		default:
+			stageCtx.setRequeueAfter(time.Second, "")
+		}
+		return
+	}
+
+	rollbackJob, err := createHelmJobProto(addon)
+	if err != nil {
+		stageCtx.setRequeueWithErr(err, "")
+		return
+	}
+	rollbackJob.ObjectMeta.Name = key.Name
+	rollbackJob.ObjectMeta.Namespace = key.Namespace
+	args := []string{"rollback", "$(RELEASE_NAME)"}
+	if revision := currentUpgrade(addon).FromRevision; revision > 0 {
+		args = append(args, strconv.Itoa(revision))
+	}
+	rollbackJob.Spec.Template.Spec.Containers[0].Args = append(args, "--namespace", "$(RELEASE_NS)", "--wait")
+	if err = stageCtx.reconciler.Create(ctx, rollbackJob); err != nil {
+		stageCtx.setRequeueWithErr(err, "")
+		return
+	}
+	stageCtx.setRequeueAfter(time.Second, "")
+}
+
+func getPrecheckJobName(addon *extensionsv1alpha1.Addon) string {
+	return fmt.Sprintf("precheck-%s-addon", addon.Name)
+}
+
+func getRollbackJobName(addon *extensionsv1alpha1.Addon) string {
+	return fmt.Sprintf("rollback-%s-addon", addon.Name)
+}
+
+// isAddonUpgrade checks whether the version or the charts of an installed addon is changed.
+func isAddonUpgrade(addon *extensionsv1alpha1.Addon) bool {
+	if addon.Spec.Helm == nil || (addon.Status.InstalledVersion == "" && addon.Status.InstalledChartLocationURL == "") {
+		return false
+	}
+	return addon.Spec.Version != addon.Status.InstalledVersion ||
+		addon.Spec.Helm.GetChartLocation() != addon.Status.InstalledChartLocationURL
+}
+
+// currentUpgrade returns the record of the ongoing upgrade to the version in spec, or nil if there is none.
+func currentUpgrade(addon *extensionsv1alpha1.Addon) *extensionsv1alpha1.AddonUpgradeRecord {
+	history := addon.Status.UpgradeHistory
+	if len(history) == 0 || addon.Spec.Helm == nil {
+		return nil
+	}
+	record := &history[len(history)-1]
+	if record.ToVersion != addon.Spec.Version || record.ToChartLocationURL != addon.Spec.Helm.GetChartLocation() {
+		return nil
+	}
+	switch record.Phase {
+	case extensionsv1alpha1.AddonUpgradePrechecking, extensionsv1alpha1.AddonUpgradeUpgrading, extensionsv1alpha1.AddonUpgradeRollingBack:
+		return record
+	}
+	return nil
+}
+
+// appendUpgradeRecord appends a record to the upgrade history, and drops the oldest ones beyond the limit.
+func appendUpgradeRecord(history []extensionsv1alpha1.AddonUpgradeRecord,
+	record extensionsv1alpha1.AddonUpgradeRecord) []extensionsv1alpha1.AddonUpgradeRecord {
+	history = append(history, record)
+	if len(history) > maxAddonUpgradeHistory {
+		history = slices.Clone(history[len(history)-maxAddonUpgradeHistory:])
+	}
+	return history
+}
+
+// setAddonInstalled records the version and the charts in spec as the installed ones.
+func setAddonInstalled(addon *extensionsv1alpha1.Addon) {
+	addon.Status.InstalledVersion = addon.Spec.Version
+	addon.Status.InstalledChartLocationURL = addon.Spec.Helm.GetChartLocation()
+}
+
+// completeUpgrade marks the ongoing upgrade as succeeded once the Helm upgrade is done.
+func completeUpgrade(addon *extensionsv1alpha1.Addon) {
+	record := currentUpgrade(addon)
+	if record == nil || record.Phase != extensionsv1alpha1.AddonUpgradeUpgrading {
+		return
+	}
+	record.Phase = extensionsv1alpha1.AddonUpgradeSucceeded
+	record.CompletionTime = &metav1.Time{Time: time.Now()}
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               extensionsv1alpha1.ConditionTypeUpgraded,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: addon.Generation,
+		Reason:             UpgradeSucceeded,
+		Message:            fmt.Sprintf("Upgraded from version %s to %s", record.FromVersion, record.ToVersion),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// getDeployedRevision returns the revision of the deployed Helm release of the addon, 0 if not found.
+func getDeployedRevision(ctx context.Context, cli client.Client, addon *extensionsv1alpha1.Addon) (int, error) {
+	secrets := &corev1.SecretList{}
+	if err := cli.List(ctx, secrets, client.InNamespace(viper.GetString(constant.CfgKeyCtrlrMgrNS)),
+		client.MatchingLabels{
+			"owner":  "helm",
+			"name":   getHelmReleaseName(addon),
+			"status": "deployed",
+		}); err != nil {
+		return 0, err
+	}
+	revision := 0
+	for _, secret := range secrets.Items {
+		if v, err := strconv.Atoi(secret.Labels["version"]); err == nil && v > revision {
+			revision = v
+		}
+	}
+	return revision, nil
+}
+
+// getJobPodLogs returns the logs of the latest succeeded pod of the job.
+func getJobPodLogs(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon, jobName string) (string, error) {
+	podList := &corev1.PodList{}
+	if err := stageCtx.reconciler.List(ctx, podList,
+		client.InNamespace(viper.GetString(constant.CfgKeyCtrlrMgrNS)),
+		client.MatchingLabels{
+			constant.AddonNameLabelKey:    addon.Name,
+			constant.AppManagedByLabelKey: constant.AppName,
+			"job-name":                    jobName,
+		}); err != nil {
+		return "", err
+	}
+	slices.SortFunc(podList.Items, func(a, b corev1.Pod) int {
+		return b.CreationTimestamp.Compare(a.CreationTimestamp.Time)
+	})
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		clientset, err := corev1client.NewForConfig(stageCtx.reconciler.RestConfig)
+		if err != nil {
+			return "", err
+		}
+		req := clientset.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+			Container: getJobMainContainerName(addon),
+		})
+		data, err := req.DoRaw(ctx)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("no succeeded pod found for job %s", jobName)
+}
+
+// parseAddonManifests parses the ComponentDefinitions and ComponentVersions from the manifests rendered by Helm.
+func parseAddonManifests(manifests string) ([]appsv1.ComponentDefinition, []appsv1.ComponentVersion, error) {
+	var (
+		compDefs     []appsv1.ComponentDefinition
+		compVersions []appsv1.ComponentVersion
+	)
+	for _, doc := range manifestSeparator.Split(manifests, -1) {
+		typeMeta := metav1.TypeMeta{}
+		// the logs of Helm may be mixed in, skip the documents that are not objects
+		if err := yaml.Unmarshal([]byte(doc), &typeMeta); err != nil || typeMeta.APIVersion != appsv1.GroupVersion.String() {
+			continue
+		}
+		switch typeMeta.Kind {
+		case "ComponentDefinition":
+			compDef := appsv1.ComponentDefinition{}
+			if err := yaml.Unmarshal([]byte(doc), &compDef); err != nil {
+				return nil, nil, err
+			}
+			compDefs = append(compDefs, compDef)
+		case "ComponentVersion":
+			compVersion := appsv1.ComponentVersion{}
+			if err := yaml.Unmarshal([]byte(doc), &compVersion); err != nil {
+				return nil, nil, err
+			}
+			compVersions = append(compVersions, compVersion)
+		}
+	}
+	return compDefs, compVersions, nil
+}
+
+// checkUpgradeCompatibility checks whether the Components are still supported by the ComponentDefinitions and
+// ComponentVersions of the new version, and returns the incompatibilities found.
+func checkUpgradeCompatibility(comps []appsv1.Component, clusters map[types.NamespacedName]*appsv1.Cluster,
+	compDefs []appsv1.ComponentDefinition, compVersions []appsv1.ComponentVersion) []string {
+	var issues []string
+	for i := range comps {
+		comp := &comps[i]
+		pattern := compDefPatternOf(comp, clusters)
+		var matched []string
+		for _, compDef := range compDefs {
+			if component.PrefixOrRegexMatched(compDef.Name, pattern) {
+				matched = append(matched, compDef.Name)
+			}
+		}
+		if len(matched) == 0 {
+			issues = append(issues, fmt.Sprintf("component %s/%s: no ComponentDefinition matches %q",
+				comp.Namespace, comp.Name, pattern))
+			continue
+		}
+		serviceVersion := comp.Spec.ServiceVersion
+		if serviceVersion == "" {
+			continue
+		}
+		supported := slices.ContainsFunc(compDefs, func(compDef appsv1.ComponentDefinition) bool {
+			return slices.Contains(matched, compDef.Name) && isServiceVersionSupported(&compDef, compVersions, serviceVersion)
+		})
+		if !supported {
+			issues = append(issues, fmt.Sprintf("component %s/%s: service version %s is not supported by ComponentDefinition %s",
+				comp.Namespace, comp.Name, serviceVersion, strings.Join(matched, ",")))
+		}
+	}
+	slices.Sort(issues)
+	return issues
+}
+
+// compDefPatternOf returns the ComponentDefinition name or regex specified in the Cluster for the Component.
+func compDefPatternOf(comp *appsv1.Component, clusters map[types.NamespacedName]*appsv1.Cluster) string {
+	cluster, ok := clusters[types.NamespacedName{Namespace: comp.Namespace, Name: comp.Labels[constant.AppInstanceLabelKey]}]
+	if !ok {
+		return comp.Spec.CompDef
+	}
+	if shardingName := comp.Labels[constant.KBAppShardingNameLabelKey]; shardingName != "" {
+		for _, sharding := range cluster.Spec.Shardings {
+			if sharding.Name == shardingName && sharding.Template.ComponentDef != "" {
+				return sharding.Template.ComponentDef
+			}
+		}
+		return comp.Spec.CompDef
+	}
+	for _, spec := range cluster.Spec.ComponentSpecs {
+		if spec.Name == comp.Labels[constant.KBAppComponentLabelKey] && spec.ComponentDef != "" {
+			return spec.ComponentDef
+		}
+	}
+	return comp.Spec.CompDef
+}
+
+func isServiceVersionSupported(compDef *appsv1.ComponentDefinition, compVersions []appsv1.ComponentVersion, serviceVersion string) bool {
+	matches := func(provided string) bool {
+		ok, _ := component.CompareServiceVersion(serviceVersion, provided)
+		return ok || serviceVersion == provided
+	}
+	if compDef.Spec.ServiceVersion != "" && matches(compDef.Spec.ServiceVersion) {
+		return true
+	}
+	for _, compVersion := range compVersions {
+		releases := sets.New[string]()
+		for _, rule := range compVersion.Spec.CompatibilityRules {
+			if slices.ContainsFunc(rule.CompDefs, func(pattern string) bool {
+				return component.PrefixOrRegexMatched(compDef.Name, pattern)
+			}) {
+				releases.Insert(rule.Releases...)
+			}
+		}
+		for _, release := range compVersion.Spec.Releases {
+			if releases.Has(release.Name) && matches(release.ServiceVersion) {
+				return true
+			}
+		}
+	}
+	return false
+}
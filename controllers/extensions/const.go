@@ -30,13 +30,25 @@ const (
 	AddonDefaultIsEmpty  = "addons.extensions.kubeblocks.io/default-is-empty"
 	KBVersionValidate    = "addon.kubeblocks.io/kubeblocks-version"
 
+	helmReleaseNameAnnotationKey = "meta.helm.sh/release-name"
+
 	// label keys
 	AddonProvider = "addon.kubeblocks.io/provider"
 	AddonVersion  = "addon.kubeblocks.io/version"
 
 	// condition reasons
-	AddonDisabled = "AddonDisabled"
-	AddonEnabled  = "AddonEnabled"
+	AddonDisabled              = "AddonDisabled"
+	AddonEnabled               = "AddonEnabled"
+	DependencySatisfied        = "DependencySatisfied"
+	DependencyNotFound         = "DependencyNotFound"
+	DependencyVersionUnmatched = "DependencyVersionUnmatched"
+	DependencyNotEnabled       = "DependencyNotEnabled"
+	DependencyCycle            = "DependencyCycle"
+	RequiredByOthers           = "RequiredByOthers"
+	UpgradePrecheckFailed      = "UpgradePrecheckFailed"
+	UpgradeSucceeded           = "UpgradeSucceeded"
+	UpgradeRolledBack          = "UpgradeRolledBack"
+	UpgradeRollbackFailed      = "UpgradeRollbackFailed"
 
 	// event reasons
 	InstallableCheckSkipped         = "InstallableCheckSkipped"
@@ -51,6 +63,9 @@ const (
 	UninstallationFailedLogs        = "UninstallationFailedLogs"
 	AddonRefObjError                = "ReferenceObjectError"
 	AddonCheckError                 = "AddonCheckError"
+	AddonUpgrading                  = "AddonUpgrading"
+	UpgradePrecheckPassed           = "UpgradePrecheckPassed"
+	UpgradeFailed                   = "UpgradeFailed"
 
 	// config keys used in viper
 	maxConcurrentReconcilesKey = "MAXCONCURRENTRECONCILES_ADDON"
@@ -104,6 +104,7 @@ func (r *OpsRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&batchv1.Job{}).
 		Owns(&dpv1alpha1.Restore{}).
 		Owns(&parametersv1alpha1.Parameter{}).
+		Owns(&opsv1alpha1.OpsRequest{}).
 		Complete(r)
 }
 
@@ -126,7 +127,8 @@ func (r *OpsRequestReconciler) fetchOpsRequest(reqCtx intctrlutil.RequestCtx, op
 
 // handleDeletion handles the delete event of the OpsRequest.
 func (r *OpsRequestReconciler) handleDeletion(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
-	if opsRes.OpsRequest.Status.Phase == opsv1alpha1.OpsRunningPhase && !opsRes.Cluster.IsDeleting() {
+	if (opsRes.OpsRequest.Status.Phase == opsv1alpha1.OpsRunningPhase || opsRes.OpsRequest.Status.Phase == opsv1alpha1.OpsRollingBackPhase) &&
+		!opsRes.Cluster.IsDeleting() {
 		return nil, nil
 	}
 	if opsRes.Cluster.IsDeleting() && opsRes.OpsRequest.DeletionTimestamp.IsZero() {
@@ -188,9 +190,9 @@ func (r *OpsRequestReconciler) handleOpsRequestByPhase(reqCtx intctrlutil.Reques
 			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 		}
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
-	case opsv1alpha1.OpsPendingPhase, opsv1alpha1.OpsCreatingPhase:
+	case opsv1alpha1.OpsPendingPhase, opsv1alpha1.OpsScheduledPhase, opsv1alpha1.OpsCreatingPhase:
 		return r.doOpsRequestAction(reqCtx, opsRes)
-	case opsv1alpha1.OpsRunningPhase, opsv1alpha1.OpsCancellingPhase:
+	case opsv1alpha1.OpsRunningPhase, opsv1alpha1.OpsCancellingPhase, opsv1alpha1.OpsRollingBackPhase:
 		return r.reconcileStatusDuringRunningOrCanceling(reqCtx, opsRes)
 	case opsv1alpha1.OpsSucceedPhase:
 		return r.handleSucceedOpsRequest(reqCtx, opsRes.OpsRequest)
@@ -205,10 +207,11 @@ func (r *OpsRequestReconciler) handleCancelSignal(reqCtx intctrlutil.RequestCtx,
 	if !opsRequest.Spec.Cancel {
 		return nil, nil
 	}
-	if opsRequest.IsComplete() || opsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
+	if opsRequest.IsComplete() || opsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase ||
+		opsRequest.Status.Phase == opsv1alpha1.OpsRollingBackPhase {
 		return nil, nil
 	}
-	if opsRequest.Status.Phase == opsv1alpha1.OpsPendingPhase {
+	if opsRequest.Status.Phase == opsv1alpha1.OpsPendingPhase || opsRequest.Status.Phase == opsv1alpha1.OpsScheduledPhase {
 		return &ctrl.Result{}, operations.PatchOpsStatus(reqCtx.Ctx, r.Client, opsRes, opsv1alpha1.OpsCancelledPhase)
 	}
 	opsBehaviour := operations.GetOpsManager().OpsMap[opsRequest.Spec.Type]
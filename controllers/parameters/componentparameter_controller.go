@@ -29,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -48,13 +49,17 @@ import (
 // ComponentParameterReconciler reconciles a ComponentParameter object
 type ComponentParameterReconciler struct {
 	client.Client
-	Scheme   *runtime.Scheme
-	Recorder record.EventRecorder
+	Scheme     *runtime.Scheme
+	Recorder   record.EventRecorder
+	RestConfig *rest.Config
+
+	executor podExecutor
 }
 
 // +kubebuilder:rbac:groups=parameters.kubeblocks.io,resources=componentparameters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=parameters.kubeblocks.io,resources=componentparameters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=parameters.kubeblocks.io,resources=componentparameters/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -85,6 +90,21 @@ func (r *ComponentParameterReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ComponentParameterReconciler) SetupWithManager(mgr ctrl.Manager, multiClusterMgr multicluster.Manager) error {
+	switch {
+	case multiClusterMgr != nil:
+		// the pods are placed in the data-plane k8s clusters, exec through the cluster owning the pod
+		executor, err := newMultiClusterPodExecutor(multiClusterMgr)
+		if err != nil {
+			return err
+		}
+		r.executor = executor
+	case r.RestConfig != nil:
+		executor, err := newPodExecutor(r.RestConfig)
+		if err != nil {
+			return err
+		}
+		r.executor = executor
+	}
 	builder := intctrlutil.NewControllerManagedBy(mgr).
 		For(&parametersv1alpha1.ComponentParameter{}).
 		WithOptions(controller.Options{
@@ -98,6 +118,17 @@ func (r *ComponentParameterReconciler) SetupWithManager(mgr ctrl.Manager, multiC
 }
 
 func (r *ComponentParameterReconciler) reconcile(reqCtx intctrlutil.RequestCtx, componentParameter *parametersv1alpha1.ComponentParameter) (ctrl.Result, error) {
+	if componentParameter.Spec.RollbackToRevision != nil {
+		if err := r.rollback(reqCtx, componentParameter); err != nil {
+			if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+				reqCtx.Recorder.Event(componentParameter, corev1.EventTypeWarning, "RollbackFailed", err.Error())
+				return r.failWithMessage(componentParameter, reqCtx, err.Error())
+			}
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to roll back parameters")
+		}
+		return intctrlutil.Reconciled()
+	}
+
 	tasks := generateReconcileTasks(reqCtx, componentParameter)
 	if len(tasks) == 0 {
 		reqCtx.Log.Info("nothing to reconcile")
@@ -124,12 +155,14 @@ func (r *ComponentParameterReconciler) reconcile(reqCtx intctrlutil.RequestCtx,
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log,
 			errors.Wrap(err, "failed to run parameters reconcile task").Error())
 	}
-	return intctrlutil.Reconciled()
+	return r.detectConfigDrift(reqCtx, taskCtx, fetcherTask)
 }
 
 func (r *ComponentParameterReconciler) failWithInvalidComponent(componentParam *parametersv1alpha1.ComponentParameter, reqCtx intctrlutil.RequestCtx) (ctrl.Result, error) {
-	msg := fmt.Sprintf("not found cluster component: [%s]", componentParam.Spec.ComponentName)
+	return r.failWithMessage(componentParam, reqCtx, fmt.Sprintf("not found cluster component: [%s]", componentParam.Spec.ComponentName))
+}
 
+func (r *ComponentParameterReconciler) failWithMessage(componentParam *parametersv1alpha1.ComponentParameter, reqCtx intctrlutil.RequestCtx, msg string) (ctrl.Result, error) {
 	reqCtx.Log.Error(fmt.Errorf("%s", msg), "")
 	patch := client.MergeFrom(componentParam.DeepCopy())
 	componentParam.Status.Message = msg
@@ -156,6 +189,9 @@ func (r *ComponentParameterReconciler) runTasks(taskCtx *TaskContext, tasks []Ta
 	}
 
 	updateCompParamStatus(&compParameter.Status, errs, compParameter.Generation)
+	if err := r.syncRevisionHistory(taskCtx.ctx, compParameter); err != nil {
+		errs = append(errs, err)
+	}
 	if err := r.Client.Status().Patch(taskCtx.ctx, compParameter, patch); err != nil {
 		errs = append(errs, err)
 	}
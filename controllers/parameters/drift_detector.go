@@ -0,0 +1,651 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/configuration/openapi"
+	"github.com/apecloud/kubeblocks/pkg/configuration/validate"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	ConditionTypeConfigDrifted = "ConfigurationDrifted"
+
+	ReasonConfigDrifted         = "ConfigurationDrifted"
+	ReasonConfigInSync          = "ConfigurationInSync"
+	ReasonConfigDriftRemediated = "ConfigurationDriftRemediated"
+
+	defaultDriftDetectionPeriod = 300 * time.Second
+
+	redactedParameterValue = "******"
+)
+
+// sensitiveParameterKeywords are the keywords to identify the parameters whose values are always redacted.
+var sensitiveParameterKeywords = []string{"password", "secret", "token", "credential"}
+
+// driftDetectionTarget is a configuration file with the drift detection policy defined in its ParametersDefinition.
+type driftDetectionTarget struct {
+	config parametersv1alpha1.ComponentConfigDescription
+	policy *parametersv1alpha1.DriftDetectionPolicy
+	// the flattened parameters schema, used to compare the values by their types
+	schema map[string]apiextv1.JSONSchemaProps
+}
+
+func resolveDriftDetectionTargets(tplName string, configRender *parametersv1alpha1.ParamConfigRenderer, paramsDefs []*parametersv1alpha1.ParametersDefinition) []driftDetectionTarget {
+	if configRender == nil {
+		return nil
+	}
+	var targets []driftDetectionTarget
+	for _, config := range configRender.Spec.Configs {
+		if config.TemplateName != tplName || config.FileFormatConfig == nil {
+			continue
+		}
+		for _, paramsDef := range paramsDefs {
+			if paramsDef.Spec.FileName == config.Name && paramsDef.Spec.DriftDetection != nil {
+				targets = append(targets, driftDetectionTarget{
+					config: config,
+					policy: paramsDef.Spec.DriftDetection,
+					schema: resolveParametersSchema(paramsDef),
+				})
+				break
+			}
+		}
+	}
+	return targets
+}
+
+func resolveParametersSchema(paramsDef *parametersv1alpha1.ParametersDefinition) map[string]apiextv1.JSONSchemaProps {
+	if paramsDef.Spec.ParametersSchema == nil || paramsDef.Spec.ParametersSchema.SchemaInJSON == nil {
+		return nil
+	}
+	schema, ok := paramsDef.Spec.ParametersSchema.SchemaInJSON.Properties[openapi.DefaultSchemaName]
+	if !ok {
+		return nil
+	}
+	return openapi.FlattenSchema(schema).Properties
+}
+
+func driftDetectionPeriod(targets []driftDetectionTarget) time.Duration {
+	var period time.Duration
+	for _, target := range targets {
+		p := defaultDriftDetectionPeriod
+		if target.policy.PeriodSeconds > 0 {
+			p = time.Duration(target.policy.PeriodSeconds) * time.Second
+		}
+		if period == 0 || p < period {
+			period = p
+		}
+	}
+	return period
+}
+
+func isDriftAutoRemediate(targets []driftDetectionTarget) bool {
+	for _, target := range targets {
+		if target.policy.AutoRemediate != nil && *target.policy.AutoRemediate {
+			return true
+		}
+	}
+	return false
+}
+
+// nextDriftDetection returns how long to wait before the next detection, zero means the detection is due.
+func nextDriftDetection(status *parametersv1alpha1.ConfigDriftStatus, revision string, period time.Duration, now time.Time) time.Duration {
+	if status == nil || status.Revision != revision {
+		return 0
+	}
+	if wait := status.LastDetectTime.Add(period).Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+func (r *ComponentParameterReconciler) detectConfigDrift(reqCtx intctrlutil.RequestCtx, taskCtx *TaskContext, resource *Task) (ctrl.Result, error) {
+	compParam := taskCtx.componentParameter
+	if r.executor == nil || taskCtx.configRender == nil || compParam.Status.Phase != parametersv1alpha1.CFinishedPhase {
+		return intctrlutil.Reconciled()
+	}
+
+	var (
+		err          error
+		pods         []*corev1.Pod
+		now          = metav1.Now()
+		requeueAfter time.Duration
+		detecting    bool
+		drifted      []string
+		remediated   []string
+		original     = compParam.DeepCopy()
+	)
+	requeue := func(d time.Duration) {
+		if requeueAfter == 0 || d < requeueAfter {
+			requeueAfter = d
+		}
+	}
+	for _, item := range compParam.Spec.ConfigItemDetails {
+		status := intctrlutil.GetItemStatus(&compParam.Status, item.Name)
+		if item.ConfigSpec == nil || status == nil || status.Phase != parametersv1alpha1.CFinishedPhase {
+			continue
+		}
+		targets := resolveDriftDetectionTargets(item.Name, taskCtx.configRender, taskCtx.paramsDefs)
+		if len(targets) == 0 {
+			status.DriftStatus = nil
+			continue
+		}
+		detecting = true
+		period := driftDetectionPeriod(targets)
+		if wait := nextDriftDetection(status.DriftStatus, status.UpdateRevision, period, now.Time); wait > 0 {
+			if len(status.DriftStatus.Instances) > 0 {
+				drifted = append(drifted, item.Name)
+			}
+			requeue(wait)
+			continue
+		}
+		requeue(period)
+
+		if pods == nil {
+			if pods, err = intctrlutil.ListOwnedPods(reqCtx.Ctx, r.Client, compParam.Namespace, compParam.Spec.ClusterName, compParam.Spec.ComponentName); err != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to list pods for drift detection")
+			}
+		}
+		if err = resource.ConfigMap(item.Name).Complete(); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to get configmap for drift detection")
+		}
+		configMap := resource.ConfigMapObj
+		detector := &driftDetector{
+			ctx:        reqCtx.Ctx,
+			executor:   r.executor,
+			configMap:  configMap,
+			configs:    taskCtx.configRender.Spec.Configs,
+			volumeName: item.ConfigSpec.VolumeName,
+			targets:    targets,
+		}
+		instances := detector.detect(pods)
+		driftStatus := &parametersv1alpha1.ConfigDriftStatus{
+			LastDetectTime: now,
+			Revision:       status.UpdateRevision,
+			Instances:      instances,
+		}
+		if status.DriftStatus != nil {
+			driftStatus.RemediatedRevision = status.DriftStatus.RemediatedRevision
+		}
+		status.DriftStatus = driftStatus
+		if len(instances) > 0 {
+			drifted = append(drifted, item.Name)
+			reqCtx.Recorder.Eventf(compParam, corev1.EventTypeWarning, ReasonConfigDrifted,
+				"configuration drift detected in template %s on instances: %s", item.Name, strings.Join(driftedInstanceNames(instances), ","))
+			if isDriftAutoRemediate(targets) && r.remediateConfigDriftOnce(reqCtx, compParam, configMap, item.Name,
+				taskCtx.configRender.Spec.Configs, pods, driftStatus, now) {
+				remediated = append(remediated, item.Name)
+			}
+		}
+		// the values observed are only used to remediate the drift, the sensitive ones are not exposed in the status
+		redactParameterDrifts(targets, instances)
+	}
+
+	if detecting {
+		setConfigDriftCondition(&compParam.Status, drifted, remediated)
+	} else {
+		meta.RemoveStatusCondition(&compParam.Status.Conditions, ConditionTypeConfigDrifted)
+	}
+	if !reflect.DeepEqual(original.Status, compParam.Status) {
+		if err = r.Client.Status().Patch(reqCtx.Ctx, compParam, client.MergeFrom(original)); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to update drift status")
+		}
+	}
+	if requeueAfter == 0 {
+		return intctrlutil.Reconciled()
+	}
+	return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "wait for the next drift detection")
+}
+
+func setConfigDriftCondition(status *parametersv1alpha1.ComponentParameterStatus, drifted, remediated []string) {
+	condition := metav1.Condition{
+		Type:    ConditionTypeConfigDrifted,
+		Status:  metav1.ConditionFalse,
+		Reason:  ReasonConfigInSync,
+		Message: "the configuration of all instances is in sync with the rendered configuration",
+	}
+	switch {
+	case len(remediated) > 0 && len(remediated) == len(drifted):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonConfigDriftRemediated
+		condition.Message = fmt.Sprintf("the reconfigure policy has been re-triggered for the drifted templates: %s", strings.Join(remediated, ","))
+	case len(drifted) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonConfigDrifted
+		condition.Message = fmt.Sprintf("configuration drift detected in templates: %s", strings.Join(drifted, ","))
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// remediateConfigDriftOnce remediates the drift at most once per revision of the configuration item,
+// the drift which persists after the remediation is left to the user, instead of re-triggering the reconfigure policy forever.
+func (r *ComponentParameterReconciler) remediateConfigDriftOnce(reqCtx intctrlutil.RequestCtx,
+	compParam *parametersv1alpha1.ComponentParameter,
+	configMap *corev1.ConfigMap,
+	tplName string,
+	configs []parametersv1alpha1.ComponentConfigDescription,
+	pods []*corev1.Pod,
+	driftStatus *parametersv1alpha1.ConfigDriftStatus,
+	now metav1.Time) bool {
+	if driftStatus.RemediatedRevision == driftStatus.Revision {
+		reqCtx.Recorder.Eventf(compParam, corev1.EventTypeWarning, ReasonConfigDrifted,
+			"configuration drift in template %s persists after the remediation at revision %s, skip remediating it again", tplName, driftStatus.Revision)
+		return false
+	}
+	if err := r.remediateConfigDrift(reqCtx, configMap, tplName, configs, pods, driftStatus.Instances, now); err != nil {
+		reqCtx.Log.Error(err, "failed to remediate configuration drift", "template", tplName)
+		reqCtx.Recorder.Eventf(compParam, corev1.EventTypeWarning, ReasonConfigDrifted,
+			"failed to remediate configuration drift in template %s: %s", tplName, err.Error())
+		return false
+	}
+	driftStatus.RemediatedRevision = driftStatus.Revision
+	return true
+}
+
+// remediateConfigDrift re-triggers the reconfigure policy for the drifted instances.
+//
+// It resets the last applied configuration of the configmap to the observed one, so that the reconfigure
+// controller computes the patch between the observed and the rendered configuration, and clears the
+// config version labels of the drifted pods, so that the reconfigure policy is applied to them again.
+func (r *ComponentParameterReconciler) remediateConfigDrift(reqCtx intctrlutil.RequestCtx,
+	configMap *corev1.ConfigMap,
+	tplName string,
+	configs []parametersv1alpha1.ComponentConfigDescription,
+	pods []*corev1.Pod,
+	instances []parametersv1alpha1.InstanceDrift,
+	now metav1.Time) error {
+	observed, err := buildObservedConfig(configMap.Data, instances, configs)
+	if err != nil {
+		return err
+	}
+	lastApplied, err := json.Marshal(observed)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(configMap.DeepCopy())
+	if configMap.Annotations == nil {
+		configMap.Annotations = map[string]string{}
+	}
+	configMap.Annotations[constant.LastAppliedConfigAnnotationKey] = string(lastApplied)
+	if err = r.Client.Patch(reqCtx.Ctx, configMap, patch); err != nil {
+		return err
+	}
+
+	versionKeys := []string{configIdentifier(tplName, "")}
+	for _, config := range configs {
+		if config.TemplateName == tplName {
+			versionKeys = append(versionKeys, configIdentifier(tplName, config.Name))
+		}
+	}
+	for i := range instances {
+		if len(instances[i].Parameters) == 0 {
+			continue
+		}
+		for _, pod := range pods {
+			if pod.Name != instances[i].Name {
+				continue
+			}
+			podPatch := client.MergeFrom(pod.DeepCopy())
+			for _, key := range versionKeys {
+				delete(pod.Labels, key)
+			}
+			if err = r.Client.Patch(reqCtx.Ctx, pod, podPatch); err != nil {
+				return err
+			}
+			instances[i].RemediateTime = &now
+		}
+	}
+	return nil
+}
+
+// buildObservedConfig applies the effective values of the drifted parameters to the rendered configuration.
+func buildObservedConfig(rendered map[string]string, instances []parametersv1alpha1.InstanceDrift, configs []parametersv1alpha1.ComponentConfigDescription) (map[string]string, error) {
+	updated := make(map[string]map[string]*string)
+	for _, instance := range instances {
+		for _, param := range instance.Parameters {
+			if _, ok := updated[param.FileName]; !ok {
+				updated[param.FileName] = make(map[string]*string)
+			}
+			if _, ok := updated[param.FileName][param.Name]; !ok {
+				updated[param.FileName][param.Name] = param.Actual
+			}
+		}
+	}
+
+	observed := make(map[string]string, len(rendered))
+	for file, content := range rendered {
+		observed[file] = content
+		params, ok := updated[file]
+		if !ok {
+			continue
+		}
+		formatConfig := core.ResolveConfigFormat(configs, file)
+		if formatConfig == nil {
+			continue
+		}
+		merged, err := core.ApplyConfigPatch([]byte(content), params, formatConfig, nil)
+		if err != nil {
+			return nil, err
+		}
+		observed[file] = merged
+	}
+	return observed, nil
+}
+
+type driftDetector struct {
+	ctx        context.Context
+	executor   podExecutor
+	configMap  *corev1.ConfigMap
+	configs    []parametersv1alpha1.ComponentConfigDescription
+	volumeName string
+	targets    []driftDetectionTarget
+}
+
+func (d *driftDetector) detect(pods []*corev1.Pod) []parametersv1alpha1.InstanceDrift {
+	var instances []parametersv1alpha1.InstanceDrift
+	for _, pod := range pods {
+		if !intctrlutil.IsPodReady(pod) {
+			continue
+		}
+		instance := parametersv1alpha1.InstanceDrift{Name: pod.Name}
+		params, err := d.detectPod(pod)
+		if err != nil {
+			instance.Message = err.Error()
+		}
+		instance.Parameters = params
+		if len(instance.Parameters) > 0 || instance.Message != "" {
+			instances = append(instances, instance)
+		}
+	}
+	return instances
+}
+
+func (d *driftDetector) detectPod(pod *corev1.Pod) ([]parametersv1alpha1.ParameterDrift, error) {
+	var drifts []parametersv1alpha1.ParameterDrift
+	for _, target := range d.targets {
+		fileName := target.config.Name
+		container, filePath := resolveConfigFilePath(pod, d.volumeName, fileName)
+		if container == "" {
+			return drifts, fmt.Errorf("config file %s is not mounted in any container", fileName)
+		}
+		configRender := parametersv1alpha1.ParamConfigRendererSpec{Configs: d.configs}
+		expected, err := core.TransformConfigFileToKeyValueMap(fileName, configRender, []byte(d.configMap.Data[fileName]))
+		if err != nil {
+			return drifts, err
+		}
+		content, err := d.executor.Exec(d.ctx, pod, container, []string{"cat", filePath})
+		if err != nil {
+			return drifts, fmt.Errorf("failed to read config file %s: %s", filePath, err.Error())
+		}
+		if string(content) != d.configMap.Data[fileName] {
+			actual, err := core.TransformConfigFileToKeyValueMap(fileName, configRender, content)
+			if err != nil {
+				return drifts, err
+			}
+			drifts = append(drifts, compareFileParameters(fileName, expected, actual, target.schema)...)
+		}
+		if target.policy.RuntimeQuery == nil {
+			continue
+		}
+		queryContainer := target.policy.RuntimeQuery.Container
+		if queryContainer == "" {
+			queryContainer = container
+		}
+		output, err := d.executor.Exec(d.ctx, pod, queryContainer, target.policy.RuntimeQuery.Command)
+		if err != nil {
+			return drifts, fmt.Errorf("failed to query runtime parameters: %s", err.Error())
+		}
+		drifts = append(drifts, compareRuntimeParameters(fileName, expected, parseRuntimeValues(output), target.schema)...)
+	}
+	return drifts, nil
+}
+
+// resolveConfigFilePath returns the container which mounts the config volume and the path of the file in it.
+func resolveConfigFilePath(pod *corev1.Pod, volumeName, fileName string) (string, string) {
+	for _, container := range pod.Spec.Containers {
+		for _, mount := range container.VolumeMounts {
+			if mount.Name != volumeName {
+				continue
+			}
+			switch mount.SubPath {
+			case "":
+				return container.Name, filepath.Join(mount.MountPath, fileName)
+			case fileName:
+				return container.Name, mount.MountPath
+			}
+		}
+	}
+	return "", ""
+}
+
+// compareFileParameters compares the parameters parsed from the config file inside the pod with the rendered ones.
+func compareFileParameters(fileName string, expected, actual map[string]string, schema map[string]apiextv1.JSONSchemaProps) []parametersv1alpha1.ParameterDrift {
+	var drifts []parametersv1alpha1.ParameterDrift
+	for name, value := range expected {
+		v, ok := actual[name]
+		switch {
+		case !ok:
+			drifts = append(drifts, newParameterDrift(fileName, name, parametersv1alpha1.FileDriftSource, &value, nil))
+		case v != value && !equalParameterValues(lookupParameterSchema(schema, name), value, v):
+			drifts = append(drifts, newParameterDrift(fileName, name, parametersv1alpha1.FileDriftSource, &value, &v))
+		}
+	}
+	for name, value := range actual {
+		if _, ok := expected[name]; !ok {
+			drifts = append(drifts, newParameterDrift(fileName, name, parametersv1alpha1.FileDriftSource, nil, &value))
+		}
+	}
+	sortParameterDrifts(drifts)
+	return drifts
+}
+
+// compareRuntimeParameters compares the live values of the rendered parameters with the rendered ones.
+// Parameters not returned by the runtime query are ignored.
+func compareRuntimeParameters(fileName string, expected, runtime map[string]string, schema map[string]apiextv1.JSONSchemaProps) []parametersv1alpha1.ParameterDrift {
+	normalized := make(map[string]string, len(runtime))
+	for name, value := range runtime {
+		normalized[normalizeParameterName(name)] = value
+	}
+	var drifts []parametersv1alpha1.ParameterDrift
+	for name, value := range expected {
+		v, ok := normalized[normalizeParameterName(name)]
+		if !ok || equalParameterValues(lookupParameterSchema(schema, name), value, v) {
+			continue
+		}
+		drifts = append(drifts, newParameterDrift(fileName, name, parametersv1alpha1.RuntimeDriftSource, &value, &v))
+	}
+	sortParameterDrifts(drifts)
+	return drifts
+}
+
+// parseRuntimeValues parses the output of the runtime query, one `name=value` pair per line.
+func parseRuntimeValues(output []byte) map[string]string {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			continue
+		}
+		values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return values
+}
+
+func normalizeParameterName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "-", "_")
+}
+
+func normalizeParameterValue(value string) string {
+	return strings.Trim(strings.TrimSpace(value), `"'`)
+}
+
+func lookupParameterSchema(schema map[string]apiextv1.JSONSchemaProps, name string) *apiextv1.JSONSchemaProps {
+	if prop, ok := schema[name]; ok {
+		return &prop
+	}
+	for key, prop := range schema {
+		if normalizeParameterName(key) == normalizeParameterName(name) {
+			return &prop
+		}
+	}
+	return nil
+}
+
+// equalParameterValues compares the values by the type of the parameter defined in the schema,
+// e.g. `ON` equals `1` for a boolean, and `128M` equals `134217728` for an integer.
+func equalParameterValues(schema *apiextv1.JSONSchemaProps, expected, actual string) bool {
+	expected, actual = normalizeParameterValue(expected), normalizeParameterValue(actual)
+	if strings.EqualFold(expected, actual) {
+		return true
+	}
+	if schema == nil {
+		return false
+	}
+	switch {
+	case schema.Type == "boolean" || isBooleanEnum(schema.Enum):
+		e, ok1 := parseBooleanParameter(expected)
+		a, ok2 := parseBooleanParameter(actual)
+		return ok1 && ok2 && e == a
+	case schema.Type == "integer" || schema.Type == "number" || schema.Type == "":
+		// the untyped parameters are the union of types, e.g. an integer or a size string
+		if e, err := strconv.ParseFloat(expected, 64); err == nil {
+			if a, err := strconv.ParseFloat(actual, 64); err == nil {
+				return e == a
+			}
+		}
+		e, err1 := validate.ParseSize(expected)
+		a, err2 := validate.ParseSize(actual)
+		return err1 == nil && err2 == nil && e == a
+	default:
+		return false
+	}
+}
+
+func parseBooleanParameter(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "on", "true", "yes", "1":
+		return true, true
+	case "off", "false", "no", "0":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+func isBooleanEnum(enum []apiextv1.JSON) bool {
+	if len(enum) == 0 {
+		return false
+	}
+	for _, v := range enum {
+		if _, ok := parseBooleanParameter(strings.Trim(string(v.Raw), `"`)); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// redactParameterDrifts redacts the values of the sensitive parameters.
+func redactParameterDrifts(targets []driftDetectionTarget, instances []parametersv1alpha1.InstanceDrift) {
+	redacted := redactedParameterValue
+	for i := range instances {
+		for j := range instances[i].Parameters {
+			param := &instances[i].Parameters[j]
+			if !isSensitiveParameter(targets, param.FileName, param.Name) {
+				continue
+			}
+			if param.Expected != nil {
+				param.Expected = &redacted
+			}
+			if param.Actual != nil {
+				param.Actual = &redacted
+			}
+		}
+	}
+}
+
+func isSensitiveParameter(targets []driftDetectionTarget, fileName, name string) bool {
+	for _, keyword := range sensitiveParameterKeywords {
+		if strings.Contains(strings.ToLower(name), keyword) {
+			return true
+		}
+	}
+	for _, target := range targets {
+		if target.config.Name != fileName {
+			continue
+		}
+		for _, sensitive := range target.policy.SensitiveParameters {
+			if normalizeParameterName(sensitive) == normalizeParameterName(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func newParameterDrift(fileName, name string, source parametersv1alpha1.DriftSource, expected, actual *string) parametersv1alpha1.ParameterDrift {
+	return parametersv1alpha1.ParameterDrift{
+		FileName: fileName,
+		Name:     name,
+		Source:   source,
+		Expected: expected,
+		Actual:   actual,
+	}
+}
+
+func sortParameterDrifts(drifts []parametersv1alpha1.ParameterDrift) {
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Name < drifts[j].Name
+	})
+}
+
+func driftedInstanceNames(instances []parametersv1alpha1.InstanceDrift) []string {
+	names := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		names = append(names, instance.Name)
+	}
+	return names
+}
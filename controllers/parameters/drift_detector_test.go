@@ -0,0 +1,480 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+type fakePodExecutor struct {
+	outputs map[string]string
+}
+
+func (e *fakePodExecutor) Exec(_ context.Context, pod *corev1.Pod, container string, command []string) ([]byte, error) {
+	key := fmt.Sprintf("%s/%s/%v", pod.Name, container, command)
+	output, ok := e.outputs[key]
+	if !ok {
+		return nil, fmt.Errorf("command not found: %s", key)
+	}
+	return []byte(output), nil
+}
+
+func newDriftTestPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:         "mysql",
+				VolumeMounts: []corev1.VolumeMount{{Name: "mysql-config", MountPath: "/etc/mysql"}},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func TestCompareFileParameters(t *testing.T) {
+	expected := map[string]string{"max_connections": "1000", "innodb_buffer_pool_size": "1G", "log_bin": "ON"}
+	actual := map[string]string{"max_connections": "500", "log_bin": "ON", "sql_mode": "STRICT"}
+
+	drifts := compareFileParameters("my.cnf", expected, actual, nil)
+	require.Len(t, drifts, 3)
+	assert.Equal(t, "innodb_buffer_pool_size", drifts[0].Name)
+	assert.Nil(t, drifts[0].Actual)
+	assert.Equal(t, "max_connections", drifts[1].Name)
+	assert.Equal(t, "1000", *drifts[1].Expected)
+	assert.Equal(t, "500", *drifts[1].Actual)
+	assert.Equal(t, "sql_mode", drifts[2].Name)
+	assert.Nil(t, drifts[2].Expected)
+	for _, drift := range drifts {
+		assert.Equal(t, "my.cnf", drift.FileName)
+		assert.Equal(t, parametersv1alpha1.FileDriftSource, drift.Source)
+	}
+
+	assert.Empty(t, compareFileParameters("my.cnf", expected, expected, nil))
+}
+
+func TestCompareRuntimeParameters(t *testing.T) {
+	expected := map[string]string{"max-connections": "1000", "log_bin": "ON", "sql_mode": "'STRICT'"}
+	runtime := map[string]string{"max_connections": "2000", "LOG_BIN": "on", "sql_mode": "STRICT", "version": "8.0.30"}
+
+	drifts := compareRuntimeParameters("my.cnf", expected, runtime, nil)
+	require.Len(t, drifts, 1)
+	assert.Equal(t, "max-connections", drifts[0].Name)
+	assert.Equal(t, "1000", *drifts[0].Expected)
+	assert.Equal(t, "2000", *drifts[0].Actual)
+	assert.Equal(t, parametersv1alpha1.RuntimeDriftSource, drifts[0].Source)
+}
+
+func TestEqualParameterValues(t *testing.T) {
+	schema := map[string]apiextv1.JSONSchemaProps{
+		"innodb_buffer_pool_size": {Type: "integer"},
+		"log_bin":                 {Type: "string", Enum: []apiextv1.JSON{{Raw: []byte(`"ON"`)}, {Raw: []byte(`"OFF"`)}, {Raw: []byte(`"1"`)}, {Raw: []byte(`"0"`)}}},
+		"read_only":               {Type: "boolean"},
+		"long_query_time":         {Type: "number"},
+		"sql_mode":                {Type: "string"},
+		"max_allowed_packet":      {},
+	}
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		equal    bool
+	}{
+		{"innodb_buffer_pool_size", "128M", "134217728", true},
+		{"innodb_buffer_pool_size", "128M", "134217729", false},
+		{"log_bin", "ON", "1", true},
+		{"log_bin", "'OFF'", "0", true},
+		{"log_bin", "ON", "0", false},
+		{"read_only", "true", "on", true},
+		{"read-only", "false", "ON", false},
+		{"long_query_time", "10", "10.000000", true},
+		{"sql_mode", "STRICT", "strict", true},
+		{"sql_mode", "1", "ON", false},
+		{"max_allowed_packet", "64M", "67108864", true},
+		{"unknown", "128M", "134217728", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.equal, equalParameterValues(lookupParameterSchema(schema, tt.name), tt.expected, tt.actual), "%s: %s vs %s", tt.name, tt.expected, tt.actual)
+	}
+
+	drifts := compareRuntimeParameters("my.cnf", map[string]string{"innodb_buffer_pool_size": "128M", "log_bin": "ON"},
+		map[string]string{"innodb_buffer_pool_size": "134217728", "log_bin": "1"}, schema)
+	assert.Empty(t, drifts)
+}
+
+func TestRedactParameterDrifts(t *testing.T) {
+	targets := []driftDetectionTarget{{
+		config: parametersv1alpha1.ComponentConfigDescription{Name: "my.cnf"},
+		policy: &parametersv1alpha1.DriftDetectionPolicy{SensitiveParameters: []string{"ssl-key"}},
+	}}
+	value := "value"
+	instances := []parametersv1alpha1.InstanceDrift{{
+		Name: "pod-0",
+		Parameters: []parametersv1alpha1.ParameterDrift{
+			newParameterDrift("my.cnf", "ssl_key", parametersv1alpha1.FileDriftSource, &value, nil),
+			newParameterDrift("my.cnf", "replication_password", parametersv1alpha1.RuntimeDriftSource, &value, &value),
+			newParameterDrift("my.cnf", "max_connections", parametersv1alpha1.RuntimeDriftSource, &value, &value),
+			newParameterDrift("other.cnf", "ssl_key", parametersv1alpha1.FileDriftSource, &value, &value),
+		},
+	}}
+	redactParameterDrifts(targets, instances)
+
+	params := instances[0].Parameters
+	assert.Equal(t, redactedParameterValue, *params[0].Expected)
+	assert.Nil(t, params[0].Actual)
+	assert.Equal(t, redactedParameterValue, *params[1].Expected)
+	assert.Equal(t, redactedParameterValue, *params[1].Actual)
+	assert.Equal(t, "value", *params[2].Expected)
+	assert.Equal(t, "value", *params[2].Actual)
+	assert.Equal(t, "value", *params[3].Actual)
+}
+
+func TestRemediateConfigDriftOnce(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, parametersv1alpha1.AddToScheme(scheme))
+
+	configs := []parametersv1alpha1.ComponentConfigDescription{{
+		Name:         "my.cnf",
+		TemplateName: "mysql-config",
+		FileFormatConfig: &parametersv1alpha1.FileFormatConfig{
+			Format: parametersv1alpha1.Ini,
+			FormatterAction: parametersv1alpha1.FormatterAction{
+				IniConfig: &parametersv1alpha1.IniConfig{SectionName: "mysqld"},
+			},
+		},
+	}}
+	versionKey := configIdentifier("mysql-config", "")
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql-config", Namespace: "default"},
+		Data:       map[string]string{"my.cnf": "[mysqld]\nmax_connections=1000\n"},
+	}
+	pod := newDriftTestPod("pod-0")
+	pod.Labels = map[string]string{versionKey: "1"}
+	compParam := &parametersv1alpha1.ComponentParameter{ObjectMeta: metav1.ObjectMeta{Name: "mysql-config", Namespace: "default"}}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap, pod, compParam).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &ComponentParameterReconciler{Client: cli}
+	reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard(), Recorder: recorder}
+
+	actual := "500"
+	newDriftStatus := func(revision, remediated string) *parametersv1alpha1.ConfigDriftStatus {
+		return &parametersv1alpha1.ConfigDriftStatus{
+			Revision:           revision,
+			RemediatedRevision: remediated,
+			Instances: []parametersv1alpha1.InstanceDrift{{
+				Name:       "pod-0",
+				Parameters: []parametersv1alpha1.ParameterDrift{newParameterDrift("my.cnf", "max_connections", parametersv1alpha1.RuntimeDriftSource, nil, &actual)},
+			}},
+		}
+	}
+	remediate := func(status *parametersv1alpha1.ConfigDriftStatus) bool {
+		current := &corev1.Pod{}
+		require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(pod), current))
+		return r.remediateConfigDriftOnce(reqCtx, compParam, configMap, "mysql-config", configs, []*corev1.Pod{current}, status, metav1.Now())
+	}
+
+	status := newDriftStatus("2", "")
+	assert.True(t, remediate(status))
+	assert.Equal(t, "2", status.RemediatedRevision)
+	assert.NotNil(t, status.Instances[0].RemediateTime)
+	remediatedPod := &corev1.Pod{}
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(pod), remediatedPod))
+	assert.NotContains(t, remediatedPod.Labels, versionKey)
+
+	// the drift persists after the remediation within the same revision
+	status = newDriftStatus("2", "2")
+	assert.False(t, remediate(status))
+	assert.Nil(t, status.Instances[0].RemediateTime)
+	assert.Contains(t, <-recorder.Events, "persists after the remediation")
+
+	// remediate again for the new revision
+	status = newDriftStatus("3", "2")
+	assert.True(t, remediate(status))
+	assert.Equal(t, "3", status.RemediatedRevision)
+}
+
+func TestParseRuntimeValues(t *testing.T) {
+	output := "# variables\nmax_connections=1000\n\n sql_mode = STRICT_TRANS_TABLES,NO_ZERO_DATE \ninvalid line\n=empty\n"
+	assert.Equal(t, map[string]string{
+		"max_connections": "1000",
+		"sql_mode":        "STRICT_TRANS_TABLES,NO_ZERO_DATE",
+	}, parseRuntimeValues([]byte(output)))
+}
+
+func TestNextDriftDetection(t *testing.T) {
+	now := time.Now()
+	period := 5 * time.Minute
+	assert.Equal(t, time.Duration(0), nextDriftDetection(nil, "1", period, now))
+
+	status := &parametersv1alpha1.ConfigDriftStatus{
+		LastDetectTime: metav1.NewTime(now.Add(-time.Minute)),
+		Revision:       "1",
+	}
+	assert.Equal(t, 4*time.Minute, nextDriftDetection(status, "1", period, now))
+	assert.Equal(t, time.Duration(0), nextDriftDetection(status, "2", period, now))
+
+	status.LastDetectTime = metav1.NewTime(now.Add(-10 * time.Minute))
+	assert.Equal(t, time.Duration(0), nextDriftDetection(status, "1", period, now))
+}
+
+func TestDriftDetectionPolicy(t *testing.T) {
+	configRender := &parametersv1alpha1.ParamConfigRenderer{
+		Spec: parametersv1alpha1.ParamConfigRendererSpec{
+			Configs: []parametersv1alpha1.ComponentConfigDescription{
+				{Name: "my.cnf", TemplateName: "mysql-config", FileFormatConfig: &parametersv1alpha1.FileFormatConfig{Format: parametersv1alpha1.Ini}},
+				{Name: "extra.cnf", TemplateName: "mysql-config", FileFormatConfig: &parametersv1alpha1.FileFormatConfig{Format: parametersv1alpha1.Ini}},
+				{Name: "other.cnf", TemplateName: "other-config", FileFormatConfig: &parametersv1alpha1.FileFormatConfig{Format: parametersv1alpha1.Ini}},
+			},
+		},
+	}
+	paramsDefs := []*parametersv1alpha1.ParametersDefinition{
+		{Spec: parametersv1alpha1.ParametersDefinitionSpec{FileName: "my.cnf", DriftDetection: &parametersv1alpha1.DriftDetectionPolicy{PeriodSeconds: 600}}},
+		{Spec: parametersv1alpha1.ParametersDefinitionSpec{FileName: "extra.cnf", DriftDetection: &parametersv1alpha1.DriftDetectionPolicy{AutoRemediate: pointer.Bool(true)}}},
+		{Spec: parametersv1alpha1.ParametersDefinitionSpec{FileName: "other.cnf"}},
+	}
+
+	targets := resolveDriftDetectionTargets("mysql-config", configRender, paramsDefs)
+	require.Len(t, targets, 2)
+	assert.Equal(t, defaultDriftDetectionPeriod, driftDetectionPeriod(targets))
+	assert.True(t, isDriftAutoRemediate(targets))
+	assert.Equal(t, 10*time.Minute, driftDetectionPeriod(targets[:1]))
+	assert.False(t, isDriftAutoRemediate(targets[:1]))
+
+	assert.Empty(t, resolveDriftDetectionTargets("other-config", configRender, paramsDefs))
+	assert.Empty(t, resolveDriftDetectionTargets("mysql-config", nil, paramsDefs))
+}
+
+func TestResolveConfigFilePath(t *testing.T) {
+	pod := newDriftTestPod("pod-0")
+	container, path := resolveConfigFilePath(pod, "mysql-config", "my.cnf")
+	assert.Equal(t, "mysql", container)
+	assert.Equal(t, "/etc/mysql/my.cnf", path)
+
+	pod.Spec.Containers[0].VolumeMounts[0] = corev1.VolumeMount{Name: "mysql-config", MountPath: "/etc/my.cnf", SubPath: "my.cnf"}
+	container, path = resolveConfigFilePath(pod, "mysql-config", "my.cnf")
+	assert.Equal(t, "mysql", container)
+	assert.Equal(t, "/etc/my.cnf", path)
+
+	container, _ = resolveConfigFilePath(pod, "mysql-config", "extra.cnf")
+	assert.Empty(t, container)
+}
+
+func TestDriftDetectorDetect(t *testing.T) {
+	configs := []parametersv1alpha1.ComponentConfigDescription{{
+		Name:         "my.cnf",
+		TemplateName: "mysql-config",
+		FileFormatConfig: &parametersv1alpha1.FileFormatConfig{
+			Format: parametersv1alpha1.Ini,
+			FormatterAction: parametersv1alpha1.FormatterAction{
+				IniConfig: &parametersv1alpha1.IniConfig{SectionName: "mysqld"},
+			},
+		},
+	}}
+	rendered := "[mysqld]\nmax_connections=1000\nlog_bin=ON\n"
+	query := []string{"mysql", "-e", "show variables"}
+	detector := &driftDetector{
+		ctx:        context.Background(),
+		configMap:  &corev1.ConfigMap{Data: map[string]string{"my.cnf": rendered}},
+		configs:    configs,
+		volumeName: "mysql-config",
+		targets: []driftDetectionTarget{{
+			config: configs[0],
+			policy: &parametersv1alpha1.DriftDetectionPolicy{
+				RuntimeQuery: &parametersv1alpha1.RuntimeQueryAction{Command: query},
+			},
+		}},
+		executor: &fakePodExecutor{outputs: map[string]string{
+			"pod-0/mysql/[cat /etc/mysql/my.cnf]":                 rendered,
+			fmt.Sprintf("pod-0/mysql/%v", query):                  "max_connections=1000\nlog_bin=ON\n",
+			"pod-1/mysql/[cat /etc/mysql/my.cnf]":                 "[mysqld]\nmax_connections=500\nlog_bin=ON\n",
+			fmt.Sprintf("pod-1/mysql/%v", query):                  "max_connections=500\nlog_bin=ON\n",
+			"pod-2/mysql/[cat /etc/mysql/my.cnf]":                 rendered,
+			fmt.Sprintf("pod-2/mysql/%v", query):                  "max_connections=1000\nlog_bin=OFF\n",
+			"pod-not-ready/mysql/[cat /etc/mysql/my.cnf]":         "[mysqld]\n",
+			fmt.Sprintf("pod-not-ready/mysql/%v", query):          "",
+			fmt.Sprintf("pod-3/mysql/%v", []string{"unexpected"}): "",
+		}},
+	}
+	notReady := newDriftTestPod("pod-not-ready")
+	notReady.Status.Conditions = nil
+	pods := []*corev1.Pod{newDriftTestPod("pod-0"), newDriftTestPod("pod-1"), newDriftTestPod("pod-2"), newDriftTestPod("pod-3"), notReady}
+
+	instances := detector.detect(pods)
+	require.Len(t, instances, 3)
+
+	assert.Equal(t, "pod-1", instances[0].Name)
+	require.Len(t, instances[0].Parameters, 2)
+	assert.Equal(t, parametersv1alpha1.FileDriftSource, instances[0].Parameters[0].Source)
+	assert.Equal(t, "max_connections", instances[0].Parameters[0].Name)
+	assert.Equal(t, "500", *instances[0].Parameters[0].Actual)
+	assert.Equal(t, parametersv1alpha1.RuntimeDriftSource, instances[0].Parameters[1].Source)
+
+	assert.Equal(t, "pod-2", instances[1].Name)
+	require.Len(t, instances[1].Parameters, 1)
+	assert.Equal(t, "log_bin", instances[1].Parameters[0].Name)
+	assert.Equal(t, parametersv1alpha1.RuntimeDriftSource, instances[1].Parameters[0].Source)
+	assert.Equal(t, "OFF", *instances[1].Parameters[0].Actual)
+
+	assert.Equal(t, "pod-3", instances[2].Name)
+	assert.Empty(t, instances[2].Parameters)
+	assert.Contains(t, instances[2].Message, "failed to read config file")
+
+	observed, err := buildObservedConfig(detector.configMap.Data, instances, configs)
+	require.NoError(t, err)
+	params, err := core.TransformConfigFileToKeyValueMap("my.cnf", parametersv1alpha1.ParamConfigRendererSpec{Configs: configs}, []byte(observed["my.cnf"]))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"max_connections": "500", "log_bin": "OFF"}, params)
+}
+
+func TestDetectConfigDriftWithoutTargets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, parametersv1alpha1.AddToScheme(scheme))
+
+	newComponentParameter := func(conditions ...metav1.Condition) *parametersv1alpha1.ComponentParameter {
+		return &parametersv1alpha1.ComponentParameter{
+			ObjectMeta: metav1.ObjectMeta{Name: "mysql-config", Namespace: "default"},
+			Spec: parametersv1alpha1.ComponentParameterSpec{
+				ClusterName:   "mysql",
+				ComponentName: "mysql",
+				ConfigItemDetails: []parametersv1alpha1.ConfigTemplateItemDetail{{
+					Name:       "mysql-config",
+					ConfigSpec: &appsv1.ComponentFileTemplate{Name: "mysql-config", VolumeName: "mysql-config"},
+				}},
+			},
+			Status: parametersv1alpha1.ComponentParameterStatus{
+				Phase:      parametersv1alpha1.CFinishedPhase,
+				Conditions: conditions,
+				ConfigurationItemStatus: []parametersv1alpha1.ConfigTemplateItemDetailStatus{{
+					Name:  "mysql-config",
+					Phase: parametersv1alpha1.CFinishedPhase,
+				}},
+			},
+		}
+	}
+	detect := func(compParam *parametersv1alpha1.ComponentParameter) int {
+		patches := 0
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(compParam).
+			WithStatusSubresource(compParam).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					patches++
+					return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build()
+		r := &ComponentParameterReconciler{Client: cli, executor: &fakePodExecutor{}}
+		taskCtx := &TaskContext{
+			componentParameter: compParam,
+			configRender:       &parametersv1alpha1.ParamConfigRenderer{},
+		}
+		reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+		_, err := r.detectConfigDrift(reqCtx, taskCtx, nil)
+		require.NoError(t, err)
+		return patches
+	}
+
+	compParam := newComponentParameter()
+	assert.Equal(t, 0, detect(compParam))
+	assert.Nil(t, meta.FindStatusCondition(compParam.Status.Conditions, ConditionTypeConfigDrifted))
+
+	// the condition left by the removed drift detection policy should be cleared
+	compParam = newComponentParameter(metav1.Condition{
+		Type:               ConditionTypeConfigDrifted,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonConfigInSync,
+		LastTransitionTime: metav1.Now(),
+	})
+	assert.Equal(t, 1, detect(compParam))
+	assert.Nil(t, meta.FindStatusCondition(compParam.Status.Conditions, ConditionTypeConfigDrifted))
+}
+
+type fakeMultiClusterManager struct {
+	multicluster.Manager
+	clients map[string]client.Client
+}
+
+func (m *fakeMultiClusterManager) IsContextAvailable(context string) bool {
+	_, ok := m.clients[context]
+	return ok
+}
+
+func (m *fakeMultiClusterManager) GetContextClient(context string) (client.Client, *rest.Config) {
+	return m.clients[context], nil
+}
+
+func TestMultiClusterPodExecutor(t *testing.T) {
+	pod := newDriftTestPod("pod-0")
+	pod.UID = "uid-0"
+	stale := pod.DeepCopy()
+	stale.UID = "uid-stale"
+
+	executor := &multiClusterPodExecutor{
+		multiClusterMgr: &fakeMultiClusterManager{clients: map[string]client.Client{
+			"dp-1": fake.NewClientBuilder().WithObjects(stale).Build(),
+			"dp-2": fake.NewClientBuilder().WithObjects(pod.DeepCopy()).Build(),
+		}},
+		executors: map[string]podExecutor{
+			"dp-1": &fakePodExecutor{outputs: map[string]string{"pod-0/mysql/[cat my.cnf]": "dp-1"}},
+			"dp-2": &fakePodExecutor{outputs: map[string]string{"pod-0/mysql/[cat my.cnf]": "dp-2"}},
+			"dp-3": &fakePodExecutor{},
+		},
+	}
+
+	// the pod with the same name in another data-plane k8s cluster should be skipped
+	output, err := executor.Exec(context.Background(), pod, "mysql", []string{"cat", "my.cnf"})
+	require.NoError(t, err)
+	assert.Equal(t, "dp-2", string(output))
+
+	missing := newDriftTestPod("pod-1")
+	_, err = executor.Exec(context.Background(), missing, "mysql", []string{"cat", "my.cnf"})
+	assert.Error(t, err)
+}
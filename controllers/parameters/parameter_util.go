@@ -134,6 +134,10 @@ func updateParameters(rctx *ReconcileContext, parameter *parametersv1alpha1.Para
 	}
 
 	if updated && !reflect.DeepEqual(patch, rctx.ComponentParameterObj) {
+		setConfigChangeRequest(rctx.ComponentParameterObj, configChangeRequest{
+			Revision:    rctx.ComponentParameterObj.Generation + 1,
+			RequestedBy: resolveParameterRequester(parameter),
+		})
 		return rctx.Client.Patch(rctx.Ctx, rctx.ComponentParameterObj, client.MergeFrom(patch))
 	}
 	return nil
@@ -174,6 +178,9 @@ func classifyParameters(updatedParameters parametersv1alpha1.ComponentParameters
 			if err := validateComponentParameter(toArray(rctx.ParametersDefs), configDescs, m); err != nil {
 				return intctrlutil.NewFatalError(err.Error())
 			}
+			if err := validateParameterConstraints(rctx, configmaps[tpl], configDescs, m); err != nil {
+				return intctrlutil.NewFatalError(err.Error())
+			}
 			safeUpdateComponentParameterStatus(&parameter.Status, rctx.ComponentName, tpl, m)
 		}
 		return nil
@@ -188,6 +195,18 @@ func validateComponentParameter(parametersDefs []*parametersv1alpha1.ParametersD
 	return err
 }
 
+func validateParameterConstraints(rctx *ReconcileContext, configMap *corev1.ConfigMap, descs []parametersv1alpha1.ComponentConfigDescription, parameters map[string]*parametersv1alpha1.ParametersInFile) error {
+	if configMap == nil || rctx.BuiltinComponent == nil {
+		return nil
+	}
+	return configctrl.ValidateParameterConstraints(configMap.Data,
+		configctrl.DerefMapValues(parameters),
+		toArray(rctx.ParametersDefs),
+		descs,
+		rctx.BuiltinComponent.Replicas,
+		rctx.BuiltinComponent.Resources)
+}
+
 func resolveBaseData(updatedParameters map[string]*parametersv1alpha1.ParametersInFile) map[string]string {
 	baseData := make(map[string]string)
 	for key := range updatedParameters {
@@ -153,6 +153,17 @@ func checkParametersSchema(ctx intctrlutil.RequestCtx, parametersDef *parameters
 			"configMapName", fmt.Sprintf("%v", parametersDef.Spec.ParametersSchema))
 		return ok, err
 	}
+	// validate the syntax of the constraints
+	if err := validate.CompileConstraints(parametersDef.Spec.Constraints); err != nil {
+		ctx.Log.Error(err, "failed to validate parameter constraints!")
+		return false, err
+	}
+	for _, derived := range parametersDef.Spec.DerivedParameters {
+		if err := validate.CompileCELExpression(derived.Expression); err != nil {
+			ctx.Log.Error(err, "failed to validate derived parameter!", "parameter", derived.Name)
+			return false, err
+		}
+	}
 	return true, nil
 }
 
@@ -0,0 +1,143 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+)
+
+// podExecutor executes a command in a container of the pod and returns its stdout.
+type podExecutor interface {
+	Exec(ctx context.Context, pod *corev1.Pod, container string, command []string) ([]byte, error)
+}
+
+type remotePodExecutor struct {
+	config    *rest.Config
+	clientset kubernetes.Interface
+}
+
+func newPodExecutor(config *rest.Config) (podExecutor, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &remotePodExecutor{config: config, clientset: clientset}, nil
+}
+
+func (e *remotePodExecutor) Exec(ctx context.Context, pod *corev1.Pod, container string, command []string) ([]byte, error) {
+	req := e.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(e.config, "POST", req.URL())
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	if err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %s", err.Error(), msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// multiClusterPodExecutor executes the command through the data-plane k8s cluster in which the pod is placed.
+type multiClusterPodExecutor struct {
+	multiClusterMgr multicluster.Manager
+	executors       map[string]podExecutor
+}
+
+func newMultiClusterPodExecutor(multiClusterMgr multicluster.Manager) (podExecutor, error) {
+	executors := make(map[string]podExecutor)
+	for _, context := range multiClusterMgr.GetContexts() {
+		_, config := multiClusterMgr.GetContextClient(context)
+		if config == nil {
+			continue
+		}
+		executor, err := newPodExecutor(config)
+		if err != nil {
+			return nil, err
+		}
+		executors[context] = executor
+	}
+	return &multiClusterPodExecutor{multiClusterMgr: multiClusterMgr, executors: executors}, nil
+}
+
+func (e *multiClusterPodExecutor) Exec(ctx context.Context, pod *corev1.Pod, container string, command []string) ([]byte, error) {
+	context, err := e.locate(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	return e.executors[context].Exec(ctx, pod, container, command)
+}
+
+// locate returns the context of the data-plane k8s cluster in which the pod is placed.
+func (e *multiClusterPodExecutor) locate(ctx context.Context, pod *corev1.Pod) (string, error) {
+	contexts := maps.Keys(e.executors)
+	sort.Strings(contexts)
+	for _, context := range contexts {
+		if !e.multiClusterMgr.IsContextAvailable(context) {
+			continue
+		}
+		cli, _ := e.multiClusterMgr.GetContextClient(context)
+		if cli == nil {
+			continue
+		}
+		obj := &corev1.Pod{}
+		if err := cli.Get(ctx, client.ObjectKeyFromObject(pod), obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", err
+		}
+		if obj.UID == pod.UID {
+			return context, nil
+		}
+	}
+	return "", fmt.Errorf("the pod %s/%s is not found in any available data-plane k8s cluster", pod.Namespace, pod.Name)
+}
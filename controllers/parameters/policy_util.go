@@ -37,8 +37,10 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	cfgproto "github.com/apecloud/kubeblocks/pkg/configuration/proto"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -97,6 +99,74 @@ func getPodsForOnlineUpdate(params reconfigureContext) ([]corev1.Pod, error) {
 	return pods, nil
 }
 
+// reloadByKBAgent checks whether the HTTP trigger is performed through kbagent, which is the case
+// when the config-manager sidecar is not present in the pod.
+func reloadByKBAgent(pd *parametersv1alpha1.ParametersDefinitionSpec, podSpec *corev1.PodSpec) bool {
+	if pd == nil || pd.ReloadAction == nil || pd.ReloadAction.HTTPTrigger == nil {
+		return false
+	}
+	_, container := intctrlutil.GetContainerByName(podSpec.Containers, constant.ConfigSidecarName)
+	return container == nil
+}
+
+func reloadByKBAgentWithInstanceSets(pd *parametersv1alpha1.ParametersDefinitionSpec, instanceSets []workloads.InstanceSet) bool {
+	for i := range instanceSets {
+		if reloadByKBAgent(pd, &instanceSets[i].Spec.Template.Spec) {
+			return true
+		}
+	}
+	return false
+}
+
+// reloadPodByKBAgent calls the reload action of kbagent with the request rendered from the updated parameters.
+func reloadPodByKBAgent(rctx reconfigureContext, pod *corev1.Pod, updatedParams map[string]string) error {
+	var tpl *component.SynthesizedFileTemplate
+	for i := range rctx.SynthesizedComponent.FileTemplates {
+		if t := &rctx.SynthesizedComponent.FileTemplates[i]; t.Name == rctx.ConfigTemplate.Name && t.Reload != nil {
+			tpl = t
+			break
+		}
+	}
+	if tpl == nil {
+		return core.MakeError("the reload action of config template[%s] is not defined in kbagent", rctx.ConfigTemplate.Name)
+	}
+
+	trigger := rctx.ParametersDef.ReloadAction.HTTPTrigger
+	parameters := make(map[string]string, len(updatedParams)+2)
+	for k, v := range updatedParams {
+		parameters[k] = v
+	}
+	request, err := cfgcm.RenderReloadRequest(rctx.Ctx, trigger.RequestTemplate, updatedParams)
+	if err != nil {
+		return err
+	}
+	parameters[component.ReloadRequestParameter] = request
+	if trigger.AuthSecretRef != nil {
+		secret := &corev1.Secret{}
+		secretKey := client.ObjectKey{Namespace: pod.Namespace, Name: trigger.AuthSecretRef.Name}
+		if err = rctx.Client.Get(rctx.Ctx, secretKey, secret); err != nil {
+			return err
+		}
+		authorization, err := cfgcm.BuildReloadAuthorization(trigger.AuthSecretRef, func(key string) ([]byte, error) {
+			if v, ok := secret.Data[key]; ok {
+				return v, nil
+			}
+			return nil, fmt.Errorf("key[%s] not found", key)
+		})
+		if err != nil {
+			return err
+		}
+		parameters[component.ReloadAuthorizationParameter] = authorization
+	}
+
+	lfa, err := lifecycle.New(pod.Namespace, rctx.SynthesizedComponent.ClusterName, rctx.SynthesizedComponent.Name,
+		&appsv1.ComponentLifecycleActions{}, nil, pod)
+	if err != nil {
+		return err
+	}
+	return lfa.UserDefined(rctx.Ctx, rctx.Client, nil, component.UDFReloadActionName(*tpl), tpl.Reload, parameters)
+}
+
 // TODO commonOnlineUpdateWithPod migrate to sql command pipeline
 func commonOnlineUpdateWithPod(pod *corev1.Pod, ctx context.Context, createClient createReconfigureClient, configSpec string, configFile string, updatedParams map[string]string) error {
 	address, err := resolveReloadServerGrpcURL(pod)
@@ -322,6 +392,10 @@ func genReconfigureActionTasks(templateSpec *appsv1.ComponentFileTemplate, rctx
 		if err != nil {
 			return nil, err
 		}
+		// without the config-manager sidecar watching the files, the controller reloads the engine through kbagent.
+		if policy == parametersv1alpha1.AsyncDynamicReloadPolicy && reloadByKBAgentWithInstanceSets(&pd.Spec, rctx.InstanceSetList) {
+			policy = parametersv1alpha1.SyncDynamicReloadPolicy
+		}
 		// If a reload action is needed, append a new reload action task to the tasks slice.
 		if needReloadAction(pd, policy) {
 			tasks = append(tasks, buildReloadActionTask(policy, templateSpec, rctx, pd, configFormat, patch))
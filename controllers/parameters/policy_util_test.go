@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package parameters
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -34,11 +35,13 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -381,3 +384,89 @@ func Test_resolveReloadActionPolicy(t *testing.T) {
 		})
 	}
 }
+
+func Test_syncReloadByKBAgent(t *testing.T) {
+	httpTriggerPD := &parametersv1alpha1.ParametersDefinitionSpec{
+		ReloadAction: &parametersv1alpha1.ReloadAction{
+			HTTPTrigger: &parametersv1alpha1.HTTPTrigger{
+				HTTP: &appsv1.HTTPAction{
+					Port: 8080,
+					Path: "/reload",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		containers []corev1.Container
+		pd         *parametersv1alpha1.ParametersDefinitionSpec
+		byKBAgent  bool
+	}{{
+		name:       "http trigger without config-manager sidecar",
+		containers: []corev1.Container{{Name: "mysql"}},
+		pd:         httpTriggerPD,
+		byKBAgent:  true,
+	}, {
+		name:       "http trigger with config-manager sidecar",
+		containers: []corev1.Container{{Name: "mysql"}, {Name: constant.ConfigSidecarName}},
+		pd:         httpTriggerPD,
+		byKBAgent:  false,
+	}, {
+		name:       "shell trigger",
+		containers: []corev1.Container{{Name: "mysql"}},
+		pd: &parametersv1alpha1.ParametersDefinitionSpec{
+			ReloadAction: &parametersv1alpha1.ReloadAction{
+				ShellTrigger: &parametersv1alpha1.ShellTrigger{
+					Command: []string{"/bin/true"},
+				},
+			},
+		},
+		byKBAgent: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			its := newMockInstanceSet(2, "test", nil)
+			its.Spec.Template.Spec.Containers = tt.containers
+			pods := newMockPodsWithInstanceSet(&its, 2, withReadyPod(0, 2))
+			assert.Equal(t, tt.byKBAgent, reloadByKBAgentWithInstanceSets(tt.pd, []workloads.InstanceSet{its}))
+
+			cli := fake.NewClientBuilder().WithRuntimeObjects(fromPodObjectList(pods)...).Build()
+			rctx := newMockReconfigureParams("syncReloadByKBAgent", cli,
+				withConfigSpec("for_test", map[string]string{"a": "b"}),
+				withUpdatedParameters(map[string]string{"a": "b"}),
+				withClusterComponent(2))
+			rctx.InstanceSetUnits = []workloads.InstanceSet{its}
+			rctx.ParametersDef = tt.pd
+
+			var kbagentReloaded, onlineUpdated []string
+			funcs := RollingUpgradeFuncs{
+				OnlineUpdatePodFunc: func(pod *corev1.Pod, _ context.Context, _ createReconfigureClient, _, _ string, _ map[string]string) error {
+					onlineUpdated = append(onlineUpdated, pod.Name)
+					return nil
+				},
+				KBAgentReloadPodFunc: func(_ reconfigureContext, pod *corev1.Pod, updatedParams map[string]string) error {
+					assert.Equal(t, map[string]string{"a": "b"}, updatedParams)
+					kbagentReloaded = append(kbagentReloaded, pod.Name)
+					return nil
+				},
+			}
+			status, err := sync(rctx, rctx.UpdatedParameters, pods, funcs)
+			assert.NoError(t, err)
+			assert.Equal(t, ESNone, status.Status)
+			if tt.byKBAgent {
+				assert.Len(t, kbagentReloaded, 2)
+				assert.Empty(t, onlineUpdated)
+			} else {
+				assert.Empty(t, kbagentReloaded)
+				assert.Len(t, onlineUpdated, 2)
+			}
+		})
+	}
+}
+
+func Test_reloadPodByKBAgentWithoutAction(t *testing.T) {
+	rctx := newMockReconfigureParams("reloadPodByKBAgent", nil, withConfigSpec("for_test", nil))
+	pod := newMockPod("test-0", &corev1.PodSpec{})
+	err := reloadPodByKBAgent(rctx, &pod, map[string]string{"a": "b"})
+	assert.ErrorContains(t, err, "the reload action of config template[for_test] is not defined")
+}
@@ -58,6 +58,9 @@ type TaskContext struct {
 	ctx                context.Context
 	component          *component.SynthesizedComponent
 	paramsDefs         []*parametersv1alpha1.ParametersDefinition
+
+	// rollback is the snapshot of the revision to roll back to, if the current revision is a rollback
+	rollback *revisionSnapshot
 }
 
 func NewTaskContext(ctx context.Context, cli client.Client, componentParameter *parametersv1alpha1.ComponentParameter, fetchTask *Task) (*TaskContext, error) {
@@ -98,11 +101,17 @@ func NewTaskContext(ctx context.Context, cli client.Client, componentParameter *
 		}
 	}
 
+	rollback, err := resolveRollbackSnapshot(ctx, cli, componentParameter)
+	if err != nil {
+		return nil, err
+	}
+
 	return &TaskContext{ctx: ctx,
 		componentParameter: componentParameter,
 		configRender:       configRender,
 		component:          synthesizedComp,
 		paramsDefs:         paramsDefs,
+		rollback:           rollback,
 	}, nil
 }
 
@@ -162,7 +171,13 @@ func syncImpl(taskCtx *TaskContext,
 
 	var baseConfig = configMap
 	var updatedConfig *corev1.ConfigMap
-	if intctrlutil.IsRerender(configMap, item) {
+	snapshotItem := taskCtx.rollback.item(item.Name)
+	switch {
+	case snapshotItem != nil && len(snapshotItem.Data) != 0 && configMap != nil:
+		// restore the rendered configuration of the revision to roll back to
+		updatedConfig = configMap.DeepCopy()
+		updatedConfig.Data = snapshotItem.Data
+	case intctrlutil.IsRerender(configMap, item):
 		log.FromContext(taskCtx.ctx).
 			WithName("ParameterReconcileTask").
 			WithValues("cluster", taskCtx.component.ClusterName,
@@ -177,10 +192,12 @@ func syncImpl(taskCtx *TaskContext,
 			return failStatus(err)
 		}
 		updatedConfig = baseConfig
-	}
-	if len(item.ConfigFileParams) != 0 {
-		if updatedConfig, err = configctrl.ApplyParameters(item, baseConfig, taskCtx.configRender, taskCtx.paramsDefs); err != nil {
-			return failStatus(err)
+		fallthrough
+	default:
+		if len(item.ConfigFileParams) != 0 {
+			if updatedConfig, err = configctrl.ApplyParameters(item, baseConfig, taskCtx.configRender, taskCtx.paramsDefs); err != nil {
+				return failStatus(err)
+			}
 		}
 	}
 	if err = mergeAndApplyConfig(fetcher.ResourceCtx, updatedConfig, configMap, fetcher.ComponentParameterObj, item, revision); err != nil {
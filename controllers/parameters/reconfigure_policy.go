@@ -131,9 +131,18 @@ func GetClientFactory() createReconfigureClient {
 }
 
 func (param *reconfigureContext) generateConfigIdentifier() string {
-	key := param.ConfigTemplate.Name
-	if param.ConfigDescription != nil && param.ConfigDescription.Name != "" {
-		hash, _ := util.ComputeHash(param.ConfigDescription.Name)
+	var fileName string
+	if param.ConfigDescription != nil {
+		fileName = param.ConfigDescription.Name
+	}
+	return configIdentifier(param.ConfigTemplate.Name, fileName)
+}
+
+// configIdentifier generates the key of the pod label that records the applied config version.
+func configIdentifier(tplName, fileName string) string {
+	key := tplName
+	if fileName != "" {
+		hash, _ := util.ComputeHash(fileName)
 		key = key + "-" + hash
 	}
 	return strings.ReplaceAll(key, "_", "-")
@@ -222,6 +231,10 @@ func enableSyncTrigger(reloadAction *parametersv1alpha1.ReloadAction) bool {
 	if reloadAction.ShellTrigger != nil {
 		return !core.IsWatchModuleForShellTrigger(reloadAction.ShellTrigger)
 	}
+
+	if reloadAction.HTTPTrigger != nil {
+		return !core.IsWatchModuleForHTTPTrigger(reloadAction.HTTPTrigger)
+	}
 	return false
 }
 
@@ -0,0 +1,449 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const revisionSnapshotKey = "snapshot"
+
+// configChangeRequest describes who requested the change of a ComponentParameter revision,
+// it is stored in the annotation of the ComponentParameter by the requester.
+type configChangeRequest struct {
+	Revision           int64  `json:"revision"`
+	RequestedBy        string `json:"requestedBy,omitempty"`
+	RollbackToRevision *int64 `json:"rollbackToRevision,omitempty"`
+}
+
+// revisionSnapshot holds the parameters and the rendered configuration of a revision,
+// which are used to compute the changes of the next revision and to roll back.
+type revisionSnapshot struct {
+	Revision int64                  `json:"revision"`
+	Items    []revisionSnapshotItem `json:"items"`
+}
+
+type revisionSnapshotItem struct {
+	Name             string                                         `json:"name"`
+	ConfigFileParams map[string]parametersv1alpha1.ParametersInFile `json:"configFileParams,omitempty"`
+	CustomTemplates  *parametersv1alpha1.ConfigTemplateExtension    `json:"customTemplates,omitempty"`
+	Data             map[string]string                              `json:"data,omitempty"`
+}
+
+func (s *revisionSnapshot) item(name string) *revisionSnapshotItem {
+	if s == nil {
+		return nil
+	}
+	for i := range s.Items {
+		if s.Items[i].Name == name {
+			return &s.Items[i]
+		}
+	}
+	return nil
+}
+
+func setConfigChangeRequest(obj client.Object, request configChangeRequest) {
+	b, _ := json.Marshal(request)
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constant.ConfigChangeRequestAnnotationKey] = string(b)
+	obj.SetAnnotations(annotations)
+}
+
+// getConfigChangeRequest returns the change request of the given revision, or nil if the revision was not requested explicitly.
+func getConfigChangeRequest(obj client.Object, revision int64) *configChangeRequest {
+	value, ok := obj.GetAnnotations()[constant.ConfigChangeRequestAnnotationKey]
+	if !ok {
+		return nil
+	}
+	request := &configChangeRequest{}
+	if err := json.Unmarshal([]byte(value), request); err != nil || request.Revision != revision {
+		return nil
+	}
+	return request
+}
+
+func resolveParameterRequester(parameter *parametersv1alpha1.Parameter) string {
+	if opsName, ok := parameter.Labels[constant.OpsRequestNameLabelKey]; ok {
+		return "OpsRequest/" + opsName
+	}
+	return "Parameter/" + parameter.Name
+}
+
+// revisionSnapshotName returns the name of the ConfigMap which stores the snapshot of the revision,
+// each revision is stored in its own ConfigMap to keep the rendered configuration within the size limit of an object.
+func revisionSnapshotName(compParam *parametersv1alpha1.ComponentParameter, revision int64) string {
+	return fmt.Sprintf("%s-revision-%d", compParam.Name, revision)
+}
+
+func resolveRevisionHistoryLimit(compParam *parametersv1alpha1.ComponentParameter) int {
+	if compParam.Spec.RevisionHistoryLimit != nil && *compParam.Spec.RevisionHistoryLimit > 0 {
+		return int(*compParam.Spec.RevisionHistoryLimit)
+	}
+	return revisionHistoryLimit
+}
+
+func getRevisionSnapshot(ctx context.Context, cli client.Client, compParam *parametersv1alpha1.ComponentParameter, revision int64) (*revisionSnapshot, error) {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: compParam.Namespace, Name: revisionSnapshotName(compParam, revision)}
+	if err := cli.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	data, ok := cm.Data[revisionSnapshotKey]
+	if !ok {
+		return nil, nil
+	}
+	snapshot := &revisionSnapshot{}
+	if err := json.Unmarshal([]byte(data), snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// getLatestRevisionSnapshot returns the snapshot of the latest recorded revision before the given one.
+func getLatestRevisionSnapshot(ctx context.Context, cli client.Client, compParam *parametersv1alpha1.ComponentParameter, revision int64) (*revisionSnapshot, error) {
+	var revisions []int64
+	for _, entry := range compParam.Status.RevisionHistory {
+		if entry.Revision < revision {
+			revisions = append(revisions, entry.Revision)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i] > revisions[j] })
+	for _, r := range revisions {
+		snapshot, err := getRevisionSnapshot(ctx, cli, compParam, r)
+		if err != nil || snapshot != nil {
+			return snapshot, err
+		}
+	}
+	return nil, nil
+}
+
+func resolveRollbackSnapshot(ctx context.Context, cli client.Client, compParam *parametersv1alpha1.ComponentParameter) (*revisionSnapshot, error) {
+	request := getConfigChangeRequest(compParam, compParam.Generation)
+	if request == nil || request.RollbackToRevision == nil {
+		return nil, nil
+	}
+	return getRevisionSnapshot(ctx, cli, compParam, *request.RollbackToRevision)
+}
+
+// restoreRevisionSnapshot restores the parameters of the snapshot to the spec.
+func restoreRevisionSnapshot(spec *parametersv1alpha1.ComponentParameterSpec, snapshot *revisionSnapshot) {
+	for i := range spec.ConfigItemDetails {
+		item := &spec.ConfigItemDetails[i]
+		if snapshotItem := snapshot.item(item.Name); snapshotItem != nil {
+			item.ConfigFileParams = snapshotItem.ConfigFileParams
+			item.CustomTemplates = snapshotItem.CustomTemplates
+		}
+	}
+}
+
+// buildParameterChanges compares the parameters of the current spec with the previous revision.
+func buildParameterChanges(previous *revisionSnapshot, items []parametersv1alpha1.ConfigTemplateItemDetail) []parametersv1alpha1.ParameterChange {
+	var changes []parametersv1alpha1.ParameterChange
+	for _, item := range items {
+		var oldParams map[string]parametersv1alpha1.ParametersInFile
+		if snapshotItem := previous.item(item.Name); snapshotItem != nil {
+			oldParams = snapshotItem.ConfigFileParams
+		}
+		files := make(map[string]bool)
+		for file := range oldParams {
+			files[file] = true
+		}
+		for file := range item.ConfigFileParams {
+			files[file] = true
+		}
+		for file := range files {
+			changes = append(changes, diffParameters(item.Name, file, oldParams[file].Parameters, item.ConfigFileParams[file].Parameters)...)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].TemplateName != changes[j].TemplateName {
+			return changes[i].TemplateName < changes[j].TemplateName
+		}
+		if changes[i].FileName != changes[j].FileName {
+			return changes[i].FileName < changes[j].FileName
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+func diffParameters(tplName, fileName string, oldParams, newParams map[string]*string) []parametersv1alpha1.ParameterChange {
+	equal := func(a, b *string) bool {
+		return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+	}
+	var changes []parametersv1alpha1.ParameterChange
+	for name, newValue := range newParams {
+		if oldValue := oldParams[name]; !equal(oldValue, newValue) {
+			changes = append(changes, parametersv1alpha1.ParameterChange{
+				TemplateName: tplName,
+				FileName:     fileName,
+				Name:         name,
+				OldValue:     oldValue,
+				NewValue:     newValue,
+			})
+		}
+	}
+	for name, oldValue := range oldParams {
+		if _, ok := newParams[name]; !ok && oldValue != nil {
+			changes = append(changes, parametersv1alpha1.ParameterChange{
+				TemplateName: tplName,
+				FileName:     fileName,
+				Name:         name,
+				OldValue:     oldValue,
+			})
+		}
+	}
+	return changes
+}
+
+func getParameterRevision(status *parametersv1alpha1.ComponentParameterStatus, revision int64) *parametersv1alpha1.ParameterRevision {
+	for i := range status.RevisionHistory {
+		if status.RevisionHistory[i].Revision == revision {
+			return &status.RevisionHistory[i]
+		}
+	}
+	return nil
+}
+
+// syncParameterRevision updates the reconfigure policies and the outcome of the revision from the status.
+func syncParameterRevision(entry *parametersv1alpha1.ParameterRevision, status *parametersv1alpha1.ComponentParameterStatus, now metav1.Time) {
+	revision := strconv.FormatInt(entry.Revision, 10)
+	entry.Items = nil
+	for _, itemStatus := range status.ConfigurationItemStatus {
+		if itemStatus.UpdateRevision != revision {
+			continue
+		}
+		item := parametersv1alpha1.ParameterRevisionItem{
+			Name:  itemStatus.Name,
+			Phase: itemStatus.Phase,
+		}
+		if itemStatus.ReconcileDetail != nil {
+			item.Policy = itemStatus.ReconcileDetail.Policy
+			item.Message = itemStatus.ReconcileDetail.ErrMessage
+		}
+		if item.Message == "" && itemStatus.Message != nil {
+			item.Message = *itemStatus.Message
+		}
+		entry.Items = append(entry.Items, item)
+	}
+	if status.ObservedGeneration == entry.Revision {
+		entry.Phase = status.Phase
+	}
+	if entry.CompletionTime == nil && intctrlutil.IsParameterFinished(entry.Phase) {
+		entry.CompletionTime = &now
+	}
+}
+
+// isRevisionRendered checks whether the configmaps of all templates have been rendered with the current spec.
+func isRevisionRendered(compParam *parametersv1alpha1.ComponentParameter, configMaps map[string]*corev1.ConfigMap) bool {
+	for _, item := range compParam.Spec.ConfigItemDetails {
+		if item.ConfigSpec == nil {
+			continue
+		}
+		if !intctrlutil.IsApplyUpdatedParameters(configMaps[item.Name], item) {
+			return false
+		}
+	}
+	return true
+}
+
+func buildRevisionSnapshot(compParam *parametersv1alpha1.ComponentParameter, configMaps map[string]*corev1.ConfigMap) *revisionSnapshot {
+	snapshot := &revisionSnapshot{Revision: compParam.Generation}
+	for _, item := range compParam.Spec.ConfigItemDetails {
+		snapshotItem := revisionSnapshotItem{
+			Name:             item.Name,
+			ConfigFileParams: item.ConfigFileParams,
+			CustomTemplates:  item.CustomTemplates,
+		}
+		if cm, ok := configMaps[item.Name]; ok && cm != nil {
+			snapshotItem.Data = cm.Data
+		}
+		snapshot.Items = append(snapshot.Items, snapshotItem)
+	}
+	return snapshot
+}
+
+// truncateRevisionHistory keeps the latest revisions within the limit.
+func truncateRevisionHistory(status *parametersv1alpha1.ComponentParameterStatus, limit int) {
+	sort.SliceStable(status.RevisionHistory, func(i, j int) bool {
+		return status.RevisionHistory[i].Revision < status.RevisionHistory[j].Revision
+	})
+	if len(status.RevisionHistory) > limit {
+		status.RevisionHistory = status.RevisionHistory[len(status.RevisionHistory)-limit:]
+	}
+}
+
+// syncRevisionHistory records the current revision in the status and stores its snapshot once the configuration is rendered.
+func (r *ComponentParameterReconciler) syncRevisionHistory(ctx context.Context, compParam *parametersv1alpha1.ComponentParameter) error {
+	now := metav1.Now()
+	revision := compParam.Generation
+	entry := getParameterRevision(&compParam.Status, revision)
+	if entry == nil {
+		previous, err := getLatestRevisionSnapshot(ctx, r.Client, compParam, revision)
+		if err != nil {
+			return err
+		}
+		newEntry := parametersv1alpha1.ParameterRevision{
+			Revision:     revision,
+			CreationTime: now,
+			Changes:      buildParameterChanges(previous, compParam.Spec.ConfigItemDetails),
+		}
+		if request := getConfigChangeRequest(compParam, revision); request != nil {
+			newEntry.RequestedBy = request.RequestedBy
+			newEntry.RollbackToRevision = request.RollbackToRevision
+		}
+		compParam.Status.RevisionHistory = append(compParam.Status.RevisionHistory, newEntry)
+		entry = getParameterRevision(&compParam.Status, revision)
+	}
+	syncParameterRevision(entry, &compParam.Status, now)
+	truncateRevisionHistory(&compParam.Status, resolveRevisionHistoryLimit(compParam))
+
+	snapshot, err := getRevisionSnapshot(ctx, r.Client, compParam, revision)
+	if err != nil || snapshot != nil {
+		return err
+	}
+	configMaps := make(map[string]*corev1.ConfigMap)
+	for _, item := range compParam.Spec.ConfigItemDetails {
+		cm := &corev1.ConfigMap{}
+		key := client.ObjectKey{
+			Namespace: compParam.Namespace,
+			Name:      core.GetComponentCfgName(compParam.Spec.ClusterName, compParam.Spec.ComponentName, item.Name),
+		}
+		if err = r.Client.Get(ctx, key, cm, inDataContextUnspecified()); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		configMaps[item.Name] = cm
+	}
+	if !isRevisionRendered(compParam, configMaps) {
+		return nil
+	}
+	if err = r.saveRevisionSnapshot(ctx, compParam, buildRevisionSnapshot(compParam, configMaps)); err != nil {
+		return err
+	}
+	return r.pruneRevisionSnapshots(ctx, compParam)
+}
+
+func (r *ComponentParameterReconciler) saveRevisionSnapshot(ctx context.Context, compParam *parametersv1alpha1.ComponentParameter, snapshot *revisionSnapshot) error {
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: compParam.Namespace,
+			Name:      revisionSnapshotName(compParam, snapshot.Revision),
+			Labels: map[string]string{
+				constant.AppManagedByLabelKey:     constant.AppName,
+				constant.AppInstanceLabelKey:      compParam.Spec.ClusterName,
+				constant.RevisionSnapshotLabelKey: compParam.Name,
+				constant.ConfigurationRevision:    strconv.FormatInt(snapshot.Revision, 10),
+			},
+		},
+		Data: map[string]string{revisionSnapshotKey: string(b)},
+	}
+	if err = controllerutil.SetOwnerReference(compParam, cm, r.Scheme); err != nil {
+		return err
+	}
+	return client.IgnoreAlreadyExists(r.Client.Create(ctx, cm))
+}
+
+// pruneRevisionSnapshots deletes the snapshots of the revisions which are no longer recorded in the status.
+func (r *ComponentParameterReconciler) pruneRevisionSnapshots(ctx context.Context, compParam *parametersv1alpha1.ComponentParameter) error {
+	retained := make(map[string]bool)
+	for _, entry := range compParam.Status.RevisionHistory {
+		retained[strconv.FormatInt(entry.Revision, 10)] = true
+	}
+	snapshots := &corev1.ConfigMapList{}
+	if err := r.Client.List(ctx, snapshots, client.InNamespace(compParam.Namespace), client.MatchingLabels{
+		constant.AppInstanceLabelKey:      compParam.Spec.ClusterName,
+		constant.RevisionSnapshotLabelKey: compParam.Name,
+	}); err != nil {
+		return err
+	}
+	for i := range snapshots.Items {
+		snapshot := &snapshots.Items[i]
+		if retained[snapshot.Labels[constant.ConfigurationRevision]] {
+			continue
+		}
+		if err := r.Client.Delete(ctx, snapshot); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollback restores the parameters of the requested revision, the restored configuration is applied as a new revision.
+func (r *ComponentParameterReconciler) rollback(reqCtx intctrlutil.RequestCtx, compParam *parametersv1alpha1.ComponentParameter) error {
+	revision := *compParam.Spec.RollbackToRevision
+	snapshot, err := getRevisionSnapshot(reqCtx.Ctx, r.Client, compParam, revision)
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return intctrlutil.NewFatalError(fmt.Sprintf("revision %d not found in the revision history", revision))
+	}
+
+	// clearing the rollback request is a change of the spec, the restored spec is expected to be the next revision
+	expected := compParam.Generation + 1
+	patch := client.MergeFrom(compParam.DeepCopy())
+	restoreRevisionSnapshot(&compParam.Spec, snapshot)
+	compParam.Spec.RollbackToRevision = nil
+	setConfigChangeRequest(compParam, configChangeRequest{
+		Revision:           expected,
+		RollbackToRevision: &revision,
+	})
+	if err = r.Client.Patch(reqCtx.Ctx, compParam, patch); err != nil {
+		return err
+	}
+	if compParam.Generation != expected {
+		// the spec has been changed concurrently, attribute the rollback to the actual revision
+		patch = client.MergeFrom(compParam.DeepCopy())
+		setConfigChangeRequest(compParam, configChangeRequest{
+			Revision:           compParam.Generation,
+			RollbackToRevision: &revision,
+		})
+		if err = r.Client.Patch(reqCtx.Ctx, compParam, patch); err != nil {
+			return err
+		}
+	}
+	reqCtx.Recorder.Eventf(compParam, corev1.EventTypeNormal, "RollbackAccepted", "roll back the configuration to revision %d", revision)
+	return nil
+}
@@ -0,0 +1,318 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+func newRevisionTestClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, parametersv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&parametersv1alpha1.ComponentParameter{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// bump the generation on the changes of the spec as the API server does
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				compParam, ok := obj.(*parametersv1alpha1.ComponentParameter)
+				if !ok {
+					return c.Patch(ctx, obj, patch, opts...)
+				}
+				before := &parametersv1alpha1.ComponentParameter{}
+				if err := c.Get(ctx, client.ObjectKeyFromObject(obj), before); err != nil {
+					return err
+				}
+				if err := c.Patch(ctx, obj, patch, opts...); err != nil {
+					return err
+				}
+				if reflect.DeepEqual(before.Spec, compParam.Spec) {
+					return nil
+				}
+				compParam.Generation = before.Generation + 1
+				return c.Update(ctx, compParam)
+			},
+		}).
+		Build()
+}
+
+func newRevisionTestComponentParameter(maxConnections string, generation int64, revisions ...int64) *parametersv1alpha1.ComponentParameter {
+	compParam := &parametersv1alpha1.ComponentParameter{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql-config", Namespace: "default", Generation: generation, UID: "uid"},
+		Spec: parametersv1alpha1.ComponentParameterSpec{
+			ClusterName:   "mysql",
+			ComponentName: "mysql",
+			ConfigItemDetails: []parametersv1alpha1.ConfigTemplateItemDetail{{
+				Name: "mysql-config",
+				ConfigFileParams: map[string]parametersv1alpha1.ParametersInFile{
+					"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String(maxConnections)}},
+				},
+			}},
+		},
+	}
+	for _, revision := range revisions {
+		compParam.Status.RevisionHistory = append(compParam.Status.RevisionHistory, parametersv1alpha1.ParameterRevision{Revision: revision})
+	}
+	return compParam
+}
+
+func newRevisionTestSnapshot(revision int64, maxConnections string) *revisionSnapshot {
+	return &revisionSnapshot{
+		Revision: revision,
+		Items: []revisionSnapshotItem{{
+			Name: "mysql-config",
+			ConfigFileParams: map[string]parametersv1alpha1.ParametersInFile{
+				"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String(maxConnections)}},
+			},
+			Data: map[string]string{"my.cnf": "[mysqld]\nmax_connections=" + maxConnections + "\n"},
+		}},
+	}
+}
+
+func TestBuildParameterChanges(t *testing.T) {
+	previous := &revisionSnapshot{
+		Revision: 2,
+		Items: []revisionSnapshotItem{{
+			Name: "mysql-config",
+			ConfigFileParams: map[string]parametersv1alpha1.ParametersInFile{
+				"my.cnf": {Parameters: map[string]*string{
+					"max_connections": pointer.String("500"),
+					"log_bin":         pointer.String("ON"),
+					"sql_mode":        pointer.String("STRICT"),
+				}},
+			},
+		}},
+	}
+	items := []parametersv1alpha1.ConfigTemplateItemDetail{{
+		Name: "mysql-config",
+		ConfigFileParams: map[string]parametersv1alpha1.ParametersInFile{
+			"my.cnf": {Parameters: map[string]*string{
+				"max_connections":         pointer.String("1000"),
+				"log_bin":                 pointer.String("ON"),
+				"innodb_buffer_pool_size": pointer.String("1G"),
+			}},
+		},
+	}}
+
+	changes := buildParameterChanges(previous, items)
+	require.Len(t, changes, 3)
+	assert.Equal(t, "innodb_buffer_pool_size", changes[0].Name)
+	assert.Nil(t, changes[0].OldValue)
+	assert.Equal(t, "1G", *changes[0].NewValue)
+	assert.Equal(t, "max_connections", changes[1].Name)
+	assert.Equal(t, "500", *changes[1].OldValue)
+	assert.Equal(t, "1000", *changes[1].NewValue)
+	assert.Equal(t, "sql_mode", changes[2].Name)
+	assert.Equal(t, "STRICT", *changes[2].OldValue)
+	assert.Nil(t, changes[2].NewValue)
+	for _, change := range changes {
+		assert.Equal(t, "mysql-config", change.TemplateName)
+		assert.Equal(t, "my.cnf", change.FileName)
+	}
+
+	assert.Empty(t, buildParameterChanges(previous, []parametersv1alpha1.ConfigTemplateItemDetail{{
+		Name:             "mysql-config",
+		ConfigFileParams: previous.Items[0].ConfigFileParams,
+	}}))
+	assert.Len(t, buildParameterChanges(nil, items), 3)
+}
+
+func TestGetLatestRevisionSnapshot(t *testing.T) {
+	ctx := context.Background()
+	compParam := newRevisionTestComponentParameter("1000", 5, 1, 2, 3, 4, 5)
+	cli := newRevisionTestClient(t)
+	r := &ComponentParameterReconciler{Client: cli, Scheme: cli.Scheme()}
+	for _, revision := range []int64{1, 3, 5} {
+		require.NoError(t, r.saveRevisionSnapshot(ctx, compParam, &revisionSnapshot{Revision: revision}))
+	}
+
+	snapshot, err := getLatestRevisionSnapshot(ctx, r.Client, compParam, 5)
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	assert.Equal(t, int64(3), snapshot.Revision)
+
+	snapshot, err = getLatestRevisionSnapshot(ctx, r.Client, compParam, 1)
+	require.NoError(t, err)
+	assert.Nil(t, snapshot)
+
+	// the snapshots of the revisions truncated from the status are pruned
+	compParam.Status.RevisionHistory = compParam.Status.RevisionHistory[3:]
+	require.NoError(t, r.pruneRevisionSnapshots(ctx, compParam))
+	snapshots := &corev1.ConfigMapList{}
+	require.NoError(t, r.Client.List(ctx, snapshots))
+	require.Len(t, snapshots.Items, 1)
+	assert.Equal(t, revisionSnapshotName(compParam, 5), snapshots.Items[0].Name)
+}
+
+func TestRollbackRevision(t *testing.T) {
+	ctx := context.Background()
+	compParam := newRevisionTestComponentParameter("1000", 3, 1, 2, 3)
+	compParam.Spec.RollbackToRevision = pointer.Int64(1)
+	cli := newRevisionTestClient(t, compParam)
+	r := &ComponentParameterReconciler{Client: cli, Scheme: cli.Scheme()}
+	require.NoError(t, r.saveRevisionSnapshot(ctx, compParam, newRevisionTestSnapshot(1, "500")))
+	require.NoError(t, r.saveRevisionSnapshot(ctx, compParam, newRevisionTestSnapshot(3, "1000")))
+	reqCtx := intctrlutil.RequestCtx{Ctx: ctx, Log: logr.Discard(), Recorder: record.NewFakeRecorder(10)}
+
+	// roll back to revision 1
+	require.NoError(t, r.rollback(reqCtx, compParam))
+	assert.Equal(t, int64(4), compParam.Generation)
+	assert.Nil(t, compParam.Spec.RollbackToRevision)
+	assert.Equal(t, "500", *compParam.Spec.ConfigItemDetails[0].ConfigFileParams["my.cnf"].Parameters["max_connections"])
+	request := getConfigChangeRequest(compParam, compParam.Generation)
+	require.NotNil(t, request)
+	assert.Equal(t, int64(1), *request.RollbackToRevision)
+
+	// re-render with the rendered configuration of revision 1
+	snapshot, err := resolveRollbackSnapshot(ctx, r.Client, compParam)
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	item := snapshot.item("mysql-config")
+	require.NotNil(t, item)
+	assert.Equal(t, "[mysqld]\nmax_connections=500\n", item.Data["my.cnf"])
+
+	// apply the restored configuration as revision 4
+	applied, err := json.Marshal(compParam.Spec.ConfigItemDetails[0])
+	require.NoError(t, err)
+	rendered := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   compParam.Namespace,
+			Name:        core.GetComponentCfgName(compParam.Spec.ClusterName, compParam.Spec.ComponentName, "mysql-config"),
+			Annotations: map[string]string{constant.ConfigAppliedVersionAnnotationKey: string(applied)},
+		},
+		Data: item.Data,
+	}
+	require.NoError(t, r.Client.Create(ctx, rendered))
+	require.NoError(t, r.syncRevisionHistory(ctx, compParam))
+
+	entry := getParameterRevision(&compParam.Status, 4)
+	require.NotNil(t, entry)
+	assert.Equal(t, int64(1), *entry.RollbackToRevision)
+	require.Len(t, entry.Changes, 1)
+	assert.Equal(t, "max_connections", entry.Changes[0].Name)
+	assert.Equal(t, "1000", *entry.Changes[0].OldValue)
+	assert.Equal(t, "500", *entry.Changes[0].NewValue)
+	snapshot, err = getRevisionSnapshot(ctx, r.Client, compParam, 4)
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	assert.Equal(t, item.Data, snapshot.item("mysql-config").Data)
+
+	// roll back to a revision not recorded
+	compParam.Spec.RollbackToRevision = pointer.Int64(2)
+	err = r.rollback(reqCtx, compParam)
+	assert.True(t, intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal))
+}
+
+func TestConfigChangeRequest(t *testing.T) {
+	compParam := &parametersv1alpha1.ComponentParameter{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+	setConfigChangeRequest(compParam, configChangeRequest{Revision: 4, RollbackToRevision: pointer.Int64(2)})
+
+	assert.Nil(t, getConfigChangeRequest(compParam, compParam.Generation))
+	request := getConfigChangeRequest(compParam, 4)
+	require.NotNil(t, request)
+	assert.Equal(t, int64(2), *request.RollbackToRevision)
+
+	parameter := &parametersv1alpha1.Parameter{ObjectMeta: metav1.ObjectMeta{Name: "param"}}
+	assert.Equal(t, "Parameter/param", resolveParameterRequester(parameter))
+}
+
+func TestRestoreRevisionSnapshot(t *testing.T) {
+	spec := &parametersv1alpha1.ComponentParameterSpec{
+		ConfigItemDetails: []parametersv1alpha1.ConfigTemplateItemDetail{
+			{Name: "mysql-config", ConfigFileParams: map[string]parametersv1alpha1.ParametersInFile{
+				"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String("1000")}},
+			}},
+			{Name: "agamotto-config"},
+		},
+	}
+	snapshot := &revisionSnapshot{
+		Revision: 1,
+		Items: []revisionSnapshotItem{{
+			Name: "mysql-config",
+			ConfigFileParams: map[string]parametersv1alpha1.ParametersInFile{
+				"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String("500")}},
+			},
+		}},
+	}
+
+	restoreRevisionSnapshot(spec, snapshot)
+	assert.Equal(t, "500", *spec.ConfigItemDetails[0].ConfigFileParams["my.cnf"].Parameters["max_connections"])
+	assert.Nil(t, spec.ConfigItemDetails[1].ConfigFileParams)
+}
+
+func TestSyncParameterRevision(t *testing.T) {
+	status := &parametersv1alpha1.ComponentParameterStatus{
+		ObservedGeneration: 2,
+		Phase:              parametersv1alpha1.CFinishedPhase,
+		ConfigurationItemStatus: []parametersv1alpha1.ConfigTemplateItemDetailStatus{
+			{
+				Name:            "mysql-config",
+				UpdateRevision:  "2",
+				Phase:           parametersv1alpha1.CFinishedPhase,
+				ReconcileDetail: &parametersv1alpha1.ReconcileDetail{Policy: "restart"},
+			},
+			{
+				Name:           "agamotto-config",
+				UpdateRevision: "1",
+				Phase:          parametersv1alpha1.CFinishedPhase,
+			},
+		},
+	}
+	for i := int64(1); i <= 3; i++ {
+		status.RevisionHistory = append(status.RevisionHistory, parametersv1alpha1.ParameterRevision{Revision: i})
+	}
+
+	entry := getParameterRevision(status, 2)
+	require.NotNil(t, entry)
+	syncParameterRevision(entry, status, metav1.Now())
+	require.Len(t, entry.Items, 1)
+	assert.Equal(t, "mysql-config", entry.Items[0].Name)
+	assert.Equal(t, "restart", entry.Items[0].Policy)
+	assert.Equal(t, parametersv1alpha1.CFinishedPhase, entry.Phase)
+	assert.NotNil(t, entry.CompletionTime)
+
+	truncateRevisionHistory(status, 2)
+	require.Len(t, status.RevisionHistory, 2)
+	assert.Equal(t, int64(2), status.RevisionHistory[0].Revision)
+	assert.Equal(t, int64(3), status.RevisionHistory[1].Revision)
+}
@@ -111,7 +111,12 @@ func sync(rctx reconfigureContext, updatedParameters map[string]string, pods []c
 		if !intctrlutil.IsPodReady(&pod) {
 			continue
 		}
-		if err = funcs.OnlineUpdatePodFunc(&pod, ctx, rctx.ReconfigureClientFactory, rctx.ConfigTemplate.Name, fileName, updatedParameters); err != nil {
+		if reloadByKBAgent(rctx.ParametersDef, &pod.Spec) {
+			err = funcs.KBAgentReloadPodFunc(rctx, &pod, updatedParameters)
+		} else {
+			err = funcs.OnlineUpdatePodFunc(&pod, ctx, rctx.ReconfigureClientFactory, rctx.ConfigTemplate.Name, fileName, updatedParameters)
+		}
+		if err != nil {
 			return makeReturnedStatus(ESFailedAndRetry), err
 		}
 		if err = updatePodLabelsWithConfigVersion(&pod, configKey, versionHash, rctx.Client, ctx); err != nil {
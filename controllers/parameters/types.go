@@ -32,6 +32,7 @@ import (
 type createReconfigureClient func(addr string) (cfgproto.ReconfigureClient, error)
 
 type GetPodsFunc func(params reconfigureContext) ([]corev1.Pod, error)
+type KBAgentReloadPodFunc func(params reconfigureContext, pod *corev1.Pod, updatedParams map[string]string) error
 type RestartComponent func(client client.Client, ctx intctrlutil.RequestCtx, key string, version string, objs []client.Object, recordEvent func(obj client.Object)) (client.Object, error)
 
 type RestartContainerFunc func(pod *corev1.Pod, ctx context.Context, containerName []string, createConnFn createReconfigureClient) error
@@ -43,6 +44,7 @@ type RollingUpgradeFuncs struct {
 	GetPodsFunc          GetPodsFunc
 	RestartContainerFunc RestartContainerFunc
 	OnlineUpdatePodFunc  OnlineUpdatePodFunc
+	KBAgentReloadPodFunc KBAgentReloadPodFunc
 	RestartComponent     RestartComponent
 }
 
@@ -51,6 +53,7 @@ func GetInstanceSetRollingUpgradeFuncs() RollingUpgradeFuncs {
 		GetPodsFunc:          getPodsForOnlineUpdate,
 		RestartContainerFunc: commonStopContainerWithPod,
 		OnlineUpdatePodFunc:  commonOnlineUpdateWithPod,
+		KBAgentReloadPodFunc: reloadPodByKBAgent,
 		RestartComponent:     restartComponent,
 	}
 }
@@ -0,0 +1,360 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	certutil "k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	tracev1 "github.com/apecloud/kubeblocks/apis/trace/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const (
+	memoryStore = "memory"
+	diskStore   = "disk"
+
+	changeHistoryPath        = "/trace/changes"
+	changeHistorySubresource = "changes"
+	changeHistoryPruneGap    = time.Hour
+)
+
+// ChangeHistoryStore keeps the object changes of the trace targets, beyond the latest reconciliation cycle
+// which is kept in the status of the ReconciliationTrace.
+type ChangeHistoryStore interface {
+	// Append appends the changes of the target object.
+	Append(target types.NamespacedName, changes []tracev1.ObjectChange) error
+
+	// Query returns the changes of the target object occurred within [from, to], ordered by the time.
+	// Zero from or to means unbounded.
+	Query(target types.NamespacedName, from, to time.Time) ([]tracev1.ObjectChange, error)
+
+	// Prune deletes the changes beyond the retention.
+	Prune(now time.Time) error
+}
+
+// ChangeHistoryRetention defines how long and how many changes will be kept for each target object.
+type ChangeHistoryRetention struct {
+	// MaxAge is the max age of the changes kept, zero means no limit.
+	MaxAge time.Duration
+	// MaxChanges is the max number of the changes kept, zero means no limit.
+	MaxChanges int
+}
+
+// NewStoresFromConfig builds the ObjectRevisionStore and ChangeHistoryStore configured, the in-memory stores will be used by default.
+func NewStoresFromConfig(scheme *runtime.Scheme) (ObjectRevisionStore, ChangeHistoryStore, error) {
+	retention := ChangeHistoryRetention{
+		MaxAge:     viper.GetDuration(constant.CfgKeyTraceStoreRetention),
+		MaxChanges: viper.GetInt(constant.CfgKeyTraceStoreMaxChanges),
+	}
+	switch kind := viper.GetString(constant.CfgKeyTraceStoreType); kind {
+	case "", memoryStore:
+		return NewObjectStore(scheme), NewChangeHistoryStore(retention), nil
+	case diskStore:
+		store, err := NewDiskStore(viper.GetString(constant.CfgKeyTraceStorePath), scheme, retention)
+		if err != nil {
+			return nil, nil, err
+		}
+		return store, store, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown trace store: %s", kind)
+	}
+}
+
+type changeHistoryStore struct {
+	lock      sync.RWMutex
+	retention ChangeHistoryRetention
+	changes   map[types.NamespacedName][]tracev1.ObjectChange
+}
+
+var _ ChangeHistoryStore = &changeHistoryStore{}
+
+// NewChangeHistoryStore creates an in-memory ChangeHistoryStore, the history will be lost after the manager restarted.
+func NewChangeHistoryStore(retention ChangeHistoryRetention) ChangeHistoryStore {
+	return &changeHistoryStore{
+		retention: retention,
+		changes:   make(map[types.NamespacedName][]tracev1.ObjectChange),
+	}
+}
+
+func (s *changeHistoryStore) Append(target types.NamespacedName, changes []tracev1.ObjectChange) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// the changes are identified by (time, revision) as the disk store does, the retried appends replace the existing ones.
+	history := slices.Clone(s.changes[target])
+	index := make(map[changeHistoryKey]int, len(history))
+	for i, change := range history {
+		index[keyOfChange(change)] = i
+	}
+	for _, change := range changes {
+		key := keyOfChange(change)
+		if i, ok := index[key]; ok {
+			history[i] = change
+			continue
+		}
+		index[key] = len(history)
+		history = append(history, change)
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return changeTime(history[i]).Before(changeTime(history[j]))
+	})
+	if s.retention.MaxChanges > 0 && len(history) > s.retention.MaxChanges {
+		history = history[len(history)-s.retention.MaxChanges:]
+	}
+	s.changes[target] = history
+	return nil
+}
+
+func (s *changeHistoryStore) Query(target types.NamespacedName, from, to time.Time) ([]tracev1.ObjectChange, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var changes []tracev1.ObjectChange
+	for _, change := range s.changes[target] {
+		if inTimeRange(changeTime(change), from, to) {
+			changes = append(changes, *change.DeepCopy())
+		}
+	}
+	return changes, nil
+}
+
+func (s *changeHistoryStore) Prune(now time.Time) error {
+	if s.retention.MaxAge <= 0 {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	expiration := now.Add(-s.retention.MaxAge)
+	for target, history := range s.changes {
+		i := sort.Search(len(history), func(i int) bool {
+			return !changeTime(history[i]).Before(expiration)
+		})
+		if i == len(history) {
+			delete(s.changes, target)
+		} else {
+			s.changes[target] = history[i:]
+		}
+	}
+	return nil
+}
+
+type changeHistoryKey struct {
+	time     int64
+	revision int64
+}
+
+func keyOfChange(change tracev1.ObjectChange) changeHistoryKey {
+	return changeHistoryKey{time: changeTime(change).UnixNano(), revision: change.Revision}
+}
+
+func changeTime(change tracev1.ObjectChange) time.Time {
+	if change.Timestamp == nil {
+		return time.Time{}
+	}
+	return change.Timestamp.Time
+}
+
+func inTimeRange(t, from, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || !t.After(to))
+}
+
+// changeHistoryPruner prunes the change history periodically.
+func changeHistoryPruner(store ChangeHistoryStore) manager.Runnable {
+	return manager.RunnableFunc(func(ctx context.Context) error {
+		ticker := time.NewTicker(changeHistoryPruneGap)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case now := <-ticker.C:
+				if err := store.Prune(now); err != nil {
+					log.FromContext(ctx).Error(err, "failed to prune the trace change history")
+				}
+			}
+		}
+	})
+}
+
+// changeHistoryServer serves the query API of the change history over HTTPS:
+//
+//	GET /trace/changes?namespace=<namespace>&name=<cluster>&from=<RFC3339>&to=<RFC3339>
+//
+// The serving certificate is loaded from the certDir (tls.crt and tls.key), a self-signed one is generated if the certDir is empty.
+// The callers are authenticated by the bearer token and authorized by the RBAC, see authorizeChangeHistoryRequest.
+func changeHistoryServer(addr, certDir string, cli client.Client, store ChangeHistoryStore) manager.Runnable {
+	return manager.RunnableFunc(func(ctx context.Context) error {
+		cert, err := loadChangeHistoryServingCert(certDir)
+		if err != nil {
+			return err
+		}
+		mux := http.NewServeMux()
+		mux.Handle(changeHistoryPath, changeHistoryHandler(cli, store))
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+			TLSConfig: &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{cert},
+			},
+		}
+		go func() {
+			<-ctx.Done()
+			_ = server.Shutdown(context.Background())
+		}()
+		if err := server.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	})
+}
+
+func loadChangeHistoryServingCert(certDir string) (tls.Certificate, error) {
+	if len(certDir) > 0 {
+		return tls.LoadX509KeyPair(filepath.Join(certDir, corev1.TLSCertKey), filepath.Join(certDir, corev1.TLSPrivateKeyKey))
+	}
+	certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey("kubeblocks-trace", nil, nil)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// authorizeChangeHistoryRequest authenticates the bearer token of the request by the TokenReview, and checks whether
+// the user is allowed to get the changes subresource of the cluster by the SubjectAccessReview, that is, the RBAC rule:
+//
+//	{apiGroups: ["apps.kubeblocks.io"], resources: ["clusters/changes"], verbs: ["get"]}
+//
+// It returns the HTTP status code if the request is rejected.
+func authorizeChangeHistoryRequest(ctx context.Context, cli client.Client, r *http.Request, target types.NamespacedName) (int, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || len(token) == 0 {
+		return http.StatusUnauthorized, fmt.Errorf("the bearer token is required")
+	}
+	tokenReview := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	if err := cli.Create(ctx, tokenReview); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !tokenReview.Status.Authenticated {
+		return http.StatusUnauthorized, fmt.Errorf("the bearer token is not authenticated: %s", tokenReview.Status.Error)
+	}
+
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	accessReview := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   target.Namespace,
+				Name:        target.Name,
+				Verb:        "get",
+				Group:       kbappsv1.GroupVersion.Group,
+				Resource:    "clusters",
+				Subresource: changeHistorySubresource,
+			},
+		},
+	}
+	if err := cli.Create(ctx, accessReview); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !accessReview.Status.Allowed {
+		return http.StatusForbidden, fmt.Errorf("user %s can not get the changes of cluster %s: %s",
+			user.Username, target.String(), accessReview.Status.Reason)
+	}
+	return http.StatusOK, nil
+}
+
+func changeHistoryHandler(cli client.Client, store ChangeHistoryStore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		query := r.URL.Query()
+		target := types.NamespacedName{Namespace: query.Get("namespace"), Name: query.Get("name")}
+		if len(target.Namespace) == 0 || len(target.Name) == 0 {
+			http.Error(w, "namespace and name are required", http.StatusBadRequest)
+			return
+		}
+		if code, err := authorizeChangeHistoryRequest(r.Context(), cli, r, target); err != nil {
+			http.Error(w, err.Error(), code)
+			return
+		}
+		var (
+			from, to time.Time
+			err      error
+		)
+		if v := query.Get("from"); len(v) > 0 {
+			if from, err = time.Parse(time.RFC3339, v); err != nil {
+				http.Error(w, fmt.Sprintf("invalid from: %s", err.Error()), http.StatusBadRequest)
+				return
+			}
+		}
+		if v := query.Get("to"); len(v) > 0 {
+			if to, err = time.Parse(time.RFC3339, v); err != nil {
+				http.Error(w, fmt.Sprintf("invalid to: %s", err.Error()), http.StatusBadRequest)
+				return
+			}
+		}
+		changes, err := store.Query(target, from, to)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if changes == nil {
+			changes = []tracev1.ObjectChange{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(changes)
+	})
+}
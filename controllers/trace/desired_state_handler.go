@@ -39,10 +39,11 @@ import (
 )
 
 type stateEvaluation struct {
-	ctx    context.Context
-	cli    client.Client
-	store  ObjectRevisionStore
-	scheme *runtime.Scheme
+	ctx      context.Context
+	cli      client.Client
+	store    ObjectRevisionStore
+	scheme   *runtime.Scheme
+	exporter CycleExporter
 }
 
 func (s *stateEvaluation) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuilderx.CheckResult {
@@ -148,6 +149,11 @@ func (s *stateEvaluation) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx
 	}
 	trace.Status.DesiredState = &plan.Plan
 
+	// export the completed reconciliation cycle before it's truncated
+	if s.exporter != nil {
+		s.exporter.Export(s.ctx, trace, trace.Status.CurrentState.Changes[:latestReconciliationCycleStart])
+	}
+
 	// delete unused object revisions
 	deleteUnusedRevisions(s.store, trace.Status.CurrentState.Changes[:latestReconciliationCycleStart], trace)
 
@@ -157,12 +163,13 @@ func (s *stateEvaluation) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx
 	return kubebuilderx.Continue, nil
 }
 
-func updateDesiredState(ctx context.Context, cli client.Client, scheme *runtime.Scheme, store ObjectRevisionStore) kubebuilderx.Reconciler {
+func updateDesiredState(ctx context.Context, cli client.Client, scheme *runtime.Scheme, store ObjectRevisionStore, exporter CycleExporter) kubebuilderx.Reconciler {
 	return &stateEvaluation{
-		ctx:    ctx,
-		cli:    cli,
-		scheme: scheme,
-		store:  store,
+		ctx:      ctx,
+		cli:      cli,
+		scheme:   scheme,
+		store:    store,
+		exporter: exporter,
 	}
 }
 
@@ -292,7 +292,7 @@ var _ = Describe("desired_state_handler test", func() {
 				}).AnyTimes()
 			k8sMock.EXPECT().Scheme().Return(scheme.Scheme).AnyTimes()
 
-			reconciler := updateDesiredState(ctx, k8sMock, scheme.Scheme, store)
+			reconciler := updateDesiredState(ctx, k8sMock, scheme.Scheme, store, nil)
 			res, err := reconciler.Reconcile(tree)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(res).Should(Equal(kubebuilderx.Continue))
@@ -0,0 +1,375 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	tracev1 "github.com/apecloud/kubeblocks/apis/trace/v1"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+var (
+	revisionsBucket  = []byte("revisions")
+	referencesBucket = []byte("references")
+	changesBucket    = []byte("changes")
+)
+
+const (
+	diskStoreOpenTimeout = 30 * time.Second
+	keySeparator         = "/"
+)
+
+// DiskStore persists the object revisions and the change history in an embedded bbolt database,
+// so they survive the manager restarts and are not bounded by the memory.
+//
+// The database file is locked exclusively, it should be placed on a volume owned by a single manager.
+type DiskStore struct {
+	db        *bolt.DB
+	scheme    *runtime.Scheme
+	retention ChangeHistoryRetention
+}
+
+var _ ObjectRevisionStore = &DiskStore{}
+var _ ChangeHistoryStore = &DiskStore{}
+
+func NewDiskStore(path string, scheme *runtime.Scheme, retention ChangeHistoryRetention) (*DiskStore, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("the path of the trace disk store is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: diskStoreOpenTimeout})
+	if err != nil {
+		return nil, err
+	}
+	if err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{revisionsBucket, referencesBucket, changesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &DiskStore{
+		db:        db,
+		scheme:    scheme,
+		retention: retention,
+	}, nil
+}
+
+func (s *DiskStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *DiskStore) Insert(object, reference client.Object) error {
+	objectRef, err := getObjectRef(object, s.scheme)
+	if err != nil {
+		return err
+	}
+	key := revisionKey(objectRef, parseRevision(object.GetResourceVersion()))
+	uid := string(reference.GetUID())
+
+	// the same revisions are inserted on each reconciliation, check it first to avoid the write transactions
+	inserted := false
+	if err = s.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(revisionsBucket).Get(key) == nil {
+			return nil
+		}
+		uids, err := decodeReferences(tx.Bucket(referencesBucket).Get(key))
+		inserted = slices.Contains(uids, uid)
+		return err
+	}); err != nil || inserted {
+		return err
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(revisionsBucket).Put(key, data); err != nil {
+			return err
+		}
+		references := tx.Bucket(referencesBucket)
+		uids, err := decodeReferences(references.Get(key))
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(uids, uid) {
+			uids = append(uids, uid)
+		}
+		return putReferences(references, key, uids)
+	})
+}
+
+func (s *DiskStore) Get(objectRef *model.GVKNObjKey, revision int64) (client.Object, error) {
+	var object client.Object
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(revisionsBucket).Get(revisionKey(objectRef, revision))
+		if data == nil {
+			return apierrors.NewNotFound(objectRef.GroupVersion().WithResource(strings.ToLower(objectRef.Kind)).GroupResource(), objectRef.Name)
+		}
+		var err error
+		object, err = s.decodeObject(objectRef.GroupVersionKind, data)
+		return err
+	})
+	return object, err
+}
+
+func (s *DiskStore) List(gvk *schema.GroupVersionKind) map[types.NamespacedName]map[int64]client.Object {
+	var objectMap map[types.NamespacedName]map[int64]client.Object
+	_ = s.db.View(func(tx *bolt.Tx) error {
+		prefix := gvkKeyPrefix(*gvk)
+		c := tx.Bucket(revisionsBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			name, revision, ok := parseRevisionKey(k[len(prefix):])
+			if !ok {
+				continue
+			}
+			object, err := s.decodeObject(*gvk, v)
+			if err != nil {
+				continue
+			}
+			if objectMap == nil {
+				objectMap = make(map[types.NamespacedName]map[int64]client.Object)
+			}
+			if objectMap[name] == nil {
+				objectMap[name] = make(map[int64]client.Object)
+			}
+			objectMap[name][revision] = object
+		}
+		return nil
+	})
+	return objectMap
+}
+
+func (s *DiskStore) Delete(objectRef *model.GVKNObjKey, reference client.Object, revision int64) {
+	key := revisionKey(objectRef, revision)
+	_ = s.db.Update(func(tx *bolt.Tx) error {
+		references := tx.Bucket(referencesBucket)
+		uids, err := decodeReferences(references.Get(key))
+		if err != nil {
+			return err
+		}
+		uids = slices.DeleteFunc(uids, func(uid string) bool {
+			return uid == string(reference.GetUID())
+		})
+		if len(uids) > 0 {
+			return putReferences(references, key, uids)
+		}
+		if err = references.Delete(key); err != nil {
+			return err
+		}
+		return tx.Bucket(revisionsBucket).Delete(key)
+	})
+}
+
+func (s *DiskStore) Append(target types.NamespacedName, changes []tracev1.ObjectChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.Bucket(changesBucket).CreateBucketIfNotExists([]byte(target.String()))
+		if err != nil {
+			return err
+		}
+		for _, change := range changes {
+			data, err := json.Marshal(change)
+			if err != nil {
+				return err
+			}
+			if err = bucket.Put(changeKey(change), data); err != nil {
+				return err
+			}
+		}
+		if s.retention.MaxChanges <= 0 {
+			return nil
+		}
+		var keys [][]byte
+		c := bucket.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			keys = append(keys, slices.Clone(k))
+		}
+		return deleteKeys(bucket, keys[:max(len(keys)-s.retention.MaxChanges, 0)])
+	})
+}
+
+func (s *DiskStore) Query(target types.NamespacedName, from, to time.Time) ([]tracev1.ObjectChange, error) {
+	var changes []tracev1.ObjectChange
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(changesBucket).Bucket([]byte(target.String()))
+		if bucket == nil {
+			return nil
+		}
+		c := bucket.Cursor()
+		k, v := c.First()
+		if !from.IsZero() {
+			k, v = c.Seek(timeKey(from))
+		}
+		for ; k != nil; k, v = c.Next() {
+			change := tracev1.ObjectChange{}
+			if err := json.Unmarshal(v, &change); err != nil {
+				return err
+			}
+			if !to.IsZero() && changeTime(change).After(to) {
+				break
+			}
+			changes = append(changes, change)
+		}
+		return nil
+	})
+	return changes, err
+}
+
+func (s *DiskStore) Prune(now time.Time) error {
+	if s.retention.MaxAge <= 0 {
+		return nil
+	}
+	expiration := timeKey(now.Add(-s.retention.MaxAge))
+	return s.db.Update(func(tx *bolt.Tx) error {
+		changes := tx.Bucket(changesBucket)
+		var emptyTargets [][]byte
+		if err := changes.ForEachBucket(func(name []byte) error {
+			bucket := changes.Bucket(name)
+			var keys [][]byte
+			c := bucket.Cursor()
+			for k, _ := c.First(); k != nil && bytes.Compare(k[:len(expiration)], expiration) < 0; k, _ = c.Next() {
+				keys = append(keys, slices.Clone(k))
+			}
+			if err := deleteKeys(bucket, keys); err != nil {
+				return err
+			}
+			if k, _ := bucket.Cursor().First(); k == nil {
+				emptyTargets = append(emptyTargets, slices.Clone(name))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, name := range emptyTargets {
+			if err := changes.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *DiskStore) decodeObject(gvk schema.GroupVersionKind, data []byte) (client.Object, error) {
+	obj, err := s.scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a client.Object", gvk.String())
+	}
+	if err = json.Unmarshal(data, object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// gvkKeyPrefix returns the key prefix of the revisions of the GVK: <group>/<version>/<kind>/
+func gvkKeyPrefix(gvk schema.GroupVersionKind) []byte {
+	return []byte(strings.Join([]string{gvk.Group, gvk.Version, gvk.Kind, ""}, keySeparator))
+}
+
+// revisionKey returns the key of the object revision: <group>/<version>/<kind>/<namespace>/<name>/<revision>,
+// the revision is zero-padded to keep the keys of an object in order.
+func revisionKey(objectRef *model.GVKNObjKey, revision int64) []byte {
+	return append(gvkKeyPrefix(objectRef.GroupVersionKind),
+		[]byte(fmt.Sprintf("%s%s%s%s%020d", objectRef.Namespace, keySeparator, objectRef.Name, keySeparator, revision))...)
+}
+
+func parseRevisionKey(key []byte) (types.NamespacedName, int64, bool) {
+	parts := strings.Split(string(key), keySeparator)
+	if len(parts) != 3 {
+		return types.NamespacedName{}, 0, false
+	}
+	revision, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return types.NamespacedName{}, 0, false
+	}
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, revision, true
+}
+
+// timeKey encodes the time in big-endian to keep the keys in time order.
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(max(t.UnixNano(), 0)))
+	return key
+}
+
+// changeKey returns the key of the change: <timestamp><revision>.
+func changeKey(change tracev1.ObjectChange) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(max(change.Revision, 0)))
+	return append(timeKey(changeTime(change)), key...)
+}
+
+func deleteKeys(bucket *bolt.Bucket, keys [][]byte) error {
+	for _, key := range keys {
+		if err := bucket.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeReferences(data []byte) ([]string, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var uids []string
+	if err := json.Unmarshal(data, &uids); err != nil {
+		return nil, err
+	}
+	return uids, nil
+}
+
+func putReferences(bucket *bolt.Bucket, key []byte, uids []string) error {
+	data, err := json.Marshal(uids)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(key, data)
+}
@@ -0,0 +1,229 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	tracev1 "github.com/apecloud/kubeblocks/apis/trace/v1"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+)
+
+var _ = Describe("disk_store test", func() {
+	var (
+		path  string
+		store *DiskStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		path = filepath.Join(GinkgoT().TempDir(), "trace", "trace.db")
+		store, err = NewDiskStore(path, scheme.Scheme, ChangeHistoryRetention{MaxAge: time.Hour, MaxChanges: 3})
+		Expect(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		Expect(store.Close()).Should(Succeed())
+	})
+
+	Context("Testing object revisions", func() {
+		It("should survive the restart", func() {
+			By("Insert a component")
+			primary := builder.NewClusterBuilder(namespace, name).SetUID(uid).SetResourceVersion(resourceVersion).GetObject()
+			secondary := builder.NewComponentBuilder(namespace, fmt.Sprintf("%s-%s", primary.Name, "test"), "").
+				SetOwnerReferences(kbappsv1.APIVersion, kbappsv1.ClusterKind, primary).
+				SetUID(uid).
+				GetObject()
+			secondary.ResourceVersion = resourceVersion
+			Expect(store.Insert(secondary, primary)).Should(Succeed())
+			Expect(store.Insert(secondary, primary)).Should(Succeed())
+			objectRef, err := getObjectRef(secondary, scheme.Scheme)
+			Expect(err).Should(BeNil())
+			revision := parseRevision(secondary.ResourceVersion)
+
+			By("Reopen the store")
+			Expect(store.Close()).Should(Succeed())
+			store, err = NewDiskStore(path, scheme.Scheme, ChangeHistoryRetention{})
+			Expect(err).Should(BeNil())
+
+			By("Get the component with right revision")
+			obj, err := store.Get(objectRef, revision)
+			Expect(err).Should(BeNil())
+			Expect(obj.GetName()).Should(Equal(secondary.Name))
+			Expect(obj.GetResourceVersion()).Should(Equal(secondary.ResourceVersion))
+
+			By("Get the component with wrong revision")
+			_, err = store.Get(objectRef, revision+1)
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+
+			By("List all components")
+			objects := store.List(&objectRef.GroupVersionKind)
+			Expect(objects).Should(HaveLen(1))
+			Expect(objects[objectRef.ObjectKey]).Should(HaveKey(revision))
+
+			By("Delete the component")
+			store.Delete(objectRef, primary, revision)
+			_, err = store.Get(objectRef, revision)
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+			Expect(store.List(&objectRef.GroupVersionKind)).Should(BeEmpty())
+		})
+	})
+
+	Context("Testing change history", func() {
+		It("should query and prune the changes", func() {
+			testChangeHistoryStore(store)
+		})
+
+		It("should work well in memory", func() {
+			testChangeHistoryStore(NewChangeHistoryStore(ChangeHistoryRetention{MaxAge: time.Hour, MaxChanges: 3}))
+		})
+
+		It("should serve the query API", func() {
+			target := types.NamespacedName{Namespace: namespace, Name: name}
+			now := time.Now().Truncate(time.Second)
+			Expect(store.Append(target, []tracev1.ObjectChange{
+				{ChangeType: tracev1.ObjectCreationType, Revision: 1, Timestamp: &metav1.Time{Time: now.Add(-time.Minute)}},
+				{ChangeType: tracev1.ObjectUpdateType, Revision: 2, Timestamp: &metav1.Time{Time: now}},
+			})).Should(Succeed())
+			cli := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					switch review := obj.(type) {
+					case *authenticationv1.TokenReview:
+						review.Status.Authenticated = review.Spec.Token == "valid-token" || review.Spec.Token == "forbidden-token"
+						review.Status.User.Username = review.Spec.Token
+					case *authorizationv1.SubjectAccessReview:
+						attrs := review.Spec.ResourceAttributes
+						review.Status.Allowed = review.Spec.User == "valid-token" && attrs.Group == kbappsv1.GroupVersion.Group &&
+							attrs.Resource == "clusters" && attrs.Subresource == "changes" && attrs.Verb == "get" &&
+							attrs.Namespace == namespace && attrs.Name == name
+					}
+					return nil
+				},
+			}).Build()
+			handler := changeHistoryHandler(cli, store)
+
+			queryWithToken := func(url, token string) (int, []tracev1.ObjectChange) {
+				recorder := httptest.NewRecorder()
+				request := httptest.NewRequest(http.MethodGet, url, nil)
+				if len(token) > 0 {
+					request.Header.Set("Authorization", "Bearer "+token)
+				}
+				handler.ServeHTTP(recorder, request)
+				var changes []tracev1.ObjectChange
+				if recorder.Code == http.StatusOK {
+					Expect(json.Unmarshal(recorder.Body.Bytes(), &changes)).Should(Succeed())
+				}
+				return recorder.Code, changes
+			}
+			query := func(url string) (int, []tracev1.ObjectChange) {
+				return queryWithToken(url, "valid-token")
+			}
+			code, changes := query(fmt.Sprintf("%s?namespace=%s&name=%s&from=%s", changeHistoryPath, namespace, name,
+				url.QueryEscape(now.Add(-time.Second).Format(time.RFC3339))))
+			Expect(code).Should(Equal(http.StatusOK))
+			Expect(changes).Should(HaveLen(1))
+			Expect(changes[0].Revision).Should(BeEquivalentTo(2))
+
+			code, _ = query(fmt.Sprintf("%s?namespace=%s", changeHistoryPath, namespace))
+			Expect(code).Should(Equal(http.StatusBadRequest))
+			code, _ = query(fmt.Sprintf("%s?namespace=%s&name=%s&to=yesterday", changeHistoryPath, namespace, name))
+			Expect(code).Should(Equal(http.StatusBadRequest))
+
+			By("Reject the unauthenticated and unauthorized requests")
+			url := fmt.Sprintf("%s?namespace=%s&name=%s", changeHistoryPath, namespace, name)
+			code, _ = queryWithToken(url, "")
+			Expect(code).Should(Equal(http.StatusUnauthorized))
+			code, _ = queryWithToken(url, "invalid-token")
+			Expect(code).Should(Equal(http.StatusUnauthorized))
+			code, _ = queryWithToken(url, "forbidden-token")
+			Expect(code).Should(Equal(http.StatusForbidden))
+			code, _ = query(fmt.Sprintf("%s?namespace=%s&name=%s", changeHistoryPath, "other", name))
+			Expect(code).Should(Equal(http.StatusForbidden))
+		})
+	})
+})
+
+func testChangeHistoryStore(store ChangeHistoryStore) {
+	target := types.NamespacedName{Namespace: namespace, Name: name}
+	now := time.Now().Truncate(time.Second)
+	newChange := func(revision int64, age time.Duration) tracev1.ObjectChange {
+		return tracev1.ObjectChange{
+			ChangeType:  tracev1.ObjectUpdateType,
+			Revision:    revision,
+			Timestamp:   &metav1.Time{Time: now.Add(-age)},
+			Description: fmt.Sprintf("change-%d", revision),
+		}
+	}
+	revisionsOf := func(changes []tracev1.ObjectChange) []int64 {
+		var revisions []int64
+		for _, change := range changes {
+			revisions = append(revisions, change.Revision)
+		}
+		return revisions
+	}
+
+	By("Append the changes, the oldest ones beyond the max changes are dropped")
+	Expect(store.Append(target, []tracev1.ObjectChange{newChange(1, 4*time.Hour), newChange(2, 3*time.Hour)})).Should(Succeed())
+	Expect(store.Append(target, []tracev1.ObjectChange{newChange(3, 30*time.Minute), newChange(4, 10*time.Minute)})).Should(Succeed())
+	changes, err := store.Query(target, time.Time{}, time.Time{})
+	Expect(err).Should(BeNil())
+	Expect(revisionsOf(changes)).Should(Equal([]int64{2, 3, 4}))
+
+	By("Append the same changes again, the retries are idempotent")
+	Expect(store.Append(target, []tracev1.ObjectChange{newChange(3, 30*time.Minute), newChange(4, 10*time.Minute)})).Should(Succeed())
+	changes, err = store.Query(target, time.Time{}, time.Time{})
+	Expect(err).Should(BeNil())
+	Expect(revisionsOf(changes)).Should(Equal([]int64{2, 3, 4}))
+
+	By("Query the changes within a time range")
+	changes, err = store.Query(target, now.Add(-time.Hour), now.Add(-20*time.Minute))
+	Expect(err).Should(BeNil())
+	Expect(revisionsOf(changes)).Should(Equal([]int64{3}))
+	changes, err = store.Query(types.NamespacedName{Namespace: namespace, Name: "unknown"}, time.Time{}, time.Time{})
+	Expect(err).Should(BeNil())
+	Expect(changes).Should(BeEmpty())
+
+	By("Prune the changes beyond the max age")
+	Expect(store.Prune(now)).Should(Succeed())
+	changes, err = store.Query(target, time.Time{}, time.Time{})
+	Expect(err).Should(BeNil())
+	Expect(revisionsOf(changes)).Should(Equal([]int64{3, 4}))
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 type dryRunner struct {
@@ -77,19 +78,38 @@ func (r *dryRunner) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx.Resul
 		return kubebuilderx.Commit, err
 	}
 
-	generator := newPlanGenerator(r.ctx, r.cli, r.scheme,
-		cacheObjectLoader(r.ctx, r.cli, root, getKBOwnershipRules()),
-		buildDescriptionFormatter(i18nResource, defaultLocale, trace.Spec.Locale))
-
-	desiredRoot, err := applySpec(root.DeepCopy(), trace.Spec.DryRun.DesiredSpec)
+	var (
+		desiredRoot *kbappsv1.Cluster
+		preparers   []planPreparer
+		err         error
+	)
+	if len(trace.Spec.DryRun.OpsRequestSpec) > 0 {
+		desiredRoot, preparers, err = applyOpsRequest(r.ctx, r.cli, trace, root, trace.Spec.DryRun.OpsRequestSpec)
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			trace.Status.DryRunResult = &tracev1.DryRunResult{
+				Phase:                    tracev1.DryRunFailedPhase,
+				Reason:                   "InvalidOpsRequest",
+				Message:                  err.Error(),
+				DesiredSpecRevision:      getDryRunRevision(trace.Spec.DryRun),
+				ObservedTargetGeneration: root.Generation,
+			}
+			return kubebuilderx.Continue, nil
+		}
+	} else {
+		desiredRoot, err = applySpec(root.DeepCopy(), trace.Spec.DryRun.DesiredSpec)
+	}
 	if err != nil {
 		return kubebuilderx.Commit, err
 	}
-	plan, err := generator.generatePlan(desiredRoot)
+
+	generator := newPlanGenerator(r.ctx, r.cli, r.scheme,
+		cacheObjectLoader(r.ctx, r.cli, root, getKBOwnershipRules()),
+		buildDescriptionFormatter(i18nResource, defaultLocale, trace.Spec.Locale))
+	plan, err := generator.generatePlan(desiredRoot, preparers...)
 	if err != nil {
 		return kubebuilderx.Commit, err
 	}
-	plan.DesiredSpecRevision = getDesiredSpecRevision(trace.Spec.DryRun.DesiredSpec)
+	plan.DesiredSpecRevision = getDryRunRevision(trace.Spec.DryRun)
 	trace.Status.DryRunResult = plan
 
 	return kubebuilderx.Continue, nil
@@ -166,13 +186,17 @@ func isDesiredSpecChanged(v *tracev1.ReconciliationTrace) bool {
 	if v.Spec.DryRun == nil || v.Status.DryRunResult == nil {
 		return true
 	}
-	revision := getDesiredSpecRevision(v.Spec.DryRun.DesiredSpec)
+	revision := getDryRunRevision(v.Spec.DryRun)
 	return revision != v.Status.DryRunResult.DesiredSpecRevision
 }
 
-func getDesiredSpecRevision(desiredSpec string) string {
+func getDryRunRevision(dryRun *tracev1.DryRun) string {
 	hf := fnv.New32()
-	_, _ = hf.Write([]byte(desiredSpec))
+	_, _ = hf.Write([]byte(dryRun.DesiredSpec))
+	if len(dryRun.OpsRequestSpec) > 0 {
+		_, _ = hf.Write([]byte("\n---\n"))
+		_, _ = hf.Write([]byte(dryRun.OpsRequestSpec))
+	}
 	return rand.SafeEncodeString(fmt.Sprint(hf.Sum32()))
 }
 
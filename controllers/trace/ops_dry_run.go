@@ -0,0 +1,173 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/controllers/parameters"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/operations"
+)
+
+// dryRunOpsTypes are the OpsRequest types whose handlers change the Cluster spec or create the objects
+// reconciled into the Cluster, which can be previewed by the plan generator.
+var dryRunOpsTypes = map[opsv1alpha1.OpsType]bool{
+	opsv1alpha1.VerticalScalingType:   true,
+	opsv1alpha1.HorizontalScalingType: true,
+	opsv1alpha1.UpgradeType:           true,
+	opsv1alpha1.ReconfiguringType:     true,
+	opsv1alpha1.VolumeExpansionType:   true,
+	opsv1alpha1.RestartType:           true,
+	opsv1alpha1.StartType:             true,
+	opsv1alpha1.StopType:              true,
+}
+
+// applyOpsRequest runs the OpsRequest handler against a copy of the root without persisting anything,
+// and returns the desired root and the preparers replaying the objects created by the handler.
+func applyOpsRequest(ctx context.Context, cli client.Client, trace client.Object, root *kbappsv1.Cluster, opsRequestSpec string) (*kbappsv1.Cluster, []planPreparer, error) {
+	spec := opsv1alpha1.OpsRequestSpec{}
+	if err := yaml.Unmarshal([]byte(opsRequestSpec), &spec); err != nil {
+		return nil, nil, intctrlutil.NewFatalError(fmt.Sprintf("failed to unmarshal opsRequestSpec: %s", err.Error()))
+	}
+	if !dryRunOpsTypes[spec.Type] {
+		return nil, nil, intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal, "dry-run of OpsRequest type %s is not supported", spec.Type)
+	}
+	spec.ClusterName = root.Name
+
+	opsRes := &operations.OpsResource{
+		OpsRequest: &opsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         root.Namespace,
+				Name:              fmt.Sprintf("%s-dry-run", trace.GetName()),
+				CreationTimestamp: metav1.Now(),
+			},
+			Spec: spec,
+			// the handlers like restart take the start timestamp as the point to apply the changes
+			Status: opsv1alpha1.OpsRequestStatus{
+				StartTimestamp: metav1.Now(),
+			},
+		},
+		Cluster:  root.DeepCopy(),
+		Recorder: &record.FakeRecorder{},
+	}
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      ctrl.Request{NamespacedName: client.ObjectKeyFromObject(opsRes.OpsRequest)},
+		Log:      log.FromContext(ctx).WithName("OpsRequestDryRun"),
+		Recorder: opsRes.Recorder,
+	}
+	dryRunCli := &opsDryRunClient{Client: cli}
+	if err := operations.GetOpsManager().DryRun(reqCtx, dryRunCli, opsRes); err != nil {
+		return nil, nil, err
+	}
+
+	var preparers []planPreparer
+	for i := range dryRunCli.created {
+		preparers = append(preparers, replayCreation(dryRunCli.created[i]))
+	}
+	return opsRes.Cluster, preparers, nil
+}
+
+// replayCreation creates the object in the plan generation, and runs the controller of it if the object
+// is not part of the object tree.
+func replayCreation(obj client.Object) planPreparer {
+	return func(ctx context.Context, cli client.Client, recorder record.EventRecorder) error {
+		if err := cli.Create(ctx, obj); err != nil {
+			return err
+		}
+		if _, ok := obj.(*parametersv1alpha1.Parameter); !ok {
+			return nil
+		}
+		reconciler := &parameters.ParameterReconciler{
+			Client:   cli,
+			Scheme:   cli.Scheme(),
+			Recorder: recorder,
+		}
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+		return err
+	}
+}
+
+// opsDryRunClient reads through the real client and drops all the writes,
+// the objects created are kept to be replayed in the plan generation.
+type opsDryRunClient struct {
+	client.Client
+	created []client.Object
+}
+
+func (c *opsDryRunClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.created = append(c.created, obj.DeepCopyObject().(client.Object))
+	return nil
+}
+
+func (c *opsDryRunClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return nil
+}
+
+func (c *opsDryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return nil
+}
+
+func (c *opsDryRunClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return nil
+}
+
+func (c *opsDryRunClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return nil
+}
+
+func (c *opsDryRunClient) Status() client.SubResourceWriter {
+	return &opsDryRunSubResourceClient{}
+}
+
+func (c *opsDryRunClient) SubResource(subResource string) client.SubResourceClient {
+	return &opsDryRunSubResourceClient{SubResourceReader: c.Client.SubResource(subResource)}
+}
+
+type opsDryRunSubResourceClient struct {
+	client.SubResourceReader
+}
+
+func (c *opsDryRunSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return nil
+}
+
+func (c *opsDryRunSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return nil
+}
+
+func (c *opsDryRunSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return nil
+}
+
+var _ client.Client = &opsDryRunClient{}
+var _ client.SubResourceClient = &opsDryRunSubResourceClient{}
@@ -0,0 +1,192 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	tracev1 "github.com/apecloud/kubeblocks/apis/trace/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	testutil "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
+	"github.com/apecloud/kubeblocks/pkg/testutil/k8s/mocks"
+)
+
+var _ = Describe("ops_dry_run test", func() {
+	var (
+		k8sMock    *mocks.MockClient
+		controller *gomock.Controller
+		cluster    *kbappsv1.Cluster
+		trace      *tracev1.ReconciliationTrace
+	)
+
+	BeforeEach(func() {
+		controller, k8sMock = testutil.SetupK8sMock()
+		k8sMock.EXPECT().Scheme().Return(scheme.Scheme).AnyTimes()
+
+		cluster = &kbappsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  namespace,
+				Name:       name,
+				UID:        uid,
+				Generation: 1,
+			},
+			Spec: kbappsv1.ClusterSpec{
+				ComponentSpecs: []kbappsv1.ClusterComponentSpec{{
+					Name:     name,
+					Replicas: 1,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("1"),
+						},
+					},
+				}},
+			},
+		}
+		trace = &tracev1.ReconciliationTrace{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		controller.Finish()
+	})
+
+	Context("Testing applyOpsRequest", func() {
+		It("should build the desired root by the ops handler", func() {
+			k8sMock.EXPECT().
+				Get(gomock.Any(), gomock.Any(), &parametersv1alpha1.Parameter{}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, objKey client.ObjectKey, obj *parametersv1alpha1.Parameter, _ ...client.GetOption) error {
+					return apierrors.NewNotFound(parametersv1alpha1.Resource("parameters"), objKey.Name)
+				}).Times(1)
+			k8sMock.EXPECT().
+				Get(gomock.Any(), gomock.Any(), &kbappsv1.Component{}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, objKey client.ObjectKey, obj *kbappsv1.Component, _ ...client.GetOption) error {
+					return apierrors.NewNotFound(kbappsv1.Resource("components"), objKey.Name)
+				}).Times(1)
+			k8sMock.EXPECT().
+				List(gomock.Any(), &kbappsv1.ComponentList{}, gomock.Any()).
+				Return(nil).Times(1)
+			opsRequestSpec := `
+type: VerticalScaling
+clusterName: other
+verticalScaling:
+- componentName: bar
+  requests:
+    cpu: "2"
+`
+			desiredRoot, preparers, err := applyOpsRequest(ctx, k8sMock, trace, cluster, opsRequestSpec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(preparers).Should(BeEmpty())
+			Expect(desiredRoot.Spec.ComponentSpecs[0].Resources.Requests.Cpu().String()).Should(Equal("2"))
+			Expect(cluster.Spec.ComponentSpecs[0].Resources.Requests.Cpu().String()).Should(Equal("1"))
+		})
+
+		It("should replay the Parameter created by reconfiguring", func() {
+			k8sMock.EXPECT().
+				Get(gomock.Any(), gomock.Any(), &parametersv1alpha1.Parameter{}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, objKey client.ObjectKey, obj *parametersv1alpha1.Parameter, _ ...client.GetOption) error {
+					return apierrors.NewNotFound(parametersv1alpha1.Resource("parameters"), objKey.Name)
+				}).Times(1)
+			k8sMock.EXPECT().
+				Get(gomock.Any(), gomock.Any(), &kbappsv1.Component{}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, objKey client.ObjectKey, obj *kbappsv1.Component, _ ...client.GetOption) error {
+					return apierrors.NewNotFound(kbappsv1.Resource("components"), objKey.Name)
+				}).Times(1)
+			k8sMock.EXPECT().
+				List(gomock.Any(), &kbappsv1.ComponentList{}, gomock.Any()).
+				Return(nil).Times(1)
+			opsRequestSpec := `
+type: Reconfiguring
+clusterName: bar
+reconfigures:
+- componentName: bar
+  parameters:
+  - key: max_connections
+    value: "1000"
+`
+			desiredRoot, preparers, err := applyOpsRequest(ctx, k8sMock, trace, cluster, opsRequestSpec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(preparers).Should(HaveLen(1))
+			Expect(desiredRoot.Spec).Should(Equal(cluster.Spec))
+		})
+
+		It("should build the desired root by the restart handler", func() {
+			opsRequestSpec := `
+type: Restart
+clusterName: bar
+restart:
+- componentName: bar
+`
+			desiredRoot, preparers, err := applyOpsRequest(ctx, k8sMock, trace, cluster, opsRequestSpec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(preparers).Should(BeEmpty())
+			Expect(desiredRoot.Spec.ComponentSpecs[0].Annotations).Should(HaveKey(constant.RestartAnnotationKey))
+			Expect(cluster.Spec.ComponentSpecs[0].Annotations).ShouldNot(HaveKey(constant.RestartAnnotationKey))
+		})
+
+		It("should reject the unsupported ops type", func() {
+			_, _, err := applyOpsRequest(ctx, k8sMock, trace, cluster, "type: Switchover")
+			Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal)).Should(BeTrue())
+		})
+	})
+
+	Context("Testing dry-run with an invalid OpsRequest", func() {
+		It("should fail the dry-run result", func() {
+			trace.Spec.DryRun = &tracev1.DryRun{OpsRequestSpec: "type: Expose"}
+			k8sMock.EXPECT().
+				Get(gomock.Any(), gomock.Any(), &kbappsv1.Cluster{}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, objKey client.ObjectKey, obj *kbappsv1.Cluster, _ ...client.GetOption) error {
+					*obj = *cluster.DeepCopy()
+					return nil
+				}).Times(1)
+
+			tree := kubebuilderx.NewObjectTree()
+			tree.SetRoot(trace)
+			reconciler := dryRun(ctx, k8sMock, scheme.Scheme)
+			Expect(reconciler.PreCondition(tree)).To(Equal(kubebuilderx.ConditionSatisfied))
+			res, err := reconciler.Reconcile(tree)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).Should(Equal(kubebuilderx.Continue))
+			Expect(trace.Status.DryRunResult).ShouldNot(BeNil())
+			Expect(trace.Status.DryRunResult.Phase).Should(Equal(tracev1.DryRunFailedPhase))
+			Expect(trace.Status.DryRunResult.Reason).Should(Equal("InvalidOpsRequest"))
+			Expect(trace.Status.DryRunResult.DesiredSpecRevision).Should(Equal(getDryRunRevision(trace.Spec.DryRun)))
+			Expect(reconciler.PreCondition(tree)).To(Equal(kubebuilderx.ConditionUnsatisfied))
+		})
+	})
+})
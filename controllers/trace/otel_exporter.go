@@ -0,0 +1,277 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	tracev1 "github.com/apecloud/kubeblocks/apis/trace/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const (
+	otlpExporter   = "otlp"
+	fileExporter   = "file"
+	stdoutExporter = "stdout"
+
+	tracerName  = "github.com/apecloud/kubeblocks/controllers/trace"
+	serviceName = "kubeblocks-reconciliation-trace"
+
+	exporterShutdownTimeout = 10 * time.Second
+)
+
+// CycleExporter exports the completed reconciliation cycles of the ReconciliationTrace objects,
+// which will be truncated from the status.
+type CycleExporter interface {
+	manager.Runnable
+
+	// Export exports the changes of a completed reconciliation cycle.
+	Export(ctx context.Context, trace *tracev1.ReconciliationTrace, changes []tracev1.ObjectChange)
+}
+
+// NewCycleExporterFromConfig builds the CycleExporter configured, nil will be returned if no exporter is configured.
+func NewCycleExporterFromConfig(ctx context.Context) (CycleExporter, error) {
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+		err      error
+	)
+	switch kind := viper.GetString(constant.CfgKeyTraceExporter); kind {
+	case "":
+		return nil, nil
+	case otlpExporter:
+		var opts []otlptracegrpc.Option
+		if endpoint := viper.GetString(constant.CfgKeyTraceExporterEndpoint); len(endpoint) > 0 {
+			opts = append(opts, otlptracegrpc.WithEndpoint(endpoint))
+		}
+		if viper.GetBool(constant.CfgKeyTraceExporterInsecure) {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case fileExporter, stdoutExporter:
+		var w io.Writer = os.Stdout
+		if kind == fileExporter {
+			path := viper.GetString(constant.CfgKeyTraceExporterFilePath)
+			if len(path) == 0 {
+				return nil, fmt.Errorf("the file path of the trace exporter is required")
+			}
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+			if err != nil {
+				return nil, err
+			}
+			w, closer = f, f
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(w))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter: %s", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newOTelCycleExporter(closer, sdktrace.WithBatcher(exporter)), nil
+}
+
+// NewCycleExporter creates a CycleExporter which emits each reconciliation cycle as an OpenTelemetry trace.
+func NewCycleExporter(opts ...sdktrace.TracerProviderOption) CycleExporter {
+	return newOTelCycleExporter(nil, opts...)
+}
+
+func newOTelCycleExporter(closer io.Closer, opts ...sdktrace.TracerProviderOption) *otelCycleExporter {
+	opts = append([]sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	}, opts...)
+	provider := sdktrace.NewTracerProvider(opts...)
+	return &otelCycleExporter{
+		provider: provider,
+		tracer:   provider.Tracer(tracerName),
+		closer:   closer,
+		exported: make(map[types.NamespacedName]exportedCycle),
+	}
+}
+
+type otelCycleExporter struct {
+	provider *sdktrace.TracerProvider
+	tracer   oteltrace.Tracer
+	closer   io.Closer
+
+	lock     sync.Mutex
+	exported map[types.NamespacedName]exportedCycle
+}
+
+// exportedCycle records the last change of the latest cycle exported for a ReconciliationTrace.
+type exportedCycle struct {
+	uid  types.UID
+	last changeHistoryKey
+}
+
+var _ CycleExporter = &otelCycleExporter{}
+
+func (e *otelCycleExporter) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), exporterShutdownTimeout)
+	defer cancel()
+	err := e.provider.Shutdown(shutdownCtx)
+	if e.closer != nil {
+		_ = e.closer.Close()
+	}
+	return err
+}
+
+// Export emits the reconciliation cycle as a trace, with a span per ObjectChange which lasts until the next change
+// of the same object, and the Kubernetes Events as the events of the span of their involved objects.
+func (e *otelCycleExporter) Export(ctx context.Context, trace *tracev1.ReconciliationTrace, changes []tracev1.ObjectChange) {
+	if len(changes) == 0 || !e.markExported(trace, changes) {
+		return
+	}
+
+	start, end := cycleTimeRange(changes)
+	target := tracev1.ObjectReference{Namespace: trace.Namespace, Name: trace.Name}
+	if trace.Spec.TargetObject != nil {
+		target = *trace.Spec.TargetObject
+	}
+	ctx, root := e.tracer.Start(ctx, "ReconciliationCycle",
+		oteltrace.WithNewRoot(),
+		oteltrace.WithTimestamp(start),
+		oteltrace.WithAttributes(
+			attribute.String("trace.namespace", trace.Namespace),
+			attribute.String("trace.name", trace.Name),
+			attribute.String("target.namespace", target.Namespace),
+			attribute.String("target.name", target.Name),
+			attribute.Int("changes", len(changes)),
+		))
+
+	type objectKey struct {
+		apiVersion, kind, namespace, name string
+	}
+	keyOf := func(ref corev1.ObjectReference) objectKey {
+		return objectKey{ref.APIVersion, ref.Kind, ref.Namespace, ref.Name}
+	}
+	spans := make(map[objectKey]oteltrace.Span)
+	for _, change := range changes {
+		timestamp := start
+		if change.Timestamp != nil {
+			timestamp = change.Timestamp.Time
+		}
+		key := keyOf(change.ObjectReference)
+
+		if change.ChangeType == tracev1.EventType {
+			span, ok := spans[key]
+			if !ok {
+				span = root
+			}
+			span.AddEvent(change.Description, oteltrace.WithTimestamp(timestamp), oteltrace.WithAttributes(eventAttributes(change)...))
+			if change.EventAttributes != nil && change.EventAttributes.Type == corev1.EventTypeWarning {
+				span.SetStatus(codes.Error, change.EventAttributes.Reason)
+			}
+			continue
+		}
+
+		if span, ok := spans[key]; ok {
+			span.End(oteltrace.WithTimestamp(timestamp))
+		}
+		_, spans[key] = e.tracer.Start(ctx, fmt.Sprintf("%s %s", change.ChangeType, change.ObjectReference.Kind),
+			oteltrace.WithTimestamp(timestamp),
+			oteltrace.WithAttributes(changeAttributes(change)...))
+	}
+	for _, span := range spans {
+		span.End(oteltrace.WithTimestamp(end))
+	}
+	root.End(oteltrace.WithTimestamp(end))
+}
+
+// markExported records the cycle as exported, and returns false if it has been exported already.
+// The cycle will be exported again if the status update which truncates it is conflicted and retried,
+// so the cycles are identified by their last changes to export each only once.
+func (e *otelCycleExporter) markExported(trace *tracev1.ReconciliationTrace, changes []tracev1.ObjectChange) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	key := types.NamespacedName{Namespace: trace.Namespace, Name: trace.Name}
+	last := keyOfChange(changes[len(changes)-1])
+	if exported, ok := e.exported[key]; ok && exported.uid == trace.UID {
+		if last.time < exported.last.time || (last.time == exported.last.time && last.revision <= exported.last.revision) {
+			return false
+		}
+	}
+	e.exported[key] = exportedCycle{uid: trace.UID, last: last}
+	return true
+}
+
+func cycleTimeRange(changes []tracev1.ObjectChange) (time.Time, time.Time) {
+	var start, end time.Time
+	for _, change := range changes {
+		if change.Timestamp == nil {
+			continue
+		}
+		if start.IsZero() || change.Timestamp.Time.Before(start) {
+			start = change.Timestamp.Time
+		}
+		if end.IsZero() || end.Before(change.Timestamp.Time) {
+			end = change.Timestamp.Time
+		}
+	}
+	if start.IsZero() {
+		start = time.Now()
+		end = start
+	}
+	return start, end
+}
+
+func changeAttributes(change tracev1.ObjectChange) []attribute.KeyValue {
+	ref := change.ObjectReference
+	return []attribute.KeyValue{
+		attribute.String("object.apiVersion", ref.APIVersion),
+		attribute.String("object.kind", ref.Kind),
+		attribute.String("object.namespace", ref.Namespace),
+		attribute.String("object.name", ref.Name),
+		attribute.String("change.type", string(change.ChangeType)),
+		attribute.Int64("change.revision", change.Revision),
+		attribute.String("change.description", change.Description),
+	}
+}
+
+func eventAttributes(change tracev1.ObjectChange) []attribute.KeyValue {
+	attrs := changeAttributes(change)
+	if change.EventAttributes != nil {
+		attrs = append(attrs,
+			attribute.String("event.name", change.EventAttributes.Name),
+			attribute.String("event.type", change.EventAttributes.Type),
+			attribute.String("event.reason", change.EventAttributes.Reason))
+	}
+	return attrs
+}
@@ -0,0 +1,114 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	tracev1 "github.com/apecloud/kubeblocks/apis/trace/v1"
+)
+
+var _ = Describe("otel exporter test", func() {
+	Context("Export", func() {
+		It("should emit the reconciliation cycle as a trace", func() {
+			spanExporter := tracetest.NewInMemoryExporter()
+			exporter := NewCycleExporter(sdktrace.WithSyncer(spanExporter))
+			trace := &tracev1.ReconciliationTrace{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      name,
+				},
+			}
+
+			now := time.Now()
+			at := func(seconds int) *metav1.Time {
+				return &metav1.Time{Time: now.Add(time.Duration(seconds) * time.Second)}
+			}
+			clusterRef := corev1.ObjectReference{APIVersion: kbappsv1.APIVersion, Kind: kbappsv1.ClusterKind, Namespace: namespace, Name: name}
+			compRef := corev1.ObjectReference{APIVersion: kbappsv1.APIVersion, Kind: kbappsv1.ComponentKind, Namespace: namespace, Name: name + "-mysql"}
+			changes := []tracev1.ObjectChange{
+				{ObjectReference: clusterRef, ChangeType: tracev1.ObjectUpdateType, Revision: 1, Timestamp: at(0), Description: "Update"},
+				{ObjectReference: compRef, ChangeType: tracev1.ObjectCreationType, Revision: 2, Timestamp: at(1), Description: "Creation"},
+				{
+					ObjectReference: compRef,
+					ChangeType:      tracev1.EventType,
+					EventAttributes: &tracev1.EventAttributes{Name: "event", Type: corev1.EventTypeWarning, Reason: "Unavailable"},
+					Revision:        3,
+					Timestamp:       at(2),
+					Description:     "Warning Unavailable",
+				},
+				{ObjectReference: clusterRef, ChangeType: tracev1.ObjectUpdateType, Revision: 4, Timestamp: at(3), Description: "Update"},
+			}
+			exporter.Export(ctx, trace, changes)
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).Should(HaveLen(4))
+			var root tracetest.SpanStub
+			for _, span := range spans {
+				if span.Name == "ReconciliationCycle" {
+					root = span
+				}
+			}
+			Expect(root.Name).Should(Equal("ReconciliationCycle"))
+			Expect(root.StartTime).Should(Equal(changes[0].Timestamp.Time))
+			Expect(root.EndTime).Should(Equal(changes[3].Timestamp.Time))
+
+			for _, span := range spans {
+				Expect(span.SpanContext.TraceID()).Should(Equal(root.SpanContext.TraceID()))
+				if span.Name == root.Name {
+					continue
+				}
+				Expect(span.Parent.SpanID()).Should(Equal(root.SpanContext.SpanID()))
+				switch span.Name {
+				case "Update Cluster":
+					Expect(span.EndTime).Should(Equal(changes[3].Timestamp.Time))
+				case "Creation Component":
+					Expect(span.StartTime).Should(Equal(changes[1].Timestamp.Time))
+					Expect(span.Events).Should(HaveLen(1))
+					Expect(span.Events[0].Name).Should(Equal("Warning Unavailable"))
+					Expect(span.Status.Code).Should(Equal(codes.Error))
+				default:
+					Fail("unexpected span: " + span.Name)
+				}
+			}
+
+			By("export the same cycle again, as the status update truncating it is conflicted")
+			exporter.Export(ctx, trace, changes)
+			Expect(spanExporter.GetSpans()).Should(HaveLen(4))
+
+			By("export the next cycle")
+			next := []tracev1.ObjectChange{
+				{ObjectReference: clusterRef, ChangeType: tracev1.ObjectUpdateType, Revision: 5, Timestamp: at(4), Description: "Update"},
+			}
+			exporter.Export(ctx, trace, next)
+			Expect(spanExporter.GetSpans()).Should(HaveLen(6))
+		})
+	})
+})
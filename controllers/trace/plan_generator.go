@@ -27,6 +27,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -35,10 +36,14 @@ import (
 )
 
 type PlanGenerator interface {
-	generatePlan(desiredRoot *kbappsv1.Cluster) (*tracev1.DryRunResult, error)
+	generatePlan(desiredRoot *kbappsv1.Cluster, preparers ...planPreparer) (*tracev1.DryRunResult, error)
 }
 
 type objectLoader func() (map[model.GVKNObjKey]client.Object, error)
+
+// planPreparer runs after the desired root is applied and before the reconciler tree runs,
+// to bring in the changes made out of the object tree, e.g. the Parameter created by a Reconfiguring OpsRequest.
+type planPreparer func(ctx context.Context, cli client.Client, recorder record.EventRecorder) error
 type descriptionFormatter func(client.Object, client.Object, tracev1.ObjectChangeType, *schema.GroupVersionKind) (string, *string)
 
 type planGenerator struct {
@@ -49,7 +54,7 @@ type planGenerator struct {
 	formatter descriptionFormatter
 }
 
-func (g *planGenerator) generatePlan(desiredRoot *kbappsv1.Cluster) (*tracev1.DryRunResult, error) {
+func (g *planGenerator) generatePlan(desiredRoot *kbappsv1.Cluster, preparers ...planPreparer) (*tracev1.DryRunResult, error) {
 	// create mock client and mock event recorder
 	// kbagent client is running in dry-run mode by setting context key-value pair: dry-run=true
 	store := newChangeCaptureStore(g.scheme, g.formatter)
@@ -92,9 +97,14 @@ func (g *planGenerator) generatePlan(desiredRoot *kbappsv1.Cluster) (*tracev1.Dr
 	startTime := time.Now()
 	timeout := false
 	var reconcileErr error
+	for _, prepare := range preparers {
+		if reconcileErr = prepare(g.ctx, mClient, mEventRecorder); reconcileErr != nil {
+			break
+		}
+	}
 	previousCount := len(store.GetChanges())
 	timeoutPeriod := 5 * time.Second
-	for {
+	for reconcileErr == nil {
 		if time.Since(startTime) > timeoutPeriod {
 			timeout = true
 			break
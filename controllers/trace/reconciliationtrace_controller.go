@@ -22,6 +22,7 @@ package trace
 import (
 	"context"
 
+	"github.com/spf13/viper"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -30,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	tracev1 "github.com/apecloud/kubeblocks/apis/trace/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 )
@@ -46,11 +48,15 @@ type ReconciliationTraceReconciler struct {
 	ObjectRevisionStore  ObjectRevisionStore
 	ObjectTreeRootFinder ObjectTreeRootFinder
 	InformerManager      InformerManager
+	CycleExporter        CycleExporter
+	ChangeHistoryStore   ChangeHistoryStore
 }
 
 //+kubebuilder:rbac:groups=trace.kubeblocks.io,resources=reconciliationtraces,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=trace.kubeblocks.io,resources=reconciliationtraces/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=trace.kubeblocks.io,resources=reconciliationtraces/finalizers,verbs=update
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -66,8 +72,8 @@ func (r *ReconciliationTraceReconciler) Reconcile(ctx context.Context, req ctrl.
 		Do(assureFinalizer()).
 		Do(handleDeletion(r.ObjectRevisionStore)).
 		Do(dryRun(ctx, r.Client, r.Scheme)).
-		Do(updateCurrentState(ctx, r.Client, r.Scheme, r.ObjectRevisionStore)).
-		Do(updateDesiredState(ctx, r.Client, r.Scheme, r.ObjectRevisionStore)).
+		Do(updateCurrentState(ctx, r.Client, r.Scheme, r.ObjectRevisionStore, r.ChangeHistoryStore)).
+		Do(updateDesiredState(ctx, r.Client, r.Scheme, r.ObjectRevisionStore, r.CycleExporter)).
 		Commit()
 
 	return res, err
@@ -75,7 +81,20 @@ func (r *ReconciliationTraceReconciler) Reconcile(ctx context.Context, req ctrl.
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReconciliationTraceReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	r.ObjectRevisionStore = NewObjectStore(r.Scheme)
+	var err error
+	r.ObjectRevisionStore, r.ChangeHistoryStore, err = NewStoresFromConfig(r.Scheme)
+	if err != nil {
+		return err
+	}
+	if err = mgr.Add(changeHistoryPruner(r.ChangeHistoryStore)); err != nil {
+		return err
+	}
+	if addr := viper.GetString(constant.CfgKeyTraceStoreQueryAddress); len(addr) > 0 {
+		certDir := viper.GetString(constant.CfgKeyTraceStoreQueryCertDir)
+		if err = mgr.Add(changeHistoryServer(addr, certDir, mgr.GetClient(), r.ChangeHistoryStore)); err != nil {
+			return err
+		}
+	}
 	r.ObjectTreeRootFinder = NewObjectTreeRootFinder(r.Client)
 	r.InformerManager = NewInformerManager(r.Client, mgr.GetCache(), r.Scheme, r.ObjectTreeRootFinder.GetEventChannel())
 
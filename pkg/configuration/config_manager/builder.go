@@ -50,6 +50,7 @@ const (
 	configTemplateName = "reload.yaml"
 	scriptVolumePrefix = "cm-script-"
 	configVolumePrefix = "cm-config-"
+	authVolumePrefix   = "cm-auth-"
 
 	scriptConfigField    = "scripts"
 	formatterConfigField = "formatterConfig"
@@ -63,6 +64,7 @@ const (
 const (
 	KBScriptVolumePath = "/opt/kb-tools/reload"
 	KBConfigVolumePath = "/opt/kb-tools/config"
+	KBAuthVolumePath   = "/opt/kb-tools/auth"
 
 	KBTOOLSScriptsPathEnv  = "TOOLS_SCRIPTS_PATH"
 	KBConfigManagerPathEnv = "TOOLS_PATH"
@@ -102,6 +104,8 @@ func getWatchedVolume(volumeDirs []corev1.VolumeMount, buildParams []ConfigSpecM
 				return core.IsWatchModuleForTplTrigger(param.ReloadAction.TPLScriptTrigger)
 			case parametersv1alpha1.ShellType:
 				return core.IsWatchModuleForShellTrigger(param.ReloadAction.ShellTrigger)
+			case parametersv1alpha1.HTTPType:
+				return core.IsWatchModuleForHTTPTrigger(param.ReloadAction.HTTPTrigger)
 			default:
 				return true
 			}
@@ -224,8 +228,11 @@ func buildConfigSpecHandleMeta(cli client.Client, ctx context.Context, buildPara
 			return err
 		}
 	}
-	if buildParam.ReloadType == parametersv1alpha1.TPLScriptType {
+	switch buildParam.ReloadType {
+	case parametersv1alpha1.TPLScriptType:
 		return buildTPLScriptCM(buildParam, cmBuildParam, cli, ctx)
+	case parametersv1alpha1.HTTPType:
+		buildReloadAuthVolume(buildParam, cmBuildParam)
 	}
 	return nil
 }
@@ -276,6 +283,35 @@ func buildDownwardAPIVolume(manager *CfgManagerBuildParams, fieldInfo parameters
 	})
 }
 
+// buildReloadAuthVolume mounts the Secret referenced by the HTTP trigger into the config-manager,
+// the credentials are read from the files on each request, so that the rotated Secret takes effect without restart.
+func buildReloadAuthVolume(configSpecBuildMeta *ConfigSpecMeta, manager *CfgManagerBuildParams) {
+	trigger := configSpecBuildMeta.HTTPTrigger
+	if trigger == nil || trigger.AuthSecretRef == nil {
+		return
+	}
+	var (
+		mountPoint = filepath.Join(KBAuthVolumePath, configSpecBuildMeta.ConfigSpec.Name)
+		volumeName = fmt.Sprintf("%s%s", authVolumePrefix, configSpecBuildMeta.ConfigSpec.Name)
+	)
+	if FindVolumeMount(manager.Volumes, volumeName) == nil {
+		manager.Volumes = append(manager.Volumes, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: mountPoint,
+			ReadOnly:  true,
+		})
+		manager.CMConfigVolumes = append(manager.CMConfigVolumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: trigger.AuthSecretRef.Name,
+				},
+			},
+		})
+	}
+	configSpecBuildMeta.AuthPath = mountPoint
+}
+
 func buildReloadScriptVolume(scriptCMName string, manager *CfgManagerBuildParams, mountPoint, volumeName string) {
 	var execMode int32 = 0755
 	manager.Volumes = append(manager.Volumes, corev1.VolumeMount{
@@ -21,6 +21,7 @@ package configmanager
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"os"
@@ -36,7 +37,10 @@ import (
 	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
 	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
 	cfgutil "github.com/apecloud/kubeblocks/pkg/configuration/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/gotemplate"
+	kbaproto "github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	kbagent "github.com/apecloud/kubeblocks/pkg/kbagent/service"
 )
 
 type configVolumeHandleMeta struct {
@@ -541,6 +545,217 @@ func CreateTPLScriptHandler(name, configPath string, dirs []string, backupPath s
 	return tplHandler, nil
 }
 
+const (
+	httpReloadActionName = "reload"
+	authTokenKey         = "token"
+)
+
+// httpTriggerHandler reloads the process by calling the HTTP or gRPC endpoint exposed by the engine.
+// The request is performed in the same way as the HTTP and gRPC actions of kbagent.
+type httpTriggerHandler struct {
+	configVolumeHandleMeta
+
+	trigger    *parametersv1alpha1.HTTPTrigger
+	authPath   string
+	fileFilter regexFilter
+	backupPath string
+}
+
+func (h *httpTriggerHandler) OnlineUpdate(ctx context.Context, name string, updatedParams map[string]string) error {
+	logger.V(1).Info(fmt.Sprintf("online update[%v]", updatedParams), "file", name)
+	action, err := buildHTTPReloadAction(ctx, h.trigger, h.authPath, updatedParams)
+	if err != nil {
+		return err
+	}
+	output, err := kbagent.CallRemoteAction(ctx, logger, action, updatedParams)
+	logger.Info("do http reload action",
+		"output", string(output),
+		"error", err,
+	)
+	return err
+}
+
+func (h *httpTriggerHandler) VolumeHandle(ctx context.Context, event fsnotify.Event) error {
+	if !isOwnerEvent(h.MountPoint(), event) {
+		logger.Info(fmt.Sprintf("ignore event: %s, current watch volume: %s", event.String(), h.mountPoint))
+		return nil
+	}
+	updatedParams, files, err := h.prepare(h.backupPath, h.fileFilter, event)
+	if err != nil {
+		return err
+	}
+	if len(updatedParams) == 0 {
+		logger.Info("not parameter updated, skip")
+		return nil
+	}
+	if err := h.OnlineUpdate(ctx, event.Name, updatedParams); err != nil {
+		return err
+	}
+	return backupLastConfigFiles(files, h.backupPath)
+}
+
+func buildHTTPReloadAction(ctx context.Context, trigger *parametersv1alpha1.HTTPTrigger, authPath string, updatedParams map[string]string) (*kbaproto.Action, error) {
+	request, err := RenderReloadRequest(ctx, trigger.RequestTemplate, updatedParams)
+	if err != nil {
+		return nil, err
+	}
+	authorization, err := resolveReloadAuthorization(trigger.AuthSecretRef, authPath)
+	if err != nil {
+		return nil, err
+	}
+
+	action := &kbaproto.Action{
+		Name:           httpReloadActionName,
+		TimeoutSeconds: trigger.TimeoutSeconds,
+	}
+	if trigger.RetryPolicy != nil {
+		action.RetryPolicy = &kbaproto.RetryPolicy{
+			MaxRetries:    trigger.RetryPolicy.MaxRetries,
+			RetryInterval: trigger.RetryPolicy.RetryInterval,
+		}
+	}
+	switch {
+	case trigger.HTTP != nil:
+		action.HTTP = &kbaproto.HTTPAction{
+			Host:        trigger.HTTP.Host,
+			Port:        trigger.HTTP.Port,
+			Scheme:      string(trigger.HTTP.Scheme),
+			Path:        trigger.HTTP.Path,
+			Method:      trigger.HTTP.Method,
+			Body:        trigger.HTTP.Body,
+			Response:    buildReloadOutputMatcher(trigger.HTTP.Response),
+			StatusCodes: trigger.ExpectedStatusCodes,
+		}
+		for _, header := range trigger.HTTP.Headers {
+			action.HTTP.Headers = append(action.HTTP.Headers, kbaproto.HTTPHeader{Name: header.Name, Value: header.Value})
+		}
+		if request != "" {
+			action.HTTP.Body = request
+		}
+		if authorization != "" {
+			action.HTTP.Headers = append(action.HTTP.Headers, kbaproto.HTTPHeader{Name: "Authorization", Value: authorization})
+		}
+	case trigger.GRPC != nil:
+		action.GRPC = &kbaproto.GRPCAction{
+			Host:     trigger.GRPC.Host,
+			Port:     trigger.GRPC.Port,
+			Service:  trigger.GRPC.Service,
+			Method:   trigger.GRPC.Method,
+			Request:  trigger.GRPC.Request,
+			Metadata: make(map[string]string, len(trigger.GRPC.Metadata)+1),
+			Response: buildReloadOutputMatcher(trigger.GRPC.Response),
+		}
+		for k, v := range trigger.GRPC.Metadata {
+			action.GRPC.Metadata[k] = v
+		}
+		if request != "" {
+			action.GRPC.Request = request
+		}
+		if authorization != "" {
+			action.GRPC.Metadata["authorization"] = authorization
+		}
+	default:
+		return nil, cfgcore.MakeError("http trigger requires one of http and grpc")
+	}
+	return action, nil
+}
+
+func buildReloadOutputMatcher(matcher *appsv1.ActionOutputMatcher) *kbaproto.OutputMatcher {
+	if matcher == nil {
+		return nil
+	}
+	return &kbaproto.OutputMatcher{
+		EqualTo:  matcher.EqualTo,
+		Contains: matcher.Contains,
+	}
+}
+
+// RenderReloadRequest renders the request of the HTTP trigger with the updated parameters.
+func RenderReloadRequest(ctx context.Context, requestTemplate string, updatedParams map[string]string) (string, error) {
+	if requestTemplate == "" {
+		return "", nil
+	}
+	tplValues := gotemplate.TplValues{}
+	for k, v := range updatedParams {
+		tplValues[k] = v
+	}
+	engine := gotemplate.NewTplEngine(&tplValues, nil, "render-reload-request", nil, ctx)
+	request, err := engine.Render(requestTemplate)
+	if err != nil {
+		logger.Error(err, "cannot render the request of http trigger")
+		return "", err
+	}
+	return request, nil
+}
+
+// resolveReloadAuthorization builds the value of the authorization header from the mounted Secret.
+func resolveReloadAuthorization(authRef *parametersv1alpha1.ReloadAuthSecretRef, authPath string) (string, error) {
+	if authRef == nil {
+		return "", nil
+	}
+	if authPath == "" {
+		return "", cfgcore.MakeError("the secret[%s] of http trigger is not mounted", authRef.Name)
+	}
+	return BuildReloadAuthorization(authRef, func(key string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(authPath, key))
+	})
+}
+
+// BuildReloadAuthorization builds the value of the authorization header, the keys of the Secret are read by readKey.
+func BuildReloadAuthorization(authRef *parametersv1alpha1.ReloadAuthSecretRef, readKey func(key string) ([]byte, error)) (string, error) {
+	if authRef == nil {
+		return "", nil
+	}
+	read := func(key string) (string, error) {
+		b, err := readKey(key)
+		if err != nil {
+			return "", cfgcore.WrapError(err, "failed to read the key[%s] of secret[%s]", key, authRef.Name)
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+
+	if authRef.Type == parametersv1alpha1.BasicReloadAuth {
+		username, err := read(constant.AccountNameForSecret)
+		if err != nil {
+			return "", err
+		}
+		password, err := read(constant.AccountPasswdForSecret)
+		if err != nil {
+			return "", err
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+	}
+	token, err := read(authTokenKey)
+	if err != nil {
+		return "", err
+	}
+	return "Bearer " + token, nil
+}
+
+func CreateHTTPHandler(configMeta *ConfigSpecInfo, backupPath string) (ConfigHandler, error) {
+	if configMeta == nil || configMeta.ReloadAction == nil || configMeta.HTTPTrigger == nil {
+		return nil, cfgcore.MakeError("http trigger is nil")
+	}
+	if err := checkHTTPTrigger(configMeta.HTTPTrigger); err != nil {
+		return nil, err
+	}
+	filter, err := createFileRegex(fromConfigSpecInfo(configMeta))
+	if err != nil {
+		return nil, err
+	}
+	dirs := []string{configMeta.MountPoint}
+	if err := checkAndBackup(*configMeta, dirs, filter, backupPath); err != nil {
+		return nil, err
+	}
+	return &httpTriggerHandler{
+		configVolumeHandleMeta: createConfigVolumeMeta(configMeta.ConfigSpec.Name, parametersv1alpha1.HTTPType, dirs, &configMeta.FormatterConfig),
+		trigger:                configMeta.HTTPTrigger,
+		authPath:               configMeta.AuthPath,
+		fileFilter:             filter,
+		backupPath:             backupPath,
+	}, nil
+}
+
 func CreateCombinedHandler(config string, backupPath string) (ConfigHandler, error) {
 	shellHandler := func(configMeta ConfigSpecInfo, backupPath string) (ConfigHandler, error) {
 		if configMeta.ShellTrigger == nil {
@@ -591,6 +806,8 @@ func CreateCombinedHandler(config string, backupPath string) (ConfigHandler, err
 			h, err = signalHandler(configMeta.ReloadAction.UnixSignalTrigger, configMeta.MountPoint)
 		case parametersv1alpha1.TPLScriptType:
 			h, err = tplHandler(configMeta.ReloadAction.TPLScriptTrigger, configMeta, tmpPath)
+		case parametersv1alpha1.HTTPType:
+			h, err = CreateHTTPHandler(&configMeta, tmpPath)
 		}
 		if err != nil {
 			return nil, err
@@ -22,11 +22,16 @@ package configmanager
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -388,6 +393,170 @@ var _ = Describe("Config Handler Test", func() {
 		})
 	})
 
+	Describe("Test HTTP trigger handler", func() {
+		type reloadRequest struct {
+			method        string
+			path          string
+			authorization string
+			body          string
+		}
+
+		var (
+			server   *httptest.Server
+			port     int32
+			requests []reloadRequest
+			statuses []int
+		)
+
+		const requestTemplate = `{{- range $i, $k := keys $ | sortAlpha }}{{ if $i }}&{{ end }}{{ $k }}={{ get $ $k }}{{ end }}`
+
+		BeforeEach(func() {
+			requests = nil
+			statuses = nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				requests = append(requests, reloadRequest{
+					method:        r.Method,
+					path:          r.URL.Path,
+					authorization: r.Header.Get("Authorization"),
+					body:          string(body),
+				})
+				if len(statuses) > 0 {
+					w.WriteHeader(statuses[0])
+					statuses = statuses[1:]
+				}
+			}))
+			u, err := url.Parse(server.URL)
+			Expect(err).Should(Succeed())
+			p, err := strconv.Atoi(u.Port())
+			Expect(err).Should(Succeed())
+			port = int32(p)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		newHTTPTriggerConfig := func(trigger *parametersv1alpha1.HTTPTrigger) ConfigSpecInfo {
+			return ConfigSpecInfo{
+				ReloadAction: &parametersv1alpha1.ReloadAction{
+					HTTPTrigger: trigger,
+				},
+				ReloadType:      parametersv1alpha1.HTTPType,
+				MountPoint:      filepath.Join(tmpWorkDir, "config"),
+				ConfigSpec:      newConfigSpec(),
+				FormatterConfig: newFormatter(),
+				ConfigFile:      "my.cnf",
+			}
+		}
+
+		It("should call the endpoint with the rendered request and credentials", func() {
+			authPath := filepath.Join(tmpWorkDir, "auth")
+			Expect(os.MkdirAll(authPath, fs.ModePerm)).Should(Succeed())
+			Expect(os.WriteFile(filepath.Join(authPath, "token"), []byte("secret-token\n"), fs.ModePerm)).Should(Succeed())
+
+			config := newHTTPTriggerConfig(&parametersv1alpha1.HTTPTrigger{
+				HTTP: &appsv1.HTTPAction{
+					Port:   port,
+					Path:   "/-/reload",
+					Method: http.MethodPost,
+				},
+				RequestTemplate:     requestTemplate,
+				AuthSecretRef:       &parametersv1alpha1.ReloadAuthSecretRef{Name: "reload-auth"},
+				ExpectedStatusCodes: []int32{http.StatusAccepted},
+				Sync:                util.ToPointer(true),
+			})
+			config.AuthPath = authPath
+			handler, err := CreateCombinedHandler(toJSONString(config), "")
+			Expect(err).Should(Succeed())
+
+			statuses = []int{http.StatusAccepted}
+			Expect(handler.OnlineUpdate(context.TODO(), config.ConfigSpec.Name+"/"+config.ConfigFile, map[string]string{
+				"b": "2",
+				"a": "1",
+			})).Should(Succeed())
+			Expect(requests).Should(HaveLen(1))
+			Expect(requests[0]).Should(Equal(reloadRequest{
+				method:        http.MethodPost,
+				path:          "/-/reload",
+				authorization: "Bearer secret-token",
+				body:          "a=1&b=2",
+			}))
+
+			By("unexpected status code")
+			Expect(handler.OnlineUpdate(context.TODO(), config.ConfigSpec.Name+"/"+config.ConfigFile, map[string]string{
+				"a": "1",
+			})).ShouldNot(Succeed())
+		})
+
+		It("should retry the request on failure", func() {
+			config := newHTTPTriggerConfig(&parametersv1alpha1.HTTPTrigger{
+				HTTP: &appsv1.HTTPAction{
+					Port: port,
+					Path: "/reload/$(a)",
+				},
+				RetryPolicy: &appsv1.RetryPolicy{MaxRetries: 2},
+				Sync:        util.ToPointer(true),
+			})
+			handler, err := CreateHTTPHandler(&config, "")
+			Expect(err).Should(Succeed())
+
+			statuses = []int{http.StatusServiceUnavailable, http.StatusInternalServerError}
+			Expect(handler.OnlineUpdate(context.TODO(), config.ConfigSpec.Name, map[string]string{"a": "1"})).Should(Succeed())
+			Expect(requests).Should(HaveLen(3))
+			Expect(requests[2].path).Should(Equal("/reload/1"))
+
+			By("exceed the max retries")
+			requests = nil
+			statuses = []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable}
+			Expect(handler.OnlineUpdate(context.TODO(), config.ConfigSpec.Name, map[string]string{"a": "1"})).ShouldNot(Succeed())
+			Expect(requests).Should(HaveLen(3))
+		})
+
+		It("should fail if the credentials are not mounted", func() {
+			config := newHTTPTriggerConfig(&parametersv1alpha1.HTTPTrigger{
+				HTTP: &appsv1.HTTPAction{
+					Port: port,
+				},
+				AuthSecretRef: &parametersv1alpha1.ReloadAuthSecretRef{
+					Name: "reload-auth",
+					Type: parametersv1alpha1.BasicReloadAuth,
+				},
+				Sync: util.ToPointer(true),
+			})
+			handler, err := CreateHTTPHandler(&config, "")
+			Expect(err).Should(Succeed())
+			Expect(handler.OnlineUpdate(context.TODO(), config.ConfigSpec.Name, map[string]string{"a": "1"})).ShouldNot(Succeed())
+			Expect(requests).Should(BeEmpty())
+		})
+
+		It("should call the endpoint on the volume event", func() {
+			configPath := filepath.Join(tmpWorkDir, "config")
+			prepareTestConfig(configPath, oldVersion)
+
+			config := newHTTPTriggerConfig(&parametersv1alpha1.HTTPTrigger{
+				HTTP: &appsv1.HTTPAction{
+					Port:   port,
+					Method: http.MethodPut,
+				},
+				RequestTemplate: requestTemplate,
+			})
+			handler, err := CreateCombinedHandler(toJSONString(config), filepath.Join(tmpWorkDir, "backup"))
+			Expect(err).Should(Succeed())
+
+			By("config not changed")
+			Expect(handler.VolumeHandle(context.TODO(), fsnotify.Event{Name: configPath})).Should(Succeed())
+			Expect(requests).Should(BeEmpty())
+
+			By("change config")
+			prepareTestConfig(configPath, newVersion)
+			Expect(handler.VolumeHandle(context.TODO(), fsnotify.Event{Name: configPath})).Should(Succeed())
+			Expect(requests).Should(HaveLen(1))
+			Expect(requests[0].method).Should(Equal(http.MethodPut))
+			Expect(requests[0].body).Should(Equal("a=2&c=100"))
+		})
+	})
+
 	Describe("Test exec reload command", func() {
 		updatedParams := map[string]string{
 			"key2": "val2",
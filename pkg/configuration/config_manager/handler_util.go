@@ -85,7 +85,8 @@ func isValidReloadPolicy(reload parametersv1alpha1.ReloadAction) bool {
 	return reload.AutoTrigger != nil ||
 		reload.ShellTrigger != nil ||
 		reload.TPLScriptTrigger != nil ||
-		reload.UnixSignalTrigger != nil
+		reload.UnixSignalTrigger != nil ||
+		reload.HTTPTrigger != nil
 }
 
 func IsAutoReload(reload *parametersv1alpha1.ReloadAction) bool {
@@ -102,6 +103,8 @@ func FromReloadTypeConfig(reloadAction *parametersv1alpha1.ReloadAction) paramet
 		return parametersv1alpha1.TPLScriptType
 	case reloadAction.AutoTrigger != nil:
 		return parametersv1alpha1.AutoType
+	case reloadAction.HTTPTrigger != nil:
+		return parametersv1alpha1.HTTPType
 	}
 	return ""
 }
@@ -116,6 +119,8 @@ func ValidateReloadOptions(reloadAction *parametersv1alpha1.ReloadAction, cli cl
 		return checkTPLScriptTrigger(reloadAction.TPLScriptTrigger, cli, ctx)
 	case reloadAction.AutoTrigger != nil:
 		return nil
+	case reloadAction.HTTPTrigger != nil:
+		return checkHTTPTrigger(reloadAction.HTTPTrigger)
 	}
 	return core.MakeError("require special reload type!")
 }
@@ -135,6 +140,29 @@ func checkShellTrigger(options *parametersv1alpha1.ShellTrigger) error {
 	return nil
 }
 
+func checkHTTPTrigger(options *parametersv1alpha1.HTTPTrigger) error {
+	switch {
+	case options.HTTP != nil && options.GRPC != nil:
+		return core.MakeError("http trigger requires only one of http and grpc")
+	case options.HTTP != nil:
+		if options.HTTP.Port <= 0 {
+			return core.MakeError("invalid port of http trigger: %d", options.HTTP.Port)
+		}
+	case options.GRPC != nil:
+		if options.GRPC.Port <= 0 || options.GRPC.Service == "" || options.GRPC.Method == "" {
+			return core.MakeError("http trigger requires the port, service and method of grpc")
+		}
+	default:
+		return core.MakeError("http trigger requires one of http and grpc")
+	}
+	if options.RequestTemplate != "" {
+		if err := checkTPLScript("request-template", options.RequestTemplate); err != nil {
+			return core.WrapError(err, "invalid request template of http trigger")
+		}
+	}
+	return nil
+}
+
 func checkSignalTrigger(options *parametersv1alpha1.UnixSignalTrigger) error {
 	signal := options.Signal
 	if !IsValidUnixSignal(signal) {
@@ -286,5 +314,6 @@ func isSubPathMount(v *corev1.VolumeMount) bool {
 func isSyncReloadAction(meta ConfigSpecInfo) bool {
 	// If synchronous reloadAction is supported, kubelet limitations can be ignored.
 	return meta.ReloadType == parametersv1alpha1.TPLScriptType && !core.IsWatchModuleForTplTrigger(meta.TPLScriptTrigger) ||
-		meta.ReloadType == parametersv1alpha1.ShellType && !core.IsWatchModuleForShellTrigger(meta.ShellTrigger)
+		meta.ReloadType == parametersv1alpha1.ShellType && !core.IsWatchModuleForShellTrigger(meta.ShellTrigger) ||
+		meta.ReloadType == parametersv1alpha1.HTTPType && !core.IsWatchModuleForHTTPTrigger(meta.HTTPTrigger)
 }
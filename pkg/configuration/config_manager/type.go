@@ -47,6 +47,8 @@ type ConfigSpecInfo struct {
 	// config volume mount path
 	MountPoint string `json:"mountPoint"`
 	TPLConfig  string `json:"tplConfig"`
+	// the mount path of the Secret which holds the credentials of the reload endpoint
+	AuthPath string `json:"authPath"`
 }
 
 type ConfigSpecMeta struct {
@@ -154,6 +154,13 @@ func IsWatchModuleForTplTrigger(trigger *parametersv1alpha1.TPLScriptTrigger) bo
 	return !*trigger.Sync
 }
 
+func IsWatchModuleForHTTPTrigger(trigger *parametersv1alpha1.HTTPTrigger) bool {
+	if trigger == nil || trigger.Sync == nil {
+		return true
+	}
+	return !*trigger.Sync
+}
+
 func ToV1ConfigDescription(keys []string, format *parametersv1alpha1.FileFormatConfig) []parametersv1alpha1.ComponentConfigDescription {
 	var configs []parametersv1alpha1.ComponentConfigDescription
 	for _, key := range keys {
@@ -0,0 +1,286 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/parser"
+	"github.com/google/cel-go/cel"
+	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+)
+
+const (
+	constraintParametersVar = "parameters"
+	constraintComponentVar  = "component"
+)
+
+// ConstraintContext provides the variables referenced by the parameter constraints.
+type ConstraintContext struct {
+	// Parameters is the parameters of the configuration file.
+	Parameters map[string]string
+
+	Replicas  int32
+	Resources corev1.ResourceRequirements
+}
+
+func (c *ConstraintContext) parameters() map[string]any {
+	params := make(map[string]any, len(c.Parameters))
+	for key, value := range c.Parameters {
+		params[key] = convertParameterValue(value)
+	}
+	return params
+}
+
+func (c *ConstraintContext) component() map[string]any {
+	resourceList := func(list corev1.ResourceList) map[string]any {
+		m := make(map[string]any)
+		if cpu, ok := list[corev1.ResourceCPU]; ok {
+			m["cpu"] = cpu.MilliValue()
+		}
+		if memory, ok := list[corev1.ResourceMemory]; ok {
+			m["memory"] = memory.Value()
+		}
+		return m
+	}
+	return map[string]any{
+		"replicas": int64(c.Replicas),
+		"resources": map[string]any{
+			"limits":   resourceList(c.Resources.Limits),
+			"requests": resourceList(c.Resources.Requests),
+		},
+	}
+}
+
+func convertParameterValue(value string) any {
+	value = strings.TrimSpace(value)
+	if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return v
+	}
+	if v, err := strconv.ParseFloat(value, 64); err == nil {
+		return v
+	}
+	return value
+}
+
+// ValidateConstraints evaluates the constraints, and returns an error describing all the violated ones.
+func ValidateConstraints(constraints []parametersv1alpha1.ParameterConstraint, ctx *ConstraintContext) error {
+	var errs []error
+	for _, constraint := range constraints {
+		if err := evalConstraint(constraint, ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("parameter constraints violated: %s", utilerrors.NewAggregate(errs).Error())
+}
+
+// CompileConstraints checks the syntax of the constraints.
+func CompileConstraints(constraints []parametersv1alpha1.ParameterConstraint) error {
+	for _, constraint := range constraints {
+		switch {
+		case constraint.CEL != "":
+			if _, err := compileCELExpression(constraint.CEL, cel.BoolType); err != nil {
+				return fmt.Errorf("invalid constraint [%s]: %v", constraint.Name, err)
+			}
+		case constraint.CUE != "":
+			if _, err := parser.ParseFile(constraint.Name, constraint.CUE); err != nil {
+				return fmt.Errorf("invalid constraint [%s]: %v", constraint.Name, err)
+			}
+		default:
+			return fmt.Errorf("invalid constraint [%s]: one of cel and cue is required", constraint.Name)
+		}
+	}
+	return nil
+}
+
+func evalConstraint(constraint parametersv1alpha1.ParameterConstraint, ctx *ConstraintContext) error {
+	var (
+		satisfied bool
+		err       error
+	)
+	switch {
+	case constraint.CEL != "":
+		satisfied, err = evalCELConstraint(constraint.CEL, ctx)
+	case constraint.CUE != "":
+		satisfied, err = evalCUEConstraint(constraint.CUE, ctx)
+	default:
+		return nil
+	}
+	message := constraint.Message
+	if message == "" {
+		message = "the constraint is not satisfied"
+	}
+	switch {
+	case satisfied:
+		return nil
+	case err != nil:
+		return fmt.Errorf("[%s] %s: %v", constraint.Name, message, err)
+	default:
+		return fmt.Errorf("[%s] %s", constraint.Name, message)
+	}
+}
+
+func compileCELExpression(expression string, outputType *cel.Type) (cel.Program, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(constraintParametersVar, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(constraintComponentVar, cel.MapType(cel.StringType, cel.DynType)),
+		cel.CrossTypeNumericComparisons(true),
+		cel.Function("parseSize",
+			cel.Overload("parseSize_string", []*cel.Type{cel.StringType}, cel.IntType, cel.UnaryBinding(parseSizeBinding)),
+			cel.Overload("parseSize_int", []*cel.Type{cel.IntType}, cel.IntType, cel.UnaryBinding(parseSizeBinding)),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if outputType != nil && ast.OutputType() != outputType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("the expression must evaluate to %s, but got %s", outputType, ast.OutputType())
+	}
+	return env.Program(ast)
+}
+
+func evalCELExpression(expression string, outputType *cel.Type, ctx *ConstraintContext) (any, error) {
+	prg, err := compileCELExpression(expression, outputType)
+	if err != nil {
+		return nil, err
+	}
+	out, _, err := prg.Eval(map[string]any{
+		constraintParametersVar: ctx.parameters(),
+		constraintComponentVar:  ctx.component(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Value(), nil
+}
+
+func evalCELConstraint(expression string, ctx *ConstraintContext) (bool, error) {
+	out, err := evalCELExpression(expression, cel.BoolType, ctx)
+	if err != nil {
+		return false, err
+	}
+	satisfied, ok := out.(bool)
+	if !ok {
+		return false, fmt.Errorf("the expression must evaluate to bool, but got %v", out)
+	}
+	return satisfied, nil
+}
+
+// CompileCELExpression checks the syntax of the expression, which can reference the same variables and functions as the constraints.
+func CompileCELExpression(expression string) error {
+	_, err := compileCELExpression(expression, nil)
+	return err
+}
+
+// EvalCELExpression evaluates the expression with the same variables and functions as the constraints,
+// and formats the result as a parameter value.
+func EvalCELExpression(expression string, ctx *ConstraintContext) (string, error) {
+	out, err := evalCELExpression(expression, nil, ctx)
+	if err != nil {
+		return "", err
+	}
+	switch v := out.(type) {
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("unsupported result type %T of the expression", out)
+	}
+}
+
+func evalCUEConstraint(expression string, ctx *ConstraintContext) (bool, error) {
+	params, err := json.Marshal(ctx.parameters())
+	if err != nil {
+		return false, err
+	}
+	comp, err := json.Marshal(ctx.component())
+	if err != nil {
+		return false, err
+	}
+	// the variables are compiled together with the expression so that they can be referenced
+	cueValue := cuecontext.New().CompileString(fmt.Sprintf("%s: %s\n%s: %s\n%s",
+		constraintParametersVar, params, constraintComponentVar, comp, expression))
+	if err = cueValue.Err(); err != nil {
+		return false, err
+	}
+	if err = cueValue.Validate(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func parseSizeBinding(value ref.Val) ref.Val {
+	switch v := value.(type) {
+	case celtypes.Int:
+		return v
+	case celtypes.String:
+		size, err := ParseSize(string(v))
+		if err != nil {
+			return celtypes.NewErr("%v", err)
+		}
+		return celtypes.Int(size)
+	default:
+		return celtypes.MaybeNoSuchOverloadErr(value)
+	}
+}
+
+// ParseSize converts a size such as "1G" or "512Mi" to bytes.
+// The single letter units K, M, G and T are treated as powers of 1024, others are parsed as the resource quantity.
+func ParseSize(size string) (int64, error) {
+	size = strings.TrimSpace(size)
+	if size == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+	units := map[byte]int64{'K': 1 << 10, 'M': 1 << 20, 'G': 1 << 30, 'T': 1 << 40}
+	if unit, ok := units[strings.ToUpper(size[len(size)-1:])[0]]; ok {
+		if v, err := strconv.ParseInt(size[:len(size)-1], 10, 64); err == nil {
+			return v * unit, nil
+		}
+	}
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size [%s]: %v", size, err)
+	}
+	return quantity.Value(), nil
+}
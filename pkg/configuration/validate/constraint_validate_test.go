@@ -0,0 +1,184 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		size    string
+		want    int64
+		wantErr bool
+	}{
+		{size: "1024", want: 1024},
+		{size: "1K", want: 1 << 10},
+		{size: "128m", want: 128 << 20},
+		{size: "1G", want: 1 << 30},
+		{size: "512Mi", want: 512 << 20},
+		{size: "2Gi", want: 2 << 30},
+		{size: "", wantErr: true},
+		{size: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSize(tt.size)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSize(%q) error = %v, wantErr %v", tt.size, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSize(%q) = %v, want %v", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestValidateConstraints(t *testing.T) {
+	ctx := &ConstraintContext{
+		Parameters: map[string]string{
+			"innodb_buffer_pool_size": "3G",
+			"max_connections":         "1000",
+			"sort_buffer_size":        "8388608",
+		},
+		Replicas: 3,
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		constraint parametersv1alpha1.ParameterConstraint
+		violated   string
+	}{{
+		name: "cel satisfied",
+		constraint: parametersv1alpha1.ParameterConstraint{
+			Name: "buffer-pool",
+			CEL:  "parseSize(parameters.innodb_buffer_pool_size) * 10 < component.resources.limits.memory * 8",
+		},
+	}, {
+		name: "cel violated",
+		constraint: parametersv1alpha1.ParameterConstraint{
+			Name:    "connection-memory",
+			CEL:     "parameters.max_connections * parameters.sort_buffer_size < component.resources.limits.memory",
+			Message: "max_connections * sort_buffer_size must fit the memory limit",
+		},
+		violated: "[connection-memory] max_connections * sort_buffer_size must fit the memory limit",
+	}, {
+		name: "cel missing parameter",
+		constraint: parametersv1alpha1.ParameterConstraint{
+			Name: "missing",
+			CEL:  "parameters.join_buffer_size > 0",
+		},
+		violated: "[missing] the constraint is not satisfied: no such key",
+	}, {
+		name: "cel guarded missing parameter",
+		constraint: parametersv1alpha1.ParameterConstraint{
+			Name: "guarded",
+			CEL:  "!has(parameters.join_buffer_size) || parameters.join_buffer_size > 0",
+		},
+	}, {
+		name: "cue satisfied",
+		constraint: parametersv1alpha1.ParameterConstraint{
+			Name: "connections",
+			CUE:  "parameters: max_connections?: <=(component.replicas*1000)",
+		},
+	}, {
+		name: "cue violated",
+		constraint: parametersv1alpha1.ParameterConstraint{
+			Name: "cpu",
+			CUE:  "parameters: max_connections?: <=(component.resources.limits.cpu/4)",
+		},
+		violated: "[cpu] the constraint is not satisfied",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CompileConstraints([]parametersv1alpha1.ParameterConstraint{tt.constraint}); err != nil {
+				t.Fatalf("CompileConstraints() error = %v", err)
+			}
+			err := ValidateConstraints([]parametersv1alpha1.ParameterConstraint{tt.constraint}, ctx)
+			switch {
+			case tt.violated == "" && err != nil:
+				t.Errorf("ValidateConstraints() error = %v", err)
+			case tt.violated != "" && (err == nil || !strings.Contains(err.Error(), tt.violated)):
+				t.Errorf("ValidateConstraints() error = %v, want %s", err, tt.violated)
+			}
+		})
+	}
+}
+
+func TestCompileConstraints(t *testing.T) {
+	invalid := [][]parametersv1alpha1.ParameterConstraint{
+		{{Name: "syntax", CEL: "parameters.a <"}},
+		{{Name: "not-bool", CEL: "component.replicas + 1"}},
+		{{Name: "cue", CUE: "parameters: {"}},
+		{{Name: "empty"}},
+	}
+	for _, constraints := range invalid {
+		if err := CompileConstraints(constraints); err == nil {
+			t.Errorf("CompileConstraints(%s) expected error", constraints[0].Name)
+		}
+	}
+}
+
+func TestEvalCELExpression(t *testing.T) {
+	ctx := &ConstraintContext{
+		Parameters: map[string]string{"innodb_buffer_pool_instances": "8"},
+		Replicas:   3,
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+
+	tests := []struct {
+		expression string
+		want       string
+		wantErr    bool
+	}{
+		{expression: `string(component.resources.limits.memory * 3 / 4 / 1048576) + "M"`, want: "6144M"},
+		{expression: `component.resources.limits.cpu / 1000 * 2`, want: "8"},
+		{expression: `double(component.resources.limits.memory) / double(parameters.innodb_buffer_pool_instances) / 1073741824.0`, want: "1"},
+		{expression: `component.replicas > 1`, want: "true"},
+		{expression: `component.resources.requests.memory`, wantErr: true},
+		{expression: `[1, 2]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := EvalCELExpression(tt.expression, ctx)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("EvalCELExpression(%s) error = %v, wantErr %v", tt.expression, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("EvalCELExpression(%s) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
@@ -64,6 +64,7 @@ const (
 const (
 	KBAppMultiClusterPlacementKey   = "apps.kubeblocks.io/multi-cluster-placement"
 	MultiClusterServicePlacementKey = "apps.kubeblocks.io/multi-cluster-service-placement"
+	MultiClusterRebuildReplicasKey  = "apps.kubeblocks.io/multi-cluster-rebuild-replicas"
 )
 
 func InheritedAnnotations() []string {
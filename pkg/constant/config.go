@@ -46,6 +46,7 @@ const (
 
 	ParametersInitLabelKey               = "config.kubeblocks.io/init-parameters"
 	CustomParameterTemplateAnnotationKey = "config.kubeblocks.io/custom-template"
+	RevisionSnapshotLabelKey             = "config.kubeblocks.io/revision-snapshot-of"
 )
 
 const (
@@ -55,6 +56,7 @@ const (
 	KBParameterUpdateSourceAnnotationKey        = "config.kubeblocks.io/reconfigure-source"
 	UpgradeRestartAnnotationKey                 = "config.kubeblocks.io/restart"
 	ConfigAppliedVersionAnnotationKey           = "config.kubeblocks.io/config-applied-version"
+	ConfigChangeRequestAnnotationKey            = "config.kubeblocks.io/change-request"
 )
 
 const (
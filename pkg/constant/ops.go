@@ -24,6 +24,7 @@ const (
 	OpsRequestTypeLabelKey      = "operations.kubeblocks.io/ops-type"
 	OpsRequestNameLabelKey      = "operations.kubeblocks.io/ops-name"
 	OpsRequestNamespaceLabelKey = "operations.kubeblocks.io/ops-namespace"
+	OpsRequestStepLabelKey      = "operations.kubeblocks.io/pipeline-step"
 )
 
 // annotations
@@ -31,6 +32,7 @@ const (
 	QueueEndTimeAnnotationKey          = "operations.kubeblocks.io/queue-end-time"
 	DisableHAAnnotationKey             = "operations.kubeblocks.io/disable-ha"
 	RelatedOpsAnnotationKey            = "operations.kubeblocks.io/related-ops"
+	PreviousParametersAnnotationKey    = "operations.kubeblocks.io/previous-parameters"
 	OpsDependentOnSuccessfulOpsAnnoKey = "operations.kubeblocks.io/dependent-on-successful-ops" // OpsDependentOnSuccessfulOpsAnnoKey wait for the dependent ops to succeed before executing the current ops. If it fails, this ops will also fail.
 	IgnoreHscaleValidateAnnoKey        = "apps.kubeblocks.io/ignore-strict-horizontal-scale-validation"
 )
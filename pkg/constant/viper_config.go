@@ -52,6 +52,28 @@ const (
 	CfgKeyDPBackupEncryptionSecretKeyRef = "DP_BACKUP_ENCRYPTION_SECRET_KEY_REF"
 	CfgKeyDPBackupEncryptionAlgorithm    = "DP_BACKUP_ENCRYPTION_ALGORITHM"
 
+	// new replica data transfer config keys
+	CfgKeyNewReplicaTransferEnabled        = "NEW_REPLICA_TRANSFER_ENABLED"         // transfer the data in chunks, the raw stream is used by default
+	CfgKeyNewReplicaTransferCompression    = "NEW_REPLICA_TRANSFER_COMPRESSION"     // none, gzip or zstd
+	CfgKeyNewReplicaTransferBandwidthLimit = "NEW_REPLICA_TRANSFER_BANDWIDTH_LIMIT" // bytes per second, e.g. 100Mi
+
+	// multi-cluster config keys
+	CfgKeyMultiClusterFailoverGracePeriodSeconds = "MULTI_CLUSTER_FAILOVER_GRACE_PERIOD_SECONDS" // negative to disable the failover
+
+	// reconciliation trace exporter config keys
+	CfgKeyTraceExporter         = "TRACE_EXPORTER"           // otlp, file or stdout, disabled if empty
+	CfgKeyTraceExporterEndpoint = "TRACE_EXPORTER_ENDPOINT"  // the OTLP collector endpoint, e.g. otel-collector:4317
+	CfgKeyTraceExporterInsecure = "TRACE_EXPORTER_INSECURE"  // disable the TLS to the OTLP collector
+	CfgKeyTraceExporterFilePath = "TRACE_EXPORTER_FILE_PATH" // the file the traces are appended to
+
+	// reconciliation trace store config keys
+	CfgKeyTraceStoreType         = "TRACE_STORE_TYPE"           // memory or disk, memory by default
+	CfgKeyTraceStorePath         = "TRACE_STORE_PATH"           // the database file of the disk store
+	CfgKeyTraceStoreRetention    = "TRACE_STORE_RETENTION"      // the max age of the change history, e.g. 168h
+	CfgKeyTraceStoreMaxChanges   = "TRACE_STORE_MAX_CHANGES"    // the max number of the changes kept for each target
+	CfgKeyTraceStoreQueryAddress = "TRACE_STORE_QUERY_ADDRESS"  // the address the change history query API binds to, disabled if empty
+	CfgKeyTraceStoreQueryCertDir = "TRACE_STORE_QUERY_CERT_DIR" // the directory of the serving certificate of the query API, self-signed if empty
+
 	CfgKBReconcileWorkers = "KUBEBLOCKS_RECONCILE_WORKERS"
 	CfgClientQPS          = "CLIENT_QPS"
 	CfgClientBurst        = "CLIENT_BURST"
@@ -314,6 +314,7 @@ func buildKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) ([]corev1.En
 
 	traverseUserDefinedActions(synthesizedComp, func(name string, action *appsv1.Action) {
 		if a := buildAction4KBAgent(action, name); a != nil {
+			a.SensitiveParameters = sensitiveActionParameters(synthesizedComp, name)
 			actions = append(actions, *a)
 		}
 	})
@@ -332,16 +333,44 @@ func probeReportPeriodSeconds(periodSeconds int32) int32 {
 }
 
 func buildAction4KBAgent(action *appsv1.Action, name string) *proto.Action {
-	if action == nil || action.Exec == nil {
+	if action == nil {
 		return nil
 	}
 	a := &proto.Action{
-		Name: name,
-		Exec: &proto.ExecAction{
+		Name:           name,
+		TimeoutSeconds: action.TimeoutSeconds,
+	}
+	switch {
+	case action.Exec != nil:
+		a.Exec = &proto.ExecAction{
 			Commands: action.Exec.Command,
 			Args:     action.Exec.Args,
-		},
-		TimeoutSeconds: action.TimeoutSeconds,
+		}
+	case action.HTTP != nil:
+		a.HTTP = &proto.HTTPAction{
+			Host:     action.HTTP.Host,
+			Port:     action.HTTP.Port,
+			Scheme:   string(action.HTTP.Scheme),
+			Path:     action.HTTP.Path,
+			Method:   action.HTTP.Method,
+			Body:     action.HTTP.Body,
+			Response: buildOutputMatcher4KBAgent(action.HTTP.Response),
+		}
+		for _, header := range action.HTTP.Headers {
+			a.HTTP.Headers = append(a.HTTP.Headers, proto.HTTPHeader{Name: header.Name, Value: header.Value})
+		}
+	case action.GRPC != nil:
+		a.GRPC = &proto.GRPCAction{
+			Host:     action.GRPC.Host,
+			Port:     action.GRPC.Port,
+			Service:  action.GRPC.Service,
+			Method:   action.GRPC.Method,
+			Request:  action.GRPC.Request,
+			Metadata: action.GRPC.Metadata,
+			Response: buildOutputMatcher4KBAgent(action.GRPC.Response),
+		}
+	default:
+		return nil
 	}
 	if action.RetryPolicy != nil {
 		a.RetryPolicy = &proto.RetryPolicy{
@@ -352,11 +381,24 @@ func buildAction4KBAgent(action *appsv1.Action, name string) *proto.Action {
 	return a
 }
 
+func buildOutputMatcher4KBAgent(matcher *appsv1.ActionOutputMatcher) *proto.OutputMatcher {
+	if matcher == nil {
+		return nil
+	}
+	return &proto.OutputMatcher{
+		EqualTo:  matcher.EqualTo,
+		Contains: matcher.Contains,
+	}
+}
+
 func buildProbe4KBAgent(probe *appsv1.Probe, name, instance string) (*proto.Action, *proto.Probe) {
-	if probe == nil || probe.Exec == nil {
+	if probe == nil {
 		return nil, nil
 	}
 	a := buildAction4KBAgent(&probe.Action, name)
+	if a == nil {
+		return nil, nil
+	}
 	p := &proto.Probe{
 		Action:              name,
 		InitialDelaySeconds: probe.InitialDelaySeconds,
@@ -516,13 +558,23 @@ func hasActionDefined(synthesizedComp *SynthesizedComponent) bool {
 		return true
 	}
 	for _, tpl := range synthesizedComp.FileTemplates {
-		if tpl.Reconfigure != nil {
+		if tpl.Reconfigure != nil || tpl.Reload != nil {
 			return true
 		}
 	}
 	return false
 }
 
+// sensitiveActionParameters returns the parameters of the user-defined action which carry the credentials.
+func sensitiveActionParameters(synthesizedComp *SynthesizedComponent, name string) []string {
+	for _, tpl := range synthesizedComp.FileTemplates {
+		if tpl.Reload != nil && name == lifecycle.UDFActionName(UDFReloadActionName(tpl)) {
+			return []string{ReloadAuthorizationParameter}
+		}
+	}
+	return nil
+}
+
 func traverseUserDefinedActions(synthesizedComp *SynthesizedComponent, f func(name string, action *appsv1.Action)) {
 	// user-defined actions
 	for i, tpl := range synthesizedComp.FileTemplates {
@@ -530,5 +582,9 @@ func traverseUserDefinedActions(synthesizedComp *SynthesizedComponent, f func(na
 			name := lifecycle.UDFActionName(UDFReconfigureActionName(tpl))
 			f(name, synthesizedComp.FileTemplates[i].Reconfigure)
 		}
+		if tpl.Reload != nil {
+			name := lifecycle.UDFActionName(UDFReloadActionName(tpl))
+			f(name, synthesizedComp.FileTemplates[i].Reload)
+		}
 	}
 }
@@ -32,6 +32,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/kbagent"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
@@ -442,5 +443,62 @@ var _ = Describe("kb-agent", func() {
 				Value: "/var/run/server.conf",
 			}))
 		})
+
+		It("user-defined reload actions", func() {
+			trigger := &parametersv1alpha1.HTTPTrigger{
+				HTTP: &appsv1.HTTPAction{
+					Port:   8080,
+					Path:   "/reload",
+					Method: "POST",
+				},
+				TimeoutSeconds:  5,
+				RequestTemplate: `{"params": {{ toJson .UpdatedParams }}}`,
+				AuthSecretRef: &parametersv1alpha1.ReloadAuthSecretRef{
+					Name: "reload-auth",
+				},
+			}
+			synthesizedComp.FileTemplates = []SynthesizedFileTemplate{
+				{
+					ComponentFileTemplate: appsv1.ComponentFileTemplate{
+						Name:     "server.conf",
+						Template: "default",
+					},
+					Config: true,
+					Reload: buildHTTPReloadAction(trigger),
+				},
+			}
+
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			c := kbAgentContainer()
+			var val string
+			for _, e := range c.Env {
+				if e.Name == "KB_AGENT_ACTION" {
+					val = e.Value
+				}
+			}
+			Expect(val).ShouldNot(BeEmpty())
+
+			actions := make([]proto.Action, 0)
+			Expect(json.Unmarshal([]byte(val), &actions)).Should(BeNil())
+			Expect(actions).Should(ContainElement(proto.Action{
+				Name: "udf-reload-server.conf",
+				HTTP: &proto.HTTPAction{
+					Port:   8080,
+					Path:   "/reload",
+					Method: "POST",
+					Body:   "$(KB_RELOAD_REQUEST)",
+					Headers: []proto.HTTPHeader{
+						{
+							Name:  "Authorization",
+							Value: "$(KB_RELOAD_AUTHORIZATION)",
+						},
+					},
+				},
+				TimeoutSeconds:      5,
+				SensitiveParameters: []string{"KB_RELOAD_AUTHORIZATION"},
+			}))
+		})
 	})
 })
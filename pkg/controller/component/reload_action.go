@@ -0,0 +1,124 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	// ReloadRequestParameter is the parameter of the reload action which carries the rendered request of the HTTP trigger.
+	ReloadRequestParameter = "KB_RELOAD_REQUEST"
+	// ReloadAuthorizationParameter is the parameter of the reload action which carries the credentials of the HTTP trigger.
+	ReloadAuthorizationParameter = "KB_RELOAD_AUTHORIZATION"
+)
+
+// UDFReloadActionName returns the name of the action to reload the config template by kbagent.
+func UDFReloadActionName(tpl SynthesizedFileTemplate) string {
+	return fmt.Sprintf("reload-%s", tpl.Name)
+}
+
+// buildReloadActions builds the kbagent actions for the HTTP triggers of the config templates,
+// so the engines can be reloaded through kbagent when the config-manager sidecar is not present.
+func buildReloadActions(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent, compDef *appsv1.ComponentDefinition) error {
+	if len(ConfigTemplates(synthesizedComp)) == 0 {
+		return nil
+	}
+	configRender, paramsDefs, err := intctrlutil.ResolveCmpdParametersDefs(ctx, cli, compDef)
+	if err != nil {
+		return err
+	}
+	if configRender == nil {
+		return nil
+	}
+	resolveHTTPTrigger := func(tplName string) *parametersv1alpha1.HTTPTrigger {
+		for _, config := range configRender.Spec.Configs {
+			if config.TemplateName != tplName {
+				continue
+			}
+			for _, paramsDef := range paramsDefs {
+				if paramsDef.Spec.FileName == config.Name && paramsDef.Spec.ReloadAction != nil &&
+					paramsDef.Spec.ReloadAction.HTTPTrigger != nil {
+					return paramsDef.Spec.ReloadAction.HTTPTrigger
+				}
+			}
+		}
+		return nil
+	}
+	for i, tpl := range synthesizedComp.FileTemplates {
+		if !tpl.Config {
+			continue
+		}
+		if trigger := resolveHTTPTrigger(tpl.Name); trigger != nil {
+			synthesizedComp.FileTemplates[i].Reload = buildHTTPReloadAction(trigger)
+		}
+	}
+	return nil
+}
+
+// buildHTTPReloadAction converts the HTTP trigger to the action performed by kbagent.
+// The request rendered from the updated parameters and the credentials are resolved by the controller,
+// and passed to kbagent as the parameters of the action.
+func buildHTTPReloadAction(trigger *parametersv1alpha1.HTTPTrigger) *appsv1.Action {
+	action := &appsv1.Action{
+		TimeoutSeconds: trigger.TimeoutSeconds,
+		RetryPolicy:    trigger.RetryPolicy,
+	}
+	request := ""
+	if len(trigger.RequestTemplate) > 0 {
+		request = fmt.Sprintf("$(%s)", ReloadRequestParameter)
+	}
+	authorization := ""
+	if trigger.AuthSecretRef != nil {
+		authorization = fmt.Sprintf("$(%s)", ReloadAuthorizationParameter)
+	}
+	switch {
+	case trigger.HTTP != nil:
+		action.HTTP = trigger.HTTP.DeepCopy()
+		if len(request) > 0 {
+			action.HTTP.Body = request
+		}
+		if len(authorization) > 0 {
+			action.HTTP.Headers = append(action.HTTP.Headers, corev1.HTTPHeader{Name: "Authorization", Value: authorization})
+		}
+	case trigger.GRPC != nil:
+		action.GRPC = trigger.GRPC.DeepCopy()
+		if len(request) > 0 {
+			action.GRPC.Request = request
+		}
+		if len(authorization) > 0 {
+			if action.GRPC.Metadata == nil {
+				action.GRPC.Metadata = map[string]string{}
+			}
+			action.GRPC.Metadata["authorization"] = authorization
+		}
+	default:
+		return nil
+	}
+	return action
+}
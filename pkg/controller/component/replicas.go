@@ -29,6 +29,7 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,10 +39,12 @@ import (
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/kbagent"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 const (
-	replicaStatusAnnotationKey = "apps.kubeblocks.io/replicas-status"
+	replicaStatusAnnotationKey   = "apps.kubeblocks.io/replicas-status"
+	rebuildReplicasAnnotationKey = "apps.kubeblocks.io/rebuild-replicas"
 
 	// new replicas task & event
 	newReplicaTask                           = "newReplica"
@@ -175,6 +178,79 @@ func StatusReplicasStatus(its *workloads.InstanceSet, replicas []string, hasMemb
 	})
 }
 
+// BuildRebuildReplicasRequest builds the request to rebuild the replicas, the request is identified by the id.
+func BuildRebuildReplicasRequest(id string, replicas []string) string {
+	return fmt.Sprintf("%s:%s", id, strings.Join(replicas, ","))
+}
+
+// RebuildReplicasStatus resets the status of the replicas in the request, to provision them, load the data and
+// join the members again as the new replicas. The request will be handled only once.
+func RebuildReplicasStatus(running, proto *workloads.InstanceSet, request string, hasMemberJoin, hasDataAction bool) error {
+	if len(request) == 0 || proto == nil {
+		return nil
+	}
+	if proto.Annotations == nil {
+		proto.Annotations = make(map[string]string)
+	}
+	if running != nil && running.Annotations[rebuildReplicasAnnotationKey] == request {
+		proto.Annotations[rebuildReplicasAnnotationKey] = request
+		return nil
+	}
+
+	_, replicas, found := strings.Cut(request, ":")
+	if !found {
+		return fmt.Errorf("invalid request to rebuild replicas: %s", request)
+	}
+	loaded := func() *bool {
+		if hasDataAction {
+			return ptr.To(false)
+		}
+		return nil
+	}()
+	joined := func() *bool {
+		if hasMemberJoin {
+			return ptr.To(false)
+		}
+		return nil
+	}()
+	if err := UpdateReplicasStatusFunc(proto, func(status *ReplicasStatus) error {
+		status.Replicas = *proto.Spec.Replicas
+		for _, name := range strings.Split(replicas, ",") {
+			status.Status = slices.DeleteFunc(status.Status, func(s ReplicaStatus) bool {
+				return s.Name == name
+			})
+			status.Status = append(status.Status, ReplicaStatus{
+				Name:              name,
+				Generation:        compGenerationFromITS(proto),
+				CreationTimestamp: time.Now(),
+				Provisioned:       false,
+				DataLoaded:        loaded,
+				MemberJoined:      joined,
+			})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	proto.Annotations[rebuildReplicasAnnotationKey] = request
+	return nil
+}
+
+// IsReplicaRebuilt checks whether the replica in the request has been rebuilt.
+func IsReplicaRebuilt(its *workloads.InstanceSet, request, replica string) (bool, error) {
+	if its == nil || its.Annotations[rebuildReplicasAnnotationKey] != request {
+		return false, nil // the request has not been handled yet
+	}
+	replicas, err := GetReplicasStatusFunc(its, func(s ReplicaStatus) bool {
+		return s.Name == replica && s.Provisioned && (s.DataLoaded == nil || *s.DataLoaded) &&
+			(s.MemberJoined == nil || *s.MemberJoined)
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(replicas) > 0, nil
+}
+
 func UpdateReplicasStatusFunc(its *workloads.InstanceSet, f func(status *ReplicasStatus) error) error {
 	if f == nil {
 		return nil
@@ -214,6 +290,10 @@ func NewReplicaTask(compName, uid string, source *corev1.Pod, replicas []string)
 	if err != nil {
 		return nil, err
 	}
+	transfer, err := newReplicaTransferOptions()
+	if err != nil {
+		return nil, err
+	}
 	task := proto.Task{
 		Instance:            compName,
 		Task:                newReplicaTask,
@@ -225,11 +305,30 @@ func NewReplicaTask(compName, uid string, source *corev1.Pod, replicas []string)
 			Remote:   intctrlutil.PodFQDN(source.Namespace, compName, source.Name),
 			Port:     port,
 			Replicas: strings.Join(replicas, ","),
+			Transfer: transfer,
 		},
 	}
 	return buildKBAgentTaskEnv(task)
 }
 
+func newReplicaTransferOptions() (*proto.TransferOptions, error) {
+	// the chunked transfer spools the dumped data on the source replica, so it is opt-in.
+	if !viper.GetBool(constant.CfgKeyNewReplicaTransferEnabled) {
+		return nil, nil
+	}
+	opts := &proto.TransferOptions{
+		Compression: viper.GetString(constant.CfgKeyNewReplicaTransferCompression),
+	}
+	if limit := viper.GetString(constant.CfgKeyNewReplicaTransferBandwidthLimit); len(limit) > 0 {
+		quantity, err := resource.ParseQuantity(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bandwidth limit of the new replica transfer: %s", limit)
+		}
+		opts.BandwidthLimit = quantity.Value()
+	}
+	return opts, nil
+}
+
 func compGenerationFromITS(its *workloads.InstanceSet) string {
 	if its == nil {
 		return ""
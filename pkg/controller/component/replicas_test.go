@@ -30,6 +30,7 @@ import (
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("replicas", func() {
@@ -290,4 +291,34 @@ var _ = Describe("replicas", func() {
 		//	}
 		// })
 	})
+
+	Context("transfer options", func() {
+		AfterEach(func() {
+			viper.Set(constant.CfgKeyNewReplicaTransferEnabled, false)
+			viper.Set(constant.CfgKeyNewReplicaTransferCompression, "")
+			viper.Set(constant.CfgKeyNewReplicaTransferBandwidthLimit, "")
+		})
+
+		It("raw stream by default", func() {
+			viper.Set(constant.CfgKeyNewReplicaTransferCompression, "zstd")
+			opts, err := newReplicaTransferOptions()
+			Expect(err).Should(BeNil())
+			Expect(opts).Should(BeNil())
+		})
+
+		It("chunked transfer", func() {
+			viper.Set(constant.CfgKeyNewReplicaTransferEnabled, true)
+			viper.Set(constant.CfgKeyNewReplicaTransferCompression, "zstd")
+			viper.Set(constant.CfgKeyNewReplicaTransferBandwidthLimit, "100Mi")
+			opts, err := newReplicaTransferOptions()
+			Expect(err).Should(BeNil())
+			Expect(opts).ShouldNot(BeNil())
+			Expect(opts.Compression).Should(Equal("zstd"))
+			Expect(opts.BandwidthLimit).Should(Equal(int64(100 * 1024 * 1024)))
+
+			viper.Set(constant.CfgKeyNewReplicaTransferBandwidthLimit, "unlimited")
+			_, err = newReplicaTransferOptions()
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
 })
@@ -132,6 +132,9 @@ func BuildSynthesizedComponent(ctx context.Context, cli client.Reader,
 	if err = overrideNCheckConfigTemplates(synthesizeComp, comp); err != nil {
 		return nil, err
 	}
+	if err = buildReloadActions(ctx, cli, synthesizeComp, compDef); err != nil {
+		return nil, err
+	}
 
 	// update resources
 	buildAndUpdateResources(synthesizeComp, comp)
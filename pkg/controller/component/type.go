@@ -86,4 +86,5 @@ type SynthesizedFileTemplate struct {
 	Config      bool
 	Variables   map[string]string
 	Reconfigure *kbappsv1.Action
+	Reload      *kbappsv1.Action // the reload action of the parameters, performed by kbagent if there is no config-manager sidecar
 }
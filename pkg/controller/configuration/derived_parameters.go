@@ -0,0 +1,116 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/configuration/validate"
+)
+
+// ResolveDerivedParameters computes the parameters derived from the resources of the component,
+// and returns the ones whose values differ from the current configuration.
+func ResolveDerivedParameters(configMaps map[string]*corev1.ConfigMap,
+	paramsDefs []*parametersv1alpha1.ParametersDefinition,
+	configRender *parametersv1alpha1.ParamConfigRenderer,
+	replicas int32,
+	resources corev1.ResourceRequirements) (parametersv1alpha1.ComponentParameters, error) {
+	if configRender == nil {
+		return nil, nil
+	}
+
+	derivedParams := make(parametersv1alpha1.ComponentParameters)
+	for _, paramsDef := range paramsDefs {
+		if len(paramsDef.Spec.DerivedParameters) == 0 {
+			continue
+		}
+		current, err := resolveCurrentParameters(paramsDef.Spec.FileName, configMaps, configRender)
+		if err != nil {
+			return nil, err
+		}
+		ctx := &validate.ConstraintContext{
+			Parameters: current,
+			Replicas:   replicas,
+			Resources:  resources,
+		}
+		for _, derived := range paramsDef.Spec.DerivedParameters {
+			value, err := validate.EvalCELExpression(derived.Expression, ctx)
+			if err != nil {
+				return nil, core.WrapError(err, "failed to derive parameter [%s]", derived.Name)
+			}
+			if v, ok := current[derived.Name]; ok && v == value {
+				continue
+			}
+			derivedParams[derived.Name] = &value
+		}
+	}
+	return derivedParams, nil
+}
+
+func resolveCurrentParameters(fileName string, configMaps map[string]*corev1.ConfigMap, configRender *parametersv1alpha1.ParamConfigRenderer) (map[string]string, error) {
+	for _, desc := range configRender.Spec.Configs {
+		if desc.Name != fileName {
+			continue
+		}
+		cm, ok := configMaps[desc.TemplateName]
+		if !ok || cm == nil {
+			break
+		}
+		if content, ok := cm.Data[fileName]; ok {
+			return core.TransformConfigFileToKeyValueMap(fileName, configRender.Spec, []byte(content))
+		}
+	}
+	return map[string]string{}, nil
+}
+
+// ResolveCurrentDerivedParameters returns the current values of the given derived parameters,
+// the parameters absent from the configuration are returned as nil.
+func ResolveCurrentDerivedParameters(configMaps map[string]*corev1.ConfigMap,
+	paramsDefs []*parametersv1alpha1.ParametersDefinition,
+	configRender *parametersv1alpha1.ParamConfigRenderer,
+	derivedParams parametersv1alpha1.ComponentParameters) (parametersv1alpha1.ComponentParameters, error) {
+	if configRender == nil {
+		return nil, nil
+	}
+
+	currentParams := make(parametersv1alpha1.ComponentParameters)
+	for _, paramsDef := range paramsDefs {
+		if len(paramsDef.Spec.DerivedParameters) == 0 {
+			continue
+		}
+		current, err := resolveCurrentParameters(paramsDef.Spec.FileName, configMaps, configRender)
+		if err != nil {
+			return nil, err
+		}
+		for _, derived := range paramsDef.Spec.DerivedParameters {
+			if _, ok := derivedParams[derived.Name]; !ok {
+				continue
+			}
+			if v, ok := current[derived.Name]; ok {
+				currentParams[derived.Name] = &v
+			} else {
+				currentParams[derived.Name] = nil
+			}
+		}
+	}
+	return currentParams, nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	testparameters "github.com/apecloud/kubeblocks/pkg/testutil/parameters"
+)
+
+var _ = Describe("derived parameters", func() {
+	var paramsDef *parametersv1alpha1.ParametersDefinition
+	var pcr *parametersv1alpha1.ParamConfigRenderer
+	var configMaps map[string]*corev1.ConfigMap
+
+	newResources := func(memory string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)},
+		}
+	}
+
+	BeforeEach(func() {
+		paramsDef = testparameters.NewParametersDefinitionFactory("param_def").
+			SetConfigFile("my.cnf").
+			GetObject()
+		paramsDef.Spec.DerivedParameters = []parametersv1alpha1.DerivedParameter{
+			{
+				Name:       "innodb_buffer_pool_size",
+				Expression: `string(component.resources.requests.memory * 3 / 4 / 1048576) + "M"`,
+			},
+			{
+				Name:       "semi_sync_enabled",
+				Expression: `component.replicas > 1 ? "ON" : "OFF"`,
+			},
+		}
+		pcr = testparameters.NewParamConfigRendererFactory("test").
+			SetConfigDescription("my.cnf", configTemplateName, parametersv1alpha1.FileFormatConfig{
+				Format: parametersv1alpha1.Ini,
+				FormatterAction: parametersv1alpha1.FormatterAction{
+					IniConfig: &parametersv1alpha1.IniConfig{SectionName: "mysqld"},
+				},
+			}).
+			GetObject()
+		configMaps = map[string]*corev1.ConfigMap{
+			configTemplateName: {
+				Data: map[string]string{
+					"my.cnf": "[mysqld]\ninnodb_buffer_pool_size=768M\nsemi_sync_enabled=ON\n",
+				},
+			},
+		}
+	})
+
+	Context("ResolveDerivedParameters", func() {
+		It("returns nothing without the config renderer", func() {
+			params, err := ResolveDerivedParameters(configMaps, []*parametersv1alpha1.ParametersDefinition{paramsDef}, nil, 3, newResources("4Gi"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).Should(BeEmpty())
+		})
+
+		It("returns only the changed parameters", func() {
+			params, err := ResolveDerivedParameters(configMaps, []*parametersv1alpha1.ParametersDefinition{paramsDef}, pcr, 3, newResources("4Gi"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).Should(HaveLen(1))
+			Expect(params).Should(HaveKey("innodb_buffer_pool_size"))
+			Expect(*params["innodb_buffer_pool_size"]).Should(Equal("3072M"))
+		})
+
+		It("returns nothing if the resources are not changed", func() {
+			params, err := ResolveDerivedParameters(configMaps, []*parametersv1alpha1.ParametersDefinition{paramsDef}, pcr, 3, newResources("1Gi"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).Should(BeEmpty())
+		})
+
+		It("derives all parameters if the config file is not rendered yet", func() {
+			params, err := ResolveDerivedParameters(map[string]*corev1.ConfigMap{}, []*parametersv1alpha1.ParametersDefinition{paramsDef}, pcr, 1, newResources("1Gi"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).Should(HaveLen(2))
+			Expect(*params["innodb_buffer_pool_size"]).Should(Equal("768M"))
+			Expect(*params["semi_sync_enabled"]).Should(Equal("OFF"))
+		})
+
+		It("fails if the referenced resource is absent", func() {
+			resources := corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			}
+			_, err := ResolveDerivedParameters(configMaps, []*parametersv1alpha1.ParametersDefinition{paramsDef}, pcr, 3, resources)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("ResolveCurrentDerivedParameters", func() {
+		It("returns the current values of the derived parameters", func() {
+			value := "3072M"
+			derived := parametersv1alpha1.ComponentParameters{"innodb_buffer_pool_size": &value}
+			params, err := ResolveCurrentDerivedParameters(configMaps, []*parametersv1alpha1.ParametersDefinition{paramsDef}, pcr, derived)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).Should(HaveLen(1))
+			Expect(*params["innodb_buffer_pool_size"]).Should(Equal("768M"))
+		})
+
+		It("returns nil for the parameters absent from the config file", func() {
+			value := "OFF"
+			derived := parametersv1alpha1.ComponentParameters{"semi_sync_enabled": &value}
+			params, err := ResolveCurrentDerivedParameters(map[string]*corev1.ConfigMap{}, []*parametersv1alpha1.ParametersDefinition{paramsDef}, pcr, derived)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).Should(HaveKeyWithValue("semi_sync_enabled", BeNil()))
+		})
+	})
+})
@@ -20,6 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package configuration
 
 import (
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+
 	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/configuration/validate"
@@ -64,3 +68,49 @@ func validateConfigContent(renderedData string, paramsDef *parametersv1alpha1.Pa
 	}
 	return nil
 }
+
+// ValidateParameterConstraints merges the updated parameters into the base configuration,
+// and evaluates the cross-parameter constraints of the updated configuration files.
+func ValidateParameterConstraints(baseData map[string]string,
+	updatedParams map[string]parametersv1alpha1.ParametersInFile,
+	paramsDefs []*parametersv1alpha1.ParametersDefinition,
+	configDescs []parametersv1alpha1.ComponentConfigDescription,
+	replicas int32,
+	resources corev1.ResourceRequirements) error {
+	resolveConstraints := func(fileName string) []parametersv1alpha1.ParameterConstraint {
+		for _, paramsDef := range paramsDefs {
+			if paramsDef.Spec.FileName == fileName {
+				return paramsDef.Spec.Constraints
+			}
+		}
+		return nil
+	}
+
+	var mergedData map[string]string
+	for fileName := range updatedParams {
+		constraints := resolveConstraints(fileName)
+		if len(constraints) == 0 {
+			continue
+		}
+		if mergedData == nil {
+			var err error
+			if mergedData, err = DoMerge(maps.Clone(baseData), updatedParams, paramsDefs, configDescs); err != nil {
+				return err
+			}
+		}
+		parameters, err := core.TransformConfigFileToKeyValueMap(fileName,
+			parametersv1alpha1.ParamConfigRendererSpec{Configs: configDescs},
+			[]byte(mergedData[fileName]))
+		if err != nil {
+			return err
+		}
+		if err = validate.ValidateConstraints(constraints, &validate.ConstraintContext{
+			Parameters: parameters,
+			Replicas:   replicas,
+			Resources:  resources,
+		}); err != nil {
+			return core.WrapError(err, "failed to validate config file [%s]", fileName)
+		}
+	}
+	return nil
+}
@@ -142,7 +142,7 @@ func (a *kbagent) ignoreOutput(_ []byte, err error) error {
 }
 
 func (a *kbagent) checkedCallAction(ctx context.Context, cli client.Reader, spec *appsv1.Action, lfa lifecycleAction, opts *Options) ([]byte, error) {
-	if spec == nil || spec.Exec == nil {
+	if spec == nil || (spec.Exec == nil && spec.HTTP == nil && spec.GRPC == nil) {
 		return nil, errors.Wrap(ErrActionNotDefined, lfa.name())
 	}
 	if err := a.precondition(ctx, cli, spec); err != nil {
@@ -153,7 +153,7 @@ func (a *kbagent) checkedCallAction(ctx context.Context, cli client.Reader, spec
 }
 
 func (a *kbagent) checkedCallProbe(ctx context.Context, cli client.Reader, spec *appsv1.Probe, lfa lifecycleAction, opts *Options) ([]byte, error) {
-	if spec == nil || spec.Exec == nil {
+	if spec == nil {
 		return nil, errors.Wrap(ErrActionNotDefined, lfa.name())
 	}
 	return a.checkedCallAction(ctx, cli, &spec.Action, lfa, opts)
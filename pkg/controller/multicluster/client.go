@@ -389,7 +389,8 @@ func dataClients(mctx mcontext, workers []string) []contextCli {
 			l = append(l, contextCli{c, cli})
 		}
 	}
-	return l
+	// the placement may contain duplicate contexts for weighted distribution
+	return removeDuplicate(l)
 }
 
 func removeDuplicate(clients []contextCli) []contextCli {
@@ -20,9 +20,14 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package multicluster
 
 import (
+	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -36,6 +41,15 @@ type Manager interface {
 
 	GetContexts() []string
 
+	// IsContextAvailable returns whether the data-plane k8s cluster of the context is available.
+	IsContextAvailable(context string) bool
+
+	// DescribeContexts returns the topology and capacity of all the data-plane k8s clusters.
+	DescribeContexts(ctx context.Context) ([]ContextInfo, error)
+
+	// GetContextClient returns the client and rest config of the data-plane k8s cluster of the context.
+	GetContextClient(context string) (client.Client, *rest.Config)
+
 	Bind(mgr ctrl.Manager) error
 
 	Own(b *builder.Builder, obj, owner client.Object) Manager
@@ -43,9 +57,26 @@ type Manager interface {
 	Watch(b *builder.Builder, obj client.Object, eventHandler handler.EventHandler) Manager
 }
 
+const (
+	// contextProbeInterval is the interval to probe the availability of a data-plane k8s cluster again.
+	contextProbeInterval = 10 * time.Second
+	contextProbeTimeout  = 5 * time.Second
+)
+
 type manager struct {
-	cli    client.Client
-	caches map[string]cache.Cache
+	cli     client.Client
+	workers map[string]client.Client
+	configs map[string]*rest.Config
+	caches  map[string]cache.Cache
+
+	mu     sync.Mutex
+	probes map[string]contextProbe
+}
+
+// contextProbe is the last probed availability of a data-plane k8s cluster.
+type contextProbe struct {
+	available bool
+	timestamp time.Time
 }
 
 var _ Manager = &manager{}
@@ -58,6 +89,68 @@ func (m *manager) GetContexts() []string {
 	return maps.Keys(m.caches)
 }
 
+// IsContextAvailable probes the data-plane k8s cluster of the context, the result is reused within the probe interval.
+func (m *manager) IsContextAvailable(name string) bool {
+	cli, ok := m.workers[name]
+	if !ok || isUnavailableClient(cli) {
+		return false
+	}
+
+	m.mu.Lock()
+	probe, ok := m.probes[name]
+	m.mu.Unlock()
+	if ok && time.Since(probe.timestamp) < contextProbeInterval {
+		return probe.available
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextProbeTimeout)
+	defer cancel()
+	// take the unreachable k8s cluster as unavailable
+	available := cli.List(ctx, &corev1.NodeList{}, client.Limit(1)) == nil
+	m.recordProbe(name, available)
+	return available
+}
+
+func (m *manager) GetContextClient(name string) (client.Client, *rest.Config) {
+	cli, ok := m.workers[name]
+	if !ok {
+		return nil, nil
+	}
+	return cli, m.configs[name]
+}
+
+func (m *manager) DescribeContexts(ctx context.Context) ([]ContextInfo, error) {
+	infos := make([]ContextInfo, 0, len(m.workers))
+	for context, cli := range m.workers {
+		info := ContextInfo{Name: context}
+		if !isUnavailableClient(cli) {
+			nodes, pods := &corev1.NodeList{}, &corev1.PodList{}
+			if err := cli.List(ctx, nodes); err != nil {
+				// take the unreachable k8s cluster as unavailable
+				info.Available = false
+			} else if err = cli.List(ctx, pods); err != nil {
+				info.Available = false
+			} else {
+				info.Available = true
+				info.NodeLabels, info.Allocatable = aggregateNodes(nodes.Items)
+				info.Requested = aggregatePodRequests(pods.Items)
+			}
+			m.recordProbe(context, info.Available)
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (m *manager) recordProbe(name string, available bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.probes == nil {
+		m.probes = make(map[string]contextProbe)
+	}
+	m.probes[name] = contextProbe{available: available, timestamp: time.Now()}
+}
+
 func (m *manager) Bind(mgr ctrl.Manager) error {
 	for k, c := range m.caches {
 		if c != nil {
@@ -0,0 +1,150 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestIsContextAvailable(t *testing.T) {
+	down := false
+	cli := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if down {
+				return fmt.Errorf("connection refused")
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+	m := &manager{
+		workers: map[string]client.Client{
+			"ctx-1": cli,
+			"ctx-2": newUnavailableClient("ctx-2"),
+		},
+	}
+
+	if !m.IsContextAvailable("ctx-1") {
+		t.Error("the reachable context should be available")
+	}
+	if m.IsContextAvailable("ctx-2") {
+		t.Error("the disabled context should be unavailable")
+	}
+	if m.IsContextAvailable("ctx-3") {
+		t.Error("the unknown context should be unavailable")
+	}
+
+	down = true
+	if !m.IsContextAvailable("ctx-1") {
+		t.Error("the probe result should be reused within the probe interval")
+	}
+	m.probes["ctx-1"] = contextProbe{available: true, timestamp: time.Now().Add(-contextProbeInterval)}
+	if m.IsContextAvailable("ctx-1") {
+		t.Error("the context going down at runtime should be unavailable")
+	}
+
+	down = false
+	infos, err := m.DescribeContexts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, info := range infos {
+		if info.Name == "ctx-1" && !info.Available {
+			t.Error("the recovered context should be available")
+		}
+	}
+	if !m.IsContextAvailable("ctx-1") {
+		t.Error("the probe result should be refreshed by describing the contexts")
+	}
+}
+
+func TestDescribeContexts(t *testing.T) {
+	newNode := func(name, zone, cpu string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{corev1.LabelTopologyZone: zone}},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			},
+		}
+	}
+	newPod := func(name, nodeName string, phase corev1.PodPhase, cpu ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		for _, c := range cpu {
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(c)},
+				},
+			})
+		}
+		return pod
+	}
+	initPod := newPod("init", "node-2", corev1.PodPending, "500m")
+	initPod.Spec.InitContainers = []corev1.Container{{
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		},
+	}}
+	cli := fake.NewClientBuilder().WithObjects(
+		newNode("node-1", "zone-a", "4"),
+		newNode("node-2", "zone-b", "8"),
+		newPod("running", "node-1", corev1.PodRunning, "1", "500m"),
+		newPod("succeeded", "node-1", corev1.PodSucceeded, "4"),
+		newPod("unscheduled", "", corev1.PodPending, "4"),
+		initPod,
+	).Build()
+	m := &manager{workers: map[string]client.Client{"ctx-1": cli}}
+
+	infos, err := m.DescribeContexts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 || !infos[0].Available {
+		t.Fatalf("the context should be available, got: %v", infos)
+	}
+	info := infos[0]
+	zones := make([]string, 0)
+	for _, labels := range info.NodeLabels {
+		zones = append(zones, labels[corev1.LabelTopologyZone])
+	}
+	slices.Sort(zones)
+	if !slices.Equal(zones, []string{"zone-a", "zone-b"}) {
+		t.Errorf("the node labels should be kept per node, got zones: %v", zones)
+	}
+	if requested := info.Requested[corev1.ResourceCPU]; requested.Cmp(resource.MustParse("3500m")) != 0 {
+		t.Errorf("the requested cpu should be 3500m, got: %s", requested.String())
+	}
+	if free := info.Free(corev1.ResourceCPU); free.Cmp(resource.MustParse("8500m")) != 0 {
+		t.Errorf("the free cpu should be 8500m, got: %s", free.String())
+	}
+}
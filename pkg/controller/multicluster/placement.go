@@ -23,6 +23,7 @@ import (
 	"context"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -126,3 +127,55 @@ func fromObject(obj client.Object) []string {
 	}
 	return strings.Split(p, ",")
 }
+
+// aggregateNodes returns the label sets of the nodes and the sum of their allocatable resources.
+func aggregateNodes(nodes []corev1.Node) ([]map[string]string, corev1.ResourceList) {
+	labels := make([]map[string]string, 0, len(nodes))
+	allocatable := corev1.ResourceList{}
+	for _, node := range nodes {
+		labels = append(labels, node.Labels)
+		for name, quantity := range node.Status.Allocatable {
+			sum := allocatable[name]
+			sum.Add(quantity)
+			allocatable[name] = sum
+		}
+	}
+	return labels, allocatable
+}
+
+// aggregatePodRequests returns the sum of the resource requests of the non-terminated pods scheduled to the nodes.
+// The request of a pod is the larger one of the sum of its containers and any of its init containers, as the scheduler does.
+func aggregatePodRequests(pods []corev1.Pod) corev1.ResourceList {
+	requested := corev1.ResourceList{}
+	for _, pod := range pods {
+		if len(pod.Spec.NodeName) == 0 || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		podRequests := corev1.ResourceList{}
+		for _, container := range pod.Spec.Containers {
+			for name, quantity := range container.Resources.Requests {
+				sum := podRequests[name]
+				sum.Add(quantity)
+				podRequests[name] = sum
+			}
+		}
+		for _, container := range pod.Spec.InitContainers {
+			for name, quantity := range container.Resources.Requests {
+				if quantity.Cmp(podRequests[name]) > 0 {
+					podRequests[name] = quantity.DeepCopy()
+				}
+			}
+		}
+		for name, quantity := range pod.Spec.Overhead {
+			sum := podRequests[name]
+			sum.Add(quantity)
+			podRequests[name] = sum
+		}
+		for name, quantity := range podRequests {
+			sum := requested[name]
+			sum.Add(quantity)
+			requested[name] = sum
+		}
+	}
+	return requested
+}
@@ -59,6 +59,7 @@ func Setup(scheme *runtime.Scheme, cfg *rest.Config, cli client.Client, kubeConf
 			// reset the cache and use default cli of control cluster
 			cc.cache = nil
 			cc.client = cli
+			cc.config = cfg
 			mcc[k] = cc
 		}
 	}
@@ -70,6 +71,13 @@ func Setup(scheme *runtime.Scheme, cfg *rest.Config, cli client.Client, kubeConf
 		}
 		return m
 	}
+	configs := func() map[string]*rest.Config {
+		m := make(map[string]*rest.Config)
+		for _, c := range mcc {
+			m[c.context] = c.config
+		}
+		return m
+	}
 	caches := func() map[string]cache.Cache {
 		m := make(map[string]cache.Cache)
 		for _, c := range mcc {
@@ -79,8 +87,10 @@ func Setup(scheme *runtime.Scheme, cfg *rest.Config, cli client.Client, kubeConf
 	}
 	setupScheme(scheme)
 	return &manager{
-		cli:    NewClient(cli, clients()),
-		caches: caches(),
+		cli:     NewClient(cli, clients()),
+		workers: clients(),
+		configs: configs(),
+		caches:  caches(),
 	}, nil
 }
 
@@ -147,6 +157,7 @@ func newClientNCache4Context(scheme *runtime.Scheme, kubeConfig, context string,
 	return &multiClusterContext{
 		context: context,
 		id:      config.Host,
+		config:  config,
 		cache:   cache,
 		client:  cli,
 	}, nil
@@ -20,6 +20,9 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package multicluster
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -27,6 +30,26 @@ import (
 type multiClusterContext struct {
 	context string
 	id      string
+	config  *rest.Config
 	cache   cache.Cache
 	client  client.Client
 }
+
+// ContextInfo describes the topology and capacity of a data-plane k8s cluster.
+type ContextInfo struct {
+	Name      string
+	Available bool
+	// NodeLabels are the label sets of the nodes of the k8s cluster, e.g. the regions and zones they are in.
+	NodeLabels []map[string]string
+	// Allocatable is the sum of the allocatable resources of all the nodes.
+	Allocatable corev1.ResourceList
+	// Requested is the sum of the resource requests of the non-terminated pods scheduled to the nodes.
+	Requested corev1.ResourceList
+}
+
+// Free returns the allocatable resource which is not requested by the pods yet.
+func (info *ContextInfo) Free(name corev1.ResourceName) resource.Quantity {
+	free := info.Allocatable[name].DeepCopy()
+	free.Sub(info.Requested[name])
+	return free
+}
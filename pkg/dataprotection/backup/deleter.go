@@ -68,8 +68,17 @@ type Deleter struct {
 
 // DeleteBackupFiles builds a job to delete backup files, and returns the deletion status.
 // If the deletion job exists, it will check the job status and return the corresponding
-// deletion status.
+// deletion status. The copies of the backup in the secondary backup repos are deleted
+// after the backup files in the primary backup repo are deleted.
 func (d *Deleter) DeleteBackupFiles(backup *dpv1alpha1.Backup) (DeletionStatus, error) {
+	status, err := d.deleteBackupFiles(backup)
+	if err != nil || status != DeletionStatusSucceeded {
+		return status, err
+	}
+	return d.deleteReplicaFiles(backup)
+}
+
+func (d *Deleter) deleteBackupFiles(backup *dpv1alpha1.Backup) (DeletionStatus, error) {
 	backupMethod := backup.Status.BackupMethod
 	if backupMethod != nil && boolptr.IsSetToTrue(backupMethod.SnapshotVolumes) {
 		// if the backup is volume snapshot, ignore to delete files
@@ -163,6 +172,59 @@ func (d *Deleter) DeleteBackupFiles(backup *dpv1alpha1.Backup) (DeletionStatus,
 	return DeletionStatusDeleting, d.createDeleteBackupFilesJob(jobKey, backup, backupRepo, legacyPVCName)
 }
 
+// deleteReplicaFiles deletes the copies of the backup in the secondary backup repos.
+func (d *Deleter) deleteReplicaFiles(backup *dpv1alpha1.Backup) (DeletionStatus, error) {
+	status := DeletionStatusSucceeded
+	for _, replica := range backup.Status.Replicas {
+		if replica.StartTimestamp == nil {
+			// nothing has been copied to the backup repo
+			continue
+		}
+		replicaStatus, err := d.deleteReplicaFilesIn(backup, replica.BackupRepoName)
+		if err != nil {
+			return replicaStatus, err
+		}
+		if replicaStatus != DeletionStatusSucceeded {
+			status = replicaStatus
+		}
+	}
+	return status, nil
+}
+
+func (d *Deleter) deleteReplicaFilesIn(backup *dpv1alpha1.Backup, repoName string) (DeletionStatus, error) {
+	jobKey := BuildDeleteReplicaFilesJobKey(backup, repoName)
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(d.Ctx, d.Client, jobKey, job)
+	if err != nil {
+		return DeletionStatusUnknown, err
+	}
+	if exists {
+		_, finishedType, msg := utils.IsJobFinished(job)
+		switch finishedType {
+		case batchv1.JobComplete:
+			return DeletionStatusSucceeded, nil
+		case batchv1.JobFailed:
+			return DeletionStatusFailed,
+				fmt.Errorf("deletion backup files job \"%s\" of backup repo %s failed, you can delete it to re-delete the backup files, %s",
+					job.Name, repoName, msg)
+		}
+		return DeletionStatusDeleting, nil
+	}
+
+	backupRepo := &dpv1alpha1.BackupRepo{}
+	if err = d.Client.Get(d.Ctx, client.ObjectKey{Name: repoName}, backupRepo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return DeletionStatusSucceeded, nil
+		}
+		return DeletionStatusUnknown, err
+	}
+	// the same check as the backup files in the primary backup repo
+	if backup.Status.Path == "" || !strings.Contains(backup.Status.Path, backup.Name) {
+		return DeletionStatusSucceeded, nil
+	}
+	return DeletionStatusDeleting, d.createDeleteBackupFilesJob(jobKey, backup, backupRepo, "")
+}
+
 func (d *Deleter) buildDeleteBackupFilesScript(backupPath string) string {
 
 	// this script first deletes the directory where the backup is located (including files
@@ -389,3 +451,13 @@ func BuildDeleteBackupFilesJobKey(backup *dpv1alpha1.Backup, isPreDelete bool) c
 	}
 	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
 }
+
+// BuildDeleteReplicaFilesJobKey builds the key of the job which deletes the copy of the backup
+// in the secondary backup repo.
+func BuildDeleteReplicaFilesJobKey(backup *dpv1alpha1.Backup, repoName string) client.ObjectKey {
+	jobName := fmt.Sprintf("%s-%s-%s%s", backup.UID[:8], shortHash(repoName), deleteBackupFilesJobNamePrefix, backup.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
+}
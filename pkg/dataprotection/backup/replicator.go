@@ -0,0 +1,433 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	replicateJobNameSuffix  = "replicate"
+	replicateContainerName  = "replicator"
+	replicaVolumeMountPath  = "/backupdata-replica"
+	replicaConfigMountPath  = "/etc/datasafed-replica"
+	replicaLocalBackendPath = "DP_REPLICA_LOCAL_BACKEND_PATH"
+	replicaDatasafedConf    = "DP_REPLICA_DATASAFED_CONF"
+
+	defaultReplicationSyncInterval = 10 * time.Minute
+
+	// replicationCheckInterval is the interval to check the in-progress or pending copies.
+	replicationCheckInterval = 30 * time.Second
+)
+
+// Replicator copies the backup files from the primary backup repo to the secondary backup repos
+// specified by the replication of the backup policy, and records the state of each copy in
+// the backup status.
+//
+// The backup files are copied as they are, which includes the kopia repository the backup is stored in,
+// so a copy can be restored by the same way as the backup in the primary backup repo.
+type Replicator struct {
+	ctrlutil.RequestCtx
+	Client               client.Client
+	Scheme               *runtime.Scheme
+	WorkerServiceAccount string
+}
+
+// Replicate drives the copies of the backup, and updates backup.Status.Replicas in place.
+// It returns the duration after which the copies should be checked again, zero means
+// there is nothing to wait for.
+func (r *Replicator) Replicate(backup *dpv1alpha1.Backup, replication *dpv1alpha1.BackupReplication) (time.Duration, error) {
+	if replication == nil || !isReplicable(backup) {
+		return 0, nil
+	}
+	var requeueAfter time.Duration
+	for _, repoName := range replication.BackupRepoNames {
+		if repoName == backup.Status.BackupRepoName {
+			continue
+		}
+		replica := utils.GetBackupReplica(backup, repoName)
+		if replica == nil {
+			backup.Status.Replicas = append(backup.Status.Replicas, dpv1alpha1.BackupReplicaStatus{BackupRepoName: repoName})
+			replica = &backup.Status.Replicas[len(backup.Status.Replicas)-1]
+		}
+		after, err := r.replicate(backup, replica, getSyncInterval(replication))
+		if err != nil {
+			return 0, err
+		}
+		if after > 0 && (requeueAfter == 0 || after < requeueAfter) {
+			requeueAfter = after
+		}
+	}
+	return requeueAfter, nil
+}
+
+func (r *Replicator) replicate(backup *dpv1alpha1.Backup,
+	replica *dpv1alpha1.BackupReplicaStatus,
+	syncInterval time.Duration) (time.Duration, error) {
+	jobKey := BuildReplicateJobKey(backup, replica.BackupRepoName)
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(r.Ctx, r.Client, jobKey, job)
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		_, finishedType, msg := utils.IsJobFinished(job)
+		switch finishedType {
+		case batchv1.JobComplete:
+			replica.Phase = dpv1alpha1.BackupReplicaPhaseCompleted
+			replica.CompletionTimestamp = job.Status.CompletionTime
+			if replica.CompletionTimestamp == nil {
+				replica.CompletionTimestamp = &metav1.Time{Time: time.Now().UTC()}
+			}
+			replica.FailureReason = ""
+			// delete the finished job, so that the new data of the continuous backup
+			// can be copied by a new job.
+			if err = ctrlutil.BackgroundDeleteObject(r.Client, r.Ctx, job); err != nil {
+				return 0, err
+			}
+			r.Recorder.Eventf(backup, corev1.EventTypeNormal, "ReplicateBackup",
+				"copied the backup to the backup repo %s", replica.BackupRepoName)
+			_, after := needsCopy(backup, replica, syncInterval, time.Now())
+			return after, nil
+		case batchv1.JobFailed:
+			failureReason := fmt.Sprintf("copy job \"%s\" failed, you can delete it to copy the backup again, %s", job.Name, msg)
+			if replica.Phase != dpv1alpha1.BackupReplicaPhaseFailed {
+				r.Recorder.Event(backup, corev1.EventTypeWarning, "ReplicateBackupFailed", failureReason)
+			}
+			replica.Phase = dpv1alpha1.BackupReplicaPhaseFailed
+			replica.FailureReason = failureReason
+			return 0, nil
+		}
+		replica.Phase = dpv1alpha1.BackupReplicaPhaseCopying
+		return replicationCheckInterval, nil
+	}
+
+	copyNow, after := needsCopy(backup, replica, syncInterval, time.Now())
+	if !copyNow {
+		return after, nil
+	}
+	pending := func(format string, args ...any) (time.Duration, error) {
+		replica.Phase = dpv1alpha1.BackupReplicaPhasePending
+		replica.FailureReason = fmt.Sprintf(format, args...)
+		return replicationCheckInterval, nil
+	}
+	srcRepo, err := r.getBackupRepo(backup.Status.BackupRepoName)
+	if err != nil {
+		return 0, err
+	}
+	if srcRepo == nil {
+		return pending("the primary backup repo %s is not found", backup.Status.BackupRepoName)
+	}
+	dstRepo, err := r.getBackupRepo(replica.BackupRepoName)
+	if err != nil {
+		return 0, err
+	}
+	if dstRepo == nil {
+		return pending("the backup repo %s is not found", replica.BackupRepoName)
+	}
+	if !utils.IsBackupRepoAvailable(dstRepo) {
+		return pending("the backup repo %s is not available", replica.BackupRepoName)
+	}
+	prepared, err := r.isBackupRepoPrepared(dstRepo, backup.Namespace)
+	if err != nil {
+		return 0, err
+	}
+	if !prepared {
+		return pending("waiting for the backup repo %s to be prepared in namespace %s", replica.BackupRepoName, backup.Namespace)
+	}
+
+	job, err = r.buildReplicateJob(jobKey, backup, srcRepo, dstRepo)
+	if err != nil {
+		return 0, err
+	}
+	r.Log.V(1).Info("create a job to copy the backup", "job", job)
+	if err = client.IgnoreAlreadyExists(r.Client.Create(r.Ctx, job)); err != nil {
+		return 0, err
+	}
+	replica.Phase = dpv1alpha1.BackupReplicaPhaseCopying
+	replica.StartTimestamp = &metav1.Time{Time: time.Now().UTC()}
+	replica.FailureReason = ""
+	return replicationCheckInterval, nil
+}
+
+func (r *Replicator) getBackupRepo(name string) (*dpv1alpha1.BackupRepo, error) {
+	repo := &dpv1alpha1.BackupRepo{}
+	if err := r.Client.Get(r.Ctx, client.ObjectKey{Name: name}, repo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return repo, nil
+}
+
+// isBackupRepoPrepared checks if the PVC or the tool config secret of the backup repo
+// has been created in the namespace by the backup repo controller.
+func (r *Replicator) isBackupRepoPrepared(repo *dpv1alpha1.BackupRepo, namespace string) (bool, error) {
+	switch {
+	case repo.AccessByMount():
+		if repo.Status.BackupPVCName == "" {
+			return false, nil
+		}
+		return ctrlutil.CheckResourceExists(r.Ctx, r.Client,
+			client.ObjectKey{Namespace: namespace, Name: repo.Status.BackupPVCName}, &corev1.PersistentVolumeClaim{})
+	case repo.AccessByTool():
+		if repo.Status.ToolConfigSecretName == "" {
+			return false, nil
+		}
+		return ctrlutil.CheckResourceExists(r.Ctx, r.Client,
+			client.ObjectKey{Namespace: namespace, Name: repo.Status.ToolConfigSecretName}, &corev1.Secret{})
+	}
+	return false, nil
+}
+
+func (r *Replicator) buildReplicateJob(jobKey client.ObjectKey,
+	backup *dpv1alpha1.Backup,
+	srcRepo, dstRepo *dpv1alpha1.BackupRepo) (*batchv1.Job, error) {
+	runAsUser := int64(0)
+	container := corev1.Container{
+		Name:            replicateContainerName,
+		Command:         []string{"sh", "-c"},
+		Args:            []string{buildReplicateScript(backup)},
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+	ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+
+	podSpec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: r.WorkerServiceAccount,
+	}
+	if err := utils.AddTolerations(&podSpec); err != nil {
+		return nil, err
+	}
+	// the files are copied without decryption and without kopia, so the encryption config
+	// and the kopia repository path are not injected.
+	utils.InjectDatasafed(&podSpec, srcRepo, RepoVolumeMountPath, nil, "")
+	injectReplicaBackupRepo(&podSpec, dstRepo)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: jobKey.Namespace,
+			Name:      jobKey.Name,
+			Labels: map[string]string{
+				constant.AppManagedByLabelKey:     dptypes.AppName,
+				dptypes.ReplicaBackupRepoLabelKey: dstRepo.Name,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: jobKey.Namespace,
+					Name:      jobKey.Name,
+				},
+				Spec: podSpec,
+			},
+			BackoffLimit: &dptypes.DefaultBackOffLimit,
+		},
+	}
+	if err := utils.SetControllerReference(backup, job, r.Scheme); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// injectReplicaBackupRepo mounts the PVC or the tool config secret of the secondary backup repo,
+// they are used by the dst function of the replicate script.
+func injectReplicaBackupRepo(podSpec *corev1.PodSpec, repo *dpv1alpha1.BackupRepo) {
+	var (
+		volume      corev1.Volume
+		volumeMount corev1.VolumeMount
+		env         corev1.EnvVar
+	)
+	if repo.AccessByMount() {
+		volume = corev1.Volume{
+			Name: "dp-replica-backup-data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: repo.Status.BackupPVCName,
+				},
+			},
+		}
+		volumeMount = corev1.VolumeMount{Name: volume.Name, MountPath: replicaVolumeMountPath}
+		env = corev1.EnvVar{Name: replicaLocalBackendPath, Value: replicaVolumeMountPath}
+	} else {
+		volume = corev1.Volume{
+			Name: "dp-replica-datasafed-config",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: repo.Status.ToolConfigSecretName,
+				},
+			},
+		}
+		volumeMount = corev1.VolumeMount{Name: volume.Name, ReadOnly: true, MountPath: replicaConfigMountPath}
+		env = corev1.EnvVar{Name: replicaDatasafedConf, Value: replicaConfigMountPath + "/datasafed.conf"}
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+		container.Env = append(container.Env, env)
+	}
+}
+
+// buildReplicateScript builds the script to copy the backup files, and the kopia repository
+// if it is used, from the primary backup repo to the secondary backup repo.
+func buildReplicateScript(backup *dpv1alpha1.Backup) string {
+	paths := []string{backup.Status.Path}
+	if backup.Status.KopiaRepoPath != "" {
+		paths = append(paths, backup.Status.KopiaRepoPath, backup.Status.KopiaRepoPath+".meta")
+	}
+	var copies []string
+	for _, p := range paths {
+		// make sure the path has a leading slash
+		if !strings.HasPrefix(p, "/") {
+			p = "/" + p
+		}
+		copies = append(copies, fmt.Sprintf("copy \"%s\"", p))
+	}
+	return fmt.Sprintf(`
+set -eo pipefail
+export PATH="$PATH:$%s"
+
+# run datasafed against the secondary backup repo
+function dst() {
+	if [ -n "${%s}" ]; then
+		DATASAFED_LOCAL_BACKEND_PATH="${%s}" datasafed "$@"
+	else
+		env -u DATASAFED_LOCAL_BACKEND_PATH datasafed -c "${%s}" "$@"
+	fi
+}
+
+# copy the files under the path, the files already copied are skipped because
+# the backup files are immutable, except the files of the kopia repository itself.
+function copy() {
+	srcPath="$1"
+	if [ -z "$(datasafed list "${srcPath}")" ]; then
+		echo "${srcPath} is empty or not found, skip it"
+		return
+	fi
+	datasafed list -r -f "${srcPath}" | while read -r file; do
+		case "${file}" in
+		/*) ;;
+		*) file="/${file}" ;;
+		esac
+		case "$(basename "${file}")" in
+		kopia.*) ;;
+		*)
+			if [ -n "$(dst list "${file}")" ]; then
+				continue
+			fi
+			;;
+		esac
+		echo "copying ${file}"
+		datasafed pull "${file}" - | dst push - "${file}"
+	done
+}
+
+%s
+`, dptypes.DPDatasafedBinPath, replicaLocalBackendPath, replicaLocalBackendPath, replicaDatasafedConf,
+		strings.Join(copies, "\n"))
+}
+
+// needsCopy checks if the backup should be copied to the secondary backup repo now,
+// if not, it returns the duration to wait for the next copy, zero means no more copy is needed.
+func needsCopy(backup *dpv1alpha1.Backup,
+	replica *dpv1alpha1.BackupReplicaStatus,
+	syncInterval time.Duration,
+	now time.Time) (bool, time.Duration) {
+	if replica.CompletionTimestamp == nil || replica.Phase == dpv1alpha1.BackupReplicaPhaseFailed {
+		return true, 0
+	}
+	if backup.Status.Phase == dpv1alpha1.BackupPhaseCompleted {
+		// copy again if the backup is completed after the latest copy, e.g. the continuous backup is stopped.
+		completed := backup.Status.CompletionTimestamp
+		return completed != nil && replica.CompletionTimestamp.Before(completed), 0
+	}
+	// the continuous backup is running, copy the new data periodically.
+	next := replica.CompletionTimestamp.Add(syncInterval)
+	if now.Before(next) {
+		return false, next.Sub(now)
+	}
+	return true, 0
+}
+
+// isReplicable checks if the backup has files in the primary backup repo to be copied.
+func isReplicable(backup *dpv1alpha1.Backup) bool {
+	if backup.Status.BackupRepoName == "" || backup.Status.Path == "" {
+		return false
+	}
+	backupMethod := backup.Status.BackupMethod
+	if backupMethod != nil && boolptr.IsSetToTrue(backupMethod.SnapshotVolumes) {
+		return false
+	}
+	switch backup.Status.Phase {
+	case dpv1alpha1.BackupPhaseCompleted:
+		return true
+	case dpv1alpha1.BackupPhaseRunning:
+		return backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous)
+	}
+	return false
+}
+
+func getSyncInterval(replication *dpv1alpha1.BackupReplication) time.Duration {
+	if replication.SyncIntervalSeconds > 0 {
+		return time.Duration(replication.SyncIntervalSeconds) * time.Second
+	}
+	return defaultReplicationSyncInterval
+}
+
+// BuildReplicateJobKey builds the key of the job which copies the backup to the secondary backup repo.
+func BuildReplicateJobKey(backup *dpv1alpha1.Backup, repoName string) client.ObjectKey {
+	jobName := fmt.Sprintf("%s-%s-%s-%s", backup.UID[:8], shortHash(repoName), replicateJobNameSuffix, backup.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
+}
+
+func shortHash(s string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+	return fmt.Sprintf("%08x", h.Sum32())[:6]
+}
@@ -0,0 +1,167 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+)
+
+func TestNeedsCopy(t *testing.T) {
+	now := time.Now()
+	timeAt := func(d time.Duration) *metav1.Time {
+		return &metav1.Time{Time: now.Add(d)}
+	}
+	const syncInterval = 10 * time.Minute
+
+	tests := []struct {
+		name         string
+		backupPhase  dpv1alpha1.BackupPhase
+		completion   *metav1.Time
+		replica      dpv1alpha1.BackupReplicaStatus
+		expectCopy   bool
+		expectWaitOf time.Duration
+	}{
+		{
+			name:        "never copied",
+			backupPhase: dpv1alpha1.BackupPhaseCompleted,
+			completion:  timeAt(-time.Hour),
+			replica:     dpv1alpha1.BackupReplicaStatus{Phase: dpv1alpha1.BackupReplicaPhasePending},
+			expectCopy:  true,
+		},
+		{
+			name:        "copy failed and the job is deleted",
+			backupPhase: dpv1alpha1.BackupPhaseCompleted,
+			completion:  timeAt(-time.Hour),
+			replica: dpv1alpha1.BackupReplicaStatus{
+				Phase:               dpv1alpha1.BackupReplicaPhaseFailed,
+				CompletionTimestamp: timeAt(-time.Minute),
+			},
+			expectCopy: true,
+		},
+		{
+			name:        "copied after the backup is completed",
+			backupPhase: dpv1alpha1.BackupPhaseCompleted,
+			completion:  timeAt(-time.Hour),
+			replica: dpv1alpha1.BackupReplicaStatus{
+				Phase:               dpv1alpha1.BackupReplicaPhaseCompleted,
+				CompletionTimestamp: timeAt(-time.Minute),
+			},
+		},
+		{
+			name:        "continuous backup completed after the latest copy",
+			backupPhase: dpv1alpha1.BackupPhaseCompleted,
+			completion:  timeAt(-time.Minute),
+			replica: dpv1alpha1.BackupReplicaStatus{
+				Phase:               dpv1alpha1.BackupReplicaPhaseCompleted,
+				CompletionTimestamp: timeAt(-time.Hour),
+			},
+			expectCopy: true,
+		},
+		{
+			name:        "running continuous backup within the sync interval",
+			backupPhase: dpv1alpha1.BackupPhaseRunning,
+			replica: dpv1alpha1.BackupReplicaStatus{
+				Phase:               dpv1alpha1.BackupReplicaPhaseCompleted,
+				CompletionTimestamp: timeAt(-4 * time.Minute),
+			},
+			expectWaitOf: 6 * time.Minute,
+		},
+		{
+			name:        "running continuous backup beyond the sync interval",
+			backupPhase: dpv1alpha1.BackupPhaseRunning,
+			replica: dpv1alpha1.BackupReplicaStatus{
+				Phase:               dpv1alpha1.BackupReplicaPhaseCompleted,
+				CompletionTimestamp: timeAt(-11 * time.Minute),
+			},
+			expectCopy: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := &dpv1alpha1.Backup{}
+			backup.Status.Phase = tt.backupPhase
+			backup.Status.CompletionTimestamp = tt.completion
+			copyNow, after := needsCopy(backup, &tt.replica, syncInterval, now)
+			assert.Equal(t, tt.expectCopy, copyNow)
+			assert.Equal(t, tt.expectWaitOf, after)
+		})
+	}
+}
+
+func TestIsReplicable(t *testing.T) {
+	newBackup := func(phase dpv1alpha1.BackupPhase, backupType dpv1alpha1.BackupType) *dpv1alpha1.Backup {
+		backup := &dpv1alpha1.Backup{}
+		backup.Labels = map[string]string{dptypes.BackupTypeLabelKey: string(backupType)}
+		backup.Status.Phase = phase
+		backup.Status.BackupRepoName = "repo"
+		backup.Status.Path = "/ns/cluster/backup"
+		return backup
+	}
+	assert.True(t, isReplicable(newBackup(dpv1alpha1.BackupPhaseCompleted, dpv1alpha1.BackupTypeFull)))
+	assert.True(t, isReplicable(newBackup(dpv1alpha1.BackupPhaseRunning, dpv1alpha1.BackupTypeContinuous)))
+	assert.False(t, isReplicable(newBackup(dpv1alpha1.BackupPhaseRunning, dpv1alpha1.BackupTypeFull)))
+	assert.False(t, isReplicable(newBackup(dpv1alpha1.BackupPhaseFailed, dpv1alpha1.BackupTypeFull)))
+
+	snapshot := newBackup(dpv1alpha1.BackupPhaseCompleted, dpv1alpha1.BackupTypeFull)
+	snapshot.Status.BackupMethod = &dpv1alpha1.BackupMethod{SnapshotVolumes: boolptr.True()}
+	assert.False(t, isReplicable(snapshot))
+
+	noRepo := newBackup(dpv1alpha1.BackupPhaseCompleted, dpv1alpha1.BackupTypeFull)
+	noRepo.Status.BackupRepoName = ""
+	assert.False(t, isReplicable(noRepo))
+}
+
+func TestBuildReplicateScript(t *testing.T) {
+	backup := &dpv1alpha1.Backup{}
+	backup.Status.Path = "ns/cluster/backup"
+	script := buildReplicateScript(backup)
+	assert.Contains(t, script, `copy "/ns/cluster/backup"`)
+	assert.NotContains(t, script, "kopia\"")
+
+	backup.Status.KopiaRepoPath = "/ns/cluster/kopia"
+	script = buildReplicateScript(backup)
+	assert.Contains(t, script, `copy "/ns/cluster/backup"`)
+	assert.Contains(t, script, `copy "/ns/cluster/kopia"`)
+	assert.Contains(t, script, `copy "/ns/cluster/kopia.meta"`)
+}
+
+func TestBuildReplicateJobKey(t *testing.T) {
+	backup := &dpv1alpha1.Backup{}
+	backup.Namespace = "default"
+	backup.Name = strings.Repeat("b", 60)
+	backup.UID = "12345678-abcd"
+
+	key1 := BuildReplicateJobKey(backup, "repo1")
+	key2 := BuildReplicateJobKey(backup, "repo2")
+	assert.Equal(t, "default", key1.Namespace)
+	assert.LessOrEqual(t, len(key1.Name), 63)
+	assert.True(t, strings.HasPrefix(key1.Name, "12345678-"))
+	assert.NotEqual(t, key1.Name, key2.Name)
+	assert.Equal(t, key1, BuildReplicateJobKey(backup, "repo1"))
+}
@@ -0,0 +1,205 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+const (
+	RetentionTierHourly  = "hourly"
+	RetentionTierDaily   = "daily"
+	RetentionTierWeekly  = "weekly"
+	RetentionTierMonthly = "monthly"
+	RetentionTierYearly  = "yearly"
+	// RetentionTierPITR indicates the backup is retained as the base backup of the continuous backup.
+	RetentionTierPITR = "pitr"
+	// RetentionTierParent indicates the backup is retained as the parent of a retained incremental backup.
+	RetentionTierParent = "parent"
+)
+
+// RetentionResult is the result of the evaluation of the retention tiers.
+type RetentionResult struct {
+	// Retained maps the name of the retained backup to the tiers retaining it.
+	Retained map[string][]string
+	// Prunable is the names of the backups to be pruned, in the order of the oldest first.
+	Prunable []string
+}
+
+// IsManaged returns true if the backup is retained or pruned by the retention tiers.
+func (r *RetentionResult) IsManaged(backupName string) bool {
+	return r.IsPrunable(backupName) || len(r.Retained[backupName]) > 0
+}
+
+// IsPrunable returns true if the backup should be pruned.
+func (r *RetentionResult) IsPrunable(backupName string) bool {
+	for _, name := range r.Prunable {
+		if name == backupName {
+			return true
+		}
+	}
+	return false
+}
+
+type retentionTier struct {
+	name   string
+	count  int32
+	period func(t time.Time) string
+}
+
+// EvaluateRetentionTiers evaluates the retention tiers against the backups of a backup schedule.
+//
+// Only the completed full, selective and incremental backups are managed by the tiers. For each tier, the latest
+// backup of each period is retained, up to the count of the tier. The full backups completed within the time range
+// of the continuous backups are retained too, as they are the base backups for PITR, and so are the parent backups
+// of the retained incremental backups, as the incremental backups are deleted along with their parents.
+func EvaluateRetentionTiers(tiers *dpv1alpha1.RetentionTiers, backups []dpv1alpha1.Backup) *RetentionResult {
+	result := &RetentionResult{Retained: map[string][]string{}}
+	if tiers == nil {
+		return result
+	}
+
+	var candidates []*dpv1alpha1.Backup
+	for i := range backups {
+		if isManagedByRetentionTiers(&backups[i]) {
+			candidates = append(candidates, &backups[i])
+		}
+	}
+	// sort by stop time in descending order
+	sort.Slice(candidates, func(i, j int) bool {
+		return dputils.CompareWithBackupStopTime(*candidates[j], *candidates[i])
+	})
+
+	for _, tier := range []retentionTier{
+		{RetentionTierHourly, tiers.Hourly, func(t time.Time) string { return t.Format("2006-01-02T15") }},
+		{RetentionTierDaily, tiers.Daily, func(t time.Time) string { return t.Format("2006-01-02") }},
+		{RetentionTierWeekly, tiers.Weekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{RetentionTierMonthly, tiers.Monthly, func(t time.Time) string { return t.Format("2006-01") }},
+		{RetentionTierYearly, tiers.Yearly, func(t time.Time) string { return t.Format("2006") }},
+	} {
+		periods := map[string]bool{}
+		for _, backup := range candidates {
+			if int32(len(periods)) >= tier.count {
+				break
+			}
+			period := tier.period(backup.GetEndTime().UTC())
+			if periods[period] {
+				continue
+			}
+			periods[period] = true
+			result.Retained[backup.Name] = append(result.Retained[backup.Name], tier.name)
+		}
+	}
+
+	// retain the base backups of the continuous backups.
+	for i := range backups {
+		continuous := &backups[i]
+		if continuous.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeContinuous) ||
+			continuous.GetStartTime().IsZero() {
+			continue
+		}
+		for _, backup := range candidates {
+			if backup.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeFull) {
+				continue
+			}
+			// refer to the selection of the base backup when restoring from the continuous backup.
+			stopTime := backup.GetEndTime()
+			if stopTime.Before(continuous.GetStartTime()) {
+				continue
+			}
+			if endTime := continuous.GetEndTime(); !endTime.IsZero() && endTime.Before(stopTime) {
+				continue
+			}
+			result.Retained[backup.Name] = append(result.Retained[backup.Name], RetentionTierPITR)
+		}
+	}
+
+	// retain the parent backups of the retained incremental backups.
+	backupMap := map[string]*dpv1alpha1.Backup{}
+	for i := range backups {
+		backupMap[backups[i].Name] = &backups[i]
+	}
+	for _, backup := range candidates {
+		if backup.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeIncremental) ||
+			len(result.Retained[backup.Name]) == 0 {
+			continue
+		}
+		for parent := backupMap[parentBackupName(backup)]; parent != nil; parent = backupMap[parentBackupName(parent)] {
+			if slices.Contains(result.Retained[parent.Name], RetentionTierParent) {
+				break
+			}
+			result.Retained[parent.Name] = append(result.Retained[parent.Name], RetentionTierParent)
+		}
+	}
+
+	for i := len(candidates) - 1; i >= 0; i-- {
+		if len(result.Retained[candidates[i].Name]) == 0 {
+			result.Prunable = append(result.Prunable, candidates[i].Name)
+		}
+	}
+	return result
+}
+
+// EvaluateBackupScheduleRetention evaluates the retention tiers of the backup schedule against its backups.
+func EvaluateBackupScheduleRetention(ctx context.Context, cli client.Reader,
+	backupSchedule *dpv1alpha1.BackupSchedule) (*RetentionResult, error) {
+	if backupSchedule.Spec.RetentionTiers == nil {
+		return &RetentionResult{Retained: map[string][]string{}}, nil
+	}
+	backupList := &dpv1alpha1.BackupList{}
+	if err := cli.List(ctx, backupList, client.InNamespace(backupSchedule.Namespace),
+		client.MatchingLabels{dptypes.BackupScheduleLabelKey: backupSchedule.Name}); err != nil {
+		return nil, err
+	}
+	return EvaluateRetentionTiers(backupSchedule.Spec.RetentionTiers, backupList.Items), nil
+}
+
+func isManagedByRetentionTiers(backup *dpv1alpha1.Backup) bool {
+	if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || !backup.DeletionTimestamp.IsZero() ||
+		backup.GetEndTime().IsZero() {
+		return false
+	}
+	switch backup.Labels[dptypes.BackupTypeLabelKey] {
+	case string(dpv1alpha1.BackupTypeFull), string(dpv1alpha1.BackupTypeSelective), string(dpv1alpha1.BackupTypeIncremental):
+		return true
+	default:
+		return false
+	}
+}
+
+func parentBackupName(backup *dpv1alpha1.Backup) string {
+	if len(backup.Status.ParentBackupName) != 0 {
+		return backup.Status.ParentBackupName
+	}
+	return backup.Spec.ParentBackupName
+}
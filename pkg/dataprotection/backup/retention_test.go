@@ -0,0 +1,117 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func TestEvaluateRetentionTiers(t *testing.T) {
+	newBackup := func(name string, backupType dpv1alpha1.BackupType, start, end time.Time) dpv1alpha1.Backup {
+		return dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{dptypes.BackupTypeLabelKey: string(backupType)},
+			},
+			Status: dpv1alpha1.BackupStatus{
+				Phase: dpv1alpha1.BackupPhaseCompleted,
+				TimeRange: &dpv1alpha1.BackupTimeRange{
+					Start: &metav1.Time{Time: start},
+					End:   &metav1.Time{Time: end},
+				},
+			},
+		}
+	}
+
+	// a full backup every 6 hours in 40 days, the newest one is "full-0"
+	now := time.Date(2024, 3, 10, 23, 0, 0, 0, time.UTC)
+	var backups []dpv1alpha1.Backup
+	for i := 0; i < 160; i++ {
+		end := now.Add(-time.Duration(i) * 6 * time.Hour)
+		backups = append(backups, newBackup(fmt.Sprintf("full-%d", i), dpv1alpha1.BackupTypeFull, end.Add(-time.Minute), end))
+	}
+
+	t.Run("nil tiers", func(t *testing.T) {
+		result := EvaluateRetentionTiers(nil, backups)
+		assert.Empty(t, result.Retained)
+		assert.Empty(t, result.Prunable)
+	})
+
+	t.Run("grandfather-father-son", func(t *testing.T) {
+		result := EvaluateRetentionTiers(&dpv1alpha1.RetentionTiers{Hourly: 2, Daily: 3, Weekly: 2, Monthly: 2}, backups)
+		assert.Equal(t, []string{RetentionTierHourly, RetentionTierDaily, RetentionTierWeekly, RetentionTierMonthly}, result.Retained["full-0"])
+		assert.Equal(t, []string{RetentionTierHourly}, result.Retained["full-1"])
+		// the latest backups of 2024-03-09 and 2024-03-08
+		assert.Equal(t, []string{RetentionTierDaily}, result.Retained["full-4"])
+		assert.Equal(t, []string{RetentionTierDaily}, result.Retained["full-8"])
+		// the latest backup of the week 2024-W09, ends on 2024-03-03
+		assert.Equal(t, []string{RetentionTierWeekly}, result.Retained["full-28"])
+		// the latest backup of 2024-02
+		assert.Equal(t, []string{RetentionTierMonthly}, result.Retained["full-40"])
+		assert.Len(t, result.Retained, 6)
+		assert.Len(t, result.Prunable, len(backups)-6)
+		assert.Equal(t, "full-159", result.Prunable[0])
+		assert.True(t, result.IsManaged("full-2"))
+		assert.True(t, result.IsPrunable("full-2"))
+		assert.False(t, result.IsPrunable("full-28"))
+	})
+
+	t.Run("unmanaged backups", func(t *testing.T) {
+		failed := newBackup("failed", dpv1alpha1.BackupTypeFull, now, now.Add(time.Hour))
+		failed.Status.Phase = dpv1alpha1.BackupPhaseFailed
+		continuous := newBackup("continuous", dpv1alpha1.BackupTypeContinuous, now.Add(time.Hour), now.Add(2*time.Hour))
+		result := EvaluateRetentionTiers(&dpv1alpha1.RetentionTiers{Hourly: 1}, append([]dpv1alpha1.Backup{failed, continuous}, backups[:2]...))
+		assert.False(t, result.IsManaged("failed"))
+		assert.False(t, result.IsManaged("continuous"))
+		assert.Equal(t, []string{RetentionTierHourly}, result.Retained["full-0"])
+		assert.Equal(t, []string{"full-1"}, result.Prunable)
+	})
+
+	t.Run("parent backups of incremental backups", func(t *testing.T) {
+		// the incremental backups based on "full-1": full-1 <- incremental-0 <- incremental-1
+		incremental0 := newBackup("incremental-0", dpv1alpha1.BackupTypeIncremental, now.Add(30*time.Minute), now.Add(31*time.Minute))
+		incremental0.Status.ParentBackupName = "full-1"
+		incremental1 := newBackup("incremental-1", dpv1alpha1.BackupTypeIncremental, now.Add(time.Hour), now.Add(time.Hour+time.Minute))
+		incremental1.Status.ParentBackupName = "incremental-0"
+		result := EvaluateRetentionTiers(&dpv1alpha1.RetentionTiers{Hourly: 1}, append([]dpv1alpha1.Backup{incremental0, incremental1}, backups[:3]...))
+		assert.Equal(t, []string{RetentionTierHourly}, result.Retained["incremental-1"])
+		assert.Equal(t, []string{RetentionTierParent}, result.Retained["incremental-0"])
+		assert.Equal(t, []string{RetentionTierParent}, result.Retained["full-1"])
+		assert.Equal(t, []string{"full-2", "full-0"}, result.Prunable)
+	})
+
+	t.Run("base backups of continuous backup", func(t *testing.T) {
+		continuous := newBackup("continuous", dpv1alpha1.BackupTypeContinuous, now.Add(-30*time.Hour), now)
+		result := EvaluateRetentionTiers(&dpv1alpha1.RetentionTiers{Hourly: 1}, append([]dpv1alpha1.Backup{continuous}, backups...))
+		assert.False(t, result.IsManaged("continuous"))
+		for i := 0; i <= 5; i++ {
+			assert.Contains(t, result.Retained[fmt.Sprintf("full-%d", i)], RetentionTierPITR)
+		}
+		assert.True(t, result.IsPrunable("full-6"))
+	})
+}
@@ -131,7 +131,10 @@ func (s *Scheduler) handleSchedulePolicy(index int) error {
 	}
 
 	// create/delete/patch cronjob workload
-	return s.reconcileCronJob(schedulePolicy)
+	if err := s.reconcileCronJob(schedulePolicy); err != nil {
+		return err
+	}
+	return s.reconcileVerificationCronJob(schedulePolicy)
 }
 
 // buildCronJob builds cronjob from backup schedule.
@@ -219,12 +222,17 @@ EOF
 		s.BackupPolicy.Name, schedulePolicy.BackupMethod,
 		schedulePolicy.RetentionPeriod, parameters)
 
+	return s.buildWorkerPodSpec("backup-schedule", createBackupCmd)
+}
+
+// buildWorkerPodSpec builds the pod spec of the worker which runs the kubectl commands.
+func (s *Scheduler) buildWorkerPodSpec(containerName, cmd string) (*corev1.PodSpec, error) {
 	container := corev1.Container{
-		Name:            "backup-schedule",
+		Name:            containerName,
 		Image:           viper.GetString(constant.KBToolsImage),
 		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
 		Command:         []string{"sh", "-c"},
-		Args:            []string{createBackupCmd},
+		Args:            []string{cmd},
 	}
 	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
 
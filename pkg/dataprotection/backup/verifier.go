@@ -0,0 +1,551 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+)
+
+const (
+	verificationPrefix        = "verify"
+	verificationContainerName = "verify"
+
+	// defaultVerificationMountPath is the mount path of the sandbox volume in the check container
+	// if the mount path of the source volume is unknown.
+	defaultVerificationMountPath = "/verification"
+	defaultVerificationTimeout   = time.Hour
+
+	// verificationCheckInterval is the interval to check the timeout of the in-progress verifications.
+	verificationCheckInterval = 30 * time.Second
+)
+
+// Verifier verifies the backups of a backup schedule by restoring the backup into sandbox volumes
+// and running the check action against the restored data.
+//
+// The verification of a backup is requested by annotating it with dptypes.VerificationAnnotationKey,
+// which is done periodically by the verification cronjob of the schedule.
+type Verifier struct {
+	intctrlutil.RequestCtx
+	Client         client.Client
+	BackupSchedule *dpv1alpha1.BackupSchedule
+}
+
+// Verify handles all the backups of the schedule requested to verify.
+func (v *Verifier) Verify() error {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := v.Client.List(v.Ctx, backupList,
+		client.InNamespace(v.BackupSchedule.Namespace),
+		client.MatchingLabels{dptypes.BackupScheduleLabelKey: v.BackupSchedule.Name}); err != nil {
+		return err
+	}
+	var inProgress []string
+	for i := range backupList.Items {
+		backup := &backupList.Items[i]
+		scheduleName, ok := backup.Annotations[dptypes.VerificationAnnotationKey]
+		if !ok {
+			continue
+		}
+		finished, err := v.verifyBackup(backup, scheduleName)
+		if err != nil {
+			return err
+		}
+		if !finished {
+			inProgress = append(inProgress, backup.Name)
+		}
+	}
+	if len(inProgress) > 0 {
+		return intctrlutil.NewRequeueError(verificationCheckInterval, fmt.Sprintf("waiting for the verification of backups %v to be finished", inProgress))
+	}
+	return nil
+}
+
+// verifyBackup drives the verification of the backup, it returns true if the verification is finished.
+func (v *Verifier) verifyBackup(backup *dpv1alpha1.Backup, scheduleName string) (bool, error) {
+	verification := v.getVerification(scheduleName)
+	if verification == nil || !boolptr.IsSetToTrue(verification.Enabled) {
+		// the verification is disabled after requested, just clean up.
+		return true, v.finish(backup, nil, "")
+	}
+	if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+		return true, v.finish(backup, boolptr.False(), fmt.Sprintf("the backup phase is %s, only completed backups can be verified", backup.Status.Phase))
+	}
+
+	restore := &dpv1alpha1.Restore{}
+	exists, err := intctrlutil.CheckResourceExists(v.Ctx, v.Client, client.ObjectKey{
+		Namespace: backup.Namespace,
+		Name:      GenerateVerificationName(backup),
+	}, restore)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		restore, err = v.buildRestore(backup, scheduleName, verification)
+		if err != nil {
+			if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+				return true, v.finish(backup, boolptr.False(), err.Error())
+			}
+			return false, err
+		}
+		v.Recorder.Eventf(backup, corev1.EventTypeNormal, "VerificationStarted", "restore the backup into the sandbox by restore %s", restore.Name)
+		return false, client.IgnoreAlreadyExists(v.Client.Create(v.Ctx, restore))
+	}
+
+	timeout := defaultVerificationTimeout
+	if verification.TimeoutSeconds > 0 {
+		timeout = time.Duration(verification.TimeoutSeconds) * time.Second
+	}
+	if time.Since(restore.CreationTimestamp.Time) > timeout {
+		return true, v.finish(backup, boolptr.False(), fmt.Sprintf("the verification is not finished in %s", timeout))
+	}
+
+	switch restore.Status.Phase {
+	case dpv1alpha1.RestorePhaseFailed:
+		return true, v.finish(backup, boolptr.False(), fmt.Sprintf("failed to restore the backup: %s", restoreFailureMessage(restore)))
+	case dpv1alpha1.RestorePhaseCompleted:
+	default:
+		return false, nil
+	}
+
+	job := &batchv1.Job{}
+	exists, err = intctrlutil.CheckResourceExists(v.Ctx, v.Client, client.ObjectKeyFromObject(restore), job)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		job, err = v.buildCheckJob(backup, restore, verification)
+		if err != nil {
+			return false, err
+		}
+		return false, client.IgnoreAlreadyExists(v.Client.Create(v.Ctx, job))
+	}
+	finished, conditionType, message := dputils.IsJobFinished(job)
+	if !finished {
+		return false, nil
+	}
+	if conditionType == batchv1.JobFailed {
+		return true, v.finish(backup, boolptr.False(), fmt.Sprintf("the check of the restored data failed, %s", message))
+	}
+	return true, v.finish(backup, boolptr.True(), "the backup is restored into the sandbox and passed the check")
+}
+
+func (v *Verifier) getVerification(scheduleName string) *dpv1alpha1.BackupVerification {
+	for i, sp := range v.BackupSchedule.Spec.Schedules {
+		if sp.GetScheduleName() == scheduleName {
+			if !boolptr.IsSetToTrue(sp.Enabled) {
+				return nil
+			}
+			return v.BackupSchedule.Spec.Schedules[i].Verification
+		}
+	}
+	return nil
+}
+
+// buildRestore builds the restore to restore the backup into the sandbox volumes, which are
+// created like the volumes of the source target pod.
+func (v *Verifier) buildRestore(backup *dpv1alpha1.Backup,
+	scheduleName string,
+	verification *dpv1alpha1.BackupVerification) (*dpv1alpha1.Restore, error) {
+	backupMethod := backup.Status.BackupMethod
+	if backupMethod == nil || backupMethod.TargetVolumes == nil ||
+		(len(backupMethod.TargetVolumes.Volumes) == 0 && len(backupMethod.TargetVolumes.VolumeMounts) == 0) {
+		return nil, intctrlutil.NewFatalError("the backup has no target volumes to be restored into the sandbox")
+	}
+	if !boolptr.IsSetToTrue(backupMethod.SnapshotVolumes) {
+		actionSet, err := dputils.GetActionSetByName(v.RequestCtx, v.Client, backupMethod.ActionSetName)
+		if err != nil {
+			return nil, err
+		}
+		if actionSet == nil || !actionSet.HasPrepareDataStage() {
+			return nil, intctrlutil.NewFatalError("the backup can not be restored into the sandbox as the actionSet has no prepareData action")
+		}
+	}
+
+	var sourceTarget *dpv1alpha1.BackupStatusTarget
+	if backup.Status.Target != nil {
+		sourceTarget = backup.Status.Target
+	} else if len(backup.Status.Targets) > 0 {
+		sourceTarget = &backup.Status.Targets[0]
+	}
+	if sourceTarget == nil || len(sourceTarget.SelectedTargetPods) == 0 {
+		return nil, intctrlutil.NewFatalError("the backup has no source target pod")
+	}
+	sourcePod := &corev1.Pod{}
+	if err := v.Client.Get(v.Ctx, client.ObjectKey{Namespace: backup.Namespace, Name: sourceTarget.SelectedTargetPods[0]}, sourcePod); err != nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("failed to get the source target pod to build the sandbox volumes: %s", err.Error()))
+	}
+
+	name := GenerateVerificationName(backup)
+	labels := v.buildLabels(backup, scheduleName)
+	var volumeClaims []dpv1alpha1.RestoreVolumeClaim
+	for _, volume := range sourcePod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil || !dputils.ExistTargetVolume(backupMethod.TargetVolumes, volume.Name) {
+			continue
+		}
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := v.Client.Get(v.Ctx, client.ObjectKey{Namespace: backup.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}, pvc); err != nil {
+			return nil, err
+		}
+		volumeClaim := dpv1alpha1.RestoreVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("%s-%s", name, volume.Name),
+				Labels: labels,
+			},
+			VolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      pvc.Spec.AccessModes,
+				Resources:        pvc.Spec.Resources,
+				StorageClassName: pvc.Spec.StorageClassName,
+				VolumeMode:       pvc.Spec.VolumeMode,
+			},
+		}
+		if verification.StorageClassName != nil {
+			volumeClaim.VolumeClaimSpec.StorageClassName = verification.StorageClassName
+		}
+		if err := intctrlutil.SetControllerReference(backup, &volumeClaim.ObjectMeta); err != nil {
+			return nil, err
+		}
+		for _, name := range backupMethod.TargetVolumes.Volumes {
+			if name == volume.Name {
+				volumeClaim.VolumeSource = volume.Name
+			}
+		}
+		for _, mount := range backupMethod.TargetVolumes.VolumeMounts {
+			if mount.Name == volume.Name {
+				volumeClaim.MountPath = mount.MountPath
+			}
+		}
+		volumeClaims = append(volumeClaims, volumeClaim)
+	}
+	if len(volumeClaims) == 0 {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("no persistent volumes of the source target pod %s to be restored", sourcePod.Name))
+	}
+
+	restore := &dpv1alpha1.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: backup.Namespace,
+			Labels:    labels,
+		},
+		Spec: dpv1alpha1.RestoreSpec{
+			Backup: dpv1alpha1.BackupRef{
+				Name:      backup.Name,
+				Namespace: backup.Namespace,
+			},
+			PrepareDataConfig: &dpv1alpha1.PrepareDataConfig{
+				SchedulingSpec: dpv1alpha1.SchedulingSpec{
+					Tolerations: sourcePod.Spec.Tolerations,
+				},
+				VolumeClaimRestorePolicy: dpv1alpha1.VolumeClaimRestorePolicyParallel,
+				RestoreVolumeClaims:      volumeClaims,
+			},
+		},
+	}
+	if len(backup.Status.Targets) > 0 {
+		restore.Spec.Backup.SourceTargetName = sourceTarget.Name
+	}
+	if sourceTarget.PodSelector != nil && sourceTarget.PodSelector.Strategy == dpv1alpha1.PodSelectionStrategyAll {
+		// restore the data of one pod only.
+		restore.Spec.PrepareDataConfig.RequiredPolicyForAllPodSelection = &dpv1alpha1.RequiredPolicyForAllPodSelection{
+			DataRestorePolicy: dpv1alpha1.OneToManyRestorePolicy,
+			SourceOfOneToMany: &dpv1alpha1.SourceOfOneToMany{
+				TargetPodName: sourceTarget.SelectedTargetPods[0],
+			},
+		}
+	}
+	if err := intctrlutil.SetControllerReference(backup, restore); err != nil {
+		return nil, err
+	}
+	return restore, nil
+}
+
+// buildCheckJob builds the job to run the check action, with the sandbox volumes mounted.
+func (v *Verifier) buildCheckJob(backup *dpv1alpha1.Backup,
+	restore *dpv1alpha1.Restore,
+	verification *dpv1alpha1.BackupVerification) (*batchv1.Job, error) {
+	container := corev1.Container{
+		Name:            verificationContainerName,
+		Image:           verification.Check.Image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         verification.Check.Command,
+		Env: append([]corev1.EnvVar{
+			{Name: dptypes.DPBackupName, Value: backup.Name},
+		}, verification.Check.Env...),
+	}
+	podSpec := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		Tolerations:   restore.Spec.PrepareDataConfig.SchedulingSpec.Tolerations,
+	}
+	for i, claim := range restore.Spec.PrepareDataConfig.RestoreVolumeClaims {
+		volumeName := fmt.Sprintf("sandbox-%d", i)
+		mountPath := claim.MountPath
+		if mountPath == "" {
+			mountPath = filepath.Join(defaultVerificationMountPath, claim.VolumeSource)
+		}
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim.Name},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: mountPath,
+		})
+	}
+	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+	podSpec.Containers = []corev1.Container{container}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      restore.Name,
+			Namespace: restore.Namespace,
+			Labels:    restore.Labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &[]int32{0}[0],
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: restore.Labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	if err := intctrlutil.SetControllerReference(backup, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// finish records the outcome of the verification to the backup, cleans up the sandbox and
+// removes the verification request. If verified is nil, no outcome will be recorded.
+func (v *Verifier) finish(backup *dpv1alpha1.Backup, verified *bool, message string) error {
+	if err := v.cleanup(backup); err != nil {
+		return err
+	}
+	if verified != nil {
+		patch := client.MergeFrom(backup.DeepCopy())
+		condition := metav1.Condition{
+			Type:               dpv1alpha1.ConditionTypeVerified,
+			Status:             metav1.ConditionTrue,
+			Reason:             dpv1alpha1.ReasonVerificationSucceeded,
+			Message:            message,
+			ObservedGeneration: backup.Generation,
+		}
+		eventType := corev1.EventTypeNormal
+		if !*verified {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = dpv1alpha1.ReasonVerificationFailed
+			eventType = corev1.EventTypeWarning
+		}
+		meta.SetStatusCondition(&backup.Status.Conditions, condition)
+		backup.Status.LastVerificationTime = &metav1.Time{Time: time.Now()}
+		if err := v.Client.Status().Patch(v.Ctx, backup, patch); err != nil {
+			return err
+		}
+		v.Recorder.Event(backup, eventType, condition.Reason, message)
+	}
+	patch := client.MergeFrom(backup.DeepCopy())
+	delete(backup.Annotations, dptypes.VerificationAnnotationKey)
+	return v.Client.Patch(v.Ctx, backup, patch)
+}
+
+// cleanup deletes the sandbox of the backup verification.
+func (v *Verifier) cleanup(backup *dpv1alpha1.Backup) error {
+	inNS := client.InNamespace(backup.Namespace)
+	ml := client.MatchingLabels{
+		dptypes.BackupNameLabelKey:     backup.Name,
+		dptypes.BackupScheduleLabelKey: v.BackupSchedule.Name,
+	}
+	hasVerificationLabel := client.HasLabels{dptypes.VerificationLabelKey}
+	jobList := &batchv1.JobList{}
+	if err := v.Client.List(v.Ctx, jobList, inNS, ml, hasVerificationLabel); err != nil {
+		return err
+	}
+	for i := range jobList.Items {
+		if err := intctrlutil.BackgroundDeleteObject(v.Client, v.Ctx, &jobList.Items[i]); err != nil {
+			return err
+		}
+	}
+	restoreList := &dpv1alpha1.RestoreList{}
+	if err := v.Client.List(v.Ctx, restoreList, inNS, ml, hasVerificationLabel); err != nil {
+		return err
+	}
+	for i := range restoreList.Items {
+		if err := intctrlutil.BackgroundDeleteObject(v.Client, v.Ctx, &restoreList.Items[i]); err != nil {
+			return err
+		}
+	}
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := v.Client.List(v.Ctx, pvcList, inNS, ml, hasVerificationLabel); err != nil {
+		return err
+	}
+	for i := range pvcList.Items {
+		if err := intctrlutil.BackgroundDeleteObject(v.Client, v.Ctx, &pvcList.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) buildLabels(backup *dpv1alpha1.Backup, scheduleName string) map[string]string {
+	return map[string]string{
+		constant.AppManagedByLabelKey:  dptypes.AppName,
+		dptypes.BackupNameLabelKey:     backup.Name,
+		dptypes.BackupScheduleLabelKey: v.BackupSchedule.Name,
+		dptypes.VerificationLabelKey:   scheduleName,
+	}
+}
+
+func restoreFailureMessage(restore *dpv1alpha1.Restore) string {
+	for _, actions := range [][]dpv1alpha1.RestoreStatusAction{restore.Status.Actions.PrepareData, restore.Status.Actions.PostReady} {
+		for _, action := range actions {
+			if action.Status == dpv1alpha1.RestoreActionFailed && action.Message != "" {
+				return action.Message
+			}
+		}
+	}
+	for _, condition := range restore.Status.Conditions {
+		if condition.Status == metav1.ConditionFalse && condition.Message != "" {
+			return condition.Message
+		}
+	}
+	return fmt.Sprintf("restore %s failed", restore.Name)
+}
+
+// GenerateVerificationName generates the name of the restore and the check job for the backup verification.
+func GenerateVerificationName(backup *dpv1alpha1.Backup) string {
+	return GenerateBackupJobName(backup, verificationPrefix)
+}
+
+// reconcileVerificationCronJob creates/deletes/patches the cronjob which periodically requests to
+// verify the latest completed backup of the schedule.
+func (s *Scheduler) reconcileVerificationCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy) error {
+	cronJobName := GenerateCRNameByScheduleNameAndMethod(s.BackupSchedule, schedulePolicy.BackupMethod,
+		fmt.Sprintf("%s-%s", schedulePolicy.GetScheduleName(), verificationPrefix))
+	cronJob := &batchv1.CronJob{}
+	exists, err := intctrlutil.CheckResourceExists(s.Ctx, s.Client, client.ObjectKey{
+		Namespace: s.BackupSchedule.Namespace,
+		Name:      cronJobName,
+	}, cronJob)
+	if err != nil {
+		return err
+	}
+
+	verification := schedulePolicy.Verification
+	if !boolptr.IsSetToTrue(schedulePolicy.Enabled) || verification == nil || !boolptr.IsSetToTrue(verification.Enabled) {
+		if !exists {
+			return nil
+		}
+		if err = dputils.RemoveDataProtectionFinalizer(s.Ctx, s.Client, cronJob); err != nil {
+			return err
+		}
+		return intctrlutil.BackgroundDeleteObject(s.Client, s.Ctx, cronJob)
+	}
+
+	cronJobProto, err := s.buildVerificationCronJob(schedulePolicy, cronJobName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return s.Client.Create(s.Ctx, cronJobProto)
+	}
+	if reflect.DeepEqual(cronJob.Spec, cronJobProto.Spec) && reflect.DeepEqual(cronJob.Labels, cronJobProto.Labels) {
+		return nil
+	}
+	patch := client.MergeFrom(cronJob.DeepCopy())
+	cronJob.Spec = cronJobProto.Spec
+	cronJob.Labels = cronJobProto.Labels
+	return s.Client.Patch(s.Ctx, cronJob, patch)
+}
+
+func (s *Scheduler) buildVerificationCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy, cronJobName string) (*batchv1.CronJob, error) {
+	var (
+		successfulJobsHistoryLimit int32 = 0
+		failedJobsHistoryLimit     int32 = 1
+	)
+	scheduleName := schedulePolicy.GetScheduleName()
+	// annotate the latest completed backup of the schedule to request the verification.
+	requestVerificationCmd := fmt.Sprintf(`
+backup=$(kubectl get backups.dataprotection.kubeblocks.io -n %s --selector=%s=%s --sort-by=.metadata.creationTimestamp -o jsonpath='{range .items[?(@.spec.backupMethod=="%s")]}{.metadata.name}{" "}{.status.phase}{"\n"}{end}' | awk '$2=="Completed" {name=$1} END {print name}')
+if [ -z "$backup" ]; then
+    echo "No completed backups found. Exiting."
+    exit 0
+fi
+kubectl annotate backups.dataprotection.kubeblocks.io -n %s "$backup" %s=%s --overwrite
+`, s.BackupSchedule.Namespace, dptypes.BackupScheduleLabelKey, s.BackupSchedule.Name, schedulePolicy.BackupMethod,
+		s.BackupSchedule.Namespace, dptypes.VerificationAnnotationKey, scheduleName)
+
+	podSpec, err := s.buildWorkerPodSpec("backup-verification", requestVerificationCmd)
+	if err != nil {
+		return nil, err
+	}
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cronJobName,
+			Namespace: s.BackupSchedule.Namespace,
+			Labels:    map[string]string{},
+		},
+		Spec: batchv1.CronJobSpec{
+			SuccessfulJobsHistoryLimit: &successfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     &failedJobsHistoryLimit,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					BackoffLimit: s.BackupPolicy.Spec.BackoffLimit,
+					Template: corev1.PodTemplateSpec{
+						Spec: *podSpec,
+					},
+				},
+			},
+		},
+	}
+	timeZone, cronExpression := BuildCronJobSchedule(schedulePolicy.Verification.CronExpression)
+	if timeZone != nil {
+		cronJob.Spec.Schedule = schedulePolicy.Verification.CronExpression
+		cronJob.Spec.TimeZone = timeZone
+	} else {
+		cronJob.Spec.Schedule = cronExpression
+	}
+	controllerutil.AddFinalizer(cronJob, dptypes.DataProtectionFinalizerName)
+	for k, v := range s.BackupSchedule.Labels {
+		cronJob.Labels[k] = v
+	}
+	cronJob.Labels[dptypes.BackupScheduleLabelKey] = s.BackupSchedule.Name
+	cronJob.Labels[dptypes.VerificationLabelKey] = scheduleName
+	cronJob.Labels[constant.AppManagedByLabelKey] = dptypes.AppName
+	return cronJob, nil
+}
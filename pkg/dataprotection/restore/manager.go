@@ -442,8 +442,13 @@ func (r *RestoreManager) RestorePVCFromSnapshot(reqCtx intctrlutil.RequestCtx, c
 
 func (r *RestoreManager) prepareBackupRepo(reqCtx intctrlutil.RequestCtx, cli client.Client, backupSet BackupActionSet) (*dpv1alpha1.BackupRepo, error) {
 	if backupSet.Backup.Status.BackupRepoName != "" {
+		repoName := backupSet.Backup.Status.BackupRepoName
+		// restore from the secondary backup repo selected for the restore if the backup has been copied to it
+		if selected := r.Restore.Status.BackupRepoName; selected != "" && utils.IsBackupReplicaRestorable(backupSet.Backup, selected) {
+			repoName = selected
+		}
 		backupRepo := &dpv1alpha1.BackupRepo{}
-		err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: backupSet.Backup.Status.BackupRepoName}, backupRepo)
+		err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: repoName}, backupRepo)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				err = intctrlutil.NewFatalError(err.Error())
@@ -35,12 +35,18 @@ const (
 	CfgKeyWorkerClusterRoleName = "WORKER_CLUSTER_ROLE_NAME"
 	// CfgDataProtectionReconcileWorkers the max reconcile workers for MaxConcurrentReconciles
 	CfgDataProtectionReconcileWorkers = "DATAPROTECTION_RECONCILE_WORKERS"
+	// CfgKeyBackupRepoHealthCheckIntervalSeconds is the key of the interval of the backup repo health check,
+	// its unit is second, and the health check is disabled if it's not positive
+	CfgKeyBackupRepoHealthCheckIntervalSeconds = "BACKUP_REPO_HEALTH_CHECK_INTERVAL_SECONDS"
 )
 
 // config default values
 const (
 	// DefaultGCFrequencySeconds is the default gc frequency, its unit is second
 	DefaultGCFrequencySeconds = 60 * 60
+	// DefaultBackupRepoHealthCheckIntervalSeconds is the default interval of the backup repo health check,
+	// its unit is second
+	DefaultBackupRepoHealthCheckIntervalSeconds = 10 * 60
 )
 
 const (
@@ -66,6 +72,9 @@ const (
 	LastAppliedConfigsAnnotationKey = "dataprotection.kubeblocks.io/last-applied-configurations"
 	// SkipReconciliationAnnotationKey specifies whether to skip reconciliation.
 	SkipReconciliationAnnotationKey = "dataprotection.kubeblocks.io/skip-reconciliation"
+	// VerificationAnnotationKey requests to verify the backup, its value is the name of the schedule
+	// whose verification policy is applied.
+	VerificationAnnotationKey = "dataprotection.kubeblocks.io/verification"
 )
 
 // label keys
@@ -88,6 +97,10 @@ const (
 	AutoBackupLabelKey = "dataprotection.kubeblocks.io/autobackup"
 	// BackupTargetPodLabelKey specifies the backup target pod label key.
 	BackupTargetPodLabelKey = "dataprotection.kubeblocks.io/target-pod-name"
+	// VerificationLabelKey specifies the schedule name of the workloads and volumes created for the backup verification.
+	VerificationLabelKey = "dataprotection.kubeblocks.io/verification"
+	// ReplicaBackupRepoLabelKey specifies the secondary backup repo of the jobs copying backups to it.
+	ReplicaBackupRepoLabelKey = "dataprotection.kubeblocks.io/replica-backup-repo"
 )
 
 // env names
@@ -164,3 +177,9 @@ const (
 const (
 	LogCollectorOutput = "Log Collector Output"
 )
+
+const (
+	// BackupRepoConditionTypeHealthy is the condition type of the BackupRepo to record the result of
+	// the periodic health checks.
+	BackupRepoConditionTypeHealthy = "Healthy"
+)
@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -38,6 +39,29 @@ const (
 	datasafedConfigMountPath = "/etc/datasafed"
 )
 
+// IsBackupRepoAvailable checks if the backup repo is ready and has not failed the latest health check.
+func IsBackupRepoAvailable(repo *dpv1alpha1.BackupRepo) bool {
+	return repo.Status.Phase == dpv1alpha1.BackupRepoReady &&
+		!meta.IsStatusConditionFalse(repo.Status.Conditions, dptypes.BackupRepoConditionTypeHealthy)
+}
+
+// GetBackupReplica returns the status of the backup copy in the secondary backup repo.
+func GetBackupReplica(backup *dpv1alpha1.Backup, repoName string) *dpv1alpha1.BackupReplicaStatus {
+	for i := range backup.Status.Replicas {
+		if backup.Status.Replicas[i].BackupRepoName == repoName {
+			return &backup.Status.Replicas[i]
+		}
+	}
+	return nil
+}
+
+// IsBackupReplicaRestorable checks if the backup has been copied to the secondary backup repo,
+// so it can be restored from the repo.
+func IsBackupReplicaRestorable(backup *dpv1alpha1.Backup, repoName string) bool {
+	replica := GetBackupReplica(backup, repoName)
+	return replica != nil && replica.CompletionTimestamp != nil
+}
+
 func InjectDatasafed(podSpec *corev1.PodSpec, repo *dpv1alpha1.BackupRepo, repoVolumeMountPath string,
 	encryptionConfig *dpv1alpha1.EncryptionConfig, kopiaRepoPath string) {
 	if repo.AccessByMount() {
@@ -24,8 +24,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 )
 
 func TestGetBackupStatusTarget(t *testing.T) {
@@ -54,3 +56,35 @@ func TestGetBackupStatusTarget(t *testing.T) {
 		assert.Error(t, errors.New("backup status target should be empty"))
 	}
 }
+
+func TestIsBackupRepoAvailable(t *testing.T) {
+	repo := &dpv1alpha1.BackupRepo{}
+	assert.False(t, IsBackupRepoAvailable(repo))
+
+	repo.Status.Phase = dpv1alpha1.BackupRepoReady
+	assert.True(t, IsBackupRepoAvailable(repo))
+
+	repo.Status.Conditions = []metav1.Condition{{
+		Type:   dptypes.BackupRepoConditionTypeHealthy,
+		Status: metav1.ConditionFalse,
+	}}
+	assert.False(t, IsBackupRepoAvailable(repo))
+
+	repo.Status.Conditions[0].Status = metav1.ConditionTrue
+	assert.True(t, IsBackupRepoAvailable(repo))
+}
+
+func TestIsBackupReplicaRestorable(t *testing.T) {
+	backup := &dpv1alpha1.Backup{
+		Status: dpv1alpha1.BackupStatus{
+			Replicas: []dpv1alpha1.BackupReplicaStatus{
+				{BackupRepoName: "copying", Phase: dpv1alpha1.BackupReplicaPhaseCopying},
+				{BackupRepoName: "copied", Phase: dpv1alpha1.BackupReplicaPhaseCompleted, CompletionTimestamp: &metav1.Time{}},
+			},
+		},
+	}
+	assert.False(t, IsBackupReplicaRestorable(backup, "copying"))
+	assert.True(t, IsBackupReplicaRestorable(backup, "copied"))
+	assert.False(t, IsBackupReplicaRestorable(backup, "unknown"))
+	assert.Nil(t, GetBackupReplica(backup, "unknown"))
+}
@@ -26,8 +26,12 @@ import (
 type Action struct {
 	Name           string       `json:"name"`
 	Exec           *ExecAction  `json:"exec,omitempty"`
+	HTTP           *HTTPAction  `json:"http,omitempty"`
+	GRPC           *GRPCAction  `json:"grpc,omitempty"`
 	TimeoutSeconds int32        `json:"timeoutSeconds,omitempty"`
 	RetryPolicy    *RetryPolicy `json:"retryPolicy,omitempty"`
+	// the parameters carrying the credentials, which are redacted in the audit log
+	SensitiveParameters []string `json:"sensitiveParameters,omitempty"`
 }
 
 type ExecAction struct {
@@ -35,6 +39,38 @@ type ExecAction struct {
 	Args     []string `json:"args,omitempty"`
 }
 
+type HTTPAction struct {
+	Host        string         `json:"host,omitempty"`
+	Port        int32          `json:"port"`
+	Scheme      string         `json:"scheme,omitempty"`
+	Path        string         `json:"path,omitempty"`
+	Method      string         `json:"method,omitempty"`
+	Headers     []HTTPHeader   `json:"headers,omitempty"`
+	Body        string         `json:"body,omitempty"`
+	Response    *OutputMatcher `json:"response,omitempty"`
+	StatusCodes []int32        `json:"statusCodes,omitempty"` // the status codes considered successful, any 2xx by default
+}
+
+type HTTPHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type GRPCAction struct {
+	Host     string            `json:"host,omitempty"`
+	Port     int32             `json:"port"`
+	Service  string            `json:"service"`
+	Method   string            `json:"method"`
+	Request  string            `json:"request,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Response *OutputMatcher    `json:"response,omitempty"`
+}
+
+type OutputMatcher struct {
+	EqualTo  *string `json:"equalTo,omitempty"`
+	Contains *string `json:"contains,omitempty"`
+}
+
 type RetryPolicy struct {
 	MaxRetries    int           `json:"maxRetries,omitempty"`
 	RetryInterval time.Duration `json:"retryInterval,omitempty"`
@@ -54,6 +90,41 @@ type ActionResponse struct {
 	Output  []byte `json:"output,omitempty"`
 }
 
+type AuditRequest struct {
+	Action string     `json:"action,omitempty"` // filter the records by the action name
+	Since  *time.Time `json:"since,omitempty"`  // filter the records started after the time
+	Limit  int        `json:"limit,omitempty"`  // the maximum number of records to return, the latest ones first
+	Replay *uint64    `json:"replay,omitempty"` // the sequence of the record to replay in dry-run mode
+}
+
+type AuditResponse struct {
+	Error   string        `json:"error,omitempty"`
+	Message string        `json:"message,omitempty"`
+	Records []AuditRecord `json:"records,omitempty"`
+	Replay  *AuditReplay  `json:"replay,omitempty"`
+}
+
+type AuditRecord struct {
+	Seq            uint64            `json:"seq"`
+	Action         string            `json:"action"`
+	Parameters     map[string]string `json:"parameters,omitempty"` // sensitive values are redacted
+	NonBlocking    bool              `json:"nonBlocking,omitempty"`
+	TimeoutSeconds *int32            `json:"timeoutSeconds,omitempty"`
+	StartTime      time.Time         `json:"startTime"`
+	EndTime        time.Time         `json:"endTime"`
+	DurationMillis int64             `json:"durationMillis"`
+	ExitCode       *int32            `json:"exitCode,omitempty"` // exit code of the exec action
+	Error          string            `json:"error,omitempty"`
+	Message        string            `json:"message,omitempty"`
+	OutputSize     int               `json:"outputSize,omitempty"`
+	OutputDigest   string            `json:"outputDigest,omitempty"` // sha256 digest of the output
+}
+
+type AuditReplay struct {
+	Record AuditRecord `json:"record"`
+	Action *Action     `json:"action,omitempty"` // the action would be run, with the recorded parameters expanded
+}
+
 // TODO: define the event spec for probe or async action
 
 const (
@@ -108,4 +179,30 @@ type NewReplicaTask struct {
 	Replicas       string            `json:"replicas"`             // replicas to load the data
 	Parameters     map[string]string `json:"parameters,omitempty"` // parameters for data dump and load
 	TimeoutSeconds *int32            `json:"timeoutSeconds,omitempty"`
+	Transfer       *TransferOptions  `json:"transfer,omitempty"` // transfer the data in chunks, the raw stream is used if not set
+}
+
+type TransferOptions struct {
+	Compression    string `json:"compression,omitempty"`    // the compression of chunks: none, gzip or zstd
+	BandwidthLimit int64  `json:"bandwidthLimit,omitempty"` // the maximum bytes per second to send, 0 means unlimited
+	ChunkSize      int32  `json:"chunkSize,omitempty"`      // the size of the raw data in a chunk
+	MaxRetries     int32  `json:"maxRetries,omitempty"`     // the maximum times to resume the interrupted transfer
+	EstimatedBytes int64  `json:"estimatedBytes,omitempty"` // the estimated size of the raw data, used to calculate the ETA
+}
+
+// StreamingRequest is the handshake packet of the streaming service.
+type StreamingRequest struct {
+	ActionRequest
+	Transfer   *TransferOptions `json:"transfer,omitempty"`
+	TransferID string           `json:"transferID,omitempty"` // the id of the transfer, used to resume it from the spool of the source
+	Offset     int64            `json:"offset,omitempty"`     // resume the transfer from the offset of the raw data
+	Checksum   string           `json:"checksum,omitempty"`   // the digest of the raw data before the offset
+}
+
+type TransferProgress struct {
+	BytesTransferred int64   `json:"bytesTransferred"` // the raw data loaded
+	BytesReceived    int64   `json:"bytesReceived"`    // the data received from the network, which may be compressed
+	Rate             float64 `json:"rate"`             // bytes per second of the raw data since the last report
+	ETASeconds       *int64  `json:"etaSeconds,omitempty"`
+	Retries          int32   `json:"retries,omitempty"`
 }
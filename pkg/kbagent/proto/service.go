@@ -41,4 +41,9 @@ var (
 		Version: "v1.0",
 		URI:     "/v1.0/streaming",
 	}
+	ServiceAudit = &Service{
+		Kind:    "Audit",
+		Version: "v1.0",
+		URI:     "/v1.0/audit",
+	}
 )
@@ -21,15 +21,18 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"time"
 
 	fasthttprouter "github.com/fasthttp/router"
 	"github.com/go-logr/logr"
 	"github.com/valyala/fasthttp"
 
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/service"
 )
 
@@ -123,6 +126,55 @@ func (s *httpServer) router() fasthttp.RequestHandler {
 func (s *httpServer) registerService(router *fasthttprouter.Router, svc service.Service) {
 	router.Handle(fasthttp.MethodPost, svc.URI(), s.dispatcher(svc))
 	s.logger.Info("register service to server", "service", svc.Kind(), "method", fasthttp.MethodPost, "uri", svc.URI())
+
+	// the audit log can be queried through the GET method as well, e.g. /v1.0/audit?action=switchover&limit=10
+	if svc.Kind() == proto.ServiceAudit.Kind {
+		router.Handle(fasthttp.MethodGet, svc.URI(), s.queryDispatcher(svc))
+		s.logger.Info("register service to server", "service", svc.Kind(), "method", fasthttp.MethodGet, "uri", svc.URI())
+	}
+}
+
+// queryDispatcher translates the query arguments of the audit query into the request payload.
+func (s *httpServer) queryDispatcher(svc service.Service) func(*fasthttp.RequestCtx) {
+	dispatch := s.dispatcher(svc)
+	return func(reqCtx *fasthttp.RequestCtx) {
+		req, err := auditRequestFromQueryArgs(reqCtx.QueryArgs())
+		if err != nil {
+			httpRespond(reqCtx, fasthttp.StatusBadRequest, nil, err)
+			return
+		}
+		body, _ := json.Marshal(req)
+		reqCtx.Request.SetBody(body)
+		dispatch(reqCtx)
+	}
+}
+
+func auditRequestFromQueryArgs(args *fasthttp.Args) (*proto.AuditRequest, error) {
+	req := &proto.AuditRequest{
+		Action: string(args.Peek("action")),
+	}
+	if args.Has("since") {
+		since, err := time.Parse(time.RFC3339, string(args.Peek("since")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid since: %s", err.Error())
+		}
+		req.Since = &since
+	}
+	if args.Has("limit") {
+		limit, err := args.GetUint("limit")
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit: %s", err.Error())
+		}
+		req.Limit = limit
+	}
+	if args.Has("replay") {
+		seq, err := strconv.ParseUint(string(args.Peek("replay")), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid replay: %s", err.Error())
+		}
+		req.Replay = &seq
+	}
+	return req, nil
 }
 
 func (s *httpServer) dispatcher(svc service.Service) func(*fasthttp.RequestCtx) {
@@ -34,13 +34,17 @@ type Server interface {
 }
 
 type Config struct {
-	Server           bool
-	Address          string
-	UnixDomainSocket string
-	Port             int
-	StreamingPort    int
-	Concurrency      int
-	Logging          bool
+	Server                bool
+	Address               string
+	UnixDomainSocket      string
+	Port                  int
+	StreamingPort         int
+	Concurrency           int
+	Logging               bool
+	AuditLogDir           string
+	AuditLogRecords       int
+	TransferSpoolDir      string
+	TransferSpoolMaxBytes int64
 }
 
 // NewHTTPServer returns a new HTTP server.
@@ -24,14 +24,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/maps"
 
+	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
 )
 
 func newActionService(logger logr.Logger, actions []proto.Action) (*actionService, error) {
@@ -51,12 +55,14 @@ func newActionService(logger logr.Logger, actions []proto.Action) (*actionServic
 type actionService struct {
 	logger  logr.Logger
 	actions map[string]*proto.Action
+	audit   *auditLog
 
 	mutex          sync.Mutex
 	runningActions map[string]*runningAction
 }
 
 type runningAction struct {
+	startTime  time.Time
 	resultChan chan *commandResult
 }
 
@@ -112,19 +118,45 @@ func (s *actionService) encode(out []byte, err error) []byte {
 	return data
 }
 
+// handleRequest handles the action request, and records it to the audit log.
 func (s *actionService) handleRequest(ctx context.Context, req *proto.ActionRequest) ([]byte, error) {
+	if s.audit == nil {
+		return s.callAction(ctx, req)
+	}
+	startTime := time.Now()
+	if req.NonBlocking != nil && *req.NonBlocking {
+		s.mutex.Lock()
+		if running, ok := s.runningActions[req.Action]; ok {
+			startTime = running.startTime
+		}
+		s.mutex.Unlock()
+	}
+	output, err := s.callAction(ctx, req)
+	// only the final result of the non-blocking action is recorded
+	if !errors.Is(err, proto.ErrInProgress) {
+		if err1 := s.audit.append(newAuditRecord(s.actions[req.Action], req, startTime, output, err)); err1 != nil {
+			s.logger.Error(err1, "failed to write the audit log", "action", req.Action)
+		}
+	}
+	return output, err
+}
+
+func (s *actionService) callAction(ctx context.Context, req *proto.ActionRequest) ([]byte, error) {
 	if _, ok := s.actions[req.Action]; !ok {
 		return nil, errors.Wrapf(proto.ErrNotDefined, "%s is not defined", req.Action)
 	}
 	action := s.actions[req.Action]
-	if action.Exec == nil {
-		return nil, errors.Wrap(proto.ErrNotImplemented, "only exec action is supported")
+	if action.Exec == nil && action.HTTP == nil && action.GRPC == nil {
+		return nil, errors.Wrap(proto.ErrNotImplemented, "only exec, http and grpc actions are supported")
 	}
 	// HACK: pre-check for the reconfigure action
 	if err := checkReconfigure(ctx, req); err != nil {
 		return nil, err
 	}
-	return s.handleExecAction(ctx, req, action)
+	if action.Exec != nil {
+		return s.handleExecAction(ctx, req, action)
+	}
+	return s.handleRemoteAction(ctx, req, action)
 }
 
 func (s *actionService) handleExecAction(ctx context.Context, req *proto.ActionRequest, action *proto.Action) ([]byte, error) {
@@ -145,6 +177,7 @@ func (s *actionService) handleExecActionNonBlocking(ctx context.Context, req *pr
 			return nil, err
 		}
 		running = &runningAction{
+			startTime:  time.Now(),
 			resultChan: resultChan,
 		}
 		s.runningActions[req.Action] = running
@@ -159,3 +192,127 @@ func (s *actionService) handleExecActionNonBlocking(ctx context.Context, req *pr
 	}
 	return (*result).stdout.Bytes(), nil
 }
+
+// dryRun renders the action to run for the request without executing it.
+func (s *actionService) dryRun(req *proto.ActionRequest) (*proto.Action, error) {
+	action, ok := s.actions[req.Action]
+	if !ok {
+		return nil, errors.Wrapf(proto.ErrNotDefined, "%s is not defined", req.Action)
+	}
+	rendered := &proto.Action{
+		Name:           action.Name,
+		Exec:           action.Exec,
+		TimeoutSeconds: action.TimeoutSeconds,
+		RetryPolicy:    action.RetryPolicy,
+	}
+	if req.TimeoutSeconds != nil {
+		rendered.TimeoutSeconds = *req.TimeoutSeconds
+	}
+	if action.HTTP != nil {
+		httpAction := *action.HTTP
+		httpAction.Path = expandActionTemplate(httpAction.Path, req.Parameters)
+		httpAction.Body = expandActionTemplate(httpAction.Body, req.Parameters)
+		httpAction.Headers = make([]proto.HTTPHeader, 0, len(action.HTTP.Headers))
+		for _, header := range action.HTTP.Headers {
+			httpAction.Headers = append(httpAction.Headers, proto.HTTPHeader{
+				Name:  header.Name,
+				Value: expandActionTemplate(header.Value, req.Parameters),
+			})
+		}
+		rendered.HTTP = &httpAction
+	}
+	if action.GRPC != nil {
+		grpcAction := *action.GRPC
+		grpcAction.Request = expandActionTemplate(grpcAction.Request, req.Parameters)
+		grpcAction.Metadata = make(map[string]string, len(action.GRPC.Metadata))
+		for k, v := range action.GRPC.Metadata {
+			grpcAction.Metadata[k] = expandActionTemplate(v, req.Parameters)
+		}
+		rendered.GRPC = &grpcAction
+	}
+	return rendered, nil
+}
+
+// handleRemoteAction handles the HTTP and gRPC actions, which are always executed in blocking mode.
+func (s *actionService) handleRemoteAction(ctx context.Context, req *proto.ActionRequest, action *proto.Action) ([]byte, error) {
+	timeout := req.TimeoutSeconds
+	if timeout == nil && action.TimeoutSeconds > 0 {
+		timeout = &action.TimeoutSeconds
+	}
+	retryPolicy := req.RetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = action.RetryPolicy
+	}
+	return callRemoteAction(ctx, s.logger, req.Action, action, req.Parameters, timeout, retryPolicy)
+}
+
+// CallRemoteAction performs the HTTP or gRPC action out of the action service, with the timeout and
+// retry policy of the action itself. It allows other agents, such as the config-manager, to call
+// the endpoints of the replica in the same way as kbagent.
+func CallRemoteAction(ctx context.Context, logger logr.Logger, action *proto.Action, parameters map[string]string) ([]byte, error) {
+	if action.HTTP == nil && action.GRPC == nil {
+		return nil, errors.Wrap(proto.ErrNotImplemented, "only http and grpc actions are supported")
+	}
+	var timeout *int32
+	if action.TimeoutSeconds > 0 {
+		timeout = &action.TimeoutSeconds
+	}
+	return callRemoteAction(ctx, logger, action.Name, action, parameters, timeout, action.RetryPolicy)
+}
+
+func callRemoteAction(ctx context.Context, logger logr.Logger, name string, action *proto.Action,
+	parameters map[string]string, timeout *int32, retryPolicy *proto.RetryPolicy) ([]byte, error) {
+	call := func() ([]byte, error) {
+		var (
+			output  []byte
+			err     error
+			matcher *proto.OutputMatcher
+		)
+		if action.HTTP != nil {
+			output, err = callHTTP(ctx, action.HTTP, parameters, timeout)
+			matcher = action.HTTP.Response
+		} else {
+			output, err = callGRPC(ctx, action.GRPC, parameters, timeout)
+			matcher = action.GRPC.Response
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err = matchActionOutput(matcher, output); err != nil {
+			return nil, err
+		}
+		return output, nil
+	}
+
+	for i := 0; ; i++ {
+		output, err := call()
+		if err == nil || errors.Is(err, proto.ErrBadRequest) || retryPolicy == nil || i >= retryPolicy.MaxRetries {
+			return output, err
+		}
+		logger.Info("retry action", "action", name, "retries", i+1, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(retryPolicy.RetryInterval):
+		}
+	}
+}
+
+// expandActionTemplate replaces the $(VAR_NAME) references in the template with the action parameters,
+// or the environment variables of kbagent.
+func expandActionTemplate(template string, parameters map[string]string) string {
+	return common.Expand(template, common.MappingFuncFor(parameters, util.EnvL2M(os.Environ())))
+}
+
+func matchActionOutput(matcher *proto.OutputMatcher, output []byte) error {
+	if matcher == nil {
+		return nil
+	}
+	if matcher.EqualTo != nil && string(output) != *matcher.EqualTo {
+		return errors.Wrapf(proto.ErrFailed, "the output is not equal to %q, output: %s", *matcher.EqualTo, string(output))
+	}
+	if matcher.Contains != nil && !strings.Contains(string(output), *matcher.Contains) {
+		return errors.Wrapf(proto.ErrFailed, "the output does not contain %q, output: %s", *matcher.Contains, string(output))
+	}
+	return nil
+}
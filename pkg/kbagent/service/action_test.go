@@ -20,10 +20,238 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package service
 
 import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"k8s.io/utils/ptr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
 
 var _ = Describe("action", func() {
-	Context("action", func() {
+	Context("http action", func() {
+		var (
+			server   *httptest.Server
+			port     int32
+			requests atomic.Int32
+		)
+
+		BeforeEach(func() {
+			requests.Store(0)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				switch r.URL.Path {
+				case "/role/pod-0":
+					body, _ := io.ReadAll(r.Body)
+					_, _ = w.Write([]byte(r.Method + " " + r.Header.Get("X-Pod") + " " + string(body)))
+				case "/slow":
+					time.Sleep(2 * time.Second)
+				default:
+					w.WriteHeader(http.StatusServiceUnavailable)
+					_, _ = w.Write([]byte("unavailable"))
+				}
+			}))
+			u, err := url.Parse(server.URL)
+			Expect(err).Should(BeNil())
+			p, err := strconv.Atoi(u.Port())
+			Expect(err).Should(BeNil())
+			port = int32(p)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		newService := func(action proto.Action) *actionService {
+			action.Name = "roleProbe"
+			svc, err := newActionService(logr.New(nil), []proto.Action{action})
+			Expect(err).Should(BeNil())
+			return svc
+		}
+
+		It("request with parameters", func() {
+			svc := newService(proto.Action{
+				HTTP: &proto.HTTPAction{
+					Port:    port,
+					Path:    "/role/$(POD_NAME)",
+					Method:  "post",
+					Headers: []proto.HTTPHeader{{Name: "X-Pod", Value: "$(POD_NAME)"}},
+					Body:    `{"pod": "$(POD_NAME)"}`,
+					Response: &proto.OutputMatcher{
+						Contains: ptr.To("pod-0"),
+					},
+				},
+			})
+			output, err := svc.handleRequest(context.Background(), &proto.ActionRequest{
+				Action:     "roleProbe",
+				Parameters: map[string]string{"POD_NAME": "pod-0"},
+			})
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(Equal(`POST pod-0 {"pod": "pod-0"}`))
+		})
+
+		It("response not matched", func() {
+			svc := newService(proto.Action{
+				HTTP: &proto.HTTPAction{
+					Port: port,
+					Path: "/role/pod-0",
+					Response: &proto.OutputMatcher{
+						EqualTo: ptr.To("leader"),
+					},
+				},
+			})
+			_, err := svc.handleRequest(context.Background(), &proto.ActionRequest{Action: "roleProbe"})
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+		})
+
+		It("non-2xx status with retries", func() {
+			svc := newService(proto.Action{
+				HTTP: &proto.HTTPAction{
+					Port: port,
+					Path: "/unavailable",
+				},
+				RetryPolicy: &proto.RetryPolicy{
+					MaxRetries: 2,
+				},
+			})
+			_, err := svc.handleRequest(context.Background(), &proto.ActionRequest{Action: "roleProbe"})
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("unavailable"))
+			Expect(requests.Load()).Should(Equal(int32(3)))
+		})
+
+		It("expected status codes", func() {
+			action := &proto.Action{
+				Name: "reload",
+				HTTP: &proto.HTTPAction{
+					Port:        port,
+					Path:        "/unavailable",
+					StatusCodes: []int32{http.StatusServiceUnavailable},
+				},
+			}
+			output, err := CallRemoteAction(context.Background(), logr.New(nil), action, nil)
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(Equal("unavailable"))
+
+			action.HTTP.Path = "/role/pod-0"
+			_, err = CallRemoteAction(context.Background(), logr.New(nil), action, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+		})
+
+		It("timed out", func() {
+			svc := newService(proto.Action{
+				HTTP: &proto.HTTPAction{
+					Port: port,
+					Path: "/slow",
+				},
+				TimeoutSeconds: 1,
+			})
+			_, err := svc.handleRequest(context.Background(), &proto.ActionRequest{Action: "roleProbe"})
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrTimedOut)).Should(BeTrue())
+		})
+	})
+
+	Context("grpc action", func() {
+		var (
+			server       *grpc.Server
+			healthServer *health.Server
+			port         int32
+		)
+
+		BeforeEach(func() {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).Should(BeNil())
+			port = int32(lis.Addr().(*net.TCPAddr).Port)
+
+			server = grpc.NewServer()
+			healthServer = health.NewServer()
+			healthpb.RegisterHealthServer(server, healthServer)
+			reflection.Register(server)
+			go func() {
+				_ = server.Serve(lis)
+			}()
+		})
+
+		AfterEach(func() {
+			server.Stop()
+		})
+
+		newService := func(action proto.Action) *actionService {
+			action.Name = "roleProbe"
+			svc, err := newActionService(logr.New(nil), []proto.Action{action})
+			Expect(err).Should(BeNil())
+			return svc
+		}
+
+		It("invoke with parameters", func() {
+			healthServer.SetServingStatus("pod-0", healthpb.HealthCheckResponse_SERVING)
+			svc := newService(proto.Action{
+				GRPC: &proto.GRPCAction{
+					Port:    port,
+					Service: "grpc.health.v1.Health",
+					Method:  "Check",
+					Request: `{"service": "$(POD_NAME)"}`,
+					Response: &proto.OutputMatcher{
+						Contains: ptr.To("SERVING"),
+					},
+				},
+				TimeoutSeconds: 5,
+			})
+			output, err := svc.handleRequest(context.Background(), &proto.ActionRequest{
+				Action:     "roleProbe",
+				Parameters: map[string]string{"POD_NAME": "pod-0"},
+			})
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(ContainSubstring(`"SERVING"`))
+		})
+
+		It("error status", func() {
+			svc := newService(proto.Action{
+				GRPC: &proto.GRPCAction{
+					Port:    port,
+					Service: "grpc.health.v1.Health",
+					Method:  "Check",
+					Request: `{"service": "unknown"}`,
+				},
+				TimeoutSeconds: 5,
+			})
+			_, err := svc.handleRequest(context.Background(), &proto.ActionRequest{Action: "roleProbe"})
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+		})
+
+		It("method not found", func() {
+			svc := newService(proto.Action{
+				GRPC: &proto.GRPCAction{
+					Port:    port,
+					Service: "grpc.health.v1.Health",
+					Method:  "NotExist",
+				},
+				TimeoutSeconds: 5,
+			})
+			_, err := svc.handleRequest(context.Background(), &proto.ActionRequest{Action: "roleProbe"})
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+		})
 	})
 })
@@ -0,0 +1,360 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	auditLogSegments          = 4
+	defaultAuditLogMaxRecords = 1024
+	maxAuditParameterLength   = 512
+	maxAuditMessageLength     = 1024
+	redactedValue             = "******"
+)
+
+// sensitiveParameterKeywords are the keywords to identify the parameters whose values should be redacted.
+var sensitiveParameterKeywords = []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "CREDENTIAL", "AUTHORIZATION", "STATEMENT"}
+
+// AuditLogConfig is the config of the on-disk audit log of actions.
+type AuditLogConfig struct {
+	// Dir is the directory to store the audit log, the audit log is disabled if it is empty.
+	Dir string
+	// MaxRecords is the maximum number of records to keep.
+	MaxRecords int
+}
+
+// auditLog is a bounded ring log of the action records, which is persisted as a set of segment files.
+// When the current segment is full, the oldest segment will be truncated and reused.
+type auditLog struct {
+	mutex             sync.Mutex
+	dir               string
+	recordsPerSegment int
+	seq               uint64
+	segment           int
+	count             int
+	file              *os.File
+}
+
+func newAuditLog(config AuditLogConfig) (*auditLog, error) {
+	if len(config.Dir) == 0 {
+		return nil, nil
+	}
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, err
+	}
+	maxRecords := config.MaxRecords
+	if maxRecords <= 0 {
+		maxRecords = defaultAuditLogMaxRecords
+	}
+	l := &auditLog{
+		dir:               config.Dir,
+		recordsPerSegment: max(maxRecords/auditLogSegments, 1),
+	}
+	// recover the sequence and the current segment from the existing segments
+	for i := 0; i < auditLogSegments; i++ {
+		records, err := l.readSegment(i)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range records {
+			if r.Seq > l.seq {
+				l.seq = r.Seq
+				l.segment = i
+				l.count = len(records)
+			}
+		}
+	}
+	file, err := os.OpenFile(l.segmentPath(l.segment), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	l.file = file
+	return l, nil
+}
+
+func (l *auditLog) segmentPath(i int) string {
+	return filepath.Join(l.dir, fmt.Sprintf("audit.%d.log", i))
+}
+
+func (l *auditLog) readSegment(i int) ([]proto.AuditRecord, error) {
+	file, err := os.Open(l.segmentPath(i))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	records := make([]proto.AuditRecord, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, defaultBufferSize), 1024*1024)
+	for scanner.Scan() {
+		record := proto.AuditRecord{}
+		// ignore the broken records, e.g. the last record written partially
+		if err = json.Unmarshal(scanner.Bytes(), &record); err == nil {
+			records = append(records, record)
+		}
+	}
+	return records, scanner.Err()
+}
+
+func (l *auditLog) append(record *proto.AuditRecord) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.count >= l.recordsPerSegment {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	l.seq++
+	record.Seq = l.seq
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if _, err = l.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	l.count++
+	return nil
+}
+
+func (l *auditLog) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.segment = (l.segment + 1) % auditLogSegments
+	file, err := os.OpenFile(l.segmentPath(l.segment), os.O_CREATE|os.O_WRONLY|os.O_TRUNC|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	l.file = file
+	l.count = 0
+	return nil
+}
+
+// list returns the records matched, the latest ones first.
+func (l *auditLog) list(match func(proto.AuditRecord) bool) ([]proto.AuditRecord, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	records := make([]proto.AuditRecord, 0)
+	for i := 0; i < auditLogSegments; i++ {
+		segment, err := l.readSegment(i)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range segment {
+			if match == nil || match(r) {
+				records = append(records, r)
+			}
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Seq > records[j].Seq
+	})
+	return records, nil
+}
+
+func newAuditRecord(action *proto.Action, req *proto.ActionRequest, startTime time.Time, output []byte, err error) *proto.AuditRecord {
+	endTime := time.Now()
+	var sensitive []string
+	if action != nil {
+		sensitive = action.SensitiveParameters
+	}
+	record := &proto.AuditRecord{
+		Action:         req.Action,
+		Parameters:     redactParameters(req.Parameters, sensitive),
+		NonBlocking:    req.NonBlocking != nil && *req.NonBlocking,
+		TimeoutSeconds: req.TimeoutSeconds,
+		StartTime:      startTime,
+		EndTime:        endTime,
+		DurationMillis: endTime.Sub(startTime).Milliseconds(),
+	}
+	if err != nil {
+		record.Error = proto.Error2Type(err)
+		record.Message = truncate(err.Error(), maxAuditMessageLength)
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			record.ExitCode = &exitErr.exitCode
+		}
+		return record
+	}
+	record.OutputSize = len(output)
+	record.OutputDigest = digest(output)
+	return record
+}
+
+func redactParameters(parameters map[string]string, sensitive []string) map[string]string {
+	if len(parameters) == 0 {
+		return nil
+	}
+	redacted := make(map[string]string, len(parameters))
+	for k, v := range parameters {
+		redacted[k] = truncate(v, maxAuditParameterLength)
+		if slices.Contains(sensitive, k) {
+			redacted[k] = redactedValue
+			continue
+		}
+		key := strings.ToUpper(k)
+		for _, keyword := range sensitiveParameterKeywords {
+			if strings.Contains(key, keyword) {
+				redacted[k] = redactedValue
+				break
+			}
+		}
+	}
+	return redacted
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	return fmt.Sprintf("%s...(%d bytes truncated)", s[:length], len(s)-length)
+}
+
+func newAuditService(logger logr.Logger, actionService *actionService) (*auditService, error) {
+	return &auditService{
+		logger:        logger,
+		actionService: actionService,
+	}, nil
+}
+
+type auditService struct {
+	logger        logr.Logger
+	actionService *actionService
+}
+
+var _ Service = &auditService{}
+
+func (s *auditService) Kind() string {
+	return proto.ServiceAudit.Kind
+}
+
+func (s *auditService) URI() string {
+	return proto.ServiceAudit.URI
+}
+
+func (s *auditService) Start() error {
+	return nil
+}
+
+func (s *auditService) HandleConn(ctx context.Context, conn net.Conn) error {
+	return nil
+}
+
+func (s *auditService) HandleRequest(ctx context.Context, payload []byte) ([]byte, error) {
+	req := &proto.AuditRequest{}
+	if err := json.Unmarshal(payload, req); err != nil {
+		return s.encode(nil, errors.Wrapf(proto.ErrBadRequest, "unmarshal audit request error: %s", err.Error())), nil
+	}
+	return s.encode(s.handleRequest(req)), nil
+}
+
+func (s *auditService) encode(rsp *proto.AuditResponse, err error) []byte {
+	if rsp == nil {
+		rsp = &proto.AuditResponse{}
+	}
+	if err != nil {
+		rsp.Error = proto.Error2Type(err)
+		rsp.Message = err.Error()
+	}
+	data, _ := json.Marshal(rsp)
+	return data
+}
+
+func (s *auditService) handleRequest(req *proto.AuditRequest) (*proto.AuditResponse, error) {
+	audit := s.actionService.audit
+	if audit == nil {
+		return nil, errors.Wrap(proto.ErrNotImplemented, "the audit log is disabled")
+	}
+	if req.Replay != nil {
+		return s.replay(audit, *req.Replay)
+	}
+	records, err := audit.list(func(r proto.AuditRecord) bool {
+		if len(req.Action) > 0 && r.Action != req.Action {
+			return false
+		}
+		return req.Since == nil || !r.StartTime.Before(*req.Since)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrInternalError, "read the audit log error: %s", err.Error())
+	}
+	if req.Limit > 0 && len(records) > req.Limit {
+		records = records[:req.Limit]
+	}
+	return &proto.AuditResponse{Records: records}, nil
+}
+
+// replay re-runs the recorded request in dry-run mode, it renders the action to run with the recorded parameters
+// but never executes it.
+func (s *auditService) replay(audit *auditLog, seq uint64) (*proto.AuditResponse, error) {
+	records, err := audit.list(func(r proto.AuditRecord) bool {
+		return r.Seq == seq
+	})
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrInternalError, "read the audit log error: %s", err.Error())
+	}
+	if len(records) == 0 {
+		return nil, errors.Wrapf(proto.ErrNotDefined, "audit record %d is not found", seq)
+	}
+	action, err := s.actionService.dryRun(&proto.ActionRequest{
+		Action:         records[0].Action,
+		Parameters:     records[0].Parameters,
+		TimeoutSeconds: records[0].TimeoutSeconds,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &proto.AuditResponse{
+		Replay: &proto.AuditReplay{
+			Record: records[0],
+			Action: action,
+		},
+	}, nil
+}
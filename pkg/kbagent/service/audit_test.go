@@ -0,0 +1,208 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("audit", func() {
+	Context("audit log", func() {
+		It("bounded ring", func() {
+			dir := GinkgoT().TempDir()
+			audit, err := newAuditLog(AuditLogConfig{Dir: dir, MaxRecords: 8})
+			Expect(err).Should(BeNil())
+			for i := 0; i < 20; i++ {
+				Expect(audit.append(&proto.AuditRecord{Action: "switchover"})).Should(Succeed())
+			}
+			records, err := audit.list(nil)
+			Expect(err).Should(BeNil())
+			Expect(len(records)).Should(BeNumerically("<=", 8))
+			Expect(records[0].Seq).Should(Equal(uint64(20)))
+			for i := 1; i < len(records); i++ {
+				Expect(records[i].Seq).Should(Equal(records[i-1].Seq - 1))
+			}
+
+			// reopen and continue the sequence
+			audit, err = newAuditLog(AuditLogConfig{Dir: dir, MaxRecords: 8})
+			Expect(err).Should(BeNil())
+			Expect(audit.append(&proto.AuditRecord{Action: "switchover"})).Should(Succeed())
+			records, err = audit.list(nil)
+			Expect(err).Should(BeNil())
+			Expect(records[0].Seq).Should(Equal(uint64(21)))
+			Expect(len(records)).Should(BeNumerically("<=", 8))
+		})
+
+		It("redact parameters", func() {
+			parameters := redactParameters(map[string]string{
+				"KB_ACCOUNT_NAME":      "user",
+				"KB_ACCOUNT_PASSWORD":  "password",
+				"KB_ACCOUNT_STATEMENT": "CREATE USER user IDENTIFIED BY 'password'",
+				"KB_RELOAD_CREDS":      "Basic dXNlcjpwYXNzd29yZA==",
+			}, []string{"KB_RELOAD_CREDS"})
+			Expect(parameters).Should(HaveKeyWithValue("KB_ACCOUNT_NAME", "user"))
+			Expect(parameters).Should(HaveKeyWithValue("KB_ACCOUNT_PASSWORD", redactedValue))
+			Expect(parameters).Should(HaveKeyWithValue("KB_ACCOUNT_STATEMENT", redactedValue))
+			Expect(parameters).Should(HaveKeyWithValue("KB_RELOAD_CREDS", redactedValue))
+		})
+	})
+
+	Context("audit service", func() {
+		var (
+			actions = []proto.Action{
+				{
+					Name: "switchover",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "echo -n $KB_SWITCHOVER_CANDIDATE_NAME"},
+					},
+				},
+				{
+					Name: "memberLeave",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "exit 3"},
+					},
+				},
+				{
+					Name: "roleProbe",
+					HTTP: &proto.HTTPAction{
+						Port: 8080,
+						Path: "/role/$(KB_POD_NAME)",
+					},
+				},
+				{
+					Name: "udf-reload-mysql-config",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "echo -n $KB_RELOAD_REQUEST"},
+					},
+					SensitiveParameters: []string{"KB_RELOAD_AUTHORIZATION"},
+				},
+			}
+			actionSvc *actionService
+			auditSvc  *auditService
+		)
+
+		BeforeEach(func() {
+			var err error
+			actionSvc, err = newActionService(logr.New(nil), actions)
+			Expect(err).Should(BeNil())
+			actionSvc.audit, err = newAuditLog(AuditLogConfig{Dir: GinkgoT().TempDir()})
+			Expect(err).Should(BeNil())
+			auditSvc, err = newAuditService(logr.New(nil), actionSvc)
+			Expect(err).Should(BeNil())
+		})
+
+		query := func(req proto.AuditRequest) *proto.AuditResponse {
+			payload, err := json.Marshal(req)
+			Expect(err).Should(BeNil())
+			data, err := auditSvc.HandleRequest(context.Background(), payload)
+			Expect(err).Should(BeNil())
+			rsp := &proto.AuditResponse{}
+			Expect(json.Unmarshal(data, rsp)).Should(Succeed())
+			return rsp
+		}
+
+		It("record requests", func() {
+			output, err := actionSvc.handleRequest(context.Background(), &proto.ActionRequest{
+				Action:     "switchover",
+				Parameters: map[string]string{"KB_SWITCHOVER_CANDIDATE_NAME": "pod-1"},
+			})
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(Equal("pod-1"))
+
+			_, err = actionSvc.handleRequest(context.Background(), &proto.ActionRequest{Action: "memberLeave"})
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+
+			rsp := query(proto.AuditRequest{})
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(rsp.Records).Should(HaveLen(2))
+			Expect(rsp.Records[0].Action).Should(Equal("memberLeave"))
+			Expect(rsp.Records[0].Error).Should(Equal("failed"))
+			Expect(rsp.Records[0].ExitCode).Should(Equal(ptr.To[int32](3)))
+			Expect(rsp.Records[1].Action).Should(Equal("switchover"))
+			Expect(rsp.Records[1].Parameters).Should(HaveKeyWithValue("KB_SWITCHOVER_CANDIDATE_NAME", "pod-1"))
+			Expect(rsp.Records[1].OutputSize).Should(Equal(len("pod-1")))
+			Expect(rsp.Records[1].OutputDigest).Should(Equal(digest([]byte("pod-1"))))
+
+			rsp = query(proto.AuditRequest{Action: "switchover"})
+			Expect(rsp.Records).Should(HaveLen(1))
+			rsp = query(proto.AuditRequest{Limit: 1})
+			Expect(rsp.Records).Should(HaveLen(1))
+			Expect(rsp.Records[0].Action).Should(Equal("memberLeave"))
+		})
+
+		It("redact the reload authorization", func() {
+			_, err := actionSvc.handleRequest(context.Background(), &proto.ActionRequest{
+				Action: "udf-reload-mysql-config",
+				Parameters: map[string]string{
+					"KB_RELOAD_REQUEST":       "reload",
+					"KB_RELOAD_AUTHORIZATION": "Bearer kubeblocks",
+				},
+			})
+			Expect(err).Should(BeNil())
+
+			rsp := query(proto.AuditRequest{Action: "udf-reload-mysql-config"})
+			Expect(rsp.Records).Should(HaveLen(1))
+			Expect(rsp.Records[0].Parameters).Should(HaveKeyWithValue("KB_RELOAD_REQUEST", "reload"))
+			Expect(rsp.Records[0].Parameters).Should(HaveKeyWithValue("KB_RELOAD_AUTHORIZATION", redactedValue))
+
+			segments, err := filepath.Glob(filepath.Join(actionSvc.audit.dir, "*"))
+			Expect(err).Should(BeNil())
+			for _, segment := range segments {
+				Expect(os.ReadFile(segment)).ShouldNot(ContainSubstring("kubeblocks"))
+			}
+		})
+
+		It("replay in dry-run", func() {
+			_, _ = actionSvc.handleRequest(context.Background(), &proto.ActionRequest{
+				Action:     "roleProbe",
+				Parameters: map[string]string{"KB_POD_NAME": "pod-0"},
+			})
+			rsp := query(proto.AuditRequest{})
+			Expect(rsp.Records).Should(HaveLen(1))
+
+			rsp = query(proto.AuditRequest{Replay: &rsp.Records[0].Seq})
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(rsp.Replay).ShouldNot(BeNil())
+			Expect(rsp.Replay.Action.HTTP).ShouldNot(BeNil())
+			Expect(rsp.Replay.Action.HTTP.Path).Should(Equal("/role/pod-0"))
+
+			rsp = query(proto.AuditRequest{Replay: ptr.To[uint64](100)})
+			Expect(rsp.Error).Should(Equal("notDefined"))
+		})
+
+		It("disabled", func() {
+			actionSvc.audit = nil
+			rsp := query(proto.AuditRequest{})
+			Expect(rsp.Error).Should(Equal("notImplemented"))
+		})
+	})
+})
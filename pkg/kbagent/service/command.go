@@ -45,6 +45,20 @@ type commandResult struct {
 	stderr *bytes.Buffer
 }
 
+// exitCodeError is a proto.ErrFailed error that carries the exit code of the command.
+type exitCodeError struct {
+	exitCode int32
+	message  string
+}
+
+func (e *exitCodeError) Error() string {
+	return e.message + ": " + proto.ErrFailed.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return proto.ErrFailed
+}
+
 func gather[T interface{}](ch chan T) *T {
 	select {
 	case v, ok := <-ch:
@@ -71,7 +85,7 @@ func runCommand(ctx context.Context, action *proto.ExecAction, parameters map[st
 			if stderrMsg := result.stderr.String(); len(stderrMsg) > 0 {
 				errMsg += fmt.Sprintf(", stderr: %s", stderrMsg)
 			}
-			return nil, errors.Wrapf(proto.ErrFailed, errMsg)
+			return nil, &exitCodeError{exitCode: int32(exitErr.ExitCode()), message: errMsg}
 		}
 		return nil, err
 	}
@@ -0,0 +1,187 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	gproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+func callGRPC(ctx context.Context, action *proto.GRPCAction, parameters map[string]string, timeout *int32) ([]byte, error) {
+	if timeout != nil && *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*timeout)*time.Second)
+		defer cancel()
+	}
+
+	host := action.Host
+	if len(host) == 0 {
+		host = defaultActionHost
+	}
+	conn, err := grpc.Dial(net.JoinHostPort(host, strconv.Itoa(int(action.Port))), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrFailed, "failed to connect to the grpc server: %v", err)
+	}
+	defer conn.Close()
+
+	method, err := resolveGRPCMethod(ctx, conn, action.Service, action.Method)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+
+	in := dynamicpb.NewMessage(method.Input())
+	if len(action.Request) > 0 {
+		if err = protojson.Unmarshal([]byte(expandActionTemplate(action.Request, parameters)), in); err != nil {
+			return nil, errors.Wrapf(proto.ErrBadRequest, "invalid request message of the grpc action: %v", err)
+		}
+	}
+	if len(action.Metadata) > 0 {
+		md := metadata.MD{}
+		for k, v := range action.Metadata {
+			md.Append(k, expandActionTemplate(v, parameters))
+		}
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	out := dynamicpb.NewMessage(method.Output())
+	if err = conn.Invoke(ctx, "/"+action.Service+"/"+action.Method, in, out); err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return protojson.Marshal(out)
+}
+
+func grpcError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded {
+		return proto.ErrTimedOut
+	}
+	if errors.Is(err, proto.ErrBadRequest) {
+		return err
+	}
+	return errors.Wrapf(proto.ErrFailed, "%v", err)
+}
+
+// resolveGRPCMethod resolves the descriptor of the method through the server reflection service.
+func resolveGRPCMethod(ctx context.Context, conn *grpc.ClientConn, service, method string) (protoreflect.MethodDescriptor, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = stream.CloseSend()
+	}()
+
+	resolve := func(req *reflectionpb.ServerReflectionRequest) ([]*descriptorpb.FileDescriptorProto, error) {
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+		rsp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if e := rsp.GetErrorResponse(); e != nil {
+			return nil, status.Error(codes.Code(e.GetErrorCode()), e.GetErrorMessage())
+		}
+		var fds []*descriptorpb.FileDescriptorProto
+		for _, b := range rsp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := gproto.Unmarshal(b, fd); err != nil {
+				return nil, err
+			}
+			fds = append(fds, fd)
+		}
+		return fds, nil
+	}
+
+	files := map[string]*descriptorpb.FileDescriptorProto{}
+	pending, err := resolve(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for len(pending) > 0 {
+		fd := pending[0]
+		pending = pending[1:]
+		if _, ok := files[fd.GetName()]; ok {
+			continue
+		}
+		files[fd.GetName()] = fd
+		for _, dep := range fd.GetDependency() {
+			if _, ok := files[dep]; ok {
+				continue
+			}
+			if gfd, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+				pending = append(pending, protodesc.ToFileDescriptorProto(gfd))
+				continue
+			}
+			deps, err := resolve(&reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+			})
+			if err != nil {
+				return nil, err
+			}
+			pending = append(pending, deps...)
+		}
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{}
+	for _, fd := range files {
+		fds.File = append(fds.File, fd)
+	}
+	registry, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, errors.Wrap(proto.ErrBadRequest, err.Error())
+	}
+	desc, err := registry.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "grpc service %s is not found: %v", service, err)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "%s is not a grpc service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "grpc method %s is not found in service %s", method, service)
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "grpc method %s/%s is not unary", service, method)
+	}
+	return md, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	defaultActionHost       = "127.0.0.1"
+	maxHTTPResponseBodySize = 1024 * 1024
+)
+
+var httpActionClient = &http.Client{
+	Transport: &http.Transport{
+		// the same as the HTTP probes of kubelet, the certificate of the local endpoint is not verified.
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		DisableKeepAlives: true,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+func callHTTP(ctx context.Context, action *proto.HTTPAction, parameters map[string]string, timeout *int32) ([]byte, error) {
+	if timeout != nil && *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*timeout)*time.Second)
+		defer cancel()
+	}
+
+	req, err := newHTTPRequest(ctx, action, parameters)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := httpActionClient.Do(req)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, proto.ErrTimedOut
+		}
+		return nil, errors.Wrapf(proto.ErrFailed, "failed to request %s: %v", req.URL.String(), err)
+	}
+	defer rsp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(rsp.Body, maxHTTPResponseBodySize))
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, proto.ErrTimedOut
+		}
+		return nil, errors.Wrapf(proto.ErrFailed, "failed to read the response: %v", err)
+	}
+	if !expectedHTTPStatus(action, rsp.StatusCode) {
+		errMsg := fmt.Sprintf("status code: %d", rsp.StatusCode)
+		if len(body) > 0 {
+			errMsg += fmt.Sprintf(", body: %s", string(body))
+		}
+		return nil, errors.Wrap(proto.ErrFailed, errMsg)
+	}
+	return body, nil
+}
+
+func expectedHTTPStatus(action *proto.HTTPAction, code int) bool {
+	if len(action.StatusCodes) == 0 {
+		return code >= http.StatusOK && code < http.StatusMultipleChoices
+	}
+	return slices.Contains(action.StatusCodes, int32(code))
+}
+
+func newHTTPRequest(ctx context.Context, action *proto.HTTPAction, parameters map[string]string) (*http.Request, error) {
+	scheme := strings.ToLower(action.Scheme)
+	if len(scheme) == 0 {
+		scheme = "http"
+	}
+	host := action.Host
+	if len(host) == 0 {
+		host = defaultActionHost
+	}
+	path := expandActionTemplate(action.Path, parameters)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	method := strings.ToUpper(action.Method)
+	if len(method) == 0 {
+		method = http.MethodGet
+	}
+	url := fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(host, strconv.Itoa(int(action.Port))), path)
+
+	var body io.Reader
+	if len(action.Body) > 0 {
+		body = bytes.NewBufferString(expandActionTemplate(action.Body, parameters))
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "invalid http action: %v", err)
+	}
+	for _, header := range action.Headers {
+		value := expandActionTemplate(header.Value, parameters)
+		if http.CanonicalHeaderKey(header.Name) == "Host" {
+			req.Host = value
+		} else {
+			req.Header.Add(header.Name, value)
+		}
+	}
+	return req, nil
+}
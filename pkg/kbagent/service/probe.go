@@ -130,7 +130,8 @@ func (r *probeRunner) launchRunLoop(probe *proto.Probe) {
 
 func (r *probeRunner) runLoop(probe *proto.Probe) {
 	runOnce := func() ([]byte, error) {
-		return r.actionService.handleRequest(context.Background(), &proto.ActionRequest{Action: probe.Action})
+		// the probes are not recorded in the audit log, they are reported as events periodically
+		return r.actionService.callAction(context.Background(), &proto.ActionRequest{Action: probe.Action})
 	}
 
 	for range r.ticker.C {
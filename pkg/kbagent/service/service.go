@@ -39,11 +39,18 @@ type Service interface {
 	HandleRequest(ctx context.Context, payload []byte) ([]byte, error)
 }
 
-func New(logger logr.Logger, actions []proto.Action, probes []proto.Probe, streaming []string) ([]Service, error) {
+func New(logger logr.Logger, actions []proto.Action, probes []proto.Probe, streaming []string,
+	audit AuditLogConfig, spool TransferSpoolConfig) ([]Service, error) {
 	sa, err := newActionService(logger, actions)
 	if err != nil {
 		return nil, err
 	}
+	sa.audit, err = newAuditLog(audit)
+	if err != nil {
+		// the audit log is not critical, run without it
+		logger.Error(err, "failed to open the audit log, disable it", "dir", audit.Dir)
+	}
+	configTransferSpool(spool)
 	sp, err := newProbeService(logger, sa, probes)
 	if err != nil {
 		return nil, err
@@ -52,7 +59,11 @@ func New(logger logr.Logger, actions []proto.Action, probes []proto.Probe, strea
 	if err != nil {
 		return nil, err
 	}
-	return []Service{sa, sp, ss}, nil
+	sau, err := newAuditService(logger, sa)
+	if err != nil {
+		return nil, err
+	}
+	return []Service{sa, sp, ss, sau}, nil
 }
 
 func RunTasks(logger logr.Logger, service Service, tasks []proto.Task) error {
@@ -90,9 +90,8 @@ func (s *streamingService) HandleRequest(ctx context.Context, payload []byte) ([
 	return nil, errors.Wrapf(proto.ErrNotImplemented, "service %s does not support request handling", s.Kind())
 }
 
-func (s *streamingService) handshake(ctx context.Context, conn net.Conn) (*proto.ActionRequest, error) {
-	// reuse the action request as the handshake packet, define a new one when needed
-	req := &proto.ActionRequest{}
+func (s *streamingService) handshake(ctx context.Context, conn net.Conn) (*proto.StreamingRequest, error) {
+	req := &proto.StreamingRequest{}
 	decoder := json.NewDecoder(conn)
 	if err := decoder.Decode(req); err != nil {
 		return nil, errors.Wrapf(proto.ErrBadRequest, "read and unmarshal action request error: %s", err.Error())
@@ -100,7 +99,10 @@ func (s *streamingService) handshake(ctx context.Context, conn net.Conn) (*proto
 	return req, nil
 }
 
-func (s *streamingService) streaming(ctx context.Context, conn net.Conn, action *proto.Action, req *proto.ActionRequest) error {
+func (s *streamingService) streaming(ctx context.Context, conn net.Conn, action *proto.Action, req *proto.StreamingRequest) error {
+	if req.Transfer != nil {
+		return sendTransfer(ctx, conn, action.Exec, req)
+	}
 	errChan, err1 := runCommandX(ctx, action.Exec, req.Parameters, req.TimeoutSeconds, nil, conn, nil)
 	if err1 != nil {
 		return err1
@@ -20,13 +20,22 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package service
 
 import (
+	"bufio"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"hash"
+	"io"
 	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/uuid"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
@@ -36,6 +45,7 @@ const (
 	newReplicaDataDump              = "dataDump"
 	newReplicaDataLoad              = "dataLoad"
 	newReplicaConnectTimeoutSeconds = 10
+	newReplicaRetryInterval         = 5 * time.Second
 
 	targetPodNameEnv = "KB_TARGET_POD_NAME"
 )
@@ -44,6 +54,16 @@ type newReplicaTask struct {
 	logger        logr.Logger
 	actionService *actionService
 	task          *proto.NewReplicaTask
+	transferID    string
+
+	// progress of the transfer
+	bytesTransferred atomic.Int64
+	bytesReceived    atomic.Int64
+	retries          atomic.Int32
+
+	mutex           sync.Mutex
+	lastReportTime  time.Time
+	lastReportBytes int64
 }
 
 var _ task = &newReplicaTask{}
@@ -54,29 +74,192 @@ func (s *newReplicaTask) run(ctx context.Context) (chan error, error) {
 		return nil, fmt.Errorf("%s is not supported", newReplicaDataLoad)
 	}
 
-	conn, err := s.handshake(ctx)
+	s.lastReportTime = time.Now()
+	if s.task.Transfer == nil {
+		conn, err := s.handshake(ctx, &proto.StreamingRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return runCommandX(ctx, action.Exec, s.task.Parameters, s.task.TimeoutSeconds, conn, nil, nil)
+	}
+	return s.runTransfer(ctx, action)
+}
+
+// runTransfer loads the data transferred in chunks, and resumes the transfer from the last loaded offset
+// if it is interrupted.
+func (s *newReplicaTask) runTransfer(ctx context.Context, action *proto.Action) (chan error, error) {
+	opts := normalizeTransferOptions(s.task.Transfer)
+	codec, err := newChunkCodec(opts.Compression)
+	if err != nil {
+		return nil, err
+	}
+	s.transferID = string(uuid.NewUUID())
+
+	ctx, cancel := context.WithCancel(ctx)
+	pr, pw := io.Pipe()
+	loadErrChan, err := runCommandX(ctx, action.Exec, s.task.Parameters, s.task.TimeoutSeconds, pr, nil, nil)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
+	loaded := make(chan error, 1)
+	go func() {
+		loadErr, ok := <-loadErrChan
+		if !ok {
+			loadErr = errors.New("runtime error: error chan closed unexpectedly")
+		}
+		// unblock the transfer if the data load exits unexpectedly
+		_ = pr.CloseWithError(fmt.Errorf("%s exited: %v", newReplicaDataLoad, loadErr))
+		loaded <- loadErr
+	}()
+
+	errChan := make(chan error, 1)
+	go func() {
+		defer cancel()
+		defer close(errChan)
+		if err := s.receive(ctx, pw, opts, codec); err != nil {
+			// kill the data load, the partial data should not be committed
+			cancel()
+			_ = pw.CloseWithError(err)
+			<-loaded
+			errChan <- err
+			return
+		}
+		_ = pw.Close()
+		errChan <- <-loaded
+	}()
+	return errChan, nil
+}
+
+func (s *newReplicaTask) receive(ctx context.Context, w io.Writer, opts proto.TransferOptions, codec chunkCodec) error {
+	hasher := sha256.New()
+	for {
+		done, err := s.receiveOnce(ctx, w, opts, codec, hasher)
+		if done || s.retries.Load() >= opts.MaxRetries {
+			return err
+		}
+		s.retries.Add(1)
+		s.logger.Info("the transfer is interrupted, resume it later",
+			"offset", s.bytesTransferred.Load(), "retries", s.retries.Load(), "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(newReplicaRetryInterval):
+		}
+	}
+}
+
+// receiveOnce receives the data from the last offset, it returns true if the transfer is finished or can not be resumed.
+func (s *newReplicaTask) receiveOnce(ctx context.Context, w io.Writer, opts proto.TransferOptions, codec chunkCodec, hasher hash.Hash) (bool, error) {
+	offset := s.bytesTransferred.Load()
+	conn, err := s.handshake(ctx, &proto.StreamingRequest{
+		Transfer:   &opts,
+		TransferID: s.transferID,
+		Offset:     offset,
+		Checksum:   transferChecksum(hasher),
+	})
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	magic, err := r.Peek(len(transferMagic))
+	if err != nil && len(magic) == 0 {
+		return false, err
+	}
+	if string(magic) != transferMagic {
+		// the remote does not support the chunked transfer, fall back to the raw stream
+		if offset > 0 {
+			return true, errors.New("the remote does not support resuming the transfer")
+		}
+		_, err = io.Copy(w, &countingReader{reader: r, counters: []*atomic.Int64{&s.bytesTransferred, &s.bytesReceived}})
+		return true, err
+	}
+	if _, err = r.Discard(len(transferMagic)); err != nil {
+		return false, err
+	}
 
-	return runCommandX(ctx, action.Exec, s.task.Parameters, s.task.TimeoutSeconds, conn, nil, nil)
+	for {
+		typ, payload, err := readTransferFrame(r, maxTransferChunkSize*2)
+		if err != nil {
+			return false, err
+		}
+		s.bytesReceived.Add(int64(transferFrameHeaderSize + len(payload)))
+		switch typ {
+		case transferFrameData:
+			data, err := codec.decode(payload)
+			if err != nil {
+				return false, errors.Wrap(errTransferFrameCorrupted, err.Error())
+			}
+			if _, err = w.Write(data); err != nil {
+				return true, err
+			}
+			hasher.Write(data)
+			s.bytesTransferred.Add(int64(len(data)))
+		case transferFrameEnd:
+			size, checksum, err := decodeTransferEnd(payload)
+			if err != nil {
+				return false, err
+			}
+			if size != s.bytesTransferred.Load() || checksum != transferChecksum(hasher) {
+				return true, fmt.Errorf("the data transferred is inconsistent, size: %d, expected: %d, checksum: %s, expected: %s",
+					s.bytesTransferred.Load(), size, transferChecksum(hasher), checksum)
+			}
+			return true, nil
+		case transferFrameError:
+			return true, errors.Wrapf(proto.ErrFailed, "remote error: %s", string(payload))
+		default:
+			return false, errors.Wrapf(errTransferFrameCorrupted, "unknown frame type: %d", typ)
+		}
+	}
 }
 
 func (s *newReplicaTask) status(ctx context.Context, event *proto.TaskEvent) {
-	// TODO: query the progress
+	if s.task.Transfer == nil {
+		// the progress of the raw stream is unknown
+		event.Code = 0
+		event.Output = nil
+		event.Message = ""
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	transferred := s.bytesTransferred.Load()
+	progress := proto.TransferProgress{
+		BytesTransferred: transferred,
+		BytesReceived:    s.bytesReceived.Load(),
+		Retries:          s.retries.Load(),
+	}
+	if elapsed := now.Sub(s.lastReportTime).Seconds(); elapsed > 0 {
+		progress.Rate = float64(transferred-s.lastReportBytes) / elapsed
+	}
+	s.lastReportTime, s.lastReportBytes = now, transferred
+
+	message := fmt.Sprintf("transferred: %s, rate: %s/s", formatBytes(float64(transferred)), formatBytes(progress.Rate))
+	if s.task.Transfer.EstimatedBytes > transferred && progress.Rate > 0 {
+		eta := int64(float64(s.task.Transfer.EstimatedBytes-transferred) / progress.Rate)
+		progress.ETASeconds = &eta
+		message += fmt.Sprintf(", ETA: %s", time.Duration(eta)*time.Second)
+	}
+	output, _ := json.Marshal(progress)
+
 	event.Code = 0
-	event.Output = nil
-	event.Message = ""
+	event.Output = output
+	event.Message = message
 }
 
-func (s *newReplicaTask) handshake(ctx context.Context) (net.Conn, error) {
+func (s *newReplicaTask) handshake(ctx context.Context, req *proto.StreamingRequest) (net.Conn, error) {
 	conn, err := s.connectToRemote(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// reuse the action request as the handshake packet, define a new one when needed
-	req := proto.ActionRequest{
+	// reuse the action request as the handshake packet
+	req.ActionRequest = proto.ActionRequest{
 		Action:         newReplicaDataDump,
 		Parameters:     s.task.Parameters,
 		TimeoutSeconds: s.task.TimeoutSeconds,
@@ -87,17 +270,21 @@ func (s *newReplicaTask) handshake(ctx context.Context) (net.Conn, error) {
 	req.Parameters[targetPodNameEnv] = util.PodName()
 	data, err := json.Marshal(req)
 	if err != nil {
+		conn.Close()
 		return nil, err
 	}
 	if len(data) > maxStreamingHandshakePacketSize {
+		conn.Close()
 		return nil, fmt.Errorf("handshake packet size is too large: %d", len(data))
 	}
 
 	ret, err := conn.Write(data)
 	if err != nil {
+		conn.Close()
 		return nil, err
 	}
 	if ret != len(data) {
+		conn.Close()
 		return nil, fmt.Errorf("write streaming handshake request to remote error")
 	}
 
@@ -114,5 +301,27 @@ func (s *newReplicaTask) connectToRemote(ctx context.Context) (net.Conn, error)
 	dialer := &net.Dialer{
 		Timeout: newReplicaConnectTimeoutSeconds * time.Second,
 	}
-	return dialer.Dial("tcp", fmt.Sprintf("%s:%d", s.task.Remote, s.task.Port))
+	return dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.task.Remote, strconv.Itoa(int(s.task.Port))))
+}
+
+type countingReader struct {
+	reader   io.Reader
+	counters []*atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	for _, c := range r.counters {
+		c.Add(int64(n))
+	}
+	return n, err
+}
+
+func formatBytes(n float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	i := 0
+	for ; n >= 1024 && i < len(units)-1; i++ {
+		n /= 1024
+	}
+	return fmt.Sprintf("%.1f%s", n, units[i])
 }
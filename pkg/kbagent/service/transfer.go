@@ -0,0 +1,310 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// The chunked transfer protocol used to stream the data between replicas:
+//
+//	magic | frame | frame | ... | end frame or error frame
+//
+// Each frame consists of a 9-byte header (1-byte type, 4-byte payload length and 4-byte CRC32-C of the payload)
+// followed by the payload. The payload of a data frame is a chunk of the raw data, which is compressed independently,
+// so the transfer can be resumed at the boundary of any chunk.
+const (
+	transferMagic = "KBXFER1\n"
+
+	transferFrameData  byte = 'D'
+	transferFrameEnd   byte = 'E'
+	transferFrameError byte = 'X'
+
+	transferFrameHeaderSize = 9
+
+	transferCompressionNone = "none"
+	transferCompressionGzip = "gzip"
+	transferCompressionZstd = "zstd"
+
+	defaultTransferChunkSize  = 4 * 1024 * 1024
+	maxTransferChunkSize      = 64 * 1024 * 1024
+	defaultTransferMaxRetries = 10
+	maxTransferBurstSize      = 256 * 1024
+)
+
+var (
+	crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+	errTransferFrameCorrupted = errors.New("transfer frame corrupted")
+)
+
+func normalizeTransferOptions(opts *proto.TransferOptions) proto.TransferOptions {
+	normalized := proto.TransferOptions{}
+	if opts != nil {
+		normalized = *opts
+	}
+	if len(normalized.Compression) == 0 {
+		normalized.Compression = transferCompressionNone
+	}
+	if normalized.ChunkSize <= 0 {
+		normalized.ChunkSize = defaultTransferChunkSize
+	}
+	normalized.ChunkSize = min(normalized.ChunkSize, maxTransferChunkSize)
+	if normalized.MaxRetries <= 0 {
+		normalized.MaxRetries = defaultTransferMaxRetries
+	}
+	return normalized
+}
+
+func transferChecksum(h hash.Hash) string {
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+func writeTransferFrame(w io.Writer, typ byte, payload []byte) error {
+	header := make([]byte, transferFrameHeaderSize)
+	header[0] = typ
+	binary.BigEndian.PutUint32(header[1:5], uint32(len(payload)))
+	binary.BigEndian.PutUint32(header[5:9], crc32.Checksum(payload, crc32cTable))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+func readTransferFrame(r io.Reader, maxSize int) (byte, []byte, error) {
+	header := make([]byte, transferFrameHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+	size := int(binary.BigEndian.Uint32(header[1:5]))
+	if size > maxSize {
+		return 0, nil, errors.Wrapf(errTransferFrameCorrupted, "frame size %d exceeds the limit %d", size, maxSize)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	if crc32.Checksum(payload, crc32cTable) != binary.BigEndian.Uint32(header[5:9]) {
+		return 0, nil, errors.Wrap(errTransferFrameCorrupted, "crc mismatch")
+	}
+	return header[0], payload, nil
+}
+
+func encodeTransferEnd(size int64, checksum string) []byte {
+	payload := make([]byte, 8, 8+len(checksum))
+	binary.BigEndian.PutUint64(payload, uint64(size))
+	return append(payload, []byte(checksum)...)
+}
+
+func decodeTransferEnd(payload []byte) (int64, string, error) {
+	if len(payload) < 8 {
+		return 0, "", errors.Wrap(errTransferFrameCorrupted, "invalid end frame")
+	}
+	return int64(binary.BigEndian.Uint64(payload[:8])), string(payload[8:]), nil
+}
+
+// chunkCodec compresses and decompresses the chunks.
+type chunkCodec interface {
+	encode(src []byte) ([]byte, error)
+	decode(src []byte) ([]byte, error)
+}
+
+func newChunkCodec(compression string) (chunkCodec, error) {
+	switch compression {
+	case transferCompressionNone:
+		return &noneCodec{}, nil
+	case transferCompressionGzip:
+		return &gzipCodec{}, nil
+	case transferCompressionZstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		decoder, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxTransferChunkSize))
+		if err != nil {
+			return nil, err
+		}
+		return &zstdCodec{encoder: encoder, decoder: decoder}, nil
+	default:
+		return nil, errors.Wrapf(proto.ErrBadRequest, "unknown compression: %s", compression)
+	}
+}
+
+type noneCodec struct{}
+
+func (c *noneCodec) encode(src []byte) ([]byte, error) {
+	return src, nil
+}
+
+func (c *noneCodec) decode(src []byte) ([]byte, error) {
+	return src, nil
+}
+
+type gzipCodec struct{}
+
+func (c *gzipCodec) encode(src []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(src); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *gzipCodec) decode(src []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(io.LimitReader(r, maxTransferChunkSize))
+}
+
+type zstdCodec struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func (c *zstdCodec) encode(src []byte) ([]byte, error) {
+	return c.encoder.EncodeAll(src, nil), nil
+}
+
+func (c *zstdCodec) decode(src []byte) ([]byte, error) {
+	return c.decoder.DecodeAll(src, nil)
+}
+
+// throttledWriter limits the bandwidth of the underlying writer.
+type throttledWriter struct {
+	ctx     context.Context
+	writer  io.Writer
+	limiter *rate.Limiter
+}
+
+func newThrottledWriter(ctx context.Context, w io.Writer, bytesPerSecond int64) io.Writer {
+	if bytesPerSecond <= 0 {
+		return w
+	}
+	burst := int(min(bytesPerSecond, maxTransferBurstSize))
+	return &throttledWriter{
+		ctx:     ctx,
+		writer:  w,
+		limiter: rate.NewLimiter(rate.Limit(bytesPerSecond), burst),
+	}
+}
+
+func (w *throttledWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), w.limiter.Burst())
+		if err := w.limiter.WaitN(w.ctx, n); err != nil {
+			return written, err
+		}
+		m, err := w.writer.Write(p[:n])
+		written += m
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// sendTransfer sends the output of the command in chunks from its spool, the data before the offset is skipped after
+// its checksum is verified.
+func sendTransfer(ctx context.Context, conn io.Writer, action *proto.ExecAction, req *proto.StreamingRequest) error {
+	opts := normalizeTransferOptions(req.Transfer)
+	w := newThrottledWriter(ctx, conn, opts.BandwidthLimit)
+	if _, err := io.WriteString(w, transferMagic); err != nil {
+		return err
+	}
+	fail := func(err error) error {
+		_ = writeTransferFrame(w, transferFrameError, []byte(err.Error()))
+		return err
+	}
+
+	codec, err := newChunkCodec(opts.Compression)
+	if err != nil {
+		return fail(err)
+	}
+
+	spool, err := transferSpools.acquire(action, req)
+	if err != nil {
+		return fail(err)
+	}
+	defer transferSpools.release(spool)
+	r, err := spool.open(ctx)
+	if err != nil {
+		return fail(err)
+	}
+	defer r.Close()
+
+	hasher := sha256.New()
+	if req.Offset > 0 {
+		if _, err = io.CopyN(hasher, r, req.Offset); err != nil {
+			return fail(fmt.Errorf("failed to skip the data before offset %d: %v", req.Offset, err))
+		}
+		if transferChecksum(hasher) != req.Checksum {
+			return fail(fmt.Errorf("the data before offset %d is changed, the transfer can not be resumed", req.Offset))
+		}
+	}
+
+	size := req.Offset
+	buf := make([]byte, opts.ChunkSize)
+	for {
+		n, err1 := io.ReadFull(r, buf)
+		if n > 0 {
+			hasher.Write(buf[:n])
+			size += int64(n)
+			payload, err2 := codec.encode(buf[:n])
+			if err2 != nil {
+				return fail(err2)
+			}
+			if err2 = writeTransferFrame(w, transferFrameData, payload); err2 != nil {
+				return err2 // the connection is broken, the remote will resume the transfer
+			}
+		}
+		if err1 == io.EOF || err1 == io.ErrUnexpectedEOF {
+			break
+		}
+		if err1 != nil {
+			return fail(err1)
+		}
+	}
+	return writeTransferFrame(w, transferFrameEnd, encodeTransferEnd(size, transferChecksum(hasher)))
+}
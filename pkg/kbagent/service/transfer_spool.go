@@ -0,0 +1,289 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// The output of the data dump is persisted to a spool file on the source replica, and the transfer is served
+// from the spool, so an interrupted transfer can be resumed from any offset without running the dump again.
+const (
+	transferSpoolPollInterval = 100 * time.Millisecond
+	maxTransferIDLength       = 128
+	// transferSpoolReservedBytes is the free space kept on the volume of the spool, to not starve the database
+	// and get the replica evicted, when the spool is on the data volume or the ephemeral storage.
+	transferSpoolReservedBytes = 512 * 1024 * 1024
+)
+
+var (
+	errTransferSpoolFull = errors.New("the spool of the transfer is full")
+)
+
+// TransferSpoolConfig is the config of the spool of the data transfers.
+type TransferSpoolConfig struct {
+	// Dir is the directory to persist the spool files, use the temp dir if it is empty.
+	Dir string
+	// MaxBytes is the maximum size of a spool file, 0 means it is only bounded by the free space of the volume.
+	MaxBytes int64
+}
+
+var (
+	// transferSpoolDir is the directory to persist the spool files.
+	transferSpoolDir = filepath.Join(os.TempDir(), "kb-transfer")
+	// transferSpoolMaxBytes is the maximum size of a spool file.
+	transferSpoolMaxBytes int64
+	// transferSpoolIdleTimeout is the time to keep the spool after the last connection of the transfer is closed,
+	// the dump is killed and the spool is removed if the transfer is not resumed within it.
+	transferSpoolIdleTimeout = 10 * time.Minute
+
+	transferIDPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+	transferSpools = &transferSpoolRegistry{spools: map[string]*transferSpool{}}
+)
+
+type transferSpoolRegistry struct {
+	mutex  sync.Mutex
+	spools map[string]*transferSpool
+}
+
+type transferSpool struct {
+	id     string
+	path   string
+	cancel context.CancelFunc
+
+	// guarded by the mutex of the registry
+	readers    int
+	lastAccess time.Time
+
+	mutex sync.Mutex
+	done  bool
+	err   error
+}
+
+// acquire returns the spool of the transfer, the data dump is started if the transfer is new.
+func (r *transferSpoolRegistry) acquire(action *proto.ExecAction, req *proto.StreamingRequest) (*transferSpool, error) {
+	if len(req.TransferID) > maxTransferIDLength || !transferIDPattern.MatchString(req.TransferID) {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "invalid transfer id: %s", req.TransferID)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	spool, ok := r.spools[req.TransferID]
+	if !ok {
+		if req.Offset > 0 {
+			return nil, fmt.Errorf("the spool of transfer %s is not found, the transfer can not be resumed", req.TransferID)
+		}
+		var err error
+		if spool, err = startTransferSpool(action, req); err != nil {
+			return nil, err
+		}
+		r.spools[req.TransferID] = spool
+	}
+	spool.readers++
+	return spool, nil
+}
+
+// release releases the spool, and removes it if the transfer is not resumed within the idle timeout.
+func (r *transferSpoolRegistry) release(spool *transferSpool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	spool.readers--
+	spool.lastAccess = time.Now()
+	time.AfterFunc(transferSpoolIdleTimeout, func() {
+		r.expire(spool)
+	})
+}
+
+func (r *transferSpoolRegistry) expire(spool *transferSpool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if spool.readers > 0 || time.Since(spool.lastAccess) < transferSpoolIdleTimeout || r.spools[spool.id] != spool {
+		return
+	}
+	delete(r.spools, spool.id)
+	spool.cancel()
+	_ = os.Remove(spool.path)
+}
+
+func configTransferSpool(config TransferSpoolConfig) {
+	if len(config.Dir) > 0 {
+		transferSpoolDir = config.Dir
+	}
+	transferSpoolMaxBytes = config.MaxBytes
+}
+
+// transferSpoolLimit returns the maximum bytes the spool of the transfer can take, it fails if the estimated size
+// of the dump doesn't fit in.
+func transferSpoolLimit(req *proto.StreamingRequest) (int64, error) {
+	limit, err := availableSpace(transferSpoolDir)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to check the free space of the spool dir %s", transferSpoolDir)
+	}
+	if limit >= 0 {
+		limit = max(limit-transferSpoolReservedBytes, 0)
+	}
+	if transferSpoolMaxBytes > 0 && (limit < 0 || limit > transferSpoolMaxBytes) {
+		limit = transferSpoolMaxBytes
+	}
+	if req.Transfer != nil && req.Transfer.EstimatedBytes > 0 && limit >= 0 && req.Transfer.EstimatedBytes > limit {
+		return 0, fmt.Errorf("the spool dir %s has no space for the dump, estimated: %d bytes, available: %d bytes",
+			transferSpoolDir, req.Transfer.EstimatedBytes, limit)
+	}
+	return limit, nil
+}
+
+func startTransferSpool(action *proto.ExecAction, req *proto.StreamingRequest) (*transferSpool, error) {
+	if err := os.MkdirAll(transferSpoolDir, 0700); err != nil {
+		return nil, err
+	}
+	limit, err := transferSpoolLimit(req)
+	if err != nil {
+		return nil, err
+	}
+	spool := &transferSpool{
+		id:   req.TransferID,
+		path: filepath.Join(transferSpoolDir, req.TransferID),
+	}
+	file, err := os.OpenFile(spool.path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	// the dump is detached from the connection, which may be interrupted.
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &transferSpoolWriter{file: file, limit: limit, full: cancel}
+	errChan, err := runCommandX(ctx, action, req.Parameters, req.TimeoutSeconds, nil, w, nil)
+	if err != nil {
+		cancel()
+		_ = file.Close()
+		_ = os.Remove(spool.path)
+		return nil, err
+	}
+	spool.cancel = cancel
+	go func() {
+		execErr, ok := <-errChan
+		if !ok {
+			execErr = errors.New("runtime error: error chan closed unexpectedly")
+		}
+		var exitErr *exec.ExitError
+		if errors.As(execErr, &exitErr) {
+			execErr = fmt.Errorf("exit code: %d", exitErr.ExitCode())
+		}
+		if err := file.Close(); err != nil && execErr == nil {
+			execErr = err
+		}
+		if w.exceeded {
+			// the dump is killed, release the space as soon as possible
+			execErr = errors.Wrapf(errTransferSpoolFull, "the dump exceeds the limit %d bytes", limit)
+			_ = os.Remove(spool.path)
+		}
+		spool.finish(execErr)
+	}()
+	return spool, nil
+}
+
+// transferSpoolWriter writes the output of the dump to the spool file, and kills the dump if it exceeds the limit.
+type transferSpoolWriter struct {
+	file     *os.File
+	limit    int64 // negative means unlimited
+	written  int64
+	exceeded bool
+	full     context.CancelFunc
+}
+
+func (w *transferSpoolWriter) Write(p []byte) (int, error) {
+	if w.limit >= 0 && w.written+int64(len(p)) > w.limit {
+		w.exceeded = true
+		w.full()
+		return 0, errTransferSpoolFull
+	}
+	n, err := w.file.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+func (s *transferSpool) finish(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.done, s.err = true, err
+}
+
+func (s *transferSpool) state() (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.done, s.err
+}
+
+// open opens the spool to read, the reader follows the data appended by the dump till it is finished.
+func (s *transferSpool) open(ctx context.Context) (io.ReadCloser, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	return &transferSpoolReader{ctx: ctx, spool: s, file: file}, nil
+}
+
+type transferSpoolReader struct {
+	ctx   context.Context
+	spool *transferSpool
+	file  *os.File
+}
+
+func (r *transferSpoolReader) Read(p []byte) (int, error) {
+	for {
+		// check the state before reading, so that all the data is read if the dump is finished.
+		done, dumpErr := r.spool.state()
+		n, err := r.file.Read(p)
+		if n > 0 || (err != nil && err != io.EOF) {
+			return n, err
+		}
+		if done {
+			if dumpErr != nil {
+				return 0, dumpErr
+			}
+			return 0, io.EOF
+		}
+		select {
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		case <-time.After(transferSpoolPollInterval):
+		}
+	}
+}
+
+func (r *transferSpoolReader) Close() error {
+	return r.file.Close()
+}
@@ -17,16 +17,9 @@ You should have received a copy of the GNU Affero General Public License
 along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 
-package rollout
+package service
 
-import (
-	"fmt"
-)
-
-func strategyNotSupportedError(strategy string) error {
-	return fmt.Errorf("the \"%s\" strategy is NOT supported yet", strategy)
+// availableSpace returns -1 as the free space of the volume is unknown.
+func availableSpace(string) (int64, error) {
+	return -1, nil
 }
-
-var (
-	createStrategyNotSupportedError = strategyNotSupportedError("create")
-)
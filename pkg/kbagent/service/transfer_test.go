@@ -0,0 +1,260 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("transfer", func() {
+	Context("codec", func() {
+		It("compression", func() {
+			data := bytes.Repeat([]byte("kubeblocks"), 1024)
+			for _, compression := range []string{transferCompressionNone, transferCompressionGzip, transferCompressionZstd} {
+				codec, err := newChunkCodec(compression)
+				Expect(err).Should(BeNil())
+				encoded, err := codec.encode(data)
+				Expect(err).Should(BeNil())
+				decoded, err := codec.decode(encoded)
+				Expect(err).Should(BeNil())
+				Expect(decoded).Should(Equal(data))
+			}
+			_, err := newChunkCodec("lz4")
+			Expect(err).ShouldNot(BeNil())
+		})
+
+		It("corrupted frame", func() {
+			buf := &bytes.Buffer{}
+			Expect(writeTransferFrame(buf, transferFrameData, []byte("data"))).Should(Succeed())
+			data := buf.Bytes()
+			typ, payload, err := readTransferFrame(bytes.NewReader(data), 1024)
+			Expect(err).Should(BeNil())
+			Expect(typ).Should(Equal(transferFrameData))
+			Expect(string(payload)).Should(Equal("data"))
+
+			data[len(data)-1] = 'x'
+			_, _, err = readTransferFrame(bytes.NewReader(data), 1024)
+			Expect(err).Should(MatchError(errTransferFrameCorrupted))
+		})
+	})
+
+	Context("new replica", func() {
+		var (
+			dir      string
+			listener net.Listener
+			port     int32
+			// the number of connections to drop after transferring some data
+			drops atomic.Int32
+		)
+
+		serve := func(dump string) {
+			actionSvc, err := newActionService(logr.New(nil), []proto.Action{
+				{
+					Name: newReplicaDataDump,
+					Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", dump}},
+				},
+			})
+			Expect(err).Should(BeNil())
+			streamingSvc, err := newStreamingService(logr.New(nil), actionSvc, []string{newReplicaDataDump})
+			Expect(err).Should(BeNil())
+
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).Should(BeNil())
+			port = int32(listener.Addr().(*net.TCPAddr).Port)
+			go func() {
+				for {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					go func() {
+						defer conn.Close()
+						if drops.Load() > 0 {
+							drops.Add(-1)
+							conn = &droppingConn{Conn: conn, remaining: 256 * 1024}
+						}
+						_ = streamingSvc.HandleConn(context.Background(), conn)
+					}()
+				}
+			}()
+		}
+
+		newTask := func(transfer *proto.TransferOptions) *newReplicaTask {
+			actionSvc, err := newActionService(logr.New(nil), []proto.Action{
+				{
+					Name: newReplicaDataLoad,
+					Exec: &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "cat > " + filepath.Join(dir, "loaded")}},
+				},
+			})
+			Expect(err).Should(BeNil())
+			return &newReplicaTask{
+				logger:        logr.New(nil),
+				actionService: actionSvc,
+				task: &proto.NewReplicaTask{
+					Remote:   "127.0.0.1",
+					Port:     port,
+					Transfer: transfer,
+				},
+			}
+		}
+
+		run := func(t *newReplicaTask) error {
+			ch, err := t.run(context.Background())
+			Expect(err).Should(BeNil())
+			return <-ch
+		}
+
+		expected := func() []byte {
+			buf := &bytes.Buffer{}
+			for i := 1; i <= 200000; i++ {
+				buf.WriteString(strconv.Itoa(i) + "\n")
+			}
+			return buf.Bytes()
+		}
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			drops.Store(0)
+			transferSpoolDir = filepath.Join(dir, "spool")
+			transferSpoolMaxBytes = 0
+		})
+
+		AfterEach(func() {
+			if listener != nil {
+				listener.Close()
+			}
+		})
+
+		It("raw stream", func() {
+			serve("seq 1 200000")
+			Expect(run(newTask(nil))).Should(Succeed())
+			Expect(os.ReadFile(filepath.Join(dir, "loaded"))).Should(Equal(expected()))
+		})
+
+		It("chunked transfer with compression", func() {
+			serve("seq 1 200000")
+			t := newTask(&proto.TransferOptions{Compression: transferCompressionZstd, ChunkSize: 64 * 1024})
+			Expect(run(t)).Should(Succeed())
+			Expect(os.ReadFile(filepath.Join(dir, "loaded"))).Should(Equal(expected()))
+			Expect(t.bytesTransferred.Load()).Should(Equal(int64(len(expected()))))
+			Expect(t.bytesReceived.Load()).Should(BeNumerically("<", len(expected())))
+
+			event := &proto.TaskEvent{}
+			t.status(context.Background(), event)
+			Expect(event.Message).Should(ContainSubstring("transferred"))
+			Expect(event.Output).ShouldNot(BeEmpty())
+		})
+
+		It("resume the interrupted transfer", func() {
+			serve("seq 1 200000")
+			drops.Store(1)
+			t := newTask(&proto.TransferOptions{ChunkSize: 64 * 1024, BandwidthLimit: 8 * 1024 * 1024})
+			Expect(run(t)).Should(Succeed())
+			Expect(t.retries.Load()).Should(Equal(int32(1)))
+			Expect(os.ReadFile(filepath.Join(dir, "loaded"))).Should(Equal(expected()))
+		})
+
+		It("resume the non-reproducible dump from the spool", func() {
+			serve("echo dump >> " + filepath.Join(dir, "dumps") + "; head -c 1048576 /dev/urandom")
+			drops.Store(1)
+			t := newTask(&proto.TransferOptions{ChunkSize: 64 * 1024})
+			Expect(run(t)).Should(Succeed())
+			Expect(t.retries.Load()).Should(Equal(int32(1)))
+			Expect(os.ReadFile(filepath.Join(dir, "dumps"))).Should(Equal([]byte("dump\n")))
+			loaded, err := os.ReadFile(filepath.Join(dir, "loaded"))
+			Expect(err).Should(BeNil())
+			spooled, err := os.ReadFile(filepath.Join(transferSpoolDir, t.transferID))
+			Expect(err).Should(BeNil())
+			Expect(loaded).Should(HaveLen(1048576))
+			Expect(loaded).Should(Equal(spooled))
+		})
+
+		It("fail the transfer if the dump doesn't fit in the spool", func() {
+			transferSpoolMaxBytes = 1024 * 1024
+			buf := &bytes.Buffer{}
+			action := &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "echo dump >> " + filepath.Join(dir, "dumps")}}
+			err := sendTransfer(context.Background(), buf, action, &proto.StreamingRequest{
+				Transfer:   &proto.TransferOptions{EstimatedBytes: 2 * 1024 * 1024},
+				TransferID: "too-large",
+			})
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("has no space for the dump"))
+			Expect(buf.String()).Should(ContainSubstring("has no space for the dump"))
+			Expect(filepath.Join(dir, "dumps")).ShouldNot(BeAnExistingFile())
+		})
+
+		It("kill the dump exceeding the spool limit", func() {
+			transferSpoolMaxBytes = 1024 * 1024
+			buf := &bytes.Buffer{}
+			action := &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "head -c 4194304 /dev/zero"}}
+			err := sendTransfer(context.Background(), buf, action, &proto.StreamingRequest{
+				Transfer:   &proto.TransferOptions{},
+				TransferID: "underestimated",
+			})
+			Expect(err).Should(MatchError(errTransferSpoolFull))
+			Expect(buf.String()).Should(ContainSubstring("exceeds the limit"))
+			Expect(filepath.Join(transferSpoolDir, "underestimated")).ShouldNot(BeAnExistingFile())
+		})
+
+		It("can not resume the transfer without the spool", func() {
+			buf := &bytes.Buffer{}
+			action := &proto.ExecAction{Commands: []string{"/bin/bash", "-c", "seq 1 200000"}}
+			err := sendTransfer(context.Background(), buf, action, &proto.StreamingRequest{
+				Transfer:   &proto.TransferOptions{},
+				TransferID: "unknown",
+				Offset:     1024,
+			})
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("can not be resumed"))
+			Expect(buf.String()).Should(ContainSubstring("can not be resumed"))
+		})
+	})
+})
+
+// droppingConn closes the connection after writing the specified bytes.
+type droppingConn struct {
+	net.Conn
+	remaining int
+}
+
+func (c *droppingConn) Write(p []byte) (int, error) {
+	if len(p) > c.remaining {
+		n, _ := c.Conn.Write(p[:c.remaining])
+		c.remaining = 0
+		_ = c.Conn.Close()
+		return n, io.ErrClosedPipe
+	}
+	c.remaining -= len(p)
+	return c.Conn.Write(p)
+}
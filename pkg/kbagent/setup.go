@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -50,6 +51,11 @@ const (
 	probeEnvName     = "KB_AGENT_PROBE"
 	streamingEnvName = "KB_AGENT_STREAMING"
 	taskEnvName      = "KB_AGENT_TASK"
+
+	// TransferSpoolDirEnvName and TransferSpoolMaxBytesEnvName override the spool of the data transfers,
+	// they can be set in the env of the data dump action to put the spool on the data volume.
+	TransferSpoolDirEnvName      = "KB_AGENT_TRANSFER_SPOOL_DIR"
+	TransferSpoolMaxBytesEnvName = "KB_AGENT_TRANSFER_SPOOL_MAX_BYTES"
 )
 
 func BuildEnv4Server(actions []proto.Action, probes []proto.Probe, streaming []string) ([]corev1.EnvVar, error) {
@@ -130,7 +136,7 @@ func Launch(logger logr.Logger, config server.Config) (bool, error) {
 	envVars := util.EnvL2M(os.Environ())
 
 	// initialize kb-agent
-	services, err := initialize(logger, envVars)
+	services, err := initialize(logger, config, envVars)
 	if err != nil {
 		return false, errors.Wrap(err, "init action handlers failed")
 	}
@@ -140,7 +146,7 @@ func Launch(logger logr.Logger, config server.Config) (bool, error) {
 	return false, runAsWorker(logger, services, envVars)
 }
 
-func initialize(logger logr.Logger, envVars map[string]string) ([]service.Service, error) {
+func initialize(logger logr.Logger, config server.Config, envVars map[string]string) ([]service.Service, error) {
 	da, dp, ds := getActionProbeNStreamingEnvValues(envVars)
 	if len(da) == 0 {
 		return nil, nil
@@ -155,7 +161,24 @@ func initialize(logger logr.Logger, envVars map[string]string) ([]service.Servic
 	if len(ds) > 0 {
 		streaming = strings.Split(ds, ",")
 	}
-	return service.New(logger, actions, probes, streaming)
+	audit := service.AuditLogConfig{
+		Dir:        config.AuditLogDir,
+		MaxRecords: config.AuditLogRecords,
+	}
+	spool := service.TransferSpoolConfig{
+		Dir:      config.TransferSpoolDir,
+		MaxBytes: config.TransferSpoolMaxBytes,
+	}
+	if dir, ok := envVars[TransferSpoolDirEnvName]; ok && len(dir) > 0 {
+		spool.Dir = dir
+	}
+	if maxBytes, ok := envVars[TransferSpoolMaxBytesEnvName]; ok && len(maxBytes) > 0 {
+		spool.MaxBytes, err = strconv.ParseInt(maxBytes, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s: %s", TransferSpoolMaxBytesEnvName, maxBytes)
+		}
+	}
+	return service.New(logger, actions, probes, streaming, audit, spool)
 }
 
 func getActionProbeNStreamingEnvValues(envVars map[string]string) (string, string, string) {
@@ -0,0 +1,276 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// maxMaintenanceWindowSearchYears limits how far to search for the next maintenance window,
+// a schedule like "0 0 30 2 *" never matches.
+const maxMaintenanceWindowSearchYears = 5
+
+// handleMaintenanceWindow holds the disruptive opsRequest in the Scheduled phase until the maintenance window opens.
+// it returns a non-nil result if the opsRequest can not be started now.
+func handleMaintenanceWindow(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	opsBehaviour OpsBehaviour) (*ctrl.Result, error) {
+	opsRequest := opsRes.OpsRequest
+	window := getMaintenanceWindow(opsRes, opsBehaviour)
+	if window == nil {
+		if opsRequest.Status.Phase == opsv1alpha1.OpsScheduledPhase {
+			// the maintenance window has been removed, process the opsRequest at once.
+			return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsv1alpha1.OpsPendingPhase,
+				opsv1alpha1.NewMaintenanceWindowOpenedCondition(opsRequest))
+		}
+		return nil, nil
+	}
+	isOpen, nextStart, err := checkMaintenanceWindow(window, time.Now())
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	if isOpen {
+		if opsRequest.Status.Phase == opsv1alpha1.OpsScheduledPhase {
+			return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsv1alpha1.OpsPendingPhase,
+				opsv1alpha1.NewMaintenanceWindowOpenedCondition(opsRequest))
+		}
+		return nil, nil
+	}
+	// the window is closed, release the queue of the cluster and defer the opsRequest to the next window.
+	if err = DequeueOpsRequestInClusterAnnotation(reqCtx.Ctx, cli, opsRes); err != nil {
+		return nil, err
+	}
+	if opsRequest.Status.Phase != opsv1alpha1.OpsScheduledPhase || !opsRequest.Status.ScheduledStartTimestamp.Time.Equal(nextStart) {
+		opsDeepCopy := opsRequest.DeepCopy()
+		opsRequest.Status.ScheduledStartTimestamp = metav1.Time{Time: nextStart}
+		return &ctrl.Result{}, PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsDeepCopy,
+			opsv1alpha1.OpsScheduledPhase, opsv1alpha1.NewScheduledCondition(opsRequest))
+	}
+	return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Until(nextStart), reqCtx.Log, "wait for the maintenance window"))
+}
+
+// getMaintenanceWindow gets the maintenance window which the opsRequest should respect,
+// the window of the opsRequest takes precedence over the window of the cluster.
+func getMaintenanceWindow(opsRes *OpsResource, opsBehaviour OpsBehaviour) *appsv1.MaintenanceWindow {
+	if !opsBehaviour.Disruptive || opsRes.OpsRequest.Force() {
+		return nil
+	}
+	if opsRes.OpsRequest.Spec.MaintenanceWindow != nil {
+		return opsRes.OpsRequest.Spec.MaintenanceWindow
+	}
+	if opsRes.Cluster != nil {
+		return opsRes.Cluster.Spec.MaintenanceWindow
+	}
+	return nil
+}
+
+// checkMaintenanceWindow checks whether the maintenance window is open at the given time,
+// and returns the start time of the next window if it is closed.
+func checkMaintenanceWindow(window *appsv1.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	loc := time.UTC
+	if window.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(window.TimeZone); err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid time zone %s of the maintenance window: %s", window.TimeZone, err.Error())
+		}
+	}
+	schedule, err := parseCronSchedule(window.Schedule)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid schedule %s of the maintenance window: %s", window.Schedule, err.Error())
+	}
+	now = now.In(loc)
+	duration := time.Duration(window.DurationSeconds) * time.Second
+	// the window is open if the latest activation is within the duration.
+	if lastStart := schedule.next(now.Add(-duration)); !lastStart.IsZero() && !lastStart.After(now) {
+		return true, lastStart, nil
+	}
+	nextStart := schedule.next(now)
+	if nextStart.IsZero() {
+		return false, time.Time{}, fmt.Errorf("no time matches the schedule %s of the maintenance window", window.Schedule)
+	}
+	return false, nextStart, nil
+}
+
+// cronSchedule is a parsed standard cron expression with five fields: minute, hour, day of month, month and day of week.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar indicate whether the day fields are unrestricted,
+	// if both are restricted, a day matches if either of them matches.
+	domStar, dowStar bool
+}
+
+type cronFieldBounds struct {
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	cronMinuteBounds = cronFieldBounds{0, 59, nil}
+	cronHourBounds   = cronFieldBounds{0, 23, nil}
+	cronDomBounds    = cronFieldBounds{1, 31, nil}
+	cronMonthBounds  = cronFieldBounds{1, 12, map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	cronDowBounds = cronFieldBounds{0, 7, map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// parseCronSchedule parses a standard cron expression, e.g. "30 2 * * 1-5".
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected exactly 5 fields, found %d", len(fields))
+	}
+	var (
+		s   = &cronSchedule{}
+		err error
+	)
+	if s.minute, err = parseCronField(fields[0], cronMinuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseCronField(fields[1], cronHourBounds); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseCronField(fields[2], cronDomBounds); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseCronField(fields[3], cronMonthBounds); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseCronField(fields[4], cronDowBounds); err != nil {
+		return nil, err
+	}
+	// both 0 and 7 stand for Sunday.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*" || fields[2] == "?"
+	s.dowStar = fields[4] == "*" || fields[4] == "?"
+	return s, nil
+}
+
+// parseCronField parses a comma-separated list of ranges into a bitset.
+func parseCronField(field string, bounds cronFieldBounds) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		rangeAndStep := strings.SplitN(expr, "/", 2)
+		lowAndHigh := strings.SplitN(rangeAndStep[0], "-", 2)
+		var (
+			start, end, step uint = 0, 0, 1
+			err              error
+		)
+		if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
+			if len(lowAndHigh) > 1 {
+				return 0, fmt.Errorf("invalid range: %s", expr)
+			}
+			start, end = bounds.min, bounds.max
+		} else {
+			if start, err = parseCronValue(lowAndHigh[0], bounds); err != nil {
+				return 0, err
+			}
+			end = start
+			if len(lowAndHigh) > 1 {
+				if end, err = parseCronValue(lowAndHigh[1], bounds); err != nil {
+					return 0, err
+				}
+			}
+		}
+		if len(rangeAndStep) > 1 {
+			if step, err = parseCronValue(rangeAndStep[1], cronFieldBounds{1, bounds.max, nil}); err != nil {
+				return 0, err
+			}
+			// "N/step" means from N to the max.
+			if len(lowAndHigh) == 1 && lowAndHigh[0] != "*" && lowAndHigh[0] != "?" {
+				end = bounds.max
+			}
+		}
+		if start > end {
+			return 0, fmt.Errorf("beginning of range %d is beyond the end %d: %s", start, end, expr)
+		}
+		for i := start; i <= end; i += step {
+			bits |= 1 << i
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, bounds cronFieldBounds) (uint, error) {
+	if v, ok := bounds.names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+	v, err := strconv.ParseUint(value, 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %s", value, err.Error())
+	}
+	if uint(v) < bounds.min || uint(v) > bounds.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, bounds.min, bounds.max)
+	}
+	return uint(v), nil
+}
+
+// next returns the earliest time after t that matches the schedule, in the location of t.
+// it returns the zero time if no time matches within maxMaintenanceWindowSearchYears.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	yearLimit := t.Year() + maxMaintenanceWindowSearchYears
+	for t.Year() <= yearLimit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,113 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+)
+
+var _ = Describe("MaintenanceWindow", func() {
+
+	Context("parse cron schedule", func() {
+		It("should reject the invalid expressions", func() {
+			for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "5-1 * * * *", "*-1 * * * *", "a * * * *"} {
+				_, err := parseCronSchedule(expr)
+				Expect(err).Should(HaveOccurred(), expr)
+			}
+		})
+
+		It("should parse lists, ranges, steps and names", func() {
+			s, err := parseCronSchedule("*/15 1,3 * jan-mar mon-fri")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(s.minute).Should(Equal(uint64(1<<0 | 1<<15 | 1<<30 | 1<<45)))
+			Expect(s.hour).Should(Equal(uint64(1<<1 | 1<<3)))
+			Expect(s.month).Should(Equal(uint64(1<<1 | 1<<2 | 1<<3)))
+			Expect(s.dow).Should(Equal(uint64(0b111110)))
+
+			s, err = parseCronSchedule("0 0 * * 7")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(s.dow & 1).Should(Equal(uint64(1)))
+		})
+	})
+
+	Context("check maintenance window", func() {
+		// 2025-01-01 is a Wednesday.
+		base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		It("should be open within the duration of the window", func() {
+			window := &appsv1.MaintenanceWindow{Schedule: "0 2 * * *", DurationSeconds: 3600}
+			isOpen, start, err := checkMaintenanceWindow(window, base.Add(2*time.Hour+30*time.Minute))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(isOpen).Should(BeTrue())
+			Expect(start).Should(BeTemporally("==", base.Add(2*time.Hour)))
+		})
+
+		It("should return the next window if it is closed", func() {
+			window := &appsv1.MaintenanceWindow{Schedule: "0 2 * * sat", DurationSeconds: 3600}
+			isOpen, start, err := checkMaintenanceWindow(window, base.Add(3*time.Hour))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(isOpen).Should(BeFalse())
+			Expect(start).Should(BeTemporally("==", time.Date(2025, 1, 4, 2, 0, 0, 0, time.UTC)))
+		})
+
+		It("should interpret the schedule in the time zone", func() {
+			window := &appsv1.MaintenanceWindow{Schedule: "0 2 * * *", DurationSeconds: 3600, TimeZone: "Asia/Shanghai"}
+			// 18:30 UTC is 02:30 in Asia/Shanghai.
+			isOpen, _, err := checkMaintenanceWindow(window, base.Add(18*time.Hour+30*time.Minute))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(isOpen).Should(BeTrue())
+
+			window.TimeZone = "Invalid/Zone"
+			_, _, err = checkMaintenanceWindow(window, base)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should fail if no time matches the schedule", func() {
+			window := &appsv1.MaintenanceWindow{Schedule: "0 0 30 2 *", DurationSeconds: 3600}
+			_, _, err := checkMaintenanceWindow(window, base)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("get maintenance window", func() {
+		It("should prefer the window of the opsRequest", func() {
+			clusterWindow := &appsv1.MaintenanceWindow{Schedule: "0 2 * * *", DurationSeconds: 3600}
+			opsWindow := &appsv1.MaintenanceWindow{Schedule: "0 3 * * *", DurationSeconds: 3600}
+			opsRes := &OpsResource{
+				Cluster:    &appsv1.Cluster{Spec: appsv1.ClusterSpec{MaintenanceWindow: clusterWindow}},
+				OpsRequest: &opsv1alpha1.OpsRequest{},
+			}
+			Expect(getMaintenanceWindow(opsRes, OpsBehaviour{})).Should(BeNil())
+			Expect(getMaintenanceWindow(opsRes, OpsBehaviour{Disruptive: true})).Should(Equal(clusterWindow))
+
+			opsRes.OpsRequest.Spec.MaintenanceWindow = opsWindow
+			Expect(getMaintenanceWindow(opsRes, OpsBehaviour{Disruptive: true})).Should(Equal(opsWindow))
+
+			opsRes.OpsRequest.Spec.Force = true
+			Expect(getMaintenanceWindow(opsRes, OpsBehaviour{Disruptive: true})).Should(BeNil())
+		})
+	})
+})
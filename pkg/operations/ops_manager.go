@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"errors"
 	"slices"
 	"strings"
 	"sync"
@@ -71,10 +72,15 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
 	}
 
-	if opsRequest.Status.Phase == opsv1alpha1.OpsPendingPhase {
+	if opsRequest.Status.Phase == opsv1alpha1.OpsPendingPhase || opsRequest.Status.Phase == opsv1alpha1.OpsScheduledPhase {
 		if opsRequest.Spec.Cancel {
 			return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsv1alpha1.OpsCancelledPhase)
 		}
+		if res, err := handleMaintenanceWindow(reqCtx, cli, opsRes, opsBehaviour); intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
+		} else if res != nil || err != nil {
+			return res, err
+		}
 		if err = opsMgr.doPreConditionAndTransPhaseToCreating(reqCtx, cli, opsRes, opsBehaviour); intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
 			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
 		} else if err != nil {
@@ -92,7 +98,9 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 	if err = opsBehaviour.OpsHandler.Action(reqCtx, cli, opsRes); err != nil {
 		// patch the status.phase to Failed when the error is Fatal, which means the operation is failed and there is no need to retry
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
-			return &ctrl.Result{}, patchFatalFailErrorCondition(reqCtx.Ctx, cli, opsRes, err)
+			return &ctrl.Result{}, opsMgr.rollbackOnFailure(reqCtx, cli, opsRes, opsBehaviour, err, func() error {
+				return patchFatalFailErrorCondition(reqCtx.Ctx, cli, opsRes, err)
+			})
 		}
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeNeedWaiting) {
 			return intctrlutil.ResultToP(intctrlutil.Reconciled())
@@ -177,7 +185,9 @@ func (opsMgr *OpsManager) Reconcile(reqCtx intctrlutil.RequestCtx, cli client.Cl
 	if opsRequestPhase, requeueAfter, err = opsBehaviour.OpsHandler.ReconcileAction(reqCtx, cli, opsRes); err != nil &&
 		!isOpsRequestFailedPhase(opsRequestPhase) {
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
-			return requeueAfter, patchFatalFailErrorCondition(reqCtx.Ctx, cli, opsRes, err)
+			return requeueAfter, opsMgr.rollbackOnFailure(reqCtx, cli, opsRes, opsBehaviour, err, func() error {
+				return patchFatalFailErrorCondition(reqCtx.Ctx, cli, opsRes, err)
+			})
 		}
 		// if the opsRequest phase is not failed, skipped
 		return requeueAfter, err
@@ -187,10 +197,12 @@ func (opsMgr *OpsManager) Reconcile(reqCtx intctrlutil.RequestCtx, cli client.Cl
 		return 0, opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsRequestPhase,
 			opsv1alpha1.NewCancelSucceedCondition(opsRequest.Name), opsv1alpha1.NewSucceedCondition(opsRequest))
 	case opsv1alpha1.OpsFailedPhase:
-		return 0, opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsRequestPhase,
-			opsv1alpha1.NewCancelFailedCondition(opsRequest, err), opsv1alpha1.NewFailedCondition(opsRequest, err))
+		return 0, opsMgr.rollbackOnFailure(reqCtx, cli, opsRes, opsBehaviour, err, func() error {
+			return opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsRequestPhase,
+				opsv1alpha1.NewCancelFailedCondition(opsRequest, err), opsv1alpha1.NewFailedCondition(opsRequest, err))
+		})
 	default:
-		return opsMgr.checkAndHandleOpsTimeout(reqCtx, cli, opsRes, requeueAfter)
+		return opsMgr.checkAndHandleOpsTimeout(reqCtx, cli, opsRes, opsBehaviour, requeueAfter)
 	}
 }
 
@@ -206,9 +218,53 @@ func (opsMgr *OpsManager) handleOpsCompleted(reqCtx intctrlutil.RequestCtx,
 	if opsRes.OpsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
 		return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsv1alpha1.OpsCancelledPhase, cancelledCondition)
 	}
+	if opsRes.OpsRequest.Status.Phase == opsv1alpha1.OpsRollingBackPhase {
+		if opsRequestPhase == opsv1alpha1.OpsSucceedPhase {
+			return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsv1alpha1.OpsRolledBackPhase,
+				opsv1alpha1.NewRollbackSucceedCondition(opsRes.OpsRequest))
+		}
+		return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsRequestPhase,
+			opsv1alpha1.NewRollbackFailedCondition(opsRes.OpsRequest, nil), completedCondition)
+	}
 	return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsRequestPhase, completedCondition)
 }
 
+// needRollback checks if the failed opsRequest should be rolled back.
+func needRollback(opsRequest *opsv1alpha1.OpsRequest, opsBehaviour OpsBehaviour) bool {
+	return opsRequest.Spec.RollbackOnFailure && opsBehaviour.RollbackFunc != nil &&
+		(opsRequest.Status.Phase == opsv1alpha1.OpsCreatingPhase || opsRequest.Status.Phase == opsv1alpha1.OpsRunningPhase)
+}
+
+// rollbackOnFailure rolls back the failed opsRequest if it needs, otherwise completes it by the failedFunc.
+// All the failures of the opsRequest, i.e. the fatal errors of the action, the failed phase and the timeout,
+// go through it to honor `rollbackOnFailure`.
+func (opsMgr *OpsManager) rollbackOnFailure(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	opsBehaviour OpsBehaviour,
+	failedErr error,
+	failedFunc func() error) error {
+	if needRollback(opsRes.OpsRequest, opsBehaviour) {
+		return opsMgr.startRollback(reqCtx, cli, opsRes, opsBehaviour, failedErr)
+	}
+	return failedFunc()
+}
+
+// startRollback restores the last configuration of the failed opsRequest and transitions it to the RollingBack phase.
+func (opsMgr *OpsManager) startRollback(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	opsBehaviour OpsBehaviour,
+	failedErr error) error {
+	opsDeepCopy := opsRes.OpsRequest.DeepCopy()
+	if err := opsBehaviour.RollbackFunc(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
+	opsRes.OpsRequest.Status.RollbackTimestamp = metav1.Time{Time: time.Now()}
+	return PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsDeepCopy, opsv1alpha1.OpsRollingBackPhase,
+		opsv1alpha1.NewRollingBackCondition(opsRes.OpsRequest, failedErr))
+}
+
 // validateDependOnOps validates if the dependent ops have been successful
 func (opsMgr *OpsManager) validateDependOnSuccessfulOps(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
@@ -242,7 +298,8 @@ func (opsMgr *OpsManager) validateDependOnSuccessfulOps(reqCtx intctrlutil.Reque
 				return false, err
 			}
 		}
-		if slices.Contains([]opsv1alpha1.OpsPhase{opsv1alpha1.OpsFailedPhase, opsv1alpha1.OpsCancelledPhase, opsv1alpha1.OpsAbortedPhase}, ops.Status.Phase) {
+		if slices.Contains([]opsv1alpha1.OpsPhase{opsv1alpha1.OpsFailedPhase, opsv1alpha1.OpsCancelledPhase,
+			opsv1alpha1.OpsRolledBackPhase, opsv1alpha1.OpsAbortedPhase}, ops.Status.Phase) {
 			return false, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsv1alpha1.OpsCancelledPhase)
 		}
 		if ops.Status.Phase != opsv1alpha1.OpsSucceedPhase {
@@ -256,15 +313,24 @@ func (opsMgr *OpsManager) validateDependOnSuccessfulOps(reqCtx intctrlutil.Reque
 func (opsMgr *OpsManager) checkAndHandleOpsTimeout(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
+	opsBehaviour OpsBehaviour,
 	requeueAfter time.Duration) (time.Duration, error) {
 	timeoutSeconds := opsRes.OpsRequest.Spec.TimeoutSeconds
-	if timeoutSeconds == nil || *timeoutSeconds == 0 {
+	// the rollback is not bounded by the timeout which it may be started for
+	if timeoutSeconds == nil || *timeoutSeconds == 0 || opsRes.OpsRequest.Status.Phase == opsv1alpha1.OpsRollingBackPhase {
 		return requeueAfter, nil
 	}
 	timeoutPoint := opsRes.OpsRequest.Status.StartTimestamp.Add(time.Duration(*timeoutSeconds) * time.Second)
 	if !time.Now().Before(timeoutPoint) {
-		return 0, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsv1alpha1.OpsAbortedPhase,
-			opsv1alpha1.NewAbortedCondition("Aborted due to exceeding the specified timeout period (timeoutSeconds)"))
+		if abortFunc := opsBehaviour.AbortFunc; abortFunc != nil {
+			if err := abortFunc(reqCtx, cli, opsRes); err != nil {
+				return 0, err
+			}
+		}
+		const timeoutMessage = "Aborted due to exceeding the specified timeout period (timeoutSeconds)"
+		return 0, opsMgr.rollbackOnFailure(reqCtx, cli, opsRes, opsBehaviour, errors.New(timeoutMessage), func() error {
+			return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsv1alpha1.OpsAbortedPhase, opsv1alpha1.NewAbortedCondition(timeoutMessage))
+		})
 	}
 	if requeueAfter != 0 {
 		return requeueAfter, nil
@@ -272,6 +338,23 @@ func (opsMgr *OpsManager) checkAndHandleOpsTimeout(reqCtx intctrlutil.RequestCtx
 	return time.Until(timeoutPoint), nil
 }
 
+// DryRun performs the Action of the OpsRequest against the opsRes.Cluster without progressing the OpsRequest,
+// it is used to preview the changes the OpsRequest will make. The cli should not persist any writes.
+func (opsMgr *OpsManager) DryRun(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsBehaviour, ok := opsMgr.OpsMap[opsRes.OpsRequest.Spec.Type]
+	if !ok || opsBehaviour.OpsHandler == nil {
+		return intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal, "spec.type %s is not supported by operator", opsRes.OpsRequest.Spec.Type)
+	}
+	opsRes.ToClusterPhase = opsBehaviour.ToClusterPhase
+	if err := opsRes.OpsRequest.ValidateOps(reqCtx.Ctx, cli, opsRes.Cluster); err != nil {
+		return intctrlutil.NewFatalError(err.Error())
+	}
+	if err := opsBehaviour.OpsHandler.SaveLastConfiguration(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
+	return opsBehaviour.OpsHandler.Action(reqCtx, cli, opsRes)
+}
+
 func GetOpsManager() *OpsManager {
 	opsManagerOnce.Do(func() {
 		opsManager = &OpsManager{OpsMap: make(map[opsv1alpha1.OpsType]OpsBehaviour)}
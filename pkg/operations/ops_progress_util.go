@@ -196,13 +196,13 @@ func handleComponentStatusProgress(
 	if err != nil {
 		return expectReplicas, completedCount, err
 	}
-	if opsRes.OpsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
+	if isRevertingOpsRequest(opsRes.OpsRequest) {
 		completedCount = handleCancelProgressForPodsRollingUpdate(opsRes, pods, pgRes, compStatus, minReadySeconds, podApplyOps)
 	} else {
 		completedCount = handleProgressForPodsRollingUpdate(opsRes, pods, pgRes, compStatus, minReadySeconds, podApplyOps)
 	}
-	if opsRes.OpsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
-		// only rollback the actual re-created pod during cancelling.
+	if isRevertingOpsRequest(opsRes.OpsRequest) {
+		// only rollback the actual re-created pod during cancelling or rolling back.
 		expectReplicas = int32(len(compStatus.ProgressDetails))
 	}
 	return expectReplicas, completedCount, err
@@ -263,7 +263,7 @@ func handleCancelProgressForPodsRollingUpdate(
 			handleSucceedProgressDetail(opsRes, pgRes, compStatus, progressDetail)
 			continue
 		}
-		if notRecreatedDuringOperation(opsRes.OpsRequest.Status.CancelTimestamp, pod) &&
+		if notRecreatedDuringOperation(getRevertTimestamp(opsRes.OpsRequest), pod) &&
 			!podApplyOps(opsRes.OpsRequest, pod, pgRes) {
 			continue
 		}
@@ -166,6 +166,19 @@ func patchOpsRequestToCreating(reqCtx intctrlutil.RequestCtx,
 	return PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsDeepCoy, opsv1alpha1.OpsCreatingPhase, validatePassCondition, condition)
 }
 
+// isRevertingOpsRequest checks if the OpsRequest is reverting the changes, which happens when it is cancelled or rolled back.
+func isRevertingOpsRequest(opsRequest *opsv1alpha1.OpsRequest) bool {
+	return opsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase || opsRequest.Status.Phase == opsv1alpha1.OpsRollingBackPhase
+}
+
+// getRevertTimestamp gets the time when the OpsRequest started to revert the changes.
+func getRevertTimestamp(opsRequest *opsv1alpha1.OpsRequest) metav1.Time {
+	if opsRequest.Status.Phase == opsv1alpha1.OpsRollingBackPhase {
+		return opsRequest.Status.RollbackTimestamp
+	}
+	return opsRequest.Status.CancelTimestamp
+}
+
 // isOpsRequestFailedPhase checks the OpsRequest phase is Failed
 func isOpsRequestFailedPhase(opsRequestPhase opsv1alpha1.OpsPhase) bool {
 	return opsRequestPhase == opsv1alpha1.OpsFailedPhase
@@ -262,7 +275,7 @@ func abortEarlierOpsRequestWithSameKind(reqCtx intctrlutil.RequestCtx,
 			return err
 		}
 		if slices.Contains([]opsv1alpha1.OpsPhase{opsv1alpha1.OpsSucceedPhase, opsv1alpha1.OpsFailedPhase,
-			opsv1alpha1.OpsCancelledPhase}, earlierOps.Status.Phase) {
+			opsv1alpha1.OpsCancelledPhase, opsv1alpha1.OpsRolledBackPhase}, earlierOps.Status.Phase) {
 			continue
 		}
 		needAborted, err := matchAbortCondition(earlierOps)
@@ -0,0 +1,420 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	pipelineStepGroup     = "step"
+	pipelineRollbackGroup = "rollback"
+)
+
+type pipelineOpsHandler struct{}
+
+var _ OpsHandler = pipelineOpsHandler{}
+
+func init() {
+	// the steps of the pipeline are carried out by separate OpsRequests,
+	// which will be queued by themselves, so the pipeline doesn't need to be queued.
+	pipelineHandler := pipelineOpsHandler{}
+	pipelineBehaviour := OpsBehaviour{
+		OpsHandler: pipelineHandler,
+		CancelFunc: pipelineHandler.cancelRunningSteps,
+		AbortFunc:  pipelineHandler.cancelRunningSteps,
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(opsv1alpha1.PipelineType, pipelineBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the pipeline request.
+func (p pipelineOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return opsv1alpha1.NewPipelineCondition(opsRes.OpsRequest), nil
+}
+
+// Action the steps will be started in ReconcileAction.
+func (p pipelineOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// ReconcileAction starts the steps whose dependencies have been completed,
+// and loops till all the steps are completed or the pipeline is stopped by the failure of a step or the cancellation.
+func (p pipelineOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (opsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		opsRequest     = opsRes.OpsRequest
+		oldOpsRequest  = opsRequest.DeepCopy()
+		opsPhase       = opsRequest.Status.Phase
+		steps          = opsRequest.Spec.Pipeline.Steps
+		dependencies   = opsRequest.Spec.Pipeline.GetStepDependencies()
+		stepMap        = map[string]opsv1alpha1.PipelineStep{}
+		failedSteps    []string
+		cancelling     = opsPhase == opsv1alpha1.OpsCancellingPhase
+		stopped        = cancelling
+		needRollback   bool
+		completedCount int
+		runningCount   int
+	)
+	stepOpsMap, err := p.listStepOpsRequests(reqCtx, cli, opsRequest)
+	if err != nil {
+		return opsPhase, 0, err
+	}
+	// 1. check the failed steps to determine whether to start new steps.
+	for _, step := range steps {
+		stepMap[step.Name] = step
+		stepOps, ok := stepOpsMap[p.stepOpsName(opsRequest, step.Name)]
+		if !ok || !stepOps.IsComplete() || stepOps.Status.Phase == opsv1alpha1.OpsSucceedPhase {
+			continue
+		}
+		failedSteps = append(failedSteps, step.Name)
+		switch step.FailurePolicy {
+		case opsv1alpha1.ContinueOnStepFailure:
+		case opsv1alpha1.RollbackOnStepFailure:
+			// the cancelled pipeline is not rolled back.
+			stopped, needRollback = true, !cancelling
+		default:
+			stopped = true
+		}
+	}
+	isStepResolved := func(stepName string) bool {
+		stepOps, ok := stepOpsMap[p.stepOpsName(opsRequest, stepName)]
+		if !ok || !stepOps.IsComplete() {
+			return false
+		}
+		return stepOps.Status.Phase == opsv1alpha1.OpsSucceedPhase ||
+			stepMap[stepName].FailurePolicy == opsv1alpha1.ContinueOnStepFailure
+	}
+
+	// 2. start the steps whose dependencies have been resolved and sync their progress.
+	for _, step := range steps {
+		stepOpsName := p.stepOpsName(opsRequest, step.Name)
+		progressDetail := opsv1alpha1.ProgressStatusDetail{
+			Group:      pipelineStepGroup,
+			ObjectKey:  getProgressObjectKey(constant.OpsRequestKind, stepOpsName),
+			ActionName: step.Name,
+		}
+		stepOps, ok := stepOpsMap[stepOpsName]
+		if !ok {
+			var unresolved []string
+			for _, dep := range dependencies[step.Name] {
+				if !isStepResolved(dep) {
+					unresolved = append(unresolved, dep)
+				}
+			}
+			switch {
+			case cancelling:
+				progressDetail.SetStatusAndMessage(opsv1alpha1.PendingProgressStatus, "skipped due to the cancellation of the pipeline")
+			case stopped:
+				progressDetail.SetStatusAndMessage(opsv1alpha1.PendingProgressStatus, "skipped due to the failure of the pipeline")
+			case len(unresolved) > 0:
+				progressDetail.SetStatusAndMessage(opsv1alpha1.PendingProgressStatus,
+					fmt.Sprintf("wait for the steps to complete: %s", strings.Join(unresolved, ",")))
+			default:
+				if stepOps, err = p.createStepOpsRequest(reqCtx, cli, opsRes, step); err != nil {
+					return opsPhase, 0, err
+				}
+			}
+		}
+		if stepOps != nil {
+			p.buildStepProgressDetail(&progressDetail, stepOps, step.Name)
+			if stepOps.IsComplete() {
+				completedCount += 1
+			} else {
+				runningCount += 1
+			}
+		}
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &opsRequest.Status.PipelineProgressDetails, progressDetail)
+	}
+
+	// 3. roll back the succeeded steps after all the running steps have completed.
+	rollbackCompleted := true
+	if needRollback && runningCount == 0 {
+		if rollbackCompleted, err = p.rollbackSucceededSteps(reqCtx, cli, opsRes, stepMap, stepOpsMap); err != nil {
+			return opsPhase, 0, err
+		}
+	}
+	if err = syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, len(steps)); err != nil {
+		return opsPhase, 0, err
+	}
+	if cancelling && runningCount > 0 {
+		// cancel the steps which are started before the pipeline enters the Cancelling phase.
+		if err = p.cancelRunningSteps(reqCtx, cli, opsRes); err != nil {
+			return opsPhase, 0, err
+		}
+	}
+	if runningCount > 0 || !rollbackCompleted {
+		return opsPhase, 0, nil
+	}
+	if cancelling {
+		return opsv1alpha1.OpsSucceedPhase, 0, nil
+	}
+	if stopped {
+		return opsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("the pipeline is stopped due to the failure of the steps: %s", strings.Join(failedSteps, ","))
+	}
+	if completedCount < len(steps) {
+		return opsPhase, 0, nil
+	}
+	return opsv1alpha1.OpsSucceedPhase, 0, nil
+}
+
+// SaveLastConfiguration the last configuration is recorded by the OpsRequests of the steps.
+func (p pipelineOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// cancelRunningSteps cancels the OpsRequests of the steps which are not completed,
+// it is called when the pipeline is cancelled or aborted.
+func (p pipelineOpsHandler) cancelRunningSteps(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	stepOpsMap, err := p.listStepOpsRequests(reqCtx, cli, opsRes.OpsRequest)
+	if err != nil {
+		return err
+	}
+	for _, stepOps := range stepOpsMap {
+		if stepOps.IsComplete() || stepOps.Spec.Cancel {
+			continue
+		}
+		patch := client.MergeFrom(stepOps.DeepCopy())
+		stepOps.Spec.Cancel = true
+		if err = cli.Patch(reqCtx.Ctx, stepOps, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p pipelineOpsHandler) stepOpsName(opsRequest *opsv1alpha1.OpsRequest, stepName string) string {
+	return fmt.Sprintf("%s-%s", opsRequest.Name, stepName)
+}
+
+func (p pipelineOpsHandler) rollbackOpsName(opsRequest *opsv1alpha1.OpsRequest, stepName string) string {
+	return fmt.Sprintf("%s-%s-rollback", opsRequest.Name, stepName)
+}
+
+// listStepOpsRequests lists the OpsRequests created by the pipeline.
+func (p pipelineOpsHandler) listStepOpsRequests(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRequest *opsv1alpha1.OpsRequest) (map[string]*opsv1alpha1.OpsRequest, error) {
+	opsList := &opsv1alpha1.OpsRequestList{}
+	if err := cli.List(reqCtx.Ctx, opsList, client.InNamespace(opsRequest.Namespace),
+		client.MatchingLabels{constant.OpsRequestNameLabelKey: opsRequest.Name}); err != nil {
+		return nil, err
+	}
+	stepOpsMap := map[string]*opsv1alpha1.OpsRequest{}
+	for i := range opsList.Items {
+		stepOpsMap[opsList.Items[i].Name] = &opsList.Items[i]
+	}
+	return stepOpsMap, nil
+}
+
+// createStepOpsRequest creates the OpsRequest to carry out the step.
+func (p pipelineOpsHandler) createStepOpsRequest(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	step opsv1alpha1.PipelineStep) (*opsv1alpha1.OpsRequest, error) {
+	stepOps := p.buildOpsRequest(opsRes.OpsRequest, p.stepOpsName(opsRes.OpsRequest, step.Name), step.Name, step.Type, opsv1alpha1.SpecificOpsRequest{
+		Upgrade:               step.Upgrade,
+		HorizontalScalingList: step.HorizontalScalingList,
+		VolumeExpansionList:   step.VolumeExpansionList,
+		StartList:             step.StartList,
+		StopList:              step.StopList,
+		RestartList:           step.RestartList,
+		SwitchoverList:        step.SwitchoverList,
+		VerticalScalingList:   step.VerticalScalingList,
+		Reconfigures:          step.Reconfigures,
+		Backup:                step.Backup,
+	})
+	stepOps.Spec.TimeoutSeconds = step.TimeoutSeconds
+	return p.createOpsRequest(reqCtx, cli, opsRes, stepOps)
+}
+
+func (p pipelineOpsHandler) buildOpsRequest(pipelineOps *opsv1alpha1.OpsRequest,
+	name, stepName string,
+	opsType opsv1alpha1.OpsType,
+	specificOps opsv1alpha1.SpecificOpsRequest) *opsv1alpha1.OpsRequest {
+	return &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: pipelineOps.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:    pipelineOps.Spec.GetClusterName(),
+				constant.OpsRequestNameLabelKey: pipelineOps.Name,
+				constant.OpsRequestStepLabelKey: stepName,
+			},
+		},
+		Spec: opsv1alpha1.OpsRequestSpec{
+			ClusterName:        pipelineOps.Spec.GetClusterName(),
+			Type:               opsType,
+			Force:              pipelineOps.Spec.Force,
+			EnqueueOnForce:     pipelineOps.Spec.EnqueueOnForce,
+			MaintenanceWindow:  pipelineOps.Spec.MaintenanceWindow,
+			SpecificOpsRequest: specificOps,
+		},
+	}
+}
+
+func (p pipelineOpsHandler) createOpsRequest(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	ops *opsv1alpha1.OpsRequest) (*opsv1alpha1.OpsRequest, error) {
+	if err := controllerutil.SetControllerReference(opsRes.OpsRequest, ops, cli.Scheme()); err != nil {
+		return nil, err
+	}
+	if err := cli.Create(reqCtx.Ctx, ops); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		if err = cli.Get(reqCtx.Ctx, client.ObjectKeyFromObject(ops), ops); err != nil {
+			return nil, err
+		}
+	}
+	return ops, nil
+}
+
+// buildStepProgressDetail builds the progress detail according to the phase of the OpsRequest.
+func (p pipelineOpsHandler) buildStepProgressDetail(progressDetail *opsv1alpha1.ProgressStatusDetail,
+	ops *opsv1alpha1.OpsRequest,
+	stepName string) {
+	switch {
+	case ops.Status.Phase == opsv1alpha1.OpsSucceedPhase:
+		progressDetail.SetStatusAndMessage(opsv1alpha1.SucceedProgressStatus,
+			fmt.Sprintf(`step "%s" succeeded by OpsRequest "%s"`, stepName, ops.Name))
+	case ops.IsComplete():
+		progressDetail.SetStatusAndMessage(opsv1alpha1.FailedProgressStatus,
+			fmt.Sprintf(`step "%s" is %s by OpsRequest "%s"`, stepName, strings.ToLower(string(ops.Status.Phase)), ops.Name))
+	default:
+		progressDetail.SetStatusAndMessage(opsv1alpha1.ProcessingProgressStatus,
+			fmt.Sprintf(`step "%s" is processing by OpsRequest "%s", phase: %s`, stepName, ops.Name, ops.Status.Phase))
+	}
+}
+
+// rollbackSucceededSteps rolls back the succeeded steps one by one in the reverse order of their completion.
+// it returns true if all the rollbacks have completed.
+func (p pipelineOpsHandler) rollbackSucceededSteps(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	stepMap map[string]opsv1alpha1.PipelineStep,
+	stepOpsMap map[string]*opsv1alpha1.OpsRequest) (bool, error) {
+	opsRequest := opsRes.OpsRequest
+	var succeededSteps []*opsv1alpha1.OpsRequest
+	for _, step := range opsRequest.Spec.Pipeline.Steps {
+		stepOps, ok := stepOpsMap[p.stepOpsName(opsRequest, step.Name)]
+		if ok && stepOps.Status.Phase == opsv1alpha1.OpsSucceedPhase {
+			succeededSteps = append(succeededSteps, stepOps)
+		}
+	}
+	slices.SortStableFunc(succeededSteps, func(a, b *opsv1alpha1.OpsRequest) int {
+		return b.Status.CompletionTimestamp.Time.Compare(a.Status.CompletionTimestamp.Time)
+	})
+	for _, stepOps := range succeededSteps {
+		stepName := stepOps.Labels[constant.OpsRequestStepLabelKey]
+		rollbackOpsName := p.rollbackOpsName(opsRequest, stepName)
+		progressDetail := opsv1alpha1.ProgressStatusDetail{
+			Group:      pipelineRollbackGroup,
+			ObjectKey:  getProgressObjectKey(constant.OpsRequestKind, rollbackOpsName),
+			ActionName: stepName,
+		}
+		rollbackOps, ok := stepOpsMap[rollbackOpsName]
+		if !ok {
+			opsType, specificOps, canRollback := buildRollbackSpecificOps(stepOps)
+			if !canRollback {
+				progressDetail.SetStatusAndMessage(opsv1alpha1.SucceedProgressStatus,
+					fmt.Sprintf(`step "%s" of type "%s" can not be rolled back, skipped`, stepName, stepMap[stepName].Type))
+				setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &opsRequest.Status.PipelineProgressDetails, progressDetail)
+				continue
+			}
+			var err error
+			rollbackOps = p.buildOpsRequest(opsRequest, rollbackOpsName, stepName, opsType, specificOps)
+			if rollbackOps, err = p.createOpsRequest(reqCtx, cli, opsRes, rollbackOps); err != nil {
+				return false, err
+			}
+		}
+		p.buildStepProgressDetail(&progressDetail, rollbackOps, stepName+"-rollback")
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &opsRequest.Status.PipelineProgressDetails, progressDetail)
+		if !rollbackOps.IsComplete() {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// buildRollbackSpecificOps builds the operation to restore the configuration recorded by the completed OpsRequest.
+// only "VerticalScaling" and "Upgrade" can be rolled back.
+func buildRollbackSpecificOps(ops *opsv1alpha1.OpsRequest) (opsv1alpha1.OpsType, opsv1alpha1.SpecificOpsRequest, bool) {
+	lastCompConfigs := ops.Status.LastConfiguration.Components
+	switch ops.Spec.Type {
+	case opsv1alpha1.VerticalScalingType:
+		var verticalScalingList []opsv1alpha1.VerticalScaling
+		for _, v := range ops.Spec.VerticalScalingList {
+			lastConfig, ok := lastCompConfigs[v.ComponentName]
+			if !ok {
+				continue
+			}
+			verticalScaling := opsv1alpha1.VerticalScaling{
+				ComponentOps:         v.ComponentOps,
+				ResourceRequirements: lastConfig.ResourceRequirements,
+			}
+			for _, ins := range lastConfig.Instances {
+				if ins.Resources == nil {
+					continue
+				}
+				verticalScaling.Instances = append(verticalScaling.Instances, opsv1alpha1.InstanceResourceTemplate{
+					Name:                 ins.Name,
+					ResourceRequirements: *ins.Resources,
+				})
+			}
+			verticalScalingList = append(verticalScalingList, verticalScaling)
+		}
+		return ops.Spec.Type, opsv1alpha1.SpecificOpsRequest{VerticalScalingList: verticalScalingList}, len(verticalScalingList) > 0
+	case opsv1alpha1.UpgradeType:
+		if ops.Spec.Upgrade == nil {
+			return ops.Spec.Type, opsv1alpha1.SpecificOpsRequest{}, false
+		}
+		var upgradeComps []opsv1alpha1.UpgradeComponent
+		for _, v := range ops.Spec.Upgrade.Components {
+			lastConfig, ok := lastCompConfigs[v.ComponentName]
+			if !ok {
+				continue
+			}
+			upgradeComp := opsv1alpha1.UpgradeComponent{ComponentOps: v.ComponentOps}
+			if lastConfig.ComponentDefinitionName != "" {
+				upgradeComp.ComponentDefinitionName = &lastConfig.ComponentDefinitionName
+			}
+			if lastConfig.ServiceVersion != "" {
+				upgradeComp.ServiceVersion = &lastConfig.ServiceVersion
+			}
+			upgradeComps = append(upgradeComps, upgradeComp)
+		}
+		return ops.Spec.Type, opsv1alpha1.SpecificOpsRequest{Upgrade: &opsv1alpha1.Upgrade{Components: upgradeComps}}, len(upgradeComps) > 0
+	}
+	return ops.Spec.Type, opsv1alpha1.SpecificOpsRequest{}, false
+}
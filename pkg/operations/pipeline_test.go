@@ -0,0 +1,190 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testops "github.com/apecloud/kubeblocks/pkg/testutil/operations"
+)
+
+var _ = Describe("Pipeline", func() {
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	Context("cancel the pipeline", func() {
+		It("should cancel the running steps and skip the pending steps", func() {
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			handler := pipelineOpsHandler{}
+
+			By("create the pipeline with a running step")
+			pipelineOps := testops.NewOpsRequestObj("pipeline-ops", testCtx.DefaultNamespace,
+				"test-cluster", opsv1alpha1.PipelineType)
+			pipelineOps.Spec.Pipeline = &opsv1alpha1.Pipeline{
+				Steps: []opsv1alpha1.PipelineStep{
+					{
+						Name: "restart",
+						Type: opsv1alpha1.RestartType,
+						PipelineStepOps: opsv1alpha1.PipelineStepOps{
+							RestartList: []opsv1alpha1.ComponentOps{{ComponentName: "mysql"}},
+						},
+					},
+					{
+						Name:      "stop",
+						Type:      opsv1alpha1.StopType,
+						DependsOn: []string{"restart"},
+						PipelineStepOps: opsv1alpha1.PipelineStepOps{
+							StopList: []opsv1alpha1.ComponentOps{{ComponentName: "mysql"}},
+						},
+					},
+				},
+			}
+			pipelineOps = testops.CreateOpsRequest(ctx, testCtx, pipelineOps)
+			stepOps := testops.NewOpsRequestObj(handler.stepOpsName(pipelineOps, "restart"), testCtx.DefaultNamespace,
+				"test-cluster", opsv1alpha1.RestartType)
+			stepOps.Labels[constant.OpsRequestNameLabelKey] = pipelineOps.Name
+			stepOps.Labels[constant.OpsRequestStepLabelKey] = "restart"
+			stepOps = testops.CreateOpsRequest(ctx, testCtx, stepOps)
+			Expect(testapps.ChangeObjStatus(&testCtx, stepOps, func() {
+				stepOps.Status.Phase = opsv1alpha1.OpsRunningPhase
+			})).Should(Succeed())
+
+			By("cancel the pipeline")
+			opsRes := &OpsResource{OpsRequest: pipelineOps, Recorder: k8sManager.GetEventRecorderFor("opsrequest-controller")}
+			Expect(GetOpsManager().OpsMap[opsv1alpha1.PipelineType].CancelFunc(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(stepOps), func(g Gomega, ops *opsv1alpha1.OpsRequest) {
+				g.Expect(ops.Spec.Cancel).Should(BeTrue())
+			})).Should(Succeed())
+			Expect(testapps.ChangeObjStatus(&testCtx, pipelineOps, func() {
+				pipelineOps.Status.Phase = opsv1alpha1.OpsCancellingPhase
+			})).Should(Succeed())
+
+			By("wait for the running step to be cancelled")
+			phase, _, err := handler.ReconcileAction(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(phase).Should(Equal(opsv1alpha1.OpsCancellingPhase))
+
+			By("the pipeline is cancelled after the running step is cancelled, and the pending step is skipped")
+			Expect(testapps.ChangeObjStatus(&testCtx, stepOps, func() {
+				stepOps.Status.Phase = opsv1alpha1.OpsCancelledPhase
+				stepOps.Status.CompletionTimestamp = metav1.Now()
+			})).Should(Succeed())
+			Eventually(func(g Gomega) {
+				phase, _, err = handler.ReconcileAction(reqCtx, k8sClient, opsRes)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(phase).Should(Equal(opsv1alpha1.OpsSucceedPhase))
+			}).Should(Succeed())
+			stepOpsMap, err := handler.listStepOpsRequests(reqCtx, k8sClient, pipelineOps)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(stepOpsMap).Should(HaveLen(1))
+		})
+	})
+
+	Context("build rollback operations", func() {
+		It("should restore the resources of VerticalScaling", func() {
+			lastResources := corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}
+			ops := &opsv1alpha1.OpsRequest{
+				Spec: opsv1alpha1.OpsRequestSpec{
+					Type: opsv1alpha1.VerticalScalingType,
+					SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+						VerticalScalingList: []opsv1alpha1.VerticalScaling{
+							{ComponentOps: opsv1alpha1.ComponentOps{ComponentName: "mysql"}},
+						},
+					},
+				},
+				Status: opsv1alpha1.OpsRequestStatus{
+					LastConfiguration: opsv1alpha1.LastConfiguration{
+						Components: map[string]opsv1alpha1.LastComponentConfiguration{
+							"mysql": {
+								ResourceRequirements: lastResources,
+								Instances:            []appsv1.InstanceTemplate{{Name: "foo", Resources: &lastResources}, {Name: "bar"}},
+							},
+						},
+					},
+				},
+			}
+			opsType, specificOps, ok := buildRollbackSpecificOps(ops)
+			Expect(ok).Should(BeTrue())
+			Expect(opsType).Should(Equal(opsv1alpha1.VerticalScalingType))
+			Expect(specificOps.VerticalScalingList).Should(HaveLen(1))
+			Expect(specificOps.VerticalScalingList[0].ResourceRequirements).Should(Equal(lastResources))
+			Expect(specificOps.VerticalScalingList[0].Instances).Should(HaveLen(1))
+		})
+
+		It("should restore the service version of Upgrade", func() {
+			ops := &opsv1alpha1.OpsRequest{
+				Spec: opsv1alpha1.OpsRequestSpec{
+					Type: opsv1alpha1.UpgradeType,
+					SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+						Upgrade: &opsv1alpha1.Upgrade{
+							Components: []opsv1alpha1.UpgradeComponent{
+								{ComponentOps: opsv1alpha1.ComponentOps{ComponentName: "mysql"}, ServiceVersion: pointer.String("8.0.33")},
+							},
+						},
+					},
+				},
+				Status: opsv1alpha1.OpsRequestStatus{
+					LastConfiguration: opsv1alpha1.LastConfiguration{
+						Components: map[string]opsv1alpha1.LastComponentConfiguration{
+							"mysql": {ServiceVersion: "8.0.30"},
+						},
+					},
+				},
+			}
+			_, specificOps, ok := buildRollbackSpecificOps(ops)
+			Expect(ok).Should(BeTrue())
+			Expect(*specificOps.Upgrade.Components[0].ServiceVersion).Should(Equal("8.0.30"))
+			Expect(specificOps.Upgrade.Components[0].ComponentDefinitionName).Should(BeNil())
+		})
+
+		It("should not roll back the other operations", func() {
+			_, _, ok := buildRollbackSpecificOps(&opsv1alpha1.OpsRequest{Spec: opsv1alpha1.OpsRequestSpec{Type: opsv1alpha1.RestartType}})
+			Expect(ok).Should(BeFalse())
+		})
+	})
+})
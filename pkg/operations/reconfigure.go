@@ -20,9 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -31,6 +34,7 @@ import (
 	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -105,6 +109,9 @@ func (r *reconfigureAction) Action(reqCtx intctrlutil.RequestCtx, cli client.Cli
 	var checkObj = parametersv1alpha1.Parameter{}
 	if err = cli.Get(reqCtx.Ctx, client.ObjectKeyFromObject(parameter), &checkObj); err != nil {
 		if client.IgnoreNotFound(err) == nil {
+			if err = validateReconfigureConstraints(reqCtx, cli, resource); err != nil {
+				return intctrlutil.NewFatalError(err.Error())
+			}
 			return cli.Create(reqCtx.Ctx, parameter)
 		}
 		return err
@@ -112,6 +119,122 @@ func (r *reconfigureAction) Action(reqCtx intctrlutil.RequestCtx, cli client.Cli
 	return nil
 }
 
+// validateReconfigureConstraints pre-checks the cross-parameter constraints defined in the ParametersDefinitions,
+// so that the request violating the constraints fails before the parameters are submitted.
+func validateReconfigureConstraints(reqCtx intctrlutil.RequestCtx, cli client.Client, resource *OpsResource) error {
+	for _, reconfigure := range resource.OpsRequest.Spec.Reconfigures {
+		if len(reconfigure.Parameters) == 0 {
+			continue
+		}
+		if err := validateComponentConstraints(reqCtx.Ctx, cli, resource.Cluster, reconfigure.ComponentName,
+			intctrlutil.TransformComponentParameters(reconfigure.Parameters)); err != nil {
+			return fmt.Errorf("component[%s]: %v", reconfigure.ComponentName, err)
+		}
+	}
+	return nil
+}
+
+func validateComponentConstraints(ctx context.Context, cli client.Client, cluster *appsv1.Cluster, compName string, parameters parametersv1alpha1.ComponentParameters) error {
+	resources, err := resolveComponentConfigResources(ctx, cli, cluster, compName)
+	if err != nil || resources == nil || !hasParameterConstraints(resources.paramsDefs) {
+		return err
+	}
+
+	classParameters, err := configctrl.ClassifyComponentParameters(parameters,
+		resources.paramsDefs,
+		resources.cmpd.Spec.Configs,
+		resources.configMaps,
+		resources.configRender)
+	if err != nil {
+		return err
+	}
+	for tpl, params := range classParameters {
+		configMap, ok := resources.configMaps[tpl]
+		if !ok {
+			continue
+		}
+		if err = configctrl.ValidateParameterConstraints(configMap.Data,
+			configctrl.DerefMapValues(params),
+			resources.paramsDefs,
+			intctrlutil.GetComponentConfigDescriptions(&resources.configRender.Spec, tpl),
+			resources.comp.Spec.Replicas,
+			resources.comp.Spec.Resources); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// componentConfigResources holds the objects related to the configuration of a component.
+type componentConfigResources struct {
+	comp         *appsv1.Component
+	cmpd         *appsv1.ComponentDefinition
+	configRender *parametersv1alpha1.ParamConfigRenderer
+	paramsDefs   []*parametersv1alpha1.ParametersDefinition
+	configMaps   map[string]*corev1.ConfigMap
+}
+
+// resolveComponentConfigResources resolves the configuration related objects of the component,
+// for a sharding, the first shard is returned as all the shards share the same definition.
+// It returns nil if the component does not exist or does not support reconfigure.
+func resolveComponentConfigResources(ctx context.Context, cli client.Client, cluster *appsv1.Cluster, compName string) (*componentConfigResources, error) {
+	comp := &appsv1.Component{}
+	compKey := client.ObjectKey{Namespace: cluster.Namespace, Name: constant.GenerateClusterComponentName(cluster.Name, compName)}
+	if err := cli.Get(ctx, compKey, comp); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		shards := &appsv1.ComponentList{}
+		if err = cli.List(ctx, shards, client.InNamespace(cluster.Namespace), client.MatchingLabels{
+			constant.AppInstanceLabelKey:       cluster.Name,
+			constant.KBAppShardingNameLabelKey: compName,
+		}); err != nil {
+			return nil, err
+		}
+		if len(shards.Items) == 0 {
+			return nil, nil
+		}
+		comp = &shards.Items[0]
+	}
+
+	cmpd := &appsv1.ComponentDefinition{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: comp.Spec.CompDef}, cmpd); err != nil {
+		return nil, err
+	}
+	configRender, paramsDefs, err := intctrlutil.ResolveCmpdParametersDefs(ctx, cli, cmpd)
+	if err != nil || configRender == nil {
+		return nil, err
+	}
+
+	configMapList := &corev1.ConfigMapList{}
+	if err = cli.List(ctx, configMapList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(constant.GetCompLabels(cluster.Name, comp.Labels[constant.KBAppComponentLabelKey])),
+		client.HasLabels{constant.CMConfigurationSpecProviderLabelKey}); err != nil {
+		return nil, err
+	}
+	configMaps := make(map[string]*corev1.ConfigMap)
+	for i, cm := range configMapList.Items {
+		configMaps[cm.Labels[constant.CMConfigurationSpecProviderLabelKey]] = &configMapList.Items[i]
+	}
+	return &componentConfigResources{
+		comp:         comp,
+		cmpd:         cmpd,
+		configRender: configRender,
+		paramsDefs:   paramsDefs,
+		configMaps:   configMaps,
+	}, nil
+}
+
+func hasParameterConstraints(paramsDefs []*parametersv1alpha1.ParametersDefinition) bool {
+	for _, paramsDef := range paramsDefs {
+		if len(paramsDef.Spec.Constraints) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func buildReconfigureParameter(ops *opsv1alpha1.OpsRequest) *parametersv1alpha1.Parameter {
 	paramBuilder := builder.NewParameterBuilder(ops.Namespace, ops.GetName()).
 		AddLabels(constant.AppInstanceLabelKey, ops.Spec.ClusterName).
@@ -46,6 +46,7 @@ func init() {
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
 		QueueByCluster:    true,
 		OpsHandler:        restartOpsHandler{},
+		Disruptive:        true,
 	}
 
 	opsMgr := GetOpsManager()
@@ -57,6 +57,7 @@ func init() {
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
 		QueueByCluster:    true,
 		OpsHandler:        switchoverOpsHandler{},
+		Disruptive:        true,
 	}
 
 	opsMgr := GetOpsManager()
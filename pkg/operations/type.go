@@ -60,6 +60,14 @@ type OpsBehaviour struct {
 	// only update the opsRequest object, then opsRequest controller will update uniformly.
 	CancelFunc func(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error
 
+	// RollbackFunc this function restores the last configuration when the opsRequest fails and `rollbackOnFailure` is true.
+	// like CancelFunc, it only updates the opsRequest object, and the opsRequest controller will update uniformly.
+	RollbackFunc func(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error
+
+	// AbortFunc this function is called before the opsRequest is aborted, e.g. exceeding the timeout,
+	// to stop the operations that are still in progress.
+	AbortFunc func(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error
+
 	// IsClusterCreation indicates whether the opsRequest will create a new cluster.
 	IsClusterCreation bool
 
@@ -69,6 +77,10 @@ type OpsBehaviour struct {
 	// QueueWithSelf indicates that the operation is queued for execution within opsType scope.
 	QueueBySelf bool
 
+	// Disruptive indicates that the operation disrupts the services of the cluster,
+	// and it can only be started within the maintenance window if one is specified.
+	Disruptive bool
+
 	OpsHandler OpsHandler
 }
 
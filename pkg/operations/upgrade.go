@@ -45,6 +45,8 @@ func init() {
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
 		QueueByCluster:    true,
 		OpsHandler:        upgradeOpsHandler{},
+		RollbackFunc:      upgradeOpsHandler{}.Rollback,
+		Disruptive:        true,
 	}
 
 	opsMgr := GetOpsManager()
@@ -134,6 +136,15 @@ func (u upgradeOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// Rollback restores the componentDefinition and serviceVersion of the components.
+func (u upgradeOpsHandler) Rollback(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.Upgrade.Components)
+	return compOpsHelper.cancelComponentOps(reqCtx.Ctx, cli, opsRes, func(lastConfig *opsv1alpha1.LastComponentConfiguration, comp *appsv1.ClusterComponentSpec) {
+		comp.ComponentDef = lastConfig.ComponentDefinitionName
+		comp.ServiceVersion = lastConfig.ServiceVersion
+	})
+}
+
 // getComponentDefMapWithUpdatedImages gets the desired componentDefinition map
 // that is updated with the corresponding images of the ComponentDefinition and service version.
 func (u upgradeOpsHandler) getComponentDefMapWithUpdatedImages(reqCtx intctrlutil.RequestCtx,
@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -395,6 +397,49 @@ var _ = Describe("Upgrade OpsRequest", func() {
 				g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(""))
 			})).Should(Succeed())
 		})
+
+		It("roll back the failed upgrade opsRequest", func() {
+			By("init operations resources")
+			compDef1, compDef2, opsRes := initOpsResWithComponentDef(true)
+			lastServiceVersion := opsRes.Cluster.Spec.ComponentSpecs[0].ServiceVersion
+
+			By("create Upgrade Ops with rollbackOnFailure")
+			opsRes.OpsRequest = createUpgradeOpsRequest(opsRes.Cluster, opsv1alpha1.Upgrade{
+				Components: []opsv1alpha1.UpgradeComponent{
+					{
+						ComponentOps:            opsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+						ServiceVersion:          pointer.String(serviceVer2),
+						ComponentDefinitionName: &compDef2.Name,
+					},
+				},
+			})
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(ops *opsv1alpha1.OpsRequest) {
+				ops.Spec.RollbackOnFailure = true
+			})).Should(Succeed())
+			opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsPendingPhase
+
+			By("expect the componentDef and serviceVersion to be upgraded")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			makeUpgradeOpsIsRunning(reqCtx, opsRes)
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].ComponentDef).Should(Equal(compDef2.Name))
+				g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(serviceVer2))
+			})).Should(Succeed())
+			lastConfig := opsRes.OpsRequest.Status.LastConfiguration.Components[defaultCompName]
+			Expect(lastConfig.ComponentDefinitionName).Should(Equal(compDef1.Name))
+			Expect(lastConfig.ServiceVersion).Should(Equal(lastServiceVersion))
+
+			By("expect the previous componentDef and serviceVersion to be restored")
+			opsBehaviour := GetOpsManager().OpsMap[opsv1alpha1.UpgradeType]
+			Expect(needRollback(opsRes.OpsRequest, opsBehaviour)).Should(BeTrue())
+			Expect(GetOpsManager().startRollback(reqCtx, k8sClient, opsRes, opsBehaviour, errors.New("mock failure"))).Should(Succeed())
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(opsv1alpha1.OpsRollingBackPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].ComponentDef).Should(Equal(compDef1.Name))
+				g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(lastServiceVersion))
+			})).Should(Succeed())
+			Expect(needRollback(opsRes.OpsRequest, opsBehaviour)).Should(BeFalse())
+		})
 		// TODO: add case with ClusterDefinition and topology
 	})
 })
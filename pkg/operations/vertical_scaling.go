@@ -20,15 +20,21 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -46,6 +52,8 @@ func init() {
 		OpsHandler:        vsHandler,
 		QueueByCluster:    true,
 		CancelFunc:        vsHandler.Cancel,
+		RollbackFunc:      vsHandler.Cancel,
+		Disruptive:        true,
 	}
 
 	opsMgr := GetOpsManager()
@@ -92,6 +100,11 @@ func (vs verticalScalingHandler) Action(reqCtx intctrlutil.RequestCtx, cli clien
 	if err := compOpsSet.updateClusterComponentsAndShardings(opsRes.Cluster, applyVerticalScaling); err != nil {
 		return err
 	}
+	// submit the derived parameters before the resources are scaled, so that the static ones are rendered
+	// and take effect with the instances recreated by the scaling rather than by another restart.
+	if err := vs.applyDerivedParameters(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
 	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
 }
 
@@ -144,6 +157,99 @@ func (vs verticalScalingHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx,
 	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "vertical scale", handleComponentStatusProgressForVS)
 }
 
+// applyDerivedParameters recomputes the parameters derived from the resulting resources of the vertically scaled
+// components, and submits the changed ones as a Parameter along with the scaling, so that they are applied
+// according to the reconfigure policies within the same rollout. The previous values of the derived parameters
+// are recorded on the Parameter to restore them when the OpsRequest is cancelled or rolled back.
+func (vs verticalScalingHandler) applyDerivedParameters(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	ops := opsRes.OpsRequest
+	parameterKey := client.ObjectKey{Namespace: ops.Namespace, Name: fmt.Sprintf("%s-derived-parameters", ops.Name)}
+	if err := cli.Get(reqCtx.Ctx, parameterKey, &parametersv1alpha1.Parameter{}); err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	paramBuilder := builder.NewParameterBuilder(parameterKey.Namespace, parameterKey.Name).
+		AddLabels(constant.AppInstanceLabelKey, ops.Spec.ClusterName).
+		AddLabels(constant.OpsRequestNameLabelKey, ops.Name).
+		ClusterRef(ops.Spec.ClusterName)
+	previousParams := map[string]parametersv1alpha1.ComponentParameters{}
+	for _, verticalScaling := range ops.Spec.VerticalScalingList {
+		// the parameters are derived from the resources of the component, not of the instance templates
+		if !vs.verticalScalingComp(verticalScaling) {
+			continue
+		}
+		resources, err := resolveComponentConfigResources(reqCtx.Ctx, cli, opsRes.Cluster, verticalScaling.ComponentName)
+		if err != nil {
+			return err
+		}
+		if resources == nil {
+			continue
+		}
+		params, err := configctrl.ResolveDerivedParameters(resources.configMaps,
+			resources.paramsDefs,
+			resources.configRender,
+			resources.comp.Spec.Replicas,
+			vs.resultingResources(opsRes.Cluster, verticalScaling.ComponentName))
+		if err != nil {
+			// the resources have been scaled, do not fail the OpsRequest for the derived parameters
+			opsRes.Recorder.Eventf(ops, corev1.EventTypeWarning, "DeriveParametersFailed",
+				"failed to derive parameters for component %s: %s", verticalScaling.ComponentName, err.Error())
+			continue
+		}
+		if len(params) == 0 {
+			continue
+		}
+		previous, err := configctrl.ResolveCurrentDerivedParameters(resources.configMaps,
+			resources.paramsDefs,
+			resources.configRender,
+			params)
+		if err != nil {
+			return err
+		}
+		paramBuilder.SetComponentParameters(verticalScaling.ComponentName, params)
+		previousParams[verticalScaling.ComponentName] = previous
+	}
+	if len(previousParams) == 0 {
+		return nil
+	}
+
+	previousBytes, err := json.Marshal(previousParams)
+	if err != nil {
+		return err
+	}
+	parameter := paramBuilder.AddAnnotations(constant.PreviousParametersAnnotationKey, string(previousBytes)).GetObject()
+	if err := intctrlutil.SetControllerReference(ops, parameter); err != nil {
+		return err
+	}
+	if err := cli.Create(reqCtx.Ctx, parameter); err != nil {
+		return client.IgnoreAlreadyExists(err)
+	}
+	opsRes.Recorder.Eventf(ops, corev1.EventTypeNormal, "ParametersDerived",
+		"submitted the parameters derived from the scaled resources: %s", parameter.Name)
+	return nil
+}
+
+// resultingResources returns the resources of the component after scaling, the absent requests default to
+// the limits as the API server does for the containers.
+func (vs verticalScalingHandler) resultingResources(cluster *appsv1.Cluster, compName string) corev1.ResourceRequirements {
+	var resources corev1.ResourceRequirements
+	if compSpec := cluster.Spec.GetComponentByName(compName); compSpec != nil {
+		resources = *compSpec.Resources.DeepCopy()
+	} else if shardingSpec := cluster.Spec.GetShardingByName(compName); shardingSpec != nil {
+		resources = *shardingSpec.Template.Resources.DeepCopy()
+	}
+	for name, limit := range resources.Limits {
+		if _, ok := resources.Requests[name]; ok {
+			continue
+		}
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		resources.Requests[name] = limit.DeepCopy()
+	}
+	return resources
+}
+
 func (vs verticalScalingHandler) covertInsResourcesToMap(verticalScaling opsv1alpha1.VerticalScaling) map[string]*opsv1alpha1.InstanceResourceTemplate {
 	vsInsMap := map[string]*opsv1alpha1.InstanceResourceTemplate{}
 	for i := range verticalScaling.Instances {
@@ -188,7 +294,7 @@ func (vs verticalScalingHandler) podApplyCompOps(
 	pgRes *progressResource) bool {
 	insTemplateName := pgRes.updatedPodSet[pod.Name]
 	verticalScaling := pgRes.compOps.(opsv1alpha1.VerticalScaling)
-	if ops.Spec.Cancel {
+	if ops.Spec.Cancel || ops.Status.Phase == opsv1alpha1.OpsRollingBackPhase {
 		vs.setRevertVScalingForCancel(ops, &verticalScaling)
 	}
 	matchResources := func(podResources, vsResources corev1.ResourceRequirements) bool {
@@ -256,7 +362,7 @@ func (vs verticalScalingHandler) SaveLastConfiguration(reqCtx intctrlutil.Reques
 // Cancel this function defines the cancel verticalScaling action.
 func (vs verticalScalingHandler) Cancel(reqCxt intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
 	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.VerticalScalingList)
-	return compOpsHelper.cancelComponentOps(reqCxt.Ctx, cli, opsRes, func(lastConfig *opsv1alpha1.LastComponentConfiguration, comp *appsv1.ClusterComponentSpec) {
+	err := compOpsHelper.cancelComponentOps(reqCxt.Ctx, cli, opsRes, func(lastConfig *opsv1alpha1.LastComponentConfiguration, comp *appsv1.ClusterComponentSpec) {
 		comp.Resources = lastConfig.ResourceRequirements
 		for _, lastIns := range lastConfig.Instances {
 			for i := range comp.Instances {
@@ -268,4 +374,48 @@ func (vs verticalScalingHandler) Cancel(reqCxt intctrlutil.RequestCtx, cli clien
 			}
 		}
 	})
+	if err != nil {
+		return err
+	}
+	return vs.restoreDerivedParameters(reqCxt, cli, opsRes)
+}
+
+// restoreDerivedParameters submits the previous values of the derived parameters recorded by applyDerivedParameters,
+// so that the configuration is reverted along with the resources.
+func (vs verticalScalingHandler) restoreDerivedParameters(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	ops := opsRes.OpsRequest
+	derived := &parametersv1alpha1.Parameter{}
+	derivedKey := client.ObjectKey{Namespace: ops.Namespace, Name: fmt.Sprintf("%s-derived-parameters", ops.Name)}
+	if err := cli.Get(reqCtx.Ctx, derivedKey, derived); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	previousValue, ok := derived.Annotations[constant.PreviousParametersAnnotationKey]
+	if !ok {
+		return nil
+	}
+	previousParams := map[string]parametersv1alpha1.ComponentParameters{}
+	if err := json.Unmarshal([]byte(previousValue), &previousParams); err != nil {
+		return err
+	}
+	if len(previousParams) == 0 {
+		return nil
+	}
+
+	paramBuilder := builder.NewParameterBuilder(ops.Namespace, fmt.Sprintf("%s-restored-parameters", ops.Name)).
+		AddLabels(constant.AppInstanceLabelKey, ops.Spec.ClusterName).
+		AddLabels(constant.OpsRequestNameLabelKey, ops.Name).
+		ClusterRef(ops.Spec.ClusterName)
+	for compName, params := range previousParams {
+		paramBuilder.SetComponentParameters(compName, params)
+	}
+	parameter := paramBuilder.GetObject()
+	if err := intctrlutil.SetControllerReference(ops, parameter); err != nil {
+		return err
+	}
+	if err := cli.Create(reqCtx.Ctx, parameter); err != nil {
+		return client.IgnoreAlreadyExists(err)
+	}
+	opsRes.Recorder.Eventf(ops, corev1.EventTypeNormal, "ParametersRestored",
+		"submitted the previous values of the derived parameters: %s", parameter.Name)
+	return nil
 }
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"errors"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -33,6 +34,8 @@ import (
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
@@ -40,6 +43,7 @@ import (
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
 	testops "github.com/apecloud/kubeblocks/pkg/testutil/operations"
+	testparameters "github.com/apecloud/kubeblocks/pkg/testutil/parameters"
 )
 
 var _ = Describe("VerticalScaling OpsRequest", func() {
@@ -66,6 +70,11 @@ var _ = Describe("VerticalScaling OpsRequest", func() {
 		ml := client.HasLabels{testCtx.TestObjLabelKey}
 		// namespaced
 		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.ParameterSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.ConfigMapSignature, inNS, ml)
+		// non-namespaced
+		testapps.ClearResources(&testCtx, generics.ParametersDefinitionSignature, ml)
+		testapps.ClearResources(&testCtx, generics.ParamConfigRendererSignature, ml)
 	}
 
 	BeforeEach(cleanEnv)
@@ -245,6 +254,111 @@ var _ = Describe("VerticalScaling OpsRequest", func() {
 			Expect(progressDetail.Message).Should(ContainSubstring("with rollback"))
 		})
 
+		It("roll back the failed vertical scaling opsRequest", func() {
+			verticalScaling := []opsv1alpha1.VerticalScaling{
+				{
+					ComponentOps:         opsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+					ResourceRequirements: newResources,
+				},
+			}
+			opsRes := testVerticalScaling(verticalScaling, nil)
+			lastResources := opsRes.OpsRequest.Status.LastConfiguration.Components[defaultCompName].ResourceRequirements
+			Expect(opsRes.Cluster.Spec.ComponentSpecs[0].Resources).Should(Equal(newResources))
+
+			By("mock opsRequest is Running and enables rollbackOnFailure")
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(ops *opsv1alpha1.OpsRequest) {
+				ops.Spec.RollbackOnFailure = true
+			})).Should(Succeed())
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsRunningPhase
+			})).Should(Succeed())
+			opsBehaviour := GetOpsManager().OpsMap[opsv1alpha1.VerticalScalingType]
+			Expect(needRollback(opsRes.OpsRequest, opsBehaviour)).Should(BeTrue())
+
+			By("expect the last resources to be restored and the opsRequest to be RollingBack")
+			Expect(GetOpsManager().startRollback(reqCtx, k8sClient, opsRes, opsBehaviour, errors.New("mock failure"))).Should(Succeed())
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(opsv1alpha1.OpsRollingBackPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].Resources).Should(Equal(lastResources))
+			})).Should(Succeed())
+			Expect(opsRes.OpsRequest.Status.RollbackTimestamp.IsZero()).Should(BeFalse())
+			Expect(needRollback(opsRes.OpsRequest, opsBehaviour)).Should(BeFalse())
+		})
+
+		It("roll back the vertical scaling opsRequest on the fatal error of the action", func() {
+			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
+			lastResources := opsRes.Cluster.Spec.ComponentSpecs[0].Resources
+
+			By("create VerticalScaling ops with rollbackOnFailure")
+			ops := testops.NewOpsRequestObj("vertical-scaling-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterName, opsv1alpha1.VerticalScalingType)
+			ops.Spec.RollbackOnFailure = true
+			ops.Spec.VerticalScalingList = []opsv1alpha1.VerticalScaling{
+				{
+					ComponentOps:         opsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+					ResourceRequirements: newResources,
+				},
+			}
+			opsRes.OpsRequest = testops.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsPendingPhase
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops))).Should(Equal(opsv1alpha1.OpsCreatingPhase))
+
+			By("mock the action fails with a fatal error after scaling the resources")
+			opsBehaviour := GetOpsManager().OpsMap[opsv1alpha1.VerticalScalingType]
+			failingBehaviour := opsBehaviour
+			failingBehaviour.OpsHandler = fatalActionOpsHandler{OpsHandler: opsBehaviour.OpsHandler}
+			GetOpsManager().OpsMap[opsv1alpha1.VerticalScalingType] = failingBehaviour
+			defer func() {
+				GetOpsManager().OpsMap[opsv1alpha1.VerticalScalingType] = opsBehaviour
+			}()
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			By("expect the last resources to be restored and the opsRequest to be RollingBack")
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops))).Should(Equal(opsv1alpha1.OpsRollingBackPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].Resources).Should(Equal(lastResources))
+			})).Should(Succeed())
+		})
+
+		It("roll back the vertical scaling opsRequest on timeout", func() {
+			verticalScaling := []opsv1alpha1.VerticalScaling{
+				{
+					ComponentOps:         opsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+					ResourceRequirements: newResources,
+				},
+			}
+			opsRes := testVerticalScaling(verticalScaling, nil)
+			lastResources := opsRes.OpsRequest.Status.LastConfiguration.Components[defaultCompName].ResourceRequirements
+			Expect(opsRes.Cluster.Spec.ComponentSpecs[0].Resources).Should(Equal(newResources))
+
+			By("mock opsRequest is Running and exceeds the timeout with rollbackOnFailure")
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(ops *opsv1alpha1.OpsRequest) {
+				ops.Spec.RollbackOnFailure = true
+				ops.Spec.TimeoutSeconds = pointer.Int32(60)
+			})).Should(Succeed())
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsRunningPhase
+				opsRes.OpsRequest.Status.StartTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+			})).Should(Succeed())
+			opsBehaviour := GetOpsManager().OpsMap[opsv1alpha1.VerticalScalingType]
+
+			By("expect the last resources to be restored and the opsRequest to be RollingBack rather than Aborted")
+			_, err := GetOpsManager().checkAndHandleOpsTimeout(reqCtx, k8sClient, opsRes, opsBehaviour, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(opsv1alpha1.OpsRollingBackPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].Resources).Should(Equal(lastResources))
+			})).Should(Succeed())
+
+			By("expect the rollback is not aborted by the timeout")
+			_, err = GetOpsManager().checkAndHandleOpsTimeout(reqCtx, k8sClient, opsRes, opsBehaviour, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+			Consistently(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(opsv1alpha1.OpsRollingBackPhase))
+		})
+
 		It("force run vertical scaling opsRequests", func() {
 			By("create the first vertical scaling")
 			verticalScaling1 := []opsv1alpha1.VerticalScaling{
@@ -297,4 +411,149 @@ var _ = Describe("VerticalScaling OpsRequest", func() {
 			Expect(len(opsRequestSlice)).Should(Equal(1))
 		})
 	})
+
+	Context("Test derived parameters", func() {
+		const configSpecName = "mysql-config"
+
+		initDerivedParameters := func(compDef *appsv1.ComponentDefinition) {
+			By("create the parameters definition with derived parameters")
+			paramsDef := testparameters.NewParametersDefinitionFactory("test-pd-" + randomStr).
+				SetConfigFile(testparameters.MysqlConfigFile).
+				Apply(func(obj *parametersv1alpha1.ParametersDefinition) {
+					obj.Spec.DerivedParameters = []parametersv1alpha1.DerivedParameter{
+						{
+							Name:       "innodb_buffer_pool_size",
+							Expression: `string(component.resources.requests.memory * 3 / 4 / 1048576) + "M"`,
+						},
+					}
+				}).
+				Create(&testCtx).
+				GetObject()
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(paramsDef), func(obj *parametersv1alpha1.ParametersDefinition) {
+				obj.Status.Phase = parametersv1alpha1.PDAvailablePhase
+			})()).Should(Succeed())
+
+			By("create the config renderer of the component definition")
+			pcr := testparameters.NewParamConfigRendererFactory("test-pcr-"+randomStr).
+				SetParametersDefs(paramsDef.Name).
+				SetComponentDefinition(compDef.Name).
+				SetConfigDescription(testparameters.MysqlConfigFile, configSpecName, parametersv1alpha1.FileFormatConfig{
+					Format: parametersv1alpha1.Ini,
+					FormatterAction: parametersv1alpha1.FormatterAction{
+						IniConfig: &parametersv1alpha1.IniConfig{SectionName: "mysqld"},
+					},
+				}).
+				Create(&testCtx).
+				GetObject()
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(pcr), func(obj *parametersv1alpha1.ParamConfigRenderer) {
+				obj.Status.Phase = parametersv1alpha1.PDAvailablePhase
+			})()).Should(Succeed())
+
+			By("create the component and the rendered config")
+			testapps.NewComponentFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(clusterName, defaultCompName), compDef.Name).
+				AddLabels(constant.AppInstanceLabelKey, clusterName).
+				AddLabels(constant.KBAppComponentLabelKey, defaultCompName).
+				SetReplicas(3).
+				Create(&testCtx)
+			configMap := testapps.NewConfigMap(testCtx.DefaultNamespace, core.GetComponentCfgName(clusterName, defaultCompName, configSpecName),
+				testapps.SetConfigMapData(testparameters.MysqlConfigFile, "[mysqld]\ninnodb_buffer_pool_size=768M\n"))
+			configMap.Labels = constant.GetCompLabels(clusterName, defaultCompName)
+			configMap.Labels[constant.CMConfigurationSpecProviderLabelKey] = configSpecName
+			Expect(testCtx.Create(ctx, configMap)).Should(Succeed())
+		}
+
+		createOpsRequest := func(opsRes *OpsResource, resources corev1.ResourceRequirements) {
+			ops := testops.NewOpsRequestObj("vertical-scaling-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterName, opsv1alpha1.VerticalScalingType)
+			ops.Spec.VerticalScalingList = []opsv1alpha1.VerticalScaling{
+				{
+					ComponentOps:         opsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+					ResourceRequirements: resources,
+				},
+			}
+			opsRes.OpsRequest = testops.CreateOpsRequest(ctx, testCtx, ops)
+		}
+
+		It("submits the parameters derived from the resulting resources with the scaling", func() {
+			opsRes, compDef, _ := initOperationsResources(compDefName, clusterName)
+			initDerivedParameters(compDef)
+
+			By("scale the memory limits only")
+			createOpsRequest(opsRes, corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			})
+			Expect(verticalScalingHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+
+			By("expect the derived parameter is computed from the requests defaulted to the limits")
+			parameterKey := client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: opsRes.OpsRequest.Name + "-derived-parameters"}
+			Eventually(testapps.CheckObj(&testCtx, parameterKey, func(g Gomega, parameter *parametersv1alpha1.Parameter) {
+				g.Expect(parameter.Spec.ClusterName).Should(Equal(clusterName))
+				g.Expect(parameter.Labels).Should(HaveKeyWithValue(constant.OpsRequestNameLabelKey, opsRes.OpsRequest.Name))
+				g.Expect(parameter.OwnerReferences).Should(HaveLen(1))
+				g.Expect(parameter.Spec.ComponentParameters).Should(HaveLen(1))
+				g.Expect(parameter.Spec.ComponentParameters[0].ComponentName).Should(Equal(defaultCompName))
+				params := parameter.Spec.ComponentParameters[0].Parameters
+				g.Expect(params).Should(HaveKey("innodb_buffer_pool_size"))
+				g.Expect(*params["innodb_buffer_pool_size"]).Should(Equal("3072M"))
+			})).Should(Succeed())
+		})
+
+		It("restores the previous values of the derived parameters if the scaling is cancelled", func() {
+			opsRes, compDef, _ := initOperationsResources(compDefName, clusterName)
+			initDerivedParameters(compDef)
+
+			By("scale up the memory")
+			createOpsRequest(opsRes, corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			})
+			Expect(verticalScalingHandler{}.SaveLastConfiguration(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Expect(verticalScalingHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+
+			parameterKey := client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: opsRes.OpsRequest.Name + "-derived-parameters"}
+			Eventually(testapps.CheckObj(&testCtx, parameterKey, func(g Gomega, parameter *parametersv1alpha1.Parameter) {
+				g.Expect(parameter.Annotations).Should(HaveKey(constant.PreviousParametersAnnotationKey))
+			})).Should(Succeed())
+
+			By("cancel the scaling")
+			Expect(verticalScalingHandler{}.Cancel(reqCtx, k8sClient, opsRes)).Should(Succeed())
+
+			By("expect the previous values of the derived parameters are submitted")
+			restoredKey := client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: opsRes.OpsRequest.Name + "-restored-parameters"}
+			Eventually(testapps.CheckObj(&testCtx, restoredKey, func(g Gomega, parameter *parametersv1alpha1.Parameter) {
+				g.Expect(parameter.Spec.ClusterName).Should(Equal(clusterName))
+				g.Expect(parameter.OwnerReferences).Should(HaveLen(1))
+				g.Expect(parameter.Spec.ComponentParameters).Should(HaveLen(1))
+				g.Expect(parameter.Spec.ComponentParameters[0].ComponentName).Should(Equal(defaultCompName))
+				params := parameter.Spec.ComponentParameters[0].Parameters
+				g.Expect(params).Should(HaveKey("innodb_buffer_pool_size"))
+				g.Expect(*params["innodb_buffer_pool_size"]).Should(Equal("768M"))
+			})).Should(Succeed())
+		})
+
+		It("submits nothing if the derived parameters are not changed", func() {
+			opsRes, compDef, _ := initOperationsResources(compDefName, clusterName)
+			initDerivedParameters(compDef)
+
+			createOpsRequest(opsRes, corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			})
+			Expect(verticalScalingHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+
+			parameterKey := client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: opsRes.OpsRequest.Name + "-derived-parameters"}
+			Consistently(testapps.CheckObjExists(&testCtx, parameterKey, &parametersv1alpha1.Parameter{}, false)).Should(Succeed())
+		})
+	})
 })
+
+// fatalActionOpsHandler fails the action with a fatal error after performing it.
+type fatalActionOpsHandler struct {
+	OpsHandler
+}
+
+func (h fatalActionOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	if err := h.OpsHandler.Action(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
+	return intctrlutil.NewFatalError("mock fatal error")
+}
@@ -101,3 +101,8 @@ func (factory *MockRolloutFactory) SetReplicas(replicas int32) *MockRolloutFacto
 		comp.Replicas = ptr.To(intstr.FromInt32(replicas))
 	})
 }
+
+func (factory *MockRolloutFactory) SetPaused(paused bool) *MockRolloutFactory {
+	factory.Get().Spec.Paused = paused
+	return factory
+}